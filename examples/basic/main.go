@@ -23,23 +23,15 @@ func main() {
 	world := gameEngine.GetECS()
 
 	// Create a rotating triangle entity
-	entityID := world.CreateEntity()
-
-	// Add transform component
-	transform := ecs.NewTransformComponent(
-		mgl32.Vec3{0, 0, 0}, // Position
-		mgl32.Vec3{0, 0, 0}, // Rotation
-		mgl32.Vec3{1, 1, 1}, // Scale
-	)
-	world.AddComponent(entityID, transform)
-
-	// Add mesh component
-	mesh := ecs.NewMeshComponent("default")
-	world.AddComponent(entityID, mesh)
-
-	// Add tag component
-	tag := ecs.NewTagComponent("rotating", "triangle")
-	world.AddComponent(entityID, tag)
+	world.NewEntity().
+		WithTransform(
+			mgl32.Vec3{0, 0, 0}, // Position
+			mgl32.Vec3{0, 0, 0}, // Rotation
+			mgl32.Vec3{1, 1, 1}, // Scale
+		).
+		WithMesh("default").
+		WithTag("rotating", "triangle").
+		Build()
 
 	// Create a simple rotation system
 	rotationSystem := &RotationSystem{
@@ -85,7 +77,7 @@ func (rs *RotationSystem) Update(deltaTime float64, world *ecs.World) {
 		rotation := elapsed * rotationSpeed
 
 		// Apply rotation around Z-axis
-		transform.Rotation = mgl32.Vec3{0, 0, float32(rotation)}
+		transform.SetRotation(mgl32.Vec3{0, 0, float32(rotation)})
 	}
 }
 
@@ -3,7 +3,18 @@ module github.com/aminasadiam/jigxel-engine
 go 1.24.5
 
 require (
+	github.com/faiface/beep v1.1.0
 	github.com/go-gl/gl v0.0.0-20231021071112-07e5d0ea2e71
 	github.com/go-gl/glfw/v3.3/glfw v0.0.0-20250301202403-da16c1255728
 	github.com/go-gl/mathgl v1.2.0
+	github.com/hajimehoshi/oto/v2 v2.4.3
+)
+
+require (
+	github.com/ebitengine/purego v0.4.1 // indirect
+	github.com/hajimehoshi/go-mp3 v0.3.0 // indirect
+	github.com/jfreymuth/oggvorbis v1.0.1 // indirect
+	github.com/jfreymuth/vorbis v1.0.0 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	golang.org/x/sys v0.7.0 // indirect
 )
@@ -0,0 +1,60 @@
+package audio
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCombineVolume(t *testing.T) {
+	tests := []struct {
+		name               string
+		sound, bus, master float64
+		want               float64
+	}{
+		{"all full", 1, 1, 1, 1},
+		{"multiplied", 0.5, 0.5, 0.8, 0.2},
+		{"muted bus", 0.9, 0, 1, 0},
+		// Each factor is clamped before multiplying, so a loud sound can't
+		// make up for a quiet bus
+		{"sound above one", 2, 0.5, 1, 0.5},
+		{"master above one", 0.5, 0.5, 4, 0.25},
+		{"negative bus", 1, -1, 1, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := combineVolume(test.sound, test.bus, test.master)
+			if math.Abs(got-test.want) > 1e-9 {
+				t.Errorf("combineVolume(%v, %v, %v) = %v, want %v", test.sound, test.bus, test.master, got, test.want)
+			}
+		})
+	}
+}
+
+func TestBusVolumesApplyToPlayingInstances(t *testing.T) {
+	manager := newTestManager(t)
+	addSound(manager, "theme", &PCM{SampleRate: OutputSampleRate, Channels: 1, Samples: make([]float32, 100)})
+	manager.SetSoundBus("theme", BusMusic)
+
+	effect, _ := manager.PlaySound("tone")
+	music, _ := manager.PlaySound("theme")
+	manager.SetInstanceVolume(effect, 0.5)
+
+	manager.SetBusVolume(BusMusic, 0.5)
+	manager.SetMasterVolume(0.5)
+
+	if got := manager.instances[effect].voice.volume; got != 0.25 {
+		t.Errorf("SFX voice volume = %v, want 0.25", got)
+	}
+	if got := manager.instances[music].voice.volume; got != 0.25 {
+		t.Errorf("music voice volume = %v, want 0.25", got)
+	}
+
+	manager.SetMasterVolume(3)
+	if got := manager.GetMasterVolume(); got != 1 {
+		t.Errorf("GetMasterVolume() = %v after setting 3, want 1", got)
+	}
+	if got := manager.GetBusVolume("unknown"); got != 1 {
+		t.Errorf("GetBusVolume() of an unknown bus = %v, want 1", got)
+	}
+}
@@ -0,0 +1,98 @@
+package audio
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/faiface/beep/mp3"
+	"github.com/faiface/beep/vorbis"
+)
+
+// Decoder decodes an encoded audio stream into PCM
+type Decoder func(reader io.Reader) (*PCM, error)
+
+// audioFormat describes a supported encoding
+type audioFormat struct {
+	name       string
+	extensions []string
+	// sniff reports whether a header starts a stream in this format
+	sniff  func(header []byte) bool
+	decode Decoder
+}
+
+// sniffLength is the number of header bytes inspected to detect a format
+const sniffLength = 12
+
+var (
+	wavFormat = &audioFormat{
+		name:       "WAV",
+		extensions: []string{".wav", ".wave"},
+		sniff: func(header []byte) bool {
+			return len(header) >= 12 && string(header[0:4]) == "RIFF" && string(header[8:12]) == "WAVE"
+		},
+		decode: DecodeWAV,
+	}
+
+	oggFormat = &audioFormat{
+		name:       "OGG Vorbis",
+		extensions: []string{".ogg", ".oga"},
+		sniff: func(header []byte) bool {
+			return bytes.HasPrefix(header, []byte("OggS"))
+		},
+		decode: beepDecoder(vorbis.Decode),
+	}
+
+	mp3Format = &audioFormat{
+		name:       "MP3",
+		extensions: []string{".mp3"},
+		sniff: func(header []byte) bool {
+			// An ID3 tag or an MPEG audio frame sync
+			return bytes.HasPrefix(header, []byte("ID3")) ||
+				(len(header) >= 2 && header[0] == 0xFF && header[1]&0xE0 == 0xE0)
+		},
+		decode: beepDecoder(mp3.Decode),
+	}
+
+	audioFormats = []*audioFormat{wavFormat, oggFormat, mp3Format}
+)
+
+// Decode decodes an audio stream, detecting the format from its header and
+// falling back to the extension of name
+func Decode(reader io.Reader, name string) (*PCM, error) {
+	buffered := bufio.NewReader(reader)
+	header, _ := buffered.Peek(sniffLength)
+
+	format := detectFormat(header, name)
+	if format == nil {
+		return nil, fmt.Errorf("unsupported audio format %q", filepath.Ext(name))
+	}
+
+	pcm, err := format.decode(buffered)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", format.name, err)
+	}
+	return pcm, nil
+}
+
+// detectFormat picks the format whose header matches, then the one whose extension matches
+func detectFormat(header []byte, name string) *audioFormat {
+	for _, format := range audioFormats {
+		if format.sniff(header) {
+			return format
+		}
+	}
+
+	extension := strings.ToLower(filepath.Ext(name))
+	for _, format := range audioFormats {
+		for _, candidate := range format.extensions {
+			if candidate == extension {
+				return format
+			}
+		}
+	}
+	return nil
+}
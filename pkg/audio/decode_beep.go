@@ -0,0 +1,52 @@
+package audio
+
+import (
+	"io"
+
+	"github.com/faiface/beep"
+)
+
+// beepDecodeFunc is the signature of beep's format decoders
+type beepDecodeFunc func(rc io.ReadCloser) (beep.StreamSeekCloser, beep.Format, error)
+
+// beepDecoder adapts a beep decoder to read a whole stream into PCM
+func beepDecoder(decode beepDecodeFunc) Decoder {
+	return func(reader io.Reader) (*PCM, error) {
+		streamer, format, err := decode(io.NopCloser(reader))
+		if err != nil {
+			return nil, err
+		}
+		defer streamer.Close()
+
+		// beep always streams stereo; keep mono sources mono
+		channels := format.NumChannels
+		if channels > 2 {
+			channels = 2
+		}
+
+		pcm := &PCM{
+			SampleRate: int(format.SampleRate),
+			Channels:   channels,
+			Samples:    make([]float32, 0, streamer.Len()*channels),
+		}
+
+		buffer := make([][2]float64, 4096)
+		for {
+			n, ok := streamer.Stream(buffer)
+			for _, frame := range buffer[:n] {
+				pcm.Samples = append(pcm.Samples, float32(frame[0]))
+				if channels == 2 {
+					pcm.Samples = append(pcm.Samples, float32(frame[1]))
+				}
+			}
+			if !ok {
+				break
+			}
+		}
+
+		if err := streamer.Err(); err != nil {
+			return nil, err
+		}
+		return pcm, nil
+	}
+}
@@ -0,0 +1,221 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"testing"
+)
+
+// silentMP3 builds an MP3 stream of mono MPEG-1 Layer III frames at
+// 128 kbps and 44.1 kHz whose side information is all zero, so every frame
+// decodes to silence
+func silentMP3(frames int) []byte {
+	// 144 * bitrate / sample rate bytes per frame, without padding
+	const frameLength = 144 * 128000 / 44100
+
+	var data bytes.Buffer
+	for i := 0; i < frames; i++ {
+		frame := make([]byte, frameLength)
+		copy(frame, []byte{0xFF, 0xFB, 0x90, 0xC4})
+		data.Write(frame)
+	}
+	return data.Bytes()
+}
+
+// bitWriter packs values least significant bit first, as Vorbis headers are
+type bitWriter struct {
+	data  []byte
+	count uint
+}
+
+func (w *bitWriter) write(value uint32, bits uint) {
+	for i := uint(0); i < bits; i++ {
+		if w.count%8 == 0 {
+			w.data = append(w.data, 0)
+		}
+		if value>>i&1 != 0 {
+			w.data[len(w.data)-1] |= 1 << (w.count % 8)
+		}
+		w.count++
+	}
+}
+
+// oggCRC is the CRC-32 Ogg pages are checksummed with
+func oggCRC(data []byte) uint32 {
+	var crc uint32
+	for _, b := range data {
+		crc ^= uint32(b) << 24
+		for i := 0; i < 8; i++ {
+			if crc&0x80000000 != 0 {
+				crc = crc<<1 ^ 0x04c11db7
+			} else {
+				crc <<= 1
+			}
+		}
+	}
+	return crc
+}
+
+// oggPage frames packets into one Ogg page
+func oggPage(packets [][]byte, headerType byte, granule uint64, sequence uint32) []byte {
+	var lacing, body []byte
+	for _, packet := range packets {
+		for length := len(packet); ; length -= 255 {
+			if length < 255 {
+				lacing = append(lacing, byte(length))
+				break
+			}
+			lacing = append(lacing, 255)
+		}
+		body = append(body, packet...)
+	}
+
+	page := []byte("OggS")
+	page = append(page, 0, headerType)
+	page = binary.LittleEndian.AppendUint64(page, granule)
+	page = binary.LittleEndian.AppendUint32(page, 1)
+	page = binary.LittleEndian.AppendUint32(page, sequence)
+	page = binary.LittleEndian.AppendUint32(page, 0)
+	page = append(page, byte(len(lacing)))
+	page = append(page, lacing...)
+	page = append(page, body...)
+	binary.LittleEndian.PutUint32(page[22:], oggCRC(page))
+	return page
+}
+
+// silentOGG builds a mono Ogg Vorbis stream at 22050 Hz with the smallest
+// setup the format allows: one two-entry codebook and one floor, residue,
+// mapping and mode of 256-sample blocks. Every audio packet marks its floor
+// unused, so each one after the first decodes to 128 samples of silence.
+func silentOGG(packets int) []byte {
+	identification := []byte("\x01vorbis")
+	identification = binary.LittleEndian.AppendUint32(identification, 0)
+	identification = append(identification, 1)
+	identification = binary.LittleEndian.AppendUint32(identification, 22050)
+	identification = append(identification, make([]byte, 12)...)
+	identification = append(identification, 8|8<<4, 1)
+
+	comment := []byte("\x03vorbis")
+	comment = binary.LittleEndian.AppendUint32(comment, 0)
+	comment = binary.LittleEndian.AppendUint32(comment, 0)
+	comment = append(comment, 1)
+
+	setup := &bitWriter{data: []byte("\x05vorbis"), count: 56}
+	setup.write(0, 8)         // one codebook
+	setup.write(0x564342, 24) // codebook sync
+	setup.write(1, 16)        // dimensions
+	setup.write(2, 24)        // entries
+	setup.write(0, 1)         // unordered
+	setup.write(0, 1)         // not sparse
+	setup.write(0, 5)         // both entries one bit long
+	setup.write(0, 5)
+	setup.write(0, 4) // no lookup
+	setup.write(0, 6) // one time domain transform
+	setup.write(0, 16)
+	setup.write(0, 6) // one floor of type 1
+	setup.write(1, 16)
+	setup.write(1, 5) // one partition of class 0
+	setup.write(0, 4)
+	setup.write(0, 3) // class dimension 1
+	setup.write(0, 2) // no subclasses
+	setup.write(0, 8) // no subclass book
+	setup.write(1, 2) // multiplier 2
+	setup.write(8, 4) // range bits
+	setup.write(128, 8)
+	setup.write(0, 6) // one residue of type 0 covering nothing
+	setup.write(0, 16)
+	setup.write(0, 24)
+	setup.write(0, 24)
+	setup.write(0, 24)
+	setup.write(0, 6)
+	setup.write(0, 8)
+	setup.write(0, 3)
+	setup.write(0, 1)
+	setup.write(0, 6) // one mapping with one submap and no coupling
+	setup.write(0, 16)
+	setup.write(0, 1)
+	setup.write(0, 1)
+	setup.write(0, 2)
+	setup.write(0, 8)
+	setup.write(0, 8)
+	setup.write(0, 8)
+	setup.write(0, 6) // one mode of short blocks
+	setup.write(0, 1)
+	setup.write(0, 16)
+	setup.write(0, 16)
+	setup.write(0, 8)
+	setup.write(1, 1) // framing
+
+	audio := make([][]byte, packets)
+	for i := range audio {
+		audio[i] = []byte{0}
+	}
+
+	var stream []byte
+	stream = append(stream, oggPage([][]byte{identification}, 0x02, 0, 0)...)
+	stream = append(stream, oggPage([][]byte{comment, setup.data}, 0, 0, 1)...)
+	stream = append(stream, oggPage(audio, 0x04, uint64(packets-1)*128, 2)...)
+	return stream
+}
+
+func TestDetectFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		header []byte
+		file   string
+		want   *audioFormat
+	}{
+		{"WAV header", []byte("RIFF\x24\x00\x00\x00WAVE"), "sound", wavFormat},
+		{"OGG header", []byte("OggS\x00\x02\x00\x00\x00\x00\x00\x00"), "sound", oggFormat},
+		{"MP3 with ID3 tag", []byte("ID3\x04\x00\x00\x00\x00\x00\x00\x00\x00"), "sound", mp3Format},
+		{"MP3 frame sync", []byte{0xFF, 0xFB, 0x90, 0xC4}, "sound", mp3Format},
+		{"extension only", nil, "music/Theme.OGG", oggFormat},
+		{"header beats extension", []byte("OggS\x00\x02\x00\x00\x00\x00\x00\x00"), "theme.mp3", oggFormat},
+		{"unknown", []byte("fLaC\x00\x00\x00\x22"), "theme.flac", nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := detectFormat(test.header, test.file); got != test.want {
+				t.Errorf("detectFormat() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestDecodeUnsupportedFormat(t *testing.T) {
+	if _, err := Decode(bytes.NewReader([]byte("fLaC\x00\x00\x00\x22")), "theme.flac"); err == nil {
+		t.Error("Decode of a FLAC file succeeded, want an error")
+	}
+}
+
+func TestDecodeMP3(t *testing.T) {
+	pcm, err := Decode(bytes.NewReader(silentMP3(16)), "silence.mp3")
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if len(pcm.Samples) == 0 {
+		t.Error("decoded MP3 has no samples")
+	}
+	if pcm.SampleRate != 44100 {
+		t.Errorf("SampleRate = %d, want 44100", pcm.SampleRate)
+	}
+}
+
+func TestDecodeOGG(t *testing.T) {
+	pcm, err := Decode(bytes.NewReader(silentOGG(16)), "silence.ogg")
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if pcm.SampleRate != 22050 || pcm.Channels != 1 {
+		t.Errorf("format = %d Hz, %d channels; want 22050 Hz mono", pcm.SampleRate, pcm.Channels)
+	}
+	if got, want := len(pcm.Samples), 15*128; got != want {
+		t.Errorf("%d samples, want %d", got, want)
+	}
+	for i, sample := range pcm.Samples {
+		if sample != 0 {
+			t.Fatalf("sample %d = %v, want silence", i, sample)
+		}
+	}
+}
@@ -0,0 +1,16 @@
+package audio
+
+import (
+	"io"
+)
+
+// Device plays a signed 16-bit little-endian stereo stream at OutputSampleRate
+type Device interface {
+	// Start begins pulling samples from source until the device is closed
+	Start(source io.Reader) error
+	Close() error
+}
+
+// openDevice opens the platform audio output. It is a variable so the
+// backend chosen by build tags can be replaced, e.g. without a sound card.
+var openDevice = newDefaultDevice
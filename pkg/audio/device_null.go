@@ -0,0 +1,71 @@
+//go:build nooto
+
+package audio
+
+import (
+	"io"
+	"sync"
+	"time"
+)
+
+// nullDevicePeriod is how often the null device pulls samples
+const nullDevicePeriod = 10 * time.Millisecond
+
+// nullDevice discards samples in real time, so playback state advances as
+// it would on a sound card. It replaces oto when built with the nooto tag,
+// e.g. on machines without a sound card or audio headers.
+type nullDevice struct {
+	stop chan struct{}
+	done sync.WaitGroup
+}
+
+// newDefaultDevice opens the null device
+func newDefaultDevice() (Device, error) {
+	return &nullDevice{}, nil
+}
+
+// Start pulls from source at OutputSampleRate in a background goroutine
+func (d *nullDevice) Start(source io.Reader) error {
+	d.stop = make(chan struct{})
+	d.done.Add(1)
+
+	go func() {
+		defer d.done.Done()
+
+		ticker := time.NewTicker(nullDevicePeriod)
+		defer ticker.Stop()
+
+		last := time.Now()
+		buffer := make([]byte, 0)
+		for {
+			select {
+			case <-d.stop:
+				return
+			case now := <-ticker.C:
+				frames := int(now.Sub(last).Seconds() * OutputSampleRate)
+				if frames == 0 {
+					continue
+				}
+				last = last.Add(time.Duration(frames) * time.Second / OutputSampleRate)
+
+				if cap(buffer) < frames*outputFrameSize {
+					buffer = make([]byte, frames*outputFrameSize)
+				}
+				if _, err := source.Read(buffer[:frames*outputFrameSize]); err != nil {
+					return
+				}
+			}
+		}
+	}()
+	return nil
+}
+
+// Close stops pulling samples
+func (d *nullDevice) Close() error {
+	if d.stop != nil {
+		close(d.stop)
+		d.done.Wait()
+		d.stop = nil
+	}
+	return nil
+}
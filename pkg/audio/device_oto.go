@@ -0,0 +1,59 @@
+//go:build !nooto
+
+package audio
+
+import (
+	"io"
+	"sync"
+
+	"github.com/hajimehoshi/oto/v2"
+)
+
+// oto allows one context per process, so managers initialized after a
+// Shutdown reuse it
+var (
+	otoContextOnce sync.Once
+	otoContext     *oto.Context
+	otoContextErr  error
+)
+
+// otoDevice plays through the system's audio output with oto
+type otoDevice struct {
+	context *oto.Context
+	player  oto.Player
+}
+
+// newDefaultDevice opens the oto context for the mixer's output format
+func newDefaultDevice() (Device, error) {
+	otoContextOnce.Do(func() {
+		context, ready, err := oto.NewContext(OutputSampleRate, OutputChannels, 2)
+		if err != nil {
+			otoContextErr = err
+			return
+		}
+		<-ready
+		otoContext = context
+	})
+	if otoContextErr != nil {
+		return nil, otoContextErr
+	}
+
+	return &otoDevice{context: otoContext}, nil
+}
+
+// Start plays source on a single oto player
+func (d *otoDevice) Start(source io.Reader) error {
+	d.player = d.context.NewPlayer(source)
+	d.player.Play()
+	return d.context.Err()
+}
+
+// Close stops playback
+func (d *otoDevice) Close() error {
+	if d.player == nil {
+		return nil
+	}
+	err := d.player.Close()
+	d.player = nil
+	return err
+}
@@ -1,46 +1,105 @@
 package audio
 
 import (
+	"fmt"
 	"log"
+	"os"
 	"sync"
 )
 
+// Pitch limits, two octaves either way
+const (
+	MinPitch = 0.25
+	MaxPitch = 4.0
+)
+
 // Manager handles all audio operations
 type Manager struct {
 	context *AudioContext
 	sounds  map[string]*Sound
 	mutex   sync.RWMutex
+
+	// Playing instances of sounds
+	instances  map[PlaybackHandle]*instance
+	nextHandle PlaybackHandle
+
+	// Volume buses
+	masterVolume float64
+	busVolumes   map[string]float64
+
+	// paused is set by PauseAll
+	paused bool
 }
 
-// AudioContext represents the audio context
+// AudioContext represents the audio context: the output device and the
+// mixer it pulls samples from
 type AudioContext struct {
-	// This would be initialized with oto.Context in a real implementation
+	device      Device
+	mixer       *mixer
 	initialized bool
 }
 
-// Sound represents an audio sound
+// Sound represents a loaded audio asset. It holds no playback state; each
+// PlaySound call starts a separate instance using the sound's settings.
 type Sound struct {
-	ID       string
-	Data     []byte
-	Playing  bool
-	Volume   float64
-	Loop     bool
+	ID   string
+	Data *PCM
+	// Volume and Loop are applied to new instances
+	Volume float64
+	Loop   bool
+	// Bus is the volume category the sound plays on
+	Bus string
+	// Pitch is the playback rate; 1 is normal and 2 an octave up
+	Pitch float64
+}
+
+// PlaybackHandle identifies one playing instance of a sound
+type PlaybackHandle uint64
+
+// instance is the playback state of one PlaySound call
+type instance struct {
+	soundID string
+	bus     string
+	// volume is the instance's own volume, before bus and master volume
+	volume float64
+	// spatialGain is the distance attenuation applied by the audio system
+	spatialGain float64
+	voice       *voice
 }
 
 // NewManager creates a new audio manager
 func NewManager() *Manager {
 	return &Manager{
-		sounds: make(map[string]*Sound),
+		sounds:     make(map[string]*Sound),
+		instances:  make(map[PlaybackHandle]*instance),
+		nextHandle: 1,
+
+		masterVolume: 1,
+		busVolumes:   make(map[string]float64),
 	}
 }
 
 // Init initializes the audio manager
 func (m *Manager) Init() error {
+	device, err := openDevice()
+	if err != nil {
+		return fmt.Errorf("failed to open audio device: %w", err)
+	}
+
+	mixer := newMixer()
+	mixer.paused = m.paused
+	if err := device.Start(mixer); err != nil {
+		device.Close()
+		return fmt.Errorf("failed to start audio device: %w", err)
+	}
+
 	// Initialize audio context
 	m.context = &AudioContext{
+		device:      device,
+		mixer:       mixer,
 		initialized: true,
 	}
-	
+
 	log.Println("Audio manager initialized successfully")
 	return nil
 }
@@ -49,108 +108,248 @@ func (m *Manager) Init() error {
 func (m *Manager) Shutdown() {
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
-	
+
 	// Stop all sounds
-	for _, sound := range m.sounds {
-		sound.Playing = false
+	m.instances = make(map[PlaybackHandle]*instance)
+
+	if m.context != nil {
+		m.context.mixer.stopAll()
+		if err := m.context.device.Close(); err != nil {
+			log.Printf("Failed to close audio device: %v", err)
+		}
+		m.context = nil
 	}
-	
+
 	// Clear sounds map
 	m.sounds = make(map[string]*Sound)
-	
+
 	log.Println("Audio manager shutdown complete")
 }
 
-// LoadSound loads a sound from file
+// LoadSound loads a WAV, OGG or MP3 sound from file
 func (m *Manager) LoadSound(id, filepath string) error {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	data, err := Decode(file, filepath)
+	if err != nil {
+		return fmt.Errorf("failed to decode %s: %w", filepath, err)
+	}
+
 	m.mutex.Lock()
 	defer m.mutex.Unlock()
-	
-	// In a real implementation, this would load the audio file
-	// For now, we'll create a placeholder sound
+
 	sound := &Sound{
-		ID:      id,
-		Data:    []byte{}, // Placeholder
-		Volume:  1.0,
-		Loop:    false,
+		ID:     id,
+		Data:   data,
+		Volume: 1.0,
+		Loop:   false,
+		Bus:    BusSFX,
+		Pitch:  1.0,
 	}
-	
+
 	m.sounds[id] = sound
 	return nil
 }
 
-// PlaySound plays a sound
-func (m *Manager) PlaySound(id string) error {
-	m.mutex.RLock()
+// PlaySound starts a new instance of a sound. Instances of the same sound
+// overlap; the returned handle controls this one only.
+func (m *Manager) PlaySound(id string) (PlaybackHandle, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
 	sound, exists := m.sounds[id]
-	m.mutex.RUnlock()
-	
 	if !exists {
-		return nil
+		return 0, fmt.Errorf("sound %s not found", id)
 	}
-	
-	sound.Playing = true
-	// In a real implementation, this would start audio playback
-	return nil
+	if m.context == nil {
+		return 0, fmt.Errorf("audio manager not initialized")
+	}
+
+	m.pruneInstances()
+
+	inst := &instance{
+		soundID:     id,
+		bus:         sound.Bus,
+		volume:      sound.Volume,
+		spatialGain: 1,
+	}
+	inst.voice = m.context.mixer.play(sound.Data, m.effectiveVolume(inst), sound.Loop)
+	m.context.mixer.setPitch(inst.voice, sound.Pitch)
+
+	handle := m.nextHandle
+	m.nextHandle++
+	m.instances[handle] = inst
+	return handle, nil
 }
 
-// StopSound stops a sound
+// StopSound stops all instances of a sound
 func (m *Manager) StopSound(id string) error {
-	m.mutex.RLock()
-	sound, exists := m.sounds[id]
-	m.mutex.RUnlock()
-	
-	if !exists {
-		return nil
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for handle, inst := range m.instances {
+		if inst.soundID == id {
+			m.stopInstance(handle, inst)
+		}
 	}
-	
-	sound.Playing = false
-	// In a real implementation, this would stop audio playback
 	return nil
 }
 
-// SetVolume sets the volume of a sound
+// StopInstance stops one playing instance
+func (m *Manager) StopInstance(handle PlaybackHandle) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if inst, exists := m.instances[handle]; exists {
+		m.stopInstance(handle, inst)
+	}
+}
+
+// SetInstanceVolume sets the volume of one playing instance, before bus and master volume
+func (m *Manager) SetInstanceVolume(handle PlaybackHandle, volume float64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if inst, exists := m.instances[handle]; exists {
+		inst.volume = clampVolume(volume)
+		m.context.mixer.setVolume(inst.voice, m.effectiveVolume(inst))
+	}
+}
+
+// SetInstanceLoop sets whether one playing instance loops
+func (m *Manager) SetInstanceLoop(handle PlaybackHandle, loop bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if inst, exists := m.instances[handle]; exists {
+		m.context.mixer.setLoop(inst.voice, loop)
+	}
+}
+
+// SetInstanceSpatial sets the distance gain and stereo pan (-1 left to 1 right) of one playing instance
+func (m *Manager) SetInstanceSpatial(handle PlaybackHandle, gain, pan float64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if inst, exists := m.instances[handle]; exists {
+		inst.spatialGain = clampVolume(gain)
+		m.context.mixer.setVolume(inst.voice, m.effectiveVolume(inst))
+		m.context.mixer.setPan(inst.voice, pan)
+	}
+}
+
+// SetInstancePitch sets the playback rate of one playing instance
+func (m *Manager) SetInstancePitch(handle PlaybackHandle, pitch float64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if inst, exists := m.instances[handle]; exists {
+		m.context.mixer.setPitch(inst.voice, clampPitch(pitch))
+	}
+}
+
+// IsInstancePlaying returns true if the instance hasn't stopped or finished
+func (m *Manager) IsInstancePlaying(handle PlaybackHandle) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.pruneInstances()
+	_, exists := m.instances[handle]
+	return exists
+}
+
+// GetInstanceCount returns the number of playing or paused instances of a sound
+func (m *Manager) GetInstanceCount(id string) int {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.pruneInstances()
+	count := 0
+	for _, inst := range m.instances {
+		if inst.soundID == id {
+			count++
+		}
+	}
+	return count
+}
+
+// SetVolume sets the volume of a sound and all its playing instances
 func (m *Manager) SetVolume(id string, volume float64) error {
-	m.mutex.RLock()
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
 	sound, exists := m.sounds[id]
-	m.mutex.RUnlock()
-	
 	if !exists {
 		return nil
 	}
-	
-	// Clamp volume between 0 and 1
-	if volume < 0 {
-		volume = 0
-	} else if volume > 1 {
-		volume = 1
+
+	sound.Volume = clampVolume(volume)
+	for _, inst := range m.instances {
+		if inst.soundID == id {
+			inst.volume = sound.Volume
+			m.context.mixer.setVolume(inst.voice, m.effectiveVolume(inst))
+		}
 	}
-	
-	sound.Volume = volume
 	return nil
 }
 
-// SetLoop sets whether a sound should loop
+// SetLoop sets whether a sound and all its playing instances should loop
 func (m *Manager) SetLoop(id string, loop bool) error {
-	m.mutex.RLock()
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
 	sound, exists := m.sounds[id]
-	m.mutex.RUnlock()
-	
 	if !exists {
 		return nil
 	}
-	
+
 	sound.Loop = loop
+	for _, inst := range m.instances {
+		if inst.soundID == id {
+			m.context.mixer.setLoop(inst.voice, loop)
+		}
+	}
 	return nil
 }
 
-// IsPlaying returns true if a sound is currently playing
+// SetPitch sets the playback rate of a sound and all its playing instances.
+// 1 is normal, 2 is an octave up; the rate is clamped to [MinPitch, MaxPitch].
+func (m *Manager) SetPitch(id string, pitch float64) error {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	sound, exists := m.sounds[id]
+	if !exists {
+		return nil
+	}
+
+	sound.Pitch = clampPitch(pitch)
+	for _, inst := range m.instances {
+		if inst.soundID == id {
+			m.context.mixer.setPitch(inst.voice, sound.Pitch)
+		}
+	}
+	return nil
+}
+
+// IsPlaying returns true if any instance of a sound is playing and not paused
 func (m *Manager) IsPlaying(id string) bool {
-	m.mutex.RLock()
-	defer m.mutex.RUnlock()
-	
-	if sound, exists := m.sounds[id]; exists {
-		return sound.Playing
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.paused {
+		return false
+	}
+
+	m.pruneInstances()
+	for _, inst := range m.instances {
+		if inst.soundID == id && !m.context.mixer.isPaused(inst.voice) {
+			return true
+		}
 	}
 	return false
 }
@@ -159,9 +358,49 @@ func (m *Manager) IsPlaying(id string) bool {
 func (m *Manager) GetVolume(id string) float64 {
 	m.mutex.RLock()
 	defer m.mutex.RUnlock()
-	
+
 	if sound, exists := m.sounds[id]; exists {
 		return sound.Volume
 	}
 	return 0.0
 }
+
+// stopInstance stops and forgets an instance; the caller must hold the lock
+func (m *Manager) stopInstance(handle PlaybackHandle, inst *instance) {
+	m.context.mixer.stop(inst.voice)
+	delete(m.instances, handle)
+}
+
+// pruneInstances forgets instances that played to their end; the caller must hold the lock
+func (m *Manager) pruneInstances() {
+	if m.context == nil {
+		return
+	}
+	for handle, inst := range m.instances {
+		if !m.context.mixer.isActive(inst.voice) {
+			delete(m.instances, handle)
+		}
+	}
+}
+
+// clampPitch clamps a playback rate to [MinPitch, MaxPitch]
+func clampPitch(pitch float64) float64 {
+	if pitch < MinPitch {
+		return MinPitch
+	}
+	if pitch > MaxPitch {
+		return MaxPitch
+	}
+	return pitch
+}
+
+// clampVolume clamps a volume between 0 and 1
+func clampVolume(volume float64) float64 {
+	if volume < 0 {
+		return 0
+	}
+	if volume > 1 {
+		return 1
+	}
+	return volume
+}
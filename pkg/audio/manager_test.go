@@ -0,0 +1,92 @@
+package audio
+
+import (
+	"io"
+	"testing"
+)
+
+// manualDevice never pulls from its source, so tests advance playback by
+// reading the mixer themselves
+type manualDevice struct{}
+
+func (manualDevice) Start(source io.Reader) error { return nil }
+func (manualDevice) Close() error                 { return nil }
+
+// newTestManager creates an initialized manager on a manual device with a
+// one-second mono sound loaded as "tone"
+func newTestManager(t *testing.T) *Manager {
+	t.Helper()
+
+	previous := openDevice
+	openDevice = func() (Device, error) { return manualDevice{}, nil }
+	t.Cleanup(func() { openDevice = previous })
+
+	manager := NewManager()
+	if err := manager.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	t.Cleanup(manager.Shutdown)
+
+	addSound(manager, "tone", &PCM{
+		SampleRate: OutputSampleRate,
+		Channels:   1,
+		Samples:    make([]float32, OutputSampleRate),
+	})
+	return manager
+}
+
+// addSound registers decoded audio as a sound with default settings
+func addSound(m *Manager, id string, data *PCM) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.sounds[id] = &Sound{ID: id, Data: data, Volume: 1, Bus: BusSFX, Pitch: 1}
+}
+
+// mixFrames pulls frames of output from the manager's mixer
+func mixFrames(m *Manager, frames int) {
+	m.context.mixer.Read(make([]byte, frames*outputFrameSize))
+}
+
+func TestPlaySoundStartsSeparateVoices(t *testing.T) {
+	manager := newTestManager(t)
+
+	first, err := manager.PlaySound("tone")
+	if err != nil {
+		t.Fatalf("PlaySound: %v", err)
+	}
+	second, err := manager.PlaySound("tone")
+	if err != nil {
+		t.Fatalf("PlaySound: %v", err)
+	}
+
+	if first == second {
+		t.Fatalf("both PlaySound calls returned handle %d", first)
+	}
+	if count := manager.GetInstanceCount("tone"); count != 2 {
+		t.Errorf("GetInstanceCount() = %d, want 2", count)
+	}
+	if voices := len(manager.context.mixer.voices); voices != 2 {
+		t.Errorf("mixer has %d voices, want 2", voices)
+	}
+	if manager.instances[first].voice == manager.instances[second].voice {
+		t.Error("both instances share one voice")
+	}
+
+	// Stopping one instance leaves the other playing
+	manager.StopInstance(first)
+	if manager.IsInstancePlaying(first) {
+		t.Error("stopped instance still playing")
+	}
+	if !manager.IsInstancePlaying(second) {
+		t.Error("stopping one instance stopped the other")
+	}
+}
+
+func TestPlayUnknownSound(t *testing.T) {
+	manager := newTestManager(t)
+
+	if _, err := manager.PlaySound("missing"); err == nil {
+		t.Error("PlaySound of an unloaded sound succeeded")
+	}
+}
@@ -0,0 +1,236 @@
+package audio
+
+import (
+	"encoding/binary"
+	"math"
+	"sync"
+)
+
+// OutputSampleRate is the sample rate of the mixed output stream
+const OutputSampleRate = 44100
+
+// OutputChannels is the number of interleaved channels in the mixed output stream
+const OutputChannels = 2
+
+// outputFrameSize is the size in bytes of one signed 16-bit stereo frame
+const outputFrameSize = OutputChannels * 2
+
+// voice is one playing instance of decoded audio
+type voice struct {
+	pcm *PCM
+	// position is the fractional read position in source frames
+	position float64
+	// step is the number of source frames advanced per output frame at normal pitch
+	step   float64
+	pitch  float64
+	volume float32
+	// pan balances the output from -1 (left only) to 1 (right only)
+	pan      float32
+	loop     bool
+	paused   bool
+	finished bool
+}
+
+// mixer sums all active voices into a signed 16-bit little-endian stereo
+// stream at OutputSampleRate, resampling each voice from its native rate
+type mixer struct {
+	mutex  sync.Mutex
+	voices []*voice
+	// paused silences the output without advancing any voice
+	paused bool
+}
+
+// newMixer creates an empty mixer
+func newMixer() *mixer {
+	return &mixer{}
+}
+
+// play starts a new voice for pcm
+func (m *mixer) play(pcm *PCM, volume float64, loop bool) *voice {
+	v := &voice{
+		pcm:    pcm,
+		step:   float64(pcm.SampleRate) / OutputSampleRate,
+		pitch:  1,
+		volume: float32(volume),
+		loop:   loop,
+	}
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	// Empty sounds finish immediately
+	v.finished = pcm.Frames() == 0
+	if !v.finished {
+		m.voices = append(m.voices, v)
+	}
+	return v
+}
+
+// stop removes a voice from the mix
+func (m *mixer) stop(v *voice) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	v.finished = true
+	m.removeFinished()
+}
+
+// isActive returns true if the voice is still being mixed
+func (m *mixer) isActive(v *voice) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return !v.finished
+}
+
+// setVolume changes the volume of a voice
+func (m *mixer) setVolume(v *voice, volume float64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	v.volume = float32(volume)
+}
+
+// setPitch changes the playback rate of a voice
+func (m *mixer) setPitch(v *voice, pitch float64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	v.pitch = pitch
+}
+
+// setPan changes the stereo balance of a voice
+func (m *mixer) setPan(v *voice, pan float64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	v.pan = float32(math.Max(-1, math.Min(1, pan)))
+}
+
+// setPaused pauses or resumes a voice, keeping its position
+func (m *mixer) setPaused(v *voice, paused bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	v.paused = paused
+}
+
+// isPaused returns true if the voice is paused
+func (m *mixer) isPaused(v *voice) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return v.paused
+}
+
+// setAllPaused pauses or resumes the whole mix
+func (m *mixer) setAllPaused(paused bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	m.paused = paused
+}
+
+// setLoop changes whether a voice wraps around at its end
+func (m *mixer) setLoop(v *voice, loop bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	v.loop = loop
+}
+
+// stopAll removes every voice from the mix
+func (m *mixer) stopAll() {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, v := range m.voices {
+		v.finished = true
+	}
+	m.voices = nil
+}
+
+// Read fills p with whole mixed frames. It never fails, producing silence
+// when nothing is playing, so an audio device can pull from it indefinitely.
+func (m *mixer) Read(p []byte) (int, error) {
+	frames := len(p) / outputFrameSize
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for frame := 0; frame < frames; frame++ {
+		var left, right float32
+		for _, v := range m.voices {
+			if v.finished || v.paused || m.paused {
+				continue
+			}
+			l, r := v.next()
+			left += l
+			right += r
+		}
+
+		offset := frame * outputFrameSize
+		binary.LittleEndian.PutUint16(p[offset:], uint16(toInt16(left)))
+		binary.LittleEndian.PutUint16(p[offset+2:], uint16(toInt16(right)))
+	}
+
+	m.removeFinished()
+	return frames * outputFrameSize, nil
+}
+
+// removeFinished drops finished voices; the caller must hold the lock
+func (m *mixer) removeFinished() {
+	active := m.voices[:0]
+	for _, v := range m.voices {
+		if !v.finished {
+			active = append(active, v)
+		}
+	}
+	for i := len(active); i < len(m.voices); i++ {
+		m.voices[i] = nil
+	}
+	m.voices = active
+}
+
+// next returns the voice's next output frame, linearly interpolating
+// between source frames, and advances it
+func (v *voice) next() (float32, float32) {
+	frames := v.pcm.Frames()
+	if v.position >= float64(frames) {
+		if !v.loop {
+			v.finished = true
+			return 0, 0
+		}
+		v.position = math.Mod(v.position, float64(frames))
+	}
+
+	index := int(v.position)
+	fraction := float32(v.position - float64(index))
+
+	following := index + 1
+	if following >= frames {
+		following = index
+		if v.loop {
+			following = 0
+		}
+	}
+
+	left0, right0 := v.pcm.frame(index)
+	left1, right1 := v.pcm.frame(following)
+	v.position += v.step * v.pitch
+
+	left := left0 + (left1-left0)*fraction
+	right := right0 + (right1-right0)*fraction
+
+	// Balance keeps the centered volume and fades out the far side
+	leftGain, rightGain := float32(1), float32(1)
+	if v.pan > 0 {
+		leftGain = 1 - v.pan
+	} else if v.pan < 0 {
+		rightGain = 1 + v.pan
+	}
+
+	return left * v.volume * leftGain, right * v.volume * rightGain
+}
+
+// toInt16 converts a float sample to 16-bit, clipping out-of-range values
+func toInt16(sample float32) int16 {
+	if sample > 1 {
+		sample = 1
+	} else if sample < -1 {
+		sample = -1
+	}
+	return int16(sample * 32767)
+}
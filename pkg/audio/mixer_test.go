@@ -0,0 +1,62 @@
+package audio
+
+import (
+	"testing"
+)
+
+// rampPCM creates mono audio at the output rate whose samples count up
+func rampPCM(frames int) *PCM {
+	samples := make([]float32, frames)
+	for i := range samples {
+		samples[i] = float32(i) / float32(frames)
+	}
+	return &PCM{SampleRate: OutputSampleRate, Channels: 1, Samples: samples}
+}
+
+func TestPitchAdvancesSourceFasterThanOutput(t *testing.T) {
+	tests := []struct {
+		pitch float64
+		want  float64
+	}{
+		{1, 100},
+		{2, 200},
+		{0.5, 50},
+	}
+
+	for _, test := range tests {
+		mixer := newMixer()
+		v := mixer.play(rampPCM(1000), 1, false)
+		mixer.setPitch(v, test.pitch)
+
+		// 100 output frames consume pitch times as many source frames
+		mixer.Read(make([]byte, 100*outputFrameSize))
+		if v.position != test.want {
+			t.Errorf("pitch %v: position after 100 frames = %v, want %v", test.pitch, v.position, test.want)
+		}
+	}
+}
+
+func TestPitchInterpolatesBetweenFrames(t *testing.T) {
+	v := &voice{
+		pcm:    &PCM{SampleRate: OutputSampleRate, Channels: 1, Samples: []float32{0, 1, 0}},
+		step:   1,
+		pitch:  0.5,
+		volume: 1,
+	}
+
+	want := []float32{0, 0.5, 1, 0.5}
+	for i, expected := range want {
+		if left, _ := v.next(); left != expected {
+			t.Errorf("frame %d = %v, want %v", i, left, expected)
+		}
+	}
+}
+
+func TestClampPitch(t *testing.T) {
+	if got := clampPitch(10); got != MaxPitch {
+		t.Errorf("clampPitch(10) = %v, want %v", got, MaxPitch)
+	}
+	if got := clampPitch(0); got != MinPitch {
+		t.Errorf("clampPitch(0) = %v, want %v", got, MinPitch)
+	}
+}
@@ -0,0 +1,94 @@
+package audio
+
+// PauseSound pauses all instances of a sound, keeping their positions
+func (m *Manager) PauseSound(id string) error {
+	m.setSoundPaused(id, true)
+	return nil
+}
+
+// ResumeSound resumes the paused instances of a sound from where they stopped
+func (m *Manager) ResumeSound(id string) error {
+	m.setSoundPaused(id, false)
+	return nil
+}
+
+// PauseInstance pauses one playing instance, keeping its position
+func (m *Manager) PauseInstance(handle PlaybackHandle) {
+	m.setInstancePaused(handle, true)
+}
+
+// ResumeInstance resumes a paused instance from where it stopped
+func (m *Manager) ResumeInstance(handle PlaybackHandle) {
+	m.setInstancePaused(handle, false)
+}
+
+// PauseAll pauses the whole mixer, e.g. while the game is paused. Sounds
+// paused individually stay paused after ResumeAll.
+func (m *Manager) PauseAll() {
+	m.setAllPaused(true)
+}
+
+// ResumeAll resumes the mixer after PauseAll
+func (m *Manager) ResumeAll() {
+	m.setAllPaused(false)
+}
+
+// IsPaused returns true if a sound has instances and all of them are paused,
+// individually or by PauseAll
+func (m *Manager) IsPaused(id string) bool {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.pruneInstances()
+	found := false
+	for _, inst := range m.instances {
+		if inst.soundID != id {
+			continue
+		}
+		if !m.paused && !m.context.mixer.isPaused(inst.voice) {
+			return false
+		}
+		found = true
+	}
+	return found
+}
+
+// IsAllPaused returns true if the mixer is paused by PauseAll
+func (m *Manager) IsAllPaused() bool {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+	return m.paused
+}
+
+// setSoundPaused pauses or resumes all instances of a sound
+func (m *Manager) setSoundPaused(id string, paused bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	for _, inst := range m.instances {
+		if inst.soundID == id {
+			m.context.mixer.setPaused(inst.voice, paused)
+		}
+	}
+}
+
+// setInstancePaused pauses or resumes one instance
+func (m *Manager) setInstancePaused(handle PlaybackHandle, paused bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if inst, exists := m.instances[handle]; exists {
+		m.context.mixer.setPaused(inst.voice, paused)
+	}
+}
+
+// setAllPaused pauses or resumes the mixer
+func (m *Manager) setAllPaused(paused bool) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.paused = paused
+	if m.context != nil {
+		m.context.mixer.setAllPaused(paused)
+	}
+}
@@ -0,0 +1,72 @@
+package audio
+
+import (
+	"testing"
+)
+
+func TestResumeContinuesFromOffset(t *testing.T) {
+	manager := newTestManager(t)
+
+	handle, err := manager.PlaySound("tone")
+	if err != nil {
+		t.Fatalf("PlaySound: %v", err)
+	}
+	voice := manager.instances[handle].voice
+
+	mixFrames(manager, 500)
+	manager.PauseSound("tone")
+	if !manager.IsPaused("tone") || manager.IsPlaying("tone") {
+		t.Error("sound not reported paused after PauseSound")
+	}
+
+	// Paused voices hold their position while the mixer keeps running
+	mixFrames(manager, 500)
+	if voice.position != 500 {
+		t.Fatalf("position while paused = %v, want 500", voice.position)
+	}
+
+	manager.ResumeSound("tone")
+	mixFrames(manager, 100)
+	if voice.position != 600 {
+		t.Errorf("position after resuming = %v, want 600", voice.position)
+	}
+	if manager.IsPaused("tone") || !manager.IsPlaying("tone") {
+		t.Error("sound not reported playing after ResumeSound")
+	}
+}
+
+func TestPauseAllKeepsIndividualPauses(t *testing.T) {
+	manager := newTestManager(t)
+
+	playing, _ := manager.PlaySound("tone")
+	paused, _ := manager.PlaySound("tone")
+	manager.PauseInstance(paused)
+
+	manager.PauseAll()
+	mixFrames(manager, 100)
+	if position := manager.instances[playing].voice.position; position != 0 {
+		t.Errorf("voice advanced to %v during PauseAll", position)
+	}
+	if !manager.IsPaused("tone") {
+		t.Error("IsPaused() = false during PauseAll")
+	}
+
+	manager.ResumeAll()
+	mixFrames(manager, 100)
+	if position := manager.instances[playing].voice.position; position != 100 {
+		t.Errorf("position after ResumeAll = %v, want 100", position)
+	}
+	if position := manager.instances[paused].voice.position; position != 0 {
+		t.Errorf("individually paused voice advanced to %v after ResumeAll", position)
+	}
+}
+
+func TestStoppedIsNotPaused(t *testing.T) {
+	manager := newTestManager(t)
+
+	manager.PlaySound("tone")
+	manager.StopSound("tone")
+	if manager.IsPaused("tone") || manager.IsPlaying("tone") {
+		t.Error("stopped sound reported paused or playing")
+	}
+}
@@ -0,0 +1,36 @@
+package audio
+
+import (
+	"time"
+)
+
+// PCM holds decoded audio as interleaved float32 samples in [-1, 1]
+type PCM struct {
+	SampleRate int
+	Channels   int
+	Samples    []float32
+}
+
+// Frames returns the number of sample frames, one sample per channel each
+func (p *PCM) Frames() int {
+	if p.Channels == 0 {
+		return 0
+	}
+	return len(p.Samples) / p.Channels
+}
+
+// Duration returns the playback length at the native sample rate
+func (p *PCM) Duration() time.Duration {
+	if p.SampleRate == 0 {
+		return 0
+	}
+	return time.Duration(p.Frames()) * time.Second / time.Duration(p.SampleRate)
+}
+
+// frame returns the left and right samples of a frame; mono is duplicated
+func (p *PCM) frame(index int) (float32, float32) {
+	if p.Channels == 1 {
+		return p.Samples[index], p.Samples[index]
+	}
+	return p.Samples[index*p.Channels], p.Samples[index*p.Channels+1]
+}
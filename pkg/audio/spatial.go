@@ -0,0 +1,133 @@
+package audio
+
+import (
+	"math"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Attenuation returns the gain of a sound at distance from the listener: 1
+// up to minDistance, falling to 0 at maxDistance with the curve
+// (1 - t)^rolloff, and 0 beyond
+func Attenuation(distance, minDistance, maxDistance, rolloff float64) float64 {
+	if distance <= minDistance {
+		return 1
+	}
+	if distance >= maxDistance {
+		return 0
+	}
+	if rolloff <= 0 {
+		rolloff = 1
+	}
+
+	t := (distance - minDistance) / (maxDistance - minDistance)
+	return math.Pow(1-t, rolloff)
+}
+
+// Pan returns the stereo position of a source, from -1 (left) to 1 (right),
+// as seen by a listener at listenerPosition facing forward
+func Pan(listenerPosition, forward, sourcePosition mgl32.Vec3) float64 {
+	direction := sourcePosition.Sub(listenerPosition)
+	if direction.Len() < 1e-6 {
+		return 0
+	}
+
+	right := forward.Cross(mgl32.Vec3{0, 1, 0})
+	if right.Len() < 1e-6 {
+		// Looking straight up or down, left and right are undefined
+		return 0
+	}
+
+	pan := float64(direction.Normalize().Dot(right.Normalize()))
+	return math.Max(-1, math.Min(1, pan))
+}
+
+// System plays the sounds of audio components and applies spatial
+// attenuation and panning relative to the listener each frame
+type System struct {
+	manager *Manager
+}
+
+// NewSystem creates an audio system playing through manager
+func NewSystem(manager *Manager) *System {
+	return &System{manager: manager}
+}
+
+// Play starts the sound of an entity's audio component, replacing any instance it was playing
+func (s *System) Play(world *ecs.World, entityID ecs.EntityID) error {
+	component, ok := world.GetComponent(entityID, "audio").(*ecs.AudioComponent)
+	if !ok {
+		return nil
+	}
+
+	if component.Instance != 0 {
+		s.manager.StopInstance(PlaybackHandle(component.Instance))
+	}
+
+	handle, err := s.manager.PlaySound(component.SoundID)
+	if err != nil {
+		return err
+	}
+	s.manager.SetInstanceVolume(handle, component.Volume)
+	s.manager.SetInstanceLoop(handle, component.Loop)
+	component.Instance = uint64(handle)
+
+	// Spatialize before the first mix
+	s.spatialize(world, entityID, component)
+	return nil
+}
+
+// Update applies attenuation and panning to all spatial sounds
+func (s *System) Update(deltaTime float64, world *ecs.World) {
+	for _, entityID := range world.GetEntitiesWithComponent("audio") {
+		component, ok := world.GetComponent(entityID, "audio").(*ecs.AudioComponent)
+		if !ok || component.Instance == 0 {
+			continue
+		}
+
+		if !s.manager.IsInstancePlaying(PlaybackHandle(component.Instance)) {
+			component.Instance = 0
+			continue
+		}
+
+		s.spatialize(world, entityID, component)
+	}
+}
+
+// GetName returns the system name
+func (s *System) GetName() string {
+	return "AudioSystem"
+}
+
+// spatialize sets the gain and pan of an entity's playing sound
+func (s *System) spatialize(world *ecs.World, entityID ecs.EntityID, component *ecs.AudioComponent) {
+	spatial, ok := world.GetComponent(entityID, "spatial_audio").(*ecs.SpatialAudioComponent)
+	if !ok {
+		return
+	}
+	source, ok := world.GetComponent(entityID, "transform").(*ecs.TransformComponent)
+	if !ok {
+		return
+	}
+	listener := findListener(world)
+	if listener == nil {
+		return
+	}
+
+	distance := float64(source.Position.Sub(listener.Position).Len())
+	gain := Attenuation(distance, spatial.MinDistance, spatial.MaxDistance, spatial.Rolloff)
+	pan := Pan(listener.Position, listener.Forward(), source.Position)
+
+	s.manager.SetInstanceSpatial(PlaybackHandle(component.Instance), gain, pan)
+}
+
+// findListener returns the transform of the first listener, or nil
+func findListener(world *ecs.World) *ecs.TransformComponent {
+	for _, entityID := range world.GetEntitiesWithComponent("audio_listener") {
+		if transform, ok := world.GetComponent(entityID, "transform").(*ecs.TransformComponent); ok {
+			return transform
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package audio
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestAttenuation(t *testing.T) {
+	tests := []struct {
+		name     string
+		distance float64
+		rolloff  float64
+		want     float64
+	}{
+		{"inside min distance", 1, 1, 1},
+		{"at min distance", 2, 1, 1},
+		{"halfway linear", 6, 1, 0.5},
+		{"halfway quadratic", 6, 2, 0.25},
+		{"at max distance", 10, 1, 0},
+		{"beyond max distance", 50, 1, 0},
+		{"zero rolloff is linear", 6, 0, 0.5},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Attenuation(test.distance, 2, 10, test.rolloff)
+			if math.Abs(got-test.want) > 1e-9 {
+				t.Errorf("Attenuation(%v) = %v, want %v", test.distance, got, test.want)
+			}
+		})
+	}
+}
+
+func TestPan(t *testing.T) {
+	forward := mgl32.Vec3{0, 0, -1}
+
+	tests := []struct {
+		name   string
+		source mgl32.Vec3
+		want   float64
+	}{
+		{"right", mgl32.Vec3{5, 0, 0}, 1},
+		{"left", mgl32.Vec3{-5, 0, 0}, -1},
+		{"ahead", mgl32.Vec3{0, 0, -5}, 0},
+		{"on the listener", mgl32.Vec3{}, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := Pan(mgl32.Vec3{}, forward, test.source)
+			if math.Abs(got-test.want) > 1e-6 {
+				t.Errorf("Pan() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestSystemAttenuatesBeyondMaxDistance(t *testing.T) {
+	manager := newTestManager(t)
+	system := NewSystem(manager)
+	world := ecs.NewWorld()
+
+	listener := world.CreateEntity()
+	world.AddComponent(listener, ecs.NewTransformComponent(mgl32.Vec3{}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}))
+	world.AddComponent(listener, ecs.NewAudioListenerComponent())
+
+	source := world.CreateEntity()
+	transform := ecs.NewTransformComponent(mgl32.Vec3{1, 0, 0}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1})
+	world.AddComponent(source, transform)
+	world.AddComponent(source, ecs.NewAudioComponent("tone", 1, true))
+	world.AddComponent(source, ecs.NewSpatialAudioComponent(2, 10, 1))
+
+	if err := system.Play(world, source); err != nil {
+		t.Fatalf("Play: %v", err)
+	}
+	component := world.GetComponent(source, "audio").(*ecs.AudioComponent)
+	voice := manager.instances[PlaybackHandle(component.Instance)].voice
+
+	if voice.volume != 1 {
+		t.Errorf("volume inside min distance = %v, want 1", voice.volume)
+	}
+	if voice.pan != 1 {
+		t.Errorf("pan of a source to the right = %v, want 1", voice.pan)
+	}
+
+	transform.SetPosition(mgl32.Vec3{20, 0, 0})
+	system.Update(0.016, world)
+	if voice.volume != 0 {
+		t.Errorf("volume beyond max distance = %v, want 0", voice.volume)
+	}
+}
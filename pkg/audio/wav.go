@@ -0,0 +1,130 @@
+package audio
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// WAV format codes
+const (
+	wavFormatPCM        = 1
+	wavFormatIEEEFloat  = 3
+	wavFormatExtensible = 0xFFFE
+)
+
+// DecodeWAV decodes a RIFF WAVE stream holding integer PCM (8, 16, 24 or
+// 32 bit) or 32-bit float samples with one or two channels
+func DecodeWAV(reader io.Reader) (*PCM, error) {
+	var header [12]byte
+	if _, err := io.ReadFull(reader, header[:]); err != nil {
+		return nil, fmt.Errorf("failed to read WAV header: %w", err)
+	}
+	if string(header[0:4]) != "RIFF" || string(header[8:12]) != "WAVE" {
+		return nil, errors.New("not a RIFF WAVE file")
+	}
+
+	var format, channels, bitsPerSample uint16
+	var sampleRate uint32
+	haveFormat := false
+
+	for {
+		var chunk [8]byte
+		if _, err := io.ReadFull(reader, chunk[:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, errors.New("WAV file has no data chunk")
+			}
+			return nil, fmt.Errorf("failed to read WAV chunk: %w", err)
+		}
+		id := string(chunk[0:4])
+		size := binary.LittleEndian.Uint32(chunk[4:8])
+
+		switch id {
+		case "fmt ":
+			if size < 16 {
+				return nil, fmt.Errorf("WAV fmt chunk too short (%d bytes)", size)
+			}
+			// Chunks are padded to an even size
+			data := make([]byte, size+size&1)
+			if _, err := io.ReadFull(reader, data); err != nil {
+				return nil, fmt.Errorf("failed to read WAV fmt chunk: %w", err)
+			}
+
+			format = binary.LittleEndian.Uint16(data[0:2])
+			channels = binary.LittleEndian.Uint16(data[2:4])
+			sampleRate = binary.LittleEndian.Uint32(data[4:8])
+			bitsPerSample = binary.LittleEndian.Uint16(data[14:16])
+
+			// Extensible files keep the real format in the sub-format GUID
+			if format == wavFormatExtensible && size >= 26 {
+				format = binary.LittleEndian.Uint16(data[24:26])
+			}
+			haveFormat = true
+		case "data":
+			if !haveFormat {
+				return nil, errors.New("WAV data chunk before fmt chunk")
+			}
+			data := make([]byte, size)
+			n, err := io.ReadFull(reader, data)
+			if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+				return nil, fmt.Errorf("failed to read WAV data: %w", err)
+			}
+			// Tolerate truncated files by keeping what was read
+			return decodeWAVSamples(data[:n], format, int(channels), int(sampleRate), int(bitsPerSample))
+		default:
+			// Skip unknown chunks
+			if _, err := io.CopyN(io.Discard, reader, int64(size)+int64(size&1)); err != nil {
+				return nil, fmt.Errorf("failed to skip WAV %q chunk: %w", id, err)
+			}
+		}
+	}
+}
+
+// decodeWAVSamples converts raw WAV sample data to float PCM
+func decodeWAVSamples(data []byte, format uint16, channels, sampleRate, bitsPerSample int) (*PCM, error) {
+	if channels < 1 || channels > 2 {
+		return nil, fmt.Errorf("unsupported WAV channel count %d", channels)
+	}
+	if sampleRate <= 0 {
+		return nil, fmt.Errorf("invalid WAV sample rate %d", sampleRate)
+	}
+
+	bytesPerSample := bitsPerSample / 8
+	var convert func(b []byte) float32
+
+	switch {
+	case format == wavFormatPCM && bitsPerSample == 8:
+		// 8-bit WAV is unsigned
+		convert = func(b []byte) float32 { return (float32(b[0]) - 128) / 128 }
+	case format == wavFormatPCM && bitsPerSample == 16:
+		convert = func(b []byte) float32 { return float32(int16(binary.LittleEndian.Uint16(b))) / 32768 }
+	case format == wavFormatPCM && bitsPerSample == 24:
+		convert = func(b []byte) float32 {
+			value := int32(uint32(b[0])<<8|uint32(b[1])<<16|uint32(b[2])<<24) >> 8
+			return float32(value) / 8388608
+		}
+	case format == wavFormatPCM && bitsPerSample == 32:
+		convert = func(b []byte) float32 { return float32(int32(binary.LittleEndian.Uint32(b))) / 2147483648 }
+	case format == wavFormatIEEEFloat && bitsPerSample == 32:
+		convert = func(b []byte) float32 { return math.Float32frombits(binary.LittleEndian.Uint32(b)) }
+	default:
+		return nil, fmt.Errorf("unsupported WAV format %d with %d bits per sample", format, bitsPerSample)
+	}
+
+	// Drop a trailing partial frame
+	frameSize := bytesPerSample * channels
+	count := len(data) / frameSize * channels
+
+	samples := make([]float32, count)
+	for i := range samples {
+		samples[i] = convert(data[i*bytesPerSample:])
+	}
+
+	return &PCM{
+		SampleRate: sampleRate,
+		Channels:   channels,
+		Samples:    samples,
+	}, nil
+}
@@ -0,0 +1,93 @@
+package audio
+
+import (
+	"bytes"
+	"encoding/binary"
+	"math"
+	"testing"
+)
+
+// sineWAV encodes seconds of a 16-bit sine tone as a WAV file
+func sineWAV(frequency float64, sampleRate, channels int, seconds float64) []byte {
+	frames := int(float64(sampleRate) * seconds)
+	data := new(bytes.Buffer)
+	for i := 0; i < frames; i++ {
+		value := int16(math.Sin(2*math.Pi*frequency*float64(i)/float64(sampleRate)) * 16000)
+		for c := 0; c < channels; c++ {
+			binary.Write(data, binary.LittleEndian, value)
+		}
+	}
+
+	file := new(bytes.Buffer)
+	file.WriteString("RIFF")
+	binary.Write(file, binary.LittleEndian, uint32(36+data.Len()))
+	file.WriteString("WAVE")
+
+	file.WriteString("fmt ")
+	binary.Write(file, binary.LittleEndian, uint32(16))
+	binary.Write(file, binary.LittleEndian, uint16(wavFormatPCM))
+	binary.Write(file, binary.LittleEndian, uint16(channels))
+	binary.Write(file, binary.LittleEndian, uint32(sampleRate))
+	binary.Write(file, binary.LittleEndian, uint32(sampleRate*channels*2))
+	binary.Write(file, binary.LittleEndian, uint16(channels*2))
+	binary.Write(file, binary.LittleEndian, uint16(16))
+
+	file.WriteString("data")
+	binary.Write(file, binary.LittleEndian, uint32(data.Len()))
+	file.Write(data.Bytes())
+	return file.Bytes()
+}
+
+func TestDecodeSineWAV(t *testing.T) {
+	for _, channels := range []int{1, 2} {
+		pcm, err := DecodeWAV(bytes.NewReader(sineWAV(440, 8000, channels, 0.5)))
+		if err != nil {
+			t.Fatalf("%d channels: DecodeWAV: %v", channels, err)
+		}
+
+		if pcm.SampleRate != 8000 || pcm.Channels != channels {
+			t.Errorf("format = %d Hz, %d channels; want 8000 Hz, %d", pcm.SampleRate, pcm.Channels, channels)
+		}
+		if pcm.Frames() != 4000 || len(pcm.Samples) != 4000*channels {
+			t.Errorf("%d channels: %d frames, %d samples; want 4000 frames", channels, pcm.Frames(), len(pcm.Samples))
+		}
+		if pcm.Duration().Seconds() != 0.5 {
+			t.Errorf("Duration() = %v, want 500ms", pcm.Duration())
+		}
+
+		// A quarter period in, the tone is at its peak of 16000/32768
+		left, right := pcm.frame(8000 / 440 / 4)
+		if left < 0.45 || left > 0.5 || right != left {
+			t.Errorf("%d channels: quarter-period sample = %v, %v; want about 0.49", channels, left, right)
+		}
+	}
+}
+
+func TestDecodeDetectsWAV(t *testing.T) {
+	// The header wins over a misleading extension
+	pcm, err := Decode(bytes.NewReader(sineWAV(440, 8000, 1, 0.1)), "tone.ogg")
+	if err != nil {
+		t.Fatalf("Decode: %v", err)
+	}
+	if pcm.Frames() != 800 {
+		t.Errorf("decoded %d frames, want 800", pcm.Frames())
+	}
+}
+
+func TestDecodeWAVErrors(t *testing.T) {
+	valid := sineWAV(440, 8000, 1, 0.1)
+	eightChannels := append([]byte(nil), valid...)
+	binary.LittleEndian.PutUint16(eightChannels[22:24], 8)
+
+	files := map[string][]byte{
+		"empty":          nil,
+		"not RIFF":       []byte("RIFX\x00\x00\x00\x00WAVEfmt "),
+		"no data":        valid[:36],
+		"eight channels": eightChannels,
+	}
+	for name, file := range files {
+		if _, err := DecodeWAV(bytes.NewReader(file)); err == nil {
+			t.Errorf("%s: DecodeWAV succeeded, want an error", name)
+		}
+	}
+}
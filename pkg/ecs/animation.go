@@ -0,0 +1,129 @@
+package ecs
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// AnimationComponent plays frames from a sprite sheet on the entity's sprite.
+// Frames are texture-space rectangles (minU, minV, maxU, maxV) with (0, 0) at
+// the top-left of the atlas.
+type AnimationComponent struct {
+	// AtlasID is the sprite sheet texture; the sprite's own texture is used when empty
+	AtlasID string
+	Frames  []mgl32.Vec4
+	FPS     float64
+	// Loop restarts the animation after the last frame; otherwise it stops there
+	Loop    bool
+	Playing bool
+
+	// Frame is the index of the current frame and FrameTime how long it has been shown
+	Frame     int
+	FrameTime float64
+}
+
+func (a *AnimationComponent) GetType() string {
+	return "animation"
+}
+
+// NewAnimationComponent creates a playing animation over frames of an atlas
+func NewAnimationComponent(atlasID string, frames []mgl32.Vec4, fps float64, loop bool) *AnimationComponent {
+	return &AnimationComponent{
+		AtlasID: atlasID,
+		Frames:  frames,
+		FPS:     fps,
+		Loop:    loop,
+		Playing: true,
+	}
+}
+
+// GridFrames returns the rectangles of the first count cells of a sprite sheet
+// laid out in a grid, row by row from the top-left
+func GridFrames(columns, rows, count int) []mgl32.Vec4 {
+	if columns <= 0 || rows <= 0 {
+		return nil
+	}
+	if count > columns*rows {
+		count = columns * rows
+	}
+
+	width := 1 / float32(columns)
+	height := 1 / float32(rows)
+
+	frames := make([]mgl32.Vec4, 0, count)
+	for i := 0; i < count; i++ {
+		u := float32(i%columns) * width
+		v := float32(i/columns) * height
+		frames = append(frames, mgl32.Vec4{u, v, u + width, v + height})
+	}
+	return frames
+}
+
+// CurrentFrame returns the rectangle of the current frame, or the whole
+// texture if there are no frames
+func (a *AnimationComponent) CurrentFrame() mgl32.Vec4 {
+	if len(a.Frames) == 0 {
+		return mgl32.Vec4{0, 0, 1, 1}
+	}
+	if a.Frame < 0 || a.Frame >= len(a.Frames) {
+		return a.Frames[len(a.Frames)-1]
+	}
+	return a.Frames[a.Frame]
+}
+
+// Play restarts the animation from the first frame
+func (a *AnimationComponent) Play() {
+	a.Frame = 0
+	a.FrameTime = 0
+	a.Playing = true
+}
+
+// Advance moves the animation forward by deltaTime seconds. A non-looping
+// animation stops on its last frame instead of wrapping around.
+func (a *AnimationComponent) Advance(deltaTime float64) {
+	if !a.Playing || a.FPS <= 0 || len(a.Frames) == 0 {
+		return
+	}
+
+	frameDuration := 1 / a.FPS
+	a.FrameTime += deltaTime
+	if a.FrameTime < frameDuration {
+		return
+	}
+
+	steps := int(a.FrameTime / frameDuration)
+	a.FrameTime -= float64(steps) * frameDuration
+
+	if a.Loop {
+		a.Frame = (a.Frame + steps) % len(a.Frames)
+		return
+	}
+
+	a.Frame += steps
+	if a.Frame >= len(a.Frames)-1 {
+		a.Frame = len(a.Frames) - 1
+		a.FrameTime = 0
+		a.Playing = false
+	}
+}
+
+// AnimationSystem advances the animation components of all entities each frame
+type AnimationSystem struct{}
+
+// NewAnimationSystem creates an animation system
+func NewAnimationSystem() *AnimationSystem {
+	return &AnimationSystem{}
+}
+
+// Update advances every playing animation by deltaTime
+func (s *AnimationSystem) Update(deltaTime float64, world *World) {
+	world.ForEachWithComponent("animation", func(entityID EntityID, component Component) {
+		if animation, ok := component.(*AnimationComponent); ok {
+			animation.Advance(deltaTime)
+		}
+	})
+}
+
+// GetName returns the system name
+func (s *AnimationSystem) GetName() string {
+	return "AnimationSystem"
+}
@@ -0,0 +1,107 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestGridFrames(t *testing.T) {
+	frames := GridFrames(4, 2, 6)
+	if len(frames) != 6 {
+		t.Fatalf("len(GridFrames(4, 2, 6)) = %d, want 6", len(frames))
+	}
+	if frames[0] != (mgl32.Vec4{0, 0, 0.25, 0.5}) {
+		t.Errorf("frame 0 = %v, want the top-left cell", frames[0])
+	}
+	if frames[5] != (mgl32.Vec4{0.25, 0.5, 0.5, 1}) {
+		t.Errorf("frame 5 = %v, want the second cell of the second row", frames[5])
+	}
+
+	// The count is capped at the number of cells
+	if got := len(GridFrames(2, 2, 10)); got != 4 {
+		t.Errorf("len(GridFrames(2, 2, 10)) = %d, want 4", got)
+	}
+	if got := GridFrames(0, 2, 1); got != nil {
+		t.Errorf("GridFrames(0, 2, 1) = %v, want nil", got)
+	}
+}
+
+func TestAnimationAdvance(t *testing.T) {
+	// Four frames a second, ticked at eight frames a second
+	animation := NewAnimationComponent("atlas", GridFrames(3, 1, 3), 4, true)
+
+	ticks := []struct {
+		deltaTime float64
+		frame     int
+	}{
+		{0.125, 0},
+		{0.125, 1},
+		{0.125, 1},
+		{0.125, 2},
+		// Wraps around after the last frame
+		{0.25, 0},
+		// A slow tick skips frames
+		{0.5, 2},
+	}
+	for i, tick := range ticks {
+		animation.Advance(tick.deltaTime)
+		if animation.Frame != tick.frame {
+			t.Errorf("tick %d: Frame = %d, want %d", i, animation.Frame, tick.frame)
+		}
+	}
+	if animation.CurrentFrame() != animation.Frames[2] {
+		t.Errorf("CurrentFrame() = %v, want frame 2", animation.CurrentFrame())
+	}
+}
+
+func TestAnimationStopsWithoutLoop(t *testing.T) {
+	animation := NewAnimationComponent("atlas", GridFrames(3, 1, 3), 4, false)
+
+	animation.Advance(0.5)
+	if animation.Frame != 2 || animation.Playing {
+		t.Fatalf("Frame = %d, Playing = %v; want stopped on the last frame", animation.Frame, animation.Playing)
+	}
+
+	// Stopped animations stay on the last frame
+	animation.Advance(1)
+	if animation.Frame != 2 {
+		t.Errorf("stopped animation moved to frame %d", animation.Frame)
+	}
+
+	// A long tick can't run past the end
+	animation.Play()
+	animation.Advance(10)
+	if animation.Frame != 2 || animation.Playing {
+		t.Errorf("Frame = %d, Playing = %v; want clamped to the last frame", animation.Frame, animation.Playing)
+	}
+}
+
+func TestAnimationCurrentFrameWithoutFrames(t *testing.T) {
+	animation := NewAnimationComponent("atlas", nil, 4, true)
+	animation.Advance(1)
+	if got := animation.CurrentFrame(); got != (mgl32.Vec4{0, 0, 1, 1}) {
+		t.Errorf("CurrentFrame() = %v, want the whole texture", got)
+	}
+}
+
+func TestAnimationSystem(t *testing.T) {
+	world := NewWorld()
+	world.AddSystem(NewAnimationSystem())
+
+	playing := NewAnimationComponent("atlas", GridFrames(2, 1, 2), 4, true)
+	paused := NewAnimationComponent("atlas", GridFrames(2, 1, 2), 4, true)
+	paused.Playing = false
+	for _, animation := range []*AnimationComponent{playing, paused} {
+		world.AddComponent(world.CreateEntity(), animation)
+	}
+
+	world.Update(0.25)
+
+	if playing.Frame != 1 {
+		t.Errorf("playing animation Frame = %d, want 1", playing.Frame)
+	}
+	if paused.Frame != 0 {
+		t.Errorf("paused animation Frame = %d, want 0", paused.Frame)
+	}
+}
@@ -0,0 +1,325 @@
+package ecs
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Archetype storage groups entities that have exactly the same component
+// types and stores each group's components in parallel slices, one per
+// type, so ForEach and ForEachWithComponent read components by row instead
+// of looking them up in every entity's map. A component bitmask per group
+// makes query matching a few word compares. Alongside the groups, an index
+// lists the stored entities in creation order with their group and row, so
+// iteration visits entities in the same order as without the storage.
+
+// MaxArchetypeComponentTypes is the number of distinct component types
+// archetype storage can tell apart
+const MaxArchetypeComponentTypes = 256
+
+// componentMask has one bit per component type
+type componentMask [MaxArchetypeComponentTypes / 64]uint64
+
+func (m *componentMask) set(bit int) {
+	m[bit/64] |= 1 << (bit % 64)
+}
+
+// contains reports whether every bit of other is set in m
+func (m componentMask) contains(other componentMask) bool {
+	for i := range m {
+		if m[i]&other[i] != other[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// archetype holds the entities with one set of component types
+type archetype struct {
+	mask componentMask
+	// columns holds one slice of components per type, parallel to entities;
+	// column maps a type's bit to its index in columns
+	columns  [][]Component
+	column   [MaxArchetypeComponentTypes]uint8
+	entities []EntityID
+}
+
+// entityLocation is where an entity's components are stored
+type entityLocation struct {
+	entityID EntityID
+	group    *archetype
+	row      int
+}
+
+// archetypeStorage stores a world's components by archetype
+type archetypeStorage struct {
+	bits       map[string]int
+	archetypes map[componentMask]*archetype
+	// index holds the stored entities sorted by ID, which is creation order
+	index []entityLocation
+}
+
+// newArchetypeStorage stores the components of entities, given in creation
+// order, reusing the type bits of an earlier storage if there is one
+func newArchetypeStorage(bits map[string]int, entities []*Entity) (*archetypeStorage, error) {
+	if bits == nil {
+		bits = make(map[string]int)
+	}
+	storage := &archetypeStorage{
+		bits:       bits,
+		archetypes: make(map[componentMask]*archetype),
+	}
+	for _, entity := range entities {
+		if err := storage.sync(entity.ID, entity.Components); err != nil {
+			return nil, err
+		}
+	}
+	return storage, nil
+}
+
+// EnableArchetypeStorage turns on archetype storage and indexes the current
+// entities. It returns an error if the world has more component types than
+// MaxArchetypeComponentTypes. Once enabled, adding or removing a component
+// moves the entity between groups; ForEach and ForEachWithComponent still
+// visit entities in creation order.
+func (w *World) EnableArchetypeStorage() error {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	storage, err := newArchetypeStorage(nil, w.orderedEntitiesLocked())
+	if err != nil {
+		return err
+	}
+	w.archetypes = storage
+	return nil
+}
+
+// ArchetypeStorageEnabled reports whether the world uses archetype storage
+func (w *World) ArchetypeStorageEnabled() bool {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	return w.archetypes != nil
+}
+
+// ArchetypeCount returns the number of component sets in archetype storage,
+// or 0 when it is disabled
+func (w *World) ArchetypeCount() int {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	if w.archetypes == nil {
+		return 0
+	}
+	count := 0
+	for _, group := range w.archetypes.archetypes {
+		if len(group.entities) > 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// orderedEntitiesLocked returns the entities in creation order; the caller
+// must hold the lock
+func (w *World) orderedEntitiesLocked() []*Entity {
+	entities := make([]*Entity, len(w.order))
+	for i, entityID := range w.order {
+		entities[i] = w.entities[entityID]
+	}
+	return entities
+}
+
+// syncArchetypeLocked moves an entity to the archetype of its current
+// components, or out of the storage if it was destroyed; the caller must
+// hold the write lock
+func (w *World) syncArchetypeLocked(entityID EntityID) {
+	if w.archetypes == nil {
+		return
+	}
+
+	var components map[string]Component
+	if entity, exists := w.entities[entityID]; exists {
+		components = entity.Components
+	}
+	if err := w.archetypes.sync(entityID, components); err != nil {
+		// Too many component types to tell apart; fall back to the maps
+		w.archetypes = nil
+	}
+}
+
+// replaceArchetypeComponentLocked stores a component that replaced one of
+// the same type, which keeps the entity's archetype; the caller must hold
+// the write lock
+func (w *World) replaceArchetypeComponentLocked(entityID EntityID, component Component) {
+	if w.archetypes == nil {
+		return
+	}
+
+	storage := w.archetypes
+	if position, found := storage.find(entityID); found {
+		location := storage.index[position]
+		group := location.group
+		group.columns[group.column[storage.bits[component.GetType()]]][location.row] = component
+	}
+}
+
+// rebuildArchetypesLocked restores all entities after the entity maps were
+// replaced; the caller must hold the write lock
+func (w *World) rebuildArchetypesLocked() {
+	if w.archetypes == nil {
+		return
+	}
+
+	storage, err := newArchetypeStorage(w.archetypes.bits, w.orderedEntitiesLocked())
+	if err != nil {
+		w.archetypes = nil
+		return
+	}
+	w.archetypes = storage
+}
+
+// maskOf returns the mask of a set of component types, adding bits for
+// types that have never been stored
+func (s *archetypeStorage) maskOf(components map[string]Component) (componentMask, error) {
+	var mask componentMask
+	for componentType := range components {
+		bit, known := s.bits[componentType]
+		if !known {
+			if len(s.bits) == MaxArchetypeComponentTypes {
+				return mask, fmt.Errorf("archetype storage supports at most %d component types", MaxArchetypeComponentTypes)
+			}
+			bit = len(s.bits)
+			s.bits[componentType] = bit
+		}
+		mask.set(bit)
+	}
+	return mask, nil
+}
+
+// find returns the index position of an entity, or where it would be
+// inserted and false if it isn't stored
+func (s *archetypeStorage) find(entityID EntityID) (int, bool) {
+	position := sort.Search(len(s.index), func(i int) bool {
+		return s.index[i].entityID >= entityID
+	})
+	return position, position < len(s.index) && s.index[position].entityID == entityID
+}
+
+// sync stores an entity's components in the archetype of their types.
+// Entities without components, including destroyed ones, are not stored.
+func (s *archetypeStorage) sync(entityID EntityID, components map[string]Component) error {
+	var mask componentMask
+	if len(components) > 0 {
+		var err error
+		if mask, err = s.maskOf(components); err != nil {
+			return err
+		}
+	}
+
+	position, found := s.find(entityID)
+	if found {
+		s.detach(s.index[position])
+	}
+	if len(components) == 0 {
+		if found {
+			s.index = append(s.index[:position], s.index[position+1:]...)
+		}
+		return nil
+	}
+
+	group := s.archetype(mask, components)
+	location := entityLocation{entityID: entityID, group: group, row: len(group.entities)}
+	group.entities = append(group.entities, entityID)
+	for componentType, component := range components {
+		column := group.column[s.bits[componentType]]
+		group.columns[column] = append(group.columns[column], component)
+	}
+
+	if found {
+		s.index[position] = location
+		return nil
+	}
+	// New entities have the highest ID and append
+	s.index = append(s.index, entityLocation{})
+	copy(s.index[position+1:], s.index[position:])
+	s.index[position] = location
+	return nil
+}
+
+// archetype returns the group for a mask, creating it with a column for
+// each of the component types
+func (s *archetypeStorage) archetype(mask componentMask, components map[string]Component) *archetype {
+	if group, exists := s.archetypes[mask]; exists {
+		return group
+	}
+
+	group := &archetype{
+		mask:    mask,
+		columns: make([][]Component, 0, len(components)),
+	}
+	for componentType := range components {
+		group.column[s.bits[componentType]] = uint8(len(group.columns))
+		group.columns = append(group.columns, nil)
+	}
+	s.archetypes[mask] = group
+	return group
+}
+
+// detach removes an entity's row from its archetype, moving the last row
+// into the gap
+func (s *archetypeStorage) detach(location entityLocation) {
+	group := location.group
+	last := len(group.entities) - 1
+
+	for i, column := range group.columns {
+		column[location.row] = column[last]
+		column[last] = nil
+		group.columns[i] = column[:last]
+	}
+	moved := group.entities[last]
+	group.entities[location.row] = moved
+	group.entities = group.entities[:last]
+
+	if moved != location.entityID {
+		if position, found := s.find(moved); found {
+			s.index[position].row = location.row
+		}
+	}
+}
+
+// forEach calls visit, in creation order, for every entity in an archetype
+// holding all of componentTypes, with components filled in the order of
+// componentTypes; the caller must hold the read lock
+func (s *archetypeStorage) forEach(componentTypes []string, components []Component, visit func(entityID EntityID)) {
+	// The bits of typical queries live on the stack
+	var bitBuffer [16]int
+	bits := bitBuffer[:0]
+	var mask componentMask
+	for _, componentType := range componentTypes {
+		bit, known := s.bits[componentType]
+		if !known {
+			return
+		}
+		mask.set(bit)
+		bits = append(bits, bit)
+	}
+
+	// Consecutive entities often share an archetype, so the last match is kept
+	var last *archetype
+	matches := false
+	for _, location := range s.index {
+		group := location.group
+		if group != last {
+			last, matches = group, group.mask.contains(mask)
+		}
+		if !matches {
+			continue
+		}
+
+		for i, bit := range bits {
+			components[i] = group.columns[group.column[bit]][location.row]
+		}
+		visit(location.entityID)
+	}
+}
@@ -0,0 +1,294 @@
+package ecs
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// namedComponent is a component whose type is set per instance, for worlds
+// with many component types
+type namedComponent struct {
+	name string
+}
+
+func (c *namedComponent) GetType() string {
+	return c.name
+}
+
+// mutateIterationWorld adds, replaces, removes and destroys components of a
+// world from newIterationWorld, moving entities between archetypes
+func mutateIterationWorld(world *World) {
+	entities := world.GetEntities()
+	world.AddComponent(entities[1], NewMeshComponent("sphere"))
+	world.AddComponent(entities[2], NewMeshComponent("replaced"))
+	world.AddComponent(entities[3], NewTagComponent("enemy"))
+	world.RemoveComponent(entities[4], "mesh")
+	world.RemoveComponent(entities[5], "transform")
+	world.DestroyEntity(entities[0])
+
+	added := world.CreateEntity()
+	world.AddComponent(added, NewMeshComponent("cube"))
+	world.AddComponent(added, NewTransformComponent(mgl32.Vec3{}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}))
+}
+
+// visits returns the entities ForEach visits, sorted, and fails the test if
+// any is given components other than its own
+func visits(t *testing.T, world *World, componentTypes []string) []EntityID {
+	t.Helper()
+
+	var visited []EntityID
+	world.ForEach(componentTypes, func(entityID EntityID, components []Component) {
+		for i, componentType := range componentTypes {
+			if components[i] != world.entities[entityID].Components[componentType] {
+				t.Errorf("entity %d got %v for %s, want its own component", entityID, components[i], componentType)
+			}
+		}
+		visited = append(visited, entityID)
+	})
+	sort.Slice(visited, func(i, j int) bool { return visited[i] < visited[j] })
+	return visited
+}
+
+func TestArchetypeStorageMatchesMaps(t *testing.T) {
+	queries := [][]string{
+		{"transform"},
+		{"mesh"},
+		{"mesh", "transform"},
+		{"transform", "mesh", "tag"},
+		{"unknown"},
+	}
+
+	tests := []struct {
+		name string
+		// enable turns on archetype storage before or after the mutations
+		enable func(world *World) error
+	}{
+		{"enabled before changes", func(world *World) error {
+			err := world.EnableArchetypeStorage()
+			mutateIterationWorld(world)
+			return err
+		}},
+		{"enabled after changes", func(world *World) error {
+			mutateIterationWorld(world)
+			return world.EnableArchetypeStorage()
+		}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			maps := newIterationWorld(8)
+			mutateIterationWorld(maps)
+			archetypes := newIterationWorld(8)
+			if err := test.enable(archetypes); err != nil {
+				t.Fatalf("EnableArchetypeStorage: %v", err)
+			}
+			if !archetypes.ArchetypeStorageEnabled() {
+				t.Fatal("archetype storage is not enabled")
+			}
+			for _, query := range queries {
+				want := visits(t, maps, query)
+				if got := visits(t, archetypes, query); !reflect.DeepEqual(got, want) {
+					t.Errorf("ForEach(%v) visited %v, want %v", query, got, want)
+				}
+
+				// Single-type queries agree with GetEntitiesWithComponent too
+				if len(query) != 1 {
+					continue
+				}
+				var visited []EntityID
+				archetypes.ForEachWithComponent(query[0], func(entityID EntityID, component Component) {
+					if component != archetypes.GetComponent(entityID, query[0]) {
+						t.Errorf("ForEachWithComponent(%s) gave entity %d a foreign component", query[0], entityID)
+					}
+					visited = append(visited, entityID)
+				})
+				sort.Slice(visited, func(i, j int) bool { return visited[i] < visited[j] })
+				if want := archetypes.GetEntitiesWithComponent(query[0]); !reflect.DeepEqual(visited, want) {
+					t.Errorf("ForEachWithComponent(%s) visited %v, want %v", query[0], visited, want)
+				}
+			}
+		})
+	}
+}
+
+func TestArchetypeStorageKeepsCreationOrder(t *testing.T) {
+	// order returns the entities ForEach and ForEachWithComponent visit, as visited
+	order := func(world *World, componentTypes []string) []EntityID {
+		var visited []EntityID
+		world.ForEach(componentTypes, func(entityID EntityID, components []Component) {
+			visited = append(visited, entityID)
+		})
+		if len(componentTypes) == 1 {
+			world.ForEachWithComponent(componentTypes[0], func(entityID EntityID, component Component) {
+				visited = append(visited, entityID)
+			})
+		}
+		return visited
+	}
+
+	maps := newMixedWorld(40)
+	archetypes := newMixedWorld(40)
+	if err := archetypes.EnableArchetypeStorage(); err != nil {
+		t.Fatalf("EnableArchetypeStorage: %v", err)
+	}
+
+	// Moving entities between archetypes doesn't reorder them
+	for _, world := range []*World{maps, archetypes} {
+		entities := world.GetEntities()
+		world.RemoveComponent(entities[0], "mesh")
+		world.AddComponent(entities[1], NewMeshComponent("sphere"))
+		world.AddComponent(entities[7], NewTagComponent("moved"))
+		world.RemoveComponent(entities[12], "tag")
+		world.DestroyEntity(entities[20])
+		world.NewEntity().WithTransform(mgl32.Vec3{}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}).WithMesh("cube").Build()
+	}
+
+	for _, query := range [][]string{{"transform"}, {"mesh"}, {"tag", "transform"}, {"transform", "mesh", "tag"}} {
+		want := order(maps, query)
+		if got := order(archetypes, query); !reflect.DeepEqual(got, want) {
+			t.Errorf("%v with archetype storage visited %v, want %v", query, got, want)
+		}
+	}
+}
+
+func TestArchetypeRowsFollowMoves(t *testing.T) {
+	world := NewWorld()
+	if err := world.EnableArchetypeStorage(); err != nil {
+		t.Fatalf("EnableArchetypeStorage: %v", err)
+	}
+	for i := 0; i < 10; i++ {
+		world.NewEntity().WithTransform(mgl32.Vec3{float32(i), 0, 0}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}).WithMesh("cube").Build()
+	}
+	entities := world.GetEntities()
+
+	// Moving entities out of the middle of a group fills their rows from the end
+	world.RemoveComponent(entities[0], "mesh")
+	world.RemoveComponent(entities[3], "mesh")
+	world.RemoveComponent(entities[5], "transform")
+	world.DestroyEntity(entities[6])
+	// A replaced component is stored in the entity's row
+	world.AddComponent(entities[9], NewTransformComponent(mgl32.Vec3{9, 0, 0}, mgl32.Vec3{}, mgl32.Vec3{2, 2, 2}))
+
+	var visited []EntityID
+	world.ForEach([]string{"transform"}, func(entityID EntityID, components []Component) {
+		transform := components[0].(*TransformComponent)
+		if transform.Position.X() != float32(entityID-entities[0]) {
+			t.Errorf("entity %d got the transform at %v", entityID, transform.Position)
+		}
+		if entityID == entities[9] && transform.Scale != (mgl32.Vec3{2, 2, 2}) {
+			t.Errorf("entity %d still has its replaced transform", entityID)
+		}
+		visited = append(visited, entityID)
+	})
+	want := []EntityID{entities[0], entities[1], entities[2], entities[3], entities[4], entities[7], entities[8], entities[9]}
+	if !reflect.DeepEqual(visited, want) {
+		t.Errorf("ForEach visited %v, want %v", visited, want)
+	}
+}
+
+func TestArchetypeCount(t *testing.T) {
+	world := newIterationWorld(4)
+	if world.ArchetypeCount() != 0 {
+		t.Errorf("ArchetypeCount() = %d before enabling, want 0", world.ArchetypeCount())
+	}
+	if err := world.EnableArchetypeStorage(); err != nil {
+		t.Fatalf("EnableArchetypeStorage: %v", err)
+	}
+
+	// Transform only, and transform with mesh
+	if got := world.ArchetypeCount(); got != 2 {
+		t.Errorf("ArchetypeCount() = %d, want 2", got)
+	}
+
+	// Giving every entity a mesh empties the transform-only group
+	for _, entityID := range world.GetEntities() {
+		world.AddComponent(entityID, NewMeshComponent("cube"))
+	}
+	if got := world.ArchetypeCount(); got != 1 {
+		t.Errorf("ArchetypeCount() after adding meshes = %d, want 1", got)
+	}
+
+	// Restore regroups the restored entities
+	snapshot := world.Snapshot()
+	world.AddComponent(world.GetEntities()[0], NewTagComponent("moved"))
+	world.Restore(snapshot)
+	if got := world.ArchetypeCount(); got != 1 {
+		t.Errorf("ArchetypeCount() after Restore = %d, want 1", got)
+	}
+	if got := visits(t, world, []string{"mesh", "transform"}); len(got) != 4 {
+		t.Errorf("ForEach after Restore visited %v, want all 4 entities", got)
+	}
+}
+
+func TestArchetypeStorageTooManyTypes(t *testing.T) {
+	world := NewWorld()
+	entityID := world.CreateEntity()
+	for i := 0; i < MaxArchetypeComponentTypes; i++ {
+		world.AddComponent(entityID, &namedComponent{name: fmt.Sprintf("type%d", i)})
+	}
+	if err := world.EnableArchetypeStorage(); err != nil {
+		t.Fatalf("EnableArchetypeStorage with %d types: %v", MaxArchetypeComponentTypes, err)
+	}
+
+	// One type too many falls back to the maps without losing entities
+	world.AddComponent(entityID, &namedComponent{name: "overflow"})
+	if world.ArchetypeStorageEnabled() {
+		t.Error("archetype storage still enabled past its type limit")
+	}
+	if got := visits(t, world, []string{"type0", "overflow"}); len(got) != 1 || got[0] != entityID {
+		t.Errorf("ForEach after the fallback visited %v, want [%d]", got, entityID)
+	}
+
+	if err := world.EnableArchetypeStorage(); err == nil {
+		t.Error("EnableArchetypeStorage succeeded with too many types")
+	}
+}
+
+// newMixedWorld creates count entities spread over several archetypes, a
+// quarter of them with a transform, mesh and tag
+func newMixedWorld(count int) *World {
+	world := NewWorld()
+	for i := 0; i < count; i++ {
+		builder := world.NewEntity().WithTransform(mgl32.Vec3{float32(i), 0, 0}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1})
+		if i%2 == 0 {
+			builder.WithMesh("cube")
+		}
+		if i%4 < 2 {
+			builder.WithTag("crate")
+		}
+		if i%3 == 0 {
+			builder.With(NewAudioComponent("hum", 1, true))
+		}
+		builder.Build()
+	}
+	return world
+}
+
+func BenchmarkMultiComponentIteration(b *testing.B) {
+	types := []string{"transform", "mesh", "tag"}
+	for _, archetypes := range []bool{false, true} {
+		name := "maps"
+		if archetypes {
+			name = "archetypes"
+		}
+		b.Run(name, func(b *testing.B) {
+			world := newMixedWorld(5000)
+			if archetypes {
+				if err := world.EnableArchetypeStorage(); err != nil {
+					b.Fatalf("EnableArchetypeStorage: %v", err)
+				}
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				world.ForEach(types, func(entityID EntityID, components []Component) {
+					components[0].(*TransformComponent).Position[1] += 1
+				})
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package ecs
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// EntityBuilder collects components for a new entity and creates it atomically
+type EntityBuilder struct {
+	world      *World
+	components []Component
+}
+
+// NewEntity returns a builder for a new entity
+func (w *World) NewEntity() *EntityBuilder {
+	return &EntityBuilder{world: w}
+}
+
+// With adds a component to the entity being built
+func (b *EntityBuilder) With(component Component) *EntityBuilder {
+	b.components = append(b.components, component)
+	return b
+}
+
+// WithTransform adds a transform component
+func (b *EntityBuilder) WithTransform(position, rotation, scale mgl32.Vec3) *EntityBuilder {
+	return b.With(NewTransformComponent(position, rotation, scale))
+}
+
+// WithMesh adds a mesh component
+func (b *EntityBuilder) WithMesh(meshID string) *EntityBuilder {
+	return b.With(NewMeshComponent(meshID))
+}
+
+// WithTag adds a tag component
+func (b *EntityBuilder) WithTag(tags ...string) *EntityBuilder {
+	return b.With(NewTagComponent(tags...))
+}
+
+// Build creates the entity with all its components under a single lock
+func (b *EntityBuilder) Build() EntityID {
+	defer b.world.dispatchComponentEvents()
+	b.world.mutex.Lock()
+	defer b.world.mutex.Unlock()
+
+	entityID := b.world.createEntityLocked()
+	for _, component := range b.components {
+		b.world.addComponentLocked(entityID, component)
+	}
+	return entityID
+}
@@ -0,0 +1,102 @@
+package ecs
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestEntityBuilder(t *testing.T) {
+	world := NewWorld()
+	position := mgl32.Vec3{1, 2, 3}
+
+	entityID := world.NewEntity().
+		WithTransform(position, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}).
+		WithMesh("cube").
+		WithTag("enemy", "flying").
+		With(NewLifetimeComponent(5)).
+		Build()
+
+	if !world.HasComponents(entityID, "transform", "mesh", "tag", "lifetime") {
+		t.Fatal("built entity is missing components")
+	}
+	if got := world.GetComponent(entityID, "transform").(*TransformComponent).Position; got != position {
+		t.Errorf("position = %v, want %v", got, position)
+	}
+	if got := world.GetComponent(entityID, "mesh").(*MeshComponent).MeshID; got != "cube" {
+		t.Errorf("mesh = %q, want cube", got)
+	}
+	if got := world.GetEntitiesByTag("flying"); len(got) != 1 || got[0] != entityID {
+		t.Errorf("entities tagged flying = %v, want [%d]", got, entityID)
+	}
+}
+
+func TestEntityBuilderIsAtomic(t *testing.T) {
+	world := NewWorld()
+
+	// Listeners run after the build, so they see the whole entity
+	complete := true
+	world.OnComponentAdded("transform", func(entityID EntityID, component Component) {
+		if !world.HasComponents(entityID, "transform", "mesh", "tag") {
+			complete = false
+		}
+	})
+
+	// Readers on other goroutines never see a partly built entity
+	done := make(chan struct{})
+	var readers sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		readers.Add(1)
+		go func() {
+			defer readers.Done()
+			for {
+				select {
+				case <-done:
+					return
+				default:
+				}
+				for _, entityID := range world.GetEntitiesWithComponent("transform") {
+					if !world.HasComponents(entityID, "mesh", "tag") {
+						t.Errorf("entity %d seen without all its components", entityID)
+						return
+					}
+				}
+			}
+		}()
+	}
+
+	for i := 0; i < 200; i++ {
+		world.NewEntity().
+			WithTransform(mgl32.Vec3{}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}).
+			WithMesh("cube").
+			WithTag("built").
+			Build()
+	}
+	close(done)
+	readers.Wait()
+
+	if !complete {
+		t.Error("a component listener saw a partly built entity")
+	}
+	if got := world.GetEntityCount(); got != 200 {
+		t.Errorf("GetEntityCount() = %d, want 200", got)
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	world := NewWorld()
+	entityID := world.CreateEntity()
+
+	world.Configure(entityID, NewMeshComponent("cube"), NewTagComponent("a"), NewMeshComponent("sphere"))
+
+	if got := world.GetComponent(entityID, "mesh").(*MeshComponent).MeshID; got != "sphere" {
+		t.Errorf("mesh = %q, want the later sphere", got)
+	}
+	if got := world.GetComponentCount("mesh"); got != 1 {
+		t.Errorf("GetComponentCount(mesh) = %d, want 1", got)
+	}
+	if !world.HasComponent(entityID, "tag") {
+		t.Error("tag component missing")
+	}
+}
@@ -0,0 +1,106 @@
+package ecs
+
+import (
+	"sort"
+)
+
+// Version returns the world's change counter. Pass it to IterateChanged on
+// the next frame to visit only components changed after this point.
+func (w *World) Version() uint64 {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	return w.version
+}
+
+// Mutate changes a component through fn and marks it as changed.
+// It returns false if the entity doesn't have the component. The world
+// isn't locked while fn runs, so fn may call the World; if fn removes or
+// replaces the component, the old one is not marked.
+func (w *World) Mutate(entityID EntityID, componentType string, fn func(component Component)) bool {
+	w.mutex.RLock()
+	var component Component
+	if entity, exists := w.entities[entityID]; exists {
+		component = entity.Components[componentType]
+	}
+	version := w.versions[componentType][entityID]
+	w.mutex.RUnlock()
+	if component == nil {
+		return false
+	}
+
+	fn(component)
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	// Replacing the component changes its version, so compare versions
+	// rather than components, which may be values that can't be compared
+	if entity, exists := w.entities[entityID]; exists {
+		_, hasComponent := entity.Components[componentType]
+		if hasComponent && w.versions[componentType][entityID] == version {
+			w.markChangedLocked(entityID, componentType)
+		}
+	}
+	return true
+}
+
+// MarkChanged marks a component as changed after it was modified in place
+func (w *World) MarkChanged(entityID EntityID, componentType string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	if entity, exists := w.entities[entityID]; exists {
+		if _, hasComponent := entity.Components[componentType]; hasComponent {
+			w.markChangedLocked(entityID, componentType)
+		}
+	}
+}
+
+// ComponentVersion returns the version at which a component last changed, or 0
+func (w *World) ComponentVersion(entityID EntityID, componentType string) uint64 {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	return w.versions[componentType][entityID]
+}
+
+// IterateChanged calls fn for each entity whose component changed after
+// sinceVersion, in entity ID order. The world isn't locked while fn runs,
+// so fn may mutate components.
+func (w *World) IterateChanged(componentType string, sinceVersion uint64, fn func(entityID EntityID, component Component)) {
+	type change struct {
+		entityID  EntityID
+		component Component
+	}
+
+	w.mutex.RLock()
+	var changes []change
+	for entityID, version := range w.versions[componentType] {
+		if version > sinceVersion {
+			changes = append(changes, change{entityID, w.entities[entityID].Components[componentType]})
+		}
+	}
+	w.mutex.RUnlock()
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].entityID < changes[j].entityID
+	})
+
+	for _, c := range changes {
+		fn(c.entityID, c.component)
+	}
+}
+
+// markChangedLocked bumps the world version and stamps the component with it;
+// the caller must hold the write lock
+func (w *World) markChangedLocked(entityID EntityID, componentType string) {
+	w.version++
+	if w.versions[componentType] == nil {
+		w.versions[componentType] = make(map[EntityID]uint64)
+	}
+	w.versions[componentType][entityID] = w.version
+}
+
+// forgetVersionLocked drops a component's version; the caller must hold the write lock
+func (w *World) forgetVersionLocked(entityID EntityID, componentType string) {
+	delete(w.versions[componentType], entityID)
+}
@@ -0,0 +1,118 @@
+package ecs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// changedEntities returns the entities IterateChanged visits
+func changedEntities(world *World, componentType string, since uint64) []EntityID {
+	var entities []EntityID
+	world.IterateChanged(componentType, since, func(entityID EntityID, component Component) {
+		entities = append(entities, entityID)
+	})
+	return entities
+}
+
+func TestIterateChangedVisitsOnlyChanged(t *testing.T) {
+	world := NewWorld()
+	var entities []EntityID
+	for i := 0; i < 5; i++ {
+		entities = append(entities, world.NewEntity().
+			WithTransform(mgl32.Vec3{}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}).
+			WithMesh("cube").
+			Build())
+	}
+
+	since := world.Version()
+	if got := changedEntities(world, "transform", since); len(got) != 0 {
+		t.Errorf("changed before any mutation: %v", got)
+	}
+
+	for _, entityID := range []EntityID{entities[3], entities[1]} {
+		world.Mutate(entityID, "transform", func(component Component) {
+			component.(*TransformComponent).SetPosition(mgl32.Vec3{1, 0, 0})
+		})
+	}
+	world.MarkChanged(entities[4], "mesh")
+
+	if got, want := changedEntities(world, "transform", since), []EntityID{entities[1], entities[3]}; !reflect.DeepEqual(got, want) {
+		t.Errorf("changed transforms = %v, want %v in ID order", got, want)
+	}
+	if got, want := changedEntities(world, "mesh", since), []EntityID{entities[4]}; !reflect.DeepEqual(got, want) {
+		t.Errorf("changed meshes = %v, want %v", got, want)
+	}
+
+	// The next frame only sees changes made after it started
+	since = world.Version()
+	world.Mutate(entities[0], "transform", func(component Component) {})
+	if got, want := changedEntities(world, "transform", since), []EntityID{entities[0]}; !reflect.DeepEqual(got, want) {
+		t.Errorf("changed transforms next frame = %v, want %v", got, want)
+	}
+}
+
+func TestMutateMissingComponent(t *testing.T) {
+	world := NewWorld()
+	entityID := world.CreateEntity()
+
+	called := false
+	if world.Mutate(entityID, "transform", func(component Component) { called = true }) {
+		t.Error("Mutate of a missing component returned true")
+	}
+	if called {
+		t.Error("Mutate called fn for a missing component")
+	}
+}
+
+func TestMutateCallbackCanUseWorld(t *testing.T) {
+	world := NewWorld()
+	entityID := world.NewEntity().WithMesh("cube").Build()
+	since := world.Version()
+
+	// fn runs outside the lock, so calling back into the world doesn't deadlock
+	world.Mutate(entityID, "mesh", func(component Component) {
+		world.AddComponent(entityID, NewTagComponent("touched"))
+		_ = world.GetComponent(entityID, "mesh")
+	})
+	if got := changedEntities(world, "mesh", since); len(got) != 1 {
+		t.Errorf("changed meshes = %v, want the mutated entity", got)
+	}
+
+	// A component replaced by fn isn't the one marked
+	since = world.Version()
+	world.Mutate(entityID, "mesh", func(component Component) {
+		world.AddComponent(entityID, NewMeshComponent("sphere"))
+	})
+	if world.ComponentVersion(entityID, "mesh") <= since {
+		t.Error("the replacement mesh wasn't marked changed by AddComponent")
+	}
+}
+
+func TestMutateValueComponent(t *testing.T) {
+	world := NewWorld()
+	entityID := world.CreateEntity()
+	world.AddComponent(entityID, inventoryComponent{Items: []string{"sword"}})
+	since := world.Version()
+
+	// Value components with slices can't be compared, but still get marked
+	if !world.Mutate(entityID, "inventory", func(component Component) {}) {
+		t.Fatal("Mutate of an existing value component returned false")
+	}
+	if got := changedEntities(world, "inventory", since); len(got) != 1 {
+		t.Errorf("changed inventories = %v, want the mutated entity", got)
+	}
+}
+
+func TestDestroyedEntityLeavesChanges(t *testing.T) {
+	world := NewWorld()
+	entityID := world.NewEntity().WithMesh("cube").Build()
+
+	world.MarkChanged(entityID, "mesh")
+	world.DestroyEntity(entityID)
+
+	if got := changedEntities(world, "mesh", 0); len(got) != 0 {
+		t.Errorf("destroyed entity still visited: %v", got)
+	}
+}
@@ -0,0 +1,124 @@
+package ecs
+
+import (
+	"reflect"
+)
+
+// CloneEntity creates a new entity with deep copies of all the source
+// entity's components, so changing the clone never affects the source.
+// It returns 0 if the source doesn't exist.
+func (w *World) CloneEntity(src EntityID) EntityID {
+	defer w.dispatchComponentEvents()
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	source, exists := w.entities[src]
+	if !exists {
+		return 0
+	}
+
+	entityID := w.createEntityLocked()
+	w.entities[entityID].Active = source.Active
+	for _, component := range source.Components {
+		w.addComponentLocked(entityID, CloneComponent(component))
+	}
+	return entityID
+}
+
+// RegisterPrefab registers a named builder that Spawn calls to create an entity
+func (w *World) RegisterPrefab(name string, build func(w *World) EntityID) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.prefabs[name] = build
+}
+
+// Spawn creates an entity from a registered prefab. It returns 0 if no
+// prefab has the name.
+func (w *World) Spawn(name string) EntityID {
+	w.mutex.RLock()
+	build, exists := w.prefabs[name]
+	w.mutex.RUnlock()
+
+	if !exists {
+		return 0
+	}
+
+	// The builder uses the public API, so it must run without the lock held
+	return build(w)
+}
+
+// Cloner is implemented by components that copy themselves. Components with
+// unexported maps, slices or pointers must implement it, since CloneComponent
+// can only deep-copy exported fields.
+type Cloner interface {
+	Clone() Component
+}
+
+// CloneComponent returns a deep copy of a component, using its Clone method
+// when it has one. Otherwise maps, slices and pointers reachable through
+// exported fields are copied rather than shared, and unexported fields are
+// copied as they are.
+func CloneComponent(component Component) Component {
+	if cloner, ok := component.(Cloner); ok {
+		return cloner.Clone()
+	}
+	return deepCopy(reflect.ValueOf(component)).Interface().(Component)
+}
+
+// deepCopy recursively copies a value
+func deepCopy(value reflect.Value) reflect.Value {
+	switch value.Kind() {
+	case reflect.Ptr:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.New(value.Elem().Type())
+		copied.Elem().Set(deepCopy(value.Elem()))
+		return copied
+	case reflect.Struct:
+		// Copy the whole struct first so unexported fields are kept
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(value)
+		for i := 0; i < value.NumField(); i++ {
+			if copied.Field(i).CanSet() {
+				copied.Field(i).Set(deepCopy(value.Field(i)))
+			}
+		}
+		return copied
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.MakeSlice(value.Type(), value.Len(), value.Len())
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(deepCopy(value.Index(i)))
+		}
+		return copied
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.MakeMapWithSize(value.Type(), value.Len())
+		iter := value.MapRange()
+		for iter.Next() {
+			copied.SetMapIndex(iter.Key(), deepCopy(iter.Value()))
+		}
+		return copied
+	case reflect.Array:
+		copied := reflect.New(value.Type()).Elem()
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(deepCopy(value.Index(i)))
+		}
+		return copied
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.New(value.Type()).Elem()
+		copied.Set(deepCopy(value.Elem()))
+		return copied
+	default:
+		return value
+	}
+}
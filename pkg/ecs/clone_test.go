@@ -0,0 +1,91 @@
+package ecs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestCloneEntityCopiesTransform(t *testing.T) {
+	world := NewWorld()
+	source := world.CreateEntity()
+	world.AddComponent(source, NewTransformComponent(mgl32.Vec3{1, 2, 3}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}))
+
+	clone := world.CloneEntity(source)
+	if clone == 0 {
+		t.Fatal("CloneEntity returned 0")
+	}
+
+	original := world.GetComponent(source, "transform").(*TransformComponent)
+	copied := world.GetComponent(clone, "transform").(*TransformComponent)
+	if copied == original {
+		t.Fatal("clone shares the source's transform")
+	}
+	if copied.Position != original.Position {
+		t.Errorf("clone position = %v, want %v", copied.Position, original.Position)
+	}
+
+	copied.SetPosition(mgl32.Vec3{9, 9, 9})
+	copied.Scale[0] = 5
+	if original.Position != (mgl32.Vec3{1, 2, 3}) || original.Scale != (mgl32.Vec3{1, 1, 1}) {
+		t.Errorf("changing the clone moved the source to %v, scale %v", original.Position, original.Scale)
+	}
+}
+
+func TestCloneEntityCopiesMapsAndSlices(t *testing.T) {
+	world := NewWorld()
+	source := world.CreateEntity()
+	block := NewPropertyBlockComponent()
+	block.SetFloat("u_Metallic", 0.5)
+	world.AddComponent(source, block)
+	world.AddComponent(source, NewTagComponent("enemy"))
+
+	clone := world.CloneEntity(source)
+	if clone == 0 {
+		t.Fatal("CloneEntity returned 0")
+	}
+
+	world.GetComponent(clone, "property_block").(*PropertyBlockComponent).SetFloat("u_Metallic", 1)
+	if block.Floats["u_Metallic"] != 0.5 {
+		t.Error("changing the clone's property block changed the source's")
+	}
+
+	// The cloned tags are indexed under the clone and change independently
+	if got, want := world.GetEntitiesByTag("enemy"), []EntityID{source, clone}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetEntitiesByTag() = %v, want %v", got, want)
+	}
+	world.GetComponent(clone, "tag").(*TagComponent).AddTag("boss")
+	if world.GetComponent(source, "tag").(*TagComponent).HasTag("boss") {
+		t.Error("tagging the clone tagged the source")
+	}
+}
+
+func TestCloneMissingEntity(t *testing.T) {
+	world := NewWorld()
+	if clone := world.CloneEntity(42); clone != 0 {
+		t.Errorf("CloneEntity of a missing entity = %d, want 0", clone)
+	}
+	if world.GetEntityCount() != 0 {
+		t.Error("cloning a missing entity created one")
+	}
+}
+
+func TestSpawnPrefab(t *testing.T) {
+	world := NewWorld()
+	world.RegisterPrefab("crate", func(w *World) EntityID {
+		entityID := w.CreateEntity()
+		w.AddComponent(entityID, NewTransformComponent(mgl32.Vec3{}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}))
+		return entityID
+	})
+
+	first := world.Spawn("crate")
+	second := world.Spawn("crate")
+	if first == 0 || first == second || !world.HasComponent(second, "transform") {
+		t.Errorf("Spawn returned %d and %d, want two entities with transforms", first, second)
+	}
+
+	if spawned := world.Spawn("barrel"); spawned != 0 {
+		t.Errorf("Spawn of an unregistered prefab = %d, want 0", spawned)
+	}
+}
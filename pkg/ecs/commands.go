@@ -0,0 +1,67 @@
+package ecs
+
+// Defer queues a structural change to run after all systems have updated.
+// Systems should use it instead of destroying entities or removing
+// components while iterating query results.
+func (w *World) Defer(command func(w *World)) {
+	w.commandMutex.Lock()
+	defer w.commandMutex.Unlock()
+
+	w.commands = append(w.commands, command)
+}
+
+// DeferDestroy queues an entity to be destroyed after all systems have updated
+func (w *World) DeferDestroy(entityID EntityID) {
+	w.Defer(func(w *World) {
+		w.DestroyEntity(entityID)
+	})
+}
+
+// DeferDestroyEntities queues several entities to be destroyed together,
+// under a single lock, after all systems have updated
+func (w *World) DeferDestroyEntities(entityIDs ...EntityID) {
+	// Copy so the caller may reuse its slice
+	entityIDs = append([]EntityID(nil), entityIDs...)
+	w.Defer(func(w *World) {
+		w.DestroyEntities(entityIDs...)
+	})
+}
+
+// DeferAddComponent queues a component to be added after all systems have updated
+func (w *World) DeferAddComponent(entityID EntityID, component Component) {
+	w.Defer(func(w *World) {
+		w.AddComponent(entityID, component)
+	})
+}
+
+// DeferRemoveComponent queues a component to be removed after all systems have updated
+func (w *World) DeferRemoveComponent(entityID EntityID, componentType string) {
+	w.Defer(func(w *World) {
+		w.RemoveComponent(entityID, componentType)
+	})
+}
+
+// FlushCommands runs the queued commands in order. Commands queued while
+// flushing run in the same flush. Update calls it after the last system.
+func (w *World) FlushCommands() {
+	for {
+		w.commandMutex.Lock()
+		commands := w.commands
+		w.commands = nil
+		w.commandMutex.Unlock()
+
+		if len(commands) == 0 {
+			return
+		}
+		for _, command := range commands {
+			command(w)
+		}
+	}
+}
+
+// GetPendingCommandCount returns the number of queued commands
+func (w *World) GetPendingCommandCount() int {
+	w.commandMutex.Lock()
+	defer w.commandMutex.Unlock()
+	return len(w.commands)
+}
@@ -0,0 +1,105 @@
+package ecs
+
+import (
+	"testing"
+)
+
+// funcSystem runs a function as a system
+type funcSystem struct {
+	name   string
+	update func(world *World)
+}
+
+func (s *funcSystem) Update(deltaTime float64, world *World) {
+	s.update(world)
+}
+
+func (s *funcSystem) GetName() string {
+	return s.name
+}
+
+func TestDeferredDestroyAppliesAfterUpdate(t *testing.T) {
+	world := NewWorld()
+	target := world.CreateEntity()
+
+	seenByLaterSystem := false
+	world.AddSystem(&funcSystem{name: "killer", update: func(world *World) {
+		world.DeferDestroy(target)
+		if world.GetEntityCount() != 1 {
+			t.Error("entity destroyed as soon as DeferDestroy was called")
+		}
+	}})
+	world.AddSystem(&funcSystem{name: "observer", update: func(world *World) {
+		seenByLaterSystem = len(world.GetEntities()) == 1
+	}})
+
+	world.Update(1.0 / 60.0)
+
+	if !seenByLaterSystem {
+		t.Error("a later system in the same update didn't see the entity")
+	}
+	if world.GetEntityCount() != 0 {
+		t.Error("entity still alive after Update returned")
+	}
+	if count := world.GetPendingCommandCount(); count != 0 {
+		t.Errorf("%d commands still pending after Update", count)
+	}
+}
+
+func TestDestroyEntities(t *testing.T) {
+	world := NewWorld()
+	first := world.CreateEntity()
+	second := world.CreateEntity()
+	kept := world.CreateEntity()
+
+	removed := 0
+	world.OnComponentRemoved("tag", func(entityID EntityID, component Component) {
+		removed++
+	})
+	world.AddComponent(first, NewTagComponent("enemy"))
+	world.AddComponent(second, NewTagComponent("enemy"))
+
+	// Unknown IDs are ignored
+	world.DestroyEntities(first, second, 999)
+
+	if got := world.GetEntities(); len(got) != 1 || got[0] != kept {
+		t.Errorf("GetEntities() = %v, want [%d]", got, kept)
+	}
+	if removed != 2 {
+		t.Errorf("removal listener called %d times, want 2", removed)
+	}
+	if tagged := world.GetEntitiesByTag("enemy"); len(tagged) != 0 {
+		t.Errorf("destroyed entities still tagged: %v", tagged)
+	}
+}
+
+func TestDeferDestroyEntities(t *testing.T) {
+	world := NewWorld()
+	entities := []EntityID{world.CreateEntity(), world.CreateEntity()}
+
+	world.DeferDestroyEntities(entities...)
+	// Reusing the slice doesn't change what was queued
+	entities[0] = world.CreateEntity()
+
+	if world.GetEntityCount() != 3 {
+		t.Fatal("entities destroyed before the commands were flushed")
+	}
+	world.FlushCommands()
+	if got := world.GetEntities(); len(got) != 1 || got[0] != entities[0] {
+		t.Errorf("GetEntities() = %v, want [%d]", got, entities[0])
+	}
+}
+
+func TestCommandsQueuedWhileFlushingRunInSameFlush(t *testing.T) {
+	world := NewWorld()
+	entityID := world.CreateEntity()
+
+	world.Defer(func(w *World) {
+		w.DeferAddComponent(entityID, NewTagComponent("late"))
+	})
+	world.FlushCommands()
+
+	if !world.HasComponent(entityID, "tag") {
+		t.Error("command queued by a command didn't run in the same flush")
+	}
+}
@@ -1,14 +1,32 @@
 package ecs
 
 import (
+	"math"
+
 	"github.com/go-gl/mathgl/mgl32"
 )
 
 // TransformComponent represents position, rotation, and scale
 type TransformComponent struct {
 	Position mgl32.Vec3
+	// Rotation holds Euler angles in radians, applied in Y (yaw), X (pitch), Z (roll) order.
+	// The setters keep it in sync with Quaternion. A direct write still
+	// takes effect, over Quaternion, until the next setter call.
+	//
+	// Deprecated: use Quaternion, or SetRotation and EulerAngles.
 	Rotation mgl32.Vec3
-	Scale    mgl32.Vec3
+	// Quaternion is the rotation; the zero value is no rotation. SetRotation
+	// and EulerAngles convert from and to Euler angles.
+	Quaternion mgl32.Quat
+	Scale      mgl32.Vec3
+
+	// syncedRotation is Rotation as the setters last left it, so a direct
+	// write to Rotation can be told apart
+	syncedRotation mgl32.Vec3
+	// Cached model matrix; see ModelMatrix
+	matrixCache
+	// Last fixed step, for interpolation; see StepTo
+	previous previousState
 }
 
 func (t *TransformComponent) GetType() string {
@@ -17,23 +35,70 @@ func (t *TransformComponent) GetType() string {
 
 // NewTransformComponent creates a new transform component
 func NewTransformComponent(position, rotation, scale mgl32.Vec3) *TransformComponent {
-	return &TransformComponent{
+	transform := &TransformComponent{
 		Position: position,
-		Rotation: rotation,
 		Scale:    scale,
 	}
+	transform.SetRotation(rotation)
+	return transform
+}
+
+// Forward returns the direction the transform faces; the unrotated forward is -Z
+func (t *TransformComponent) Forward() mgl32.Vec3 {
+	return t.rotation().Rotate(mgl32.Vec3{0, 0, -1})
+}
+
+// LookAt rotates the transform so its forward points at target.
+// The rotation is left unchanged if target equals the position.
+func (t *TransformComponent) LookAt(target mgl32.Vec3, up mgl32.Vec3) {
+	direction := target.Sub(t.Position)
+	if direction.Len() < 1e-6 {
+		return
+	}
+	forward := direction.Normalize()
+
+	// Clamp to avoid NaN from rounding errors
+	pitch := math.Asin(math.Max(-1, math.Min(1, float64(forward.Y()))))
+
+	right := forward.Cross(up)
+	if right.Len() < 1e-6 {
+		// Looking along the up vector, yaw is undefined so keep the current one
+		t.SetRotation(mgl32.Vec3{float32(pitch), t.EulerAngles().Y(), 0})
+		return
+	}
+	right = right.Normalize()
+	realUp := right.Cross(forward)
+
+	yaw := math.Atan2(float64(-forward.X()), float64(-forward.Z()))
+	roll := math.Atan2(float64(right.Y()), float64(realUp.Y()))
+
+	t.SetRotation(mgl32.Vec3{float32(pitch), float32(yaw), float32(roll)})
 }
 
 // MeshComponent represents a 3D mesh
 type MeshComponent struct {
 	MeshID string
 	Visible bool
+	// BoundingRadius overrides the mesh's culling radius when positive
+	BoundingRadius float32
+	// Local-space box around the mesh vertices, for culling and picking.
+	// Renderer.NewMeshComponent and Renderer.TrackMeshBounds fill it from
+	// the mesh.
+	BoundsMin mgl32.Vec3
+	BoundsMax mgl32.Vec3
+	// Wireframe draws this mesh as lines regardless of the renderer's polygon mode
+	Wireframe bool
 }
 
 func (m *MeshComponent) GetType() string {
 	return "mesh"
 }
 
+// HasBounds reports whether the component's local box has been set
+func (m *MeshComponent) HasBounds() bool {
+	return m.BoundsMin != (mgl32.Vec3{}) || m.BoundsMax != (mgl32.Vec3{})
+}
+
 // NewMeshComponent creates a new mesh component
 func NewMeshComponent(meshID string) *MeshComponent {
 	return &MeshComponent{
@@ -62,11 +127,37 @@ func NewPhysicsComponent(bodyID uint64, mass float64) *PhysicsComponent {
 	}
 }
 
+// TriggerZoneComponent is a rectangle centered on the entity's transform
+// that reports physics entities passing through it without colliding. The
+// engine's TriggerSystem backs it with a physics sensor body.
+type TriggerZoneComponent struct {
+	Size   mgl32.Vec2
+	Offset mgl32.Vec2
+
+	// Callbacks receive the zone and the entity whose body overlaps it; any may be nil
+	OnEnter func(world *World, zone, other EntityID) `json:"-"`
+	OnStay  func(world *World, zone, other EntityID) `json:"-"`
+	OnExit  func(world *World, zone, other EntityID) `json:"-"`
+}
+
+func (t *TriggerZoneComponent) GetType() string {
+	return "trigger_zone"
+}
+
+// NewTriggerZoneComponent creates a trigger zone of the given size
+func NewTriggerZoneComponent(size mgl32.Vec2) *TriggerZoneComponent {
+	return &TriggerZoneComponent{
+		Size: size,
+	}
+}
+
 // AudioComponent represents audio properties
 type AudioComponent struct {
 	SoundID string
 	Volume  float64
 	Loop    bool
+	// Instance is the playback handle of the sound while it plays, set by the audio system
+	Instance uint64 `json:"-"`
 }
 
 func (a *AudioComponent) GetType() string {
@@ -85,6 +176,9 @@ func NewAudioComponent(soundID string, volume float64, loop bool) *AudioComponen
 // TagComponent represents entity tags
 type TagComponent struct {
 	Tags []string
+
+	// binding keeps the world's tag index in sync with AddTag and RemoveTag
+	binding tagBinding
 }
 
 func (t *TagComponent) GetType() string {
@@ -100,7 +194,14 @@ func NewTagComponent(tags ...string) *TagComponent {
 
 // AddTag adds a tag to the component
 func (t *TagComponent) AddTag(tag string) {
-	t.Tags = append(t.Tags, tag)
+	added := !t.HasTag(tag)
+	// Cap the slice so append copies rather than writing into an array
+	// shared with whoever passed the tags in
+	t.Tags = append(t.Tags[:len(t.Tags):len(t.Tags)], tag)
+
+	if added && t.binding.world != nil {
+		t.binding.world.tagChanged(t.binding.entityID, tag, true)
+	}
 }
 
 // HasTag checks if the component has a specific tag
@@ -112,3 +213,178 @@ func (t *TagComponent) HasTag(tag string) bool {
 	}
 	return false
 }
+
+// PropertyBlockComponent overrides material uniforms for a single entity
+// without modifying the shared material
+type PropertyBlockComponent struct {
+	Vec4s  map[string]mgl32.Vec4
+	Floats map[string]float32
+}
+
+func (p *PropertyBlockComponent) GetType() string {
+	return "property_block"
+}
+
+// NewPropertyBlockComponent creates a new, empty property block component
+func NewPropertyBlockComponent() *PropertyBlockComponent {
+	return &PropertyBlockComponent{
+		Vec4s:  make(map[string]mgl32.Vec4),
+		Floats: make(map[string]float32),
+	}
+}
+
+// SetVec4 overrides a vec4 uniform
+func (p *PropertyBlockComponent) SetVec4(name string, value mgl32.Vec4) {
+	p.Vec4s[name] = value
+}
+
+// SetFloat overrides a float uniform
+func (p *PropertyBlockComponent) SetFloat(name string, value float32) {
+	p.Floats[name] = value
+}
+
+// Clear removes the override of a uniform
+func (p *PropertyBlockComponent) Clear(name string) {
+	delete(p.Vec4s, name)
+	delete(p.Floats, name)
+}
+
+// SpriteComponent represents a textured 2D quad drawn at the entity's transform
+type SpriteComponent struct {
+	TextureID string
+	Size      mgl32.Vec2
+	// Origin is the pivot as a fraction of Size; (0.5, 0.5) is the center
+	Origin  mgl32.Vec2
+	Color   mgl32.Vec4
+	Visible bool
+}
+
+func (s *SpriteComponent) GetType() string {
+	return "sprite"
+}
+
+// NewSpriteComponent creates a new sprite component centered on its transform
+func NewSpriteComponent(textureID string, size mgl32.Vec2) *SpriteComponent {
+	return &SpriteComponent{
+		TextureID: textureID,
+		Size:      size,
+		Origin:    mgl32.Vec2{0.5, 0.5},
+		Color:     mgl32.Vec4{1, 1, 1, 1},
+		Visible:   true,
+	}
+}
+
+// CameraFollowComponent makes the active camera track a target entity's
+// transform. The first entity with one drives the camera.
+type CameraFollowComponent struct {
+	Target EntityID
+	// Offset is added to the target position to get the camera position
+	Offset mgl32.Vec3
+	// Smoothing is how quickly the camera closes the distance, per second;
+	// zero or less snaps to the target every frame
+	Smoothing float64
+	// Deadzone is the half-size of the box around the camera, per axis, in
+	// which the target can move without the camera following
+	Deadzone mgl32.Vec3
+	// Sprite follows with the 2D sprite view instead of the 3D camera
+	Sprite bool
+}
+
+func (c *CameraFollowComponent) GetType() string {
+	return "camera_follow"
+}
+
+// NewCameraFollowComponent creates a camera follow component tracking target
+func NewCameraFollowComponent(target EntityID, offset mgl32.Vec3, smoothing float64) *CameraFollowComponent {
+	return &CameraFollowComponent{
+		Target:    target,
+		Offset:    offset,
+		Smoothing: smoothing,
+	}
+}
+
+// NineSliceComponent draws a texture scaled to Size while its borders keep
+// their size, for UI panels and bars. The texture is split by Insets into
+// fixed corners, edges stretched along one axis and a stretched center.
+type NineSliceComponent struct {
+	TextureID string
+	// Insets are the left, right, bottom and top border widths in texture pixels
+	Insets mgl32.Vec4
+	// PixelSize is the world size of one texture pixel in the borders
+	PixelSize float32
+	Size      mgl32.Vec2
+	// Origin is the pivot as a fraction of Size; (0.5, 0.5) is the center
+	Origin  mgl32.Vec2
+	Color   mgl32.Vec4
+	Visible bool
+}
+
+func (n *NineSliceComponent) GetType() string {
+	return "nine_slice"
+}
+
+// NewNineSliceComponent creates a centered nine-slice component
+func NewNineSliceComponent(textureID string, insets mgl32.Vec4, pixelSize float32, size mgl32.Vec2) *NineSliceComponent {
+	return &NineSliceComponent{
+		TextureID: textureID,
+		Insets:    insets,
+		PixelSize: pixelSize,
+		Size:      size,
+		Origin:    mgl32.Vec2{0.5, 0.5},
+		Color:     mgl32.Vec4{1, 1, 1, 1},
+		Visible:   true,
+	}
+}
+
+// MaterialComponent selects the material used to draw an entity's mesh
+type MaterialComponent struct {
+	MaterialID string
+}
+
+func (m *MaterialComponent) GetType() string {
+	return "material"
+}
+
+// NewMaterialComponent creates a new material component
+func NewMaterialComponent(materialID string) *MaterialComponent {
+	return &MaterialComponent{
+		MaterialID: materialID,
+	}
+}
+
+// AudioListenerComponent marks the entity whose transform hears spatial audio.
+// The first listener with a transform is used.
+type AudioListenerComponent struct{}
+
+func (a *AudioListenerComponent) GetType() string {
+	return "audio_listener"
+}
+
+// NewAudioListenerComponent creates a new audio listener component
+func NewAudioListenerComponent() *AudioListenerComponent {
+	return &AudioListenerComponent{}
+}
+
+// SpatialAudioComponent makes an entity's audio attenuate and pan with its
+// transform's position relative to the listener
+type SpatialAudioComponent struct {
+	// MinDistance is the distance up to which the sound plays at full volume
+	MinDistance float64
+	// MaxDistance is the distance at which the sound becomes silent
+	MaxDistance float64
+	// Rolloff shapes the falloff between the two: 1 is linear, higher values fade faster
+	Rolloff float64
+}
+
+func (s *SpatialAudioComponent) GetType() string {
+	return "spatial_audio"
+}
+
+// NewSpatialAudioComponent creates a new spatial audio component
+func NewSpatialAudioComponent(minDistance, maxDistance, rolloff float64) *SpatialAudioComponent {
+	return &SpatialAudioComponent{
+		MinDistance: minDistance,
+		MaxDistance: maxDistance,
+		Rolloff:     rolloff,
+	}
+}
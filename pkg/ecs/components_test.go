@@ -0,0 +1,69 @@
+package ecs
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestTransformLookAt(t *testing.T) {
+	up := mgl32.Vec3{0, 1, 0}
+	tests := []struct {
+		name             string
+		position, target mgl32.Vec3
+	}{
+		{"straight ahead", mgl32.Vec3{}, mgl32.Vec3{0, 0, -5}},
+		{"behind", mgl32.Vec3{}, mgl32.Vec3{0, 0, 5}},
+		{"to the right", mgl32.Vec3{1, 2, 3}, mgl32.Vec3{6, 2, 3}},
+		{"above and to the side", mgl32.Vec3{0, 0, 0}, mgl32.Vec3{3, 4, -2}},
+		{"below", mgl32.Vec3{2, 5, 2}, mgl32.Vec3{-1, 0, 4}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			transform := NewTransformComponent(test.position, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1})
+			transform.LookAt(test.target, up)
+
+			want := test.target.Sub(test.position).Normalize()
+			if got := transform.Forward(); !vecNear(got, want) {
+				t.Errorf("Forward() = %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+func TestTransformLookAtDegenerate(t *testing.T) {
+	up := mgl32.Vec3{0, 1, 0}
+
+	// Looking at its own position leaves the rotation alone
+	transform := NewTransformComponent(mgl32.Vec3{1, 1, 1}, mgl32.Vec3{0, 0.5, 0}, mgl32.Vec3{1, 1, 1})
+	before := transform.Quaternion
+	transform.LookAt(transform.Position, up)
+	if transform.Quaternion != before {
+		t.Errorf("looking at its own position changed the rotation to %v", transform.EulerAngles())
+	}
+
+	// Looking along the up vector keeps the yaw and doesn't produce NaN
+	for _, target := range []mgl32.Vec3{{1, 5, 1}, {1, -5, 1}} {
+		transform.LookAt(target, up)
+		rotation := transform.EulerAngles()
+		for i := 0; i < 3; i++ {
+			if math.IsNaN(float64(rotation[i])) {
+				t.Fatalf("looking at %v gave rotation %v", target, rotation)
+			}
+		}
+		if math.Abs(float64(rotation.Y())-0.5) > 1e-4 {
+			t.Errorf("looking at %v changed the yaw to %v", target, rotation.Y())
+		}
+		want := target.Sub(transform.Position).Normalize()
+		if got := transform.Forward(); !vecNear(got, want) {
+			t.Errorf("looking at %v: Forward() = %v, want %v", target, got, want)
+		}
+	}
+}
+
+// vecNear reports whether two vectors are equal within rounding error
+func vecNear(a, b mgl32.Vec3) bool {
+	return a.Sub(b).Len() < 1e-5
+}
@@ -0,0 +1,126 @@
+package ecs
+
+// ForEachWithComponent calls fn for each entity with the component, in
+// creation order, without allocating.
+//
+// fn runs under the world's read lock. It may change component fields, but
+// must not call back into the world: structural changes would deadlock, and
+// so can reads while another goroutine waits to write. Queue structural
+// changes with Defer instead. AddTag and RemoveTag are safe to call: the tag
+// index and tag listeners catch up once the iteration finishes.
+func (w *World) ForEachWithComponent(componentType string, fn func(entityID EntityID, component Component)) {
+	defer w.endIteration()
+	w.beginIteration()
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	if w.archetypes != nil {
+		var components [1]Component
+		w.archetypes.forEach([]string{componentType}, components[:], func(entityID EntityID) {
+			fn(entityID, components[0])
+		})
+		return
+	}
+
+	for _, entityID := range w.order {
+		if component, hasComponent := w.entities[entityID].Components[componentType]; hasComponent {
+			fn(entityID, component)
+		}
+	}
+}
+
+// ForEach calls fn for each entity with all the component types, in creation
+// order, passing the components in the order of componentTypes. The slice
+// is reused between calls to fn, so fn must not keep it.
+//
+// fn runs under the world's read lock, with the same restrictions as in
+// ForEachWithComponent.
+func (w *World) ForEach(componentTypes []string, fn func(entityID EntityID, components []Component)) {
+	defer w.endIteration()
+	w.beginIteration()
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	components := w.takeComponentBuffer(len(componentTypes))
+	defer w.returnComponentBuffer(components)
+
+	if w.archetypes != nil {
+		w.archetypes.forEach(componentTypes, components, func(entityID EntityID) {
+			fn(entityID, components)
+		})
+		return
+	}
+
+	for _, entityID := range w.order {
+		if w.gatherComponentsLocked(entityID, componentTypes, components) {
+			fn(entityID, components)
+		}
+	}
+}
+
+// gatherComponentsLocked fills components with the entity's components of the
+// given types and returns false if any is missing; the caller must hold the
+// read lock
+func (w *World) gatherComponentsLocked(entityID EntityID, componentTypes []string, components []Component) bool {
+	entity := w.entities[entityID]
+	for i, componentType := range componentTypes {
+		component, hasComponent := entity.Components[componentType]
+		if !hasComponent {
+			return false
+		}
+		components[i] = component
+	}
+	return true
+}
+
+// beginIteration records that a ForEach callback may be about to run under
+// the read lock
+func (w *World) beginIteration() {
+	w.iterationMutex.Lock()
+	defer w.iterationMutex.Unlock()
+	w.iterations++
+}
+
+// takeComponentBuffer returns a spare slice of length n for ForEach to pass
+// to its callback. Concurrent ForEach calls each get their own slice, and
+// iterating does not allocate once enough have been returned.
+func (w *World) takeComponentBuffer(n int) []Component {
+	w.iterationMutex.Lock()
+	defer w.iterationMutex.Unlock()
+
+	if last := len(w.componentBuffers) - 1; last >= 0 {
+		buffer := w.componentBuffers[last]
+		w.componentBuffers = w.componentBuffers[:last]
+		if cap(buffer) >= n {
+			return buffer[:n]
+		}
+	}
+	return make([]Component, n)
+}
+
+// returnComponentBuffer clears a slice from takeComponentBuffer, so it keeps
+// no components alive, and keeps it for reuse
+func (w *World) returnComponentBuffer(buffer []Component) {
+	clear(buffer)
+
+	w.iterationMutex.Lock()
+	defer w.iterationMutex.Unlock()
+	w.componentBuffers = append(w.componentBuffers, buffer)
+}
+
+// endIteration applies the tag changes queued during iterations once the
+// last one has released the read lock
+func (w *World) endIteration() {
+	w.iterationMutex.Lock()
+	w.iterations--
+	var pending []tagChange
+	if w.iterations == 0 {
+		pending = w.pendingTags
+		w.pendingTags = nil
+	}
+	w.iterationMutex.Unlock()
+
+	for _, change := range pending {
+		w.tagChanged(change.entityID, change.tag, change.added)
+	}
+}
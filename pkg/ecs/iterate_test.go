@@ -0,0 +1,123 @@
+package ecs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// newIterationWorld creates count entities with transforms, every other one
+// also with a mesh
+func newIterationWorld(count int) *World {
+	world := NewWorld()
+	for i := 0; i < count; i++ {
+		entityID := world.CreateEntity()
+		world.AddComponent(entityID, NewTransformComponent(mgl32.Vec3{float32(i), 0, 0}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}))
+		if i%2 == 0 {
+			world.AddComponent(entityID, NewMeshComponent("cube"))
+		}
+	}
+	return world
+}
+
+func TestForEachWithComponent(t *testing.T) {
+	world := newIterationWorld(6)
+
+	var visited []EntityID
+	world.ForEachWithComponent("mesh", func(entityID EntityID, component Component) {
+		if _, ok := component.(*MeshComponent); !ok {
+			t.Errorf("entity %d: got %T, want *MeshComponent", entityID, component)
+		}
+		visited = append(visited, entityID)
+	})
+
+	if want := world.GetEntitiesWithComponent("mesh"); len(visited) != 3 || !reflect.DeepEqual(visited, want) {
+		t.Errorf("visited %v, want %v in creation order", visited, want)
+	}
+}
+
+func TestForEach(t *testing.T) {
+	for _, archetypes := range []bool{false, true} {
+		world := newIterationWorld(6)
+		if archetypes {
+			if err := world.EnableArchetypeStorage(); err != nil {
+				t.Fatalf("EnableArchetypeStorage: %v", err)
+			}
+		}
+
+		var visited []EntityID
+		world.ForEach([]string{"mesh", "transform"}, func(entityID EntityID, components []Component) {
+			// Components come in the order asked for
+			mesh, meshOK := components[0].(*MeshComponent)
+			transform, transformOK := components[1].(*TransformComponent)
+			if !meshOK || !transformOK || mesh != world.entities[entityID].Components["mesh"] ||
+				transform != world.entities[entityID].Components["transform"] {
+				t.Errorf("archetypes %v, entity %d: wrong components %v", archetypes, entityID, components)
+			}
+			visited = append(visited, entityID)
+		})
+
+		if len(visited) != 3 {
+			t.Errorf("archetypes %v: visited %v, want the 3 entities with both components", archetypes, visited)
+		}
+	}
+}
+
+func TestForEachDoesNotAllocate(t *testing.T) {
+	world := newIterationWorld(100)
+	types := []string{"transform", "mesh"}
+
+	count := 0
+	iterate := func() {
+		world.ForEachWithComponent("transform", func(entityID EntityID, component Component) {
+			count++
+		})
+		world.ForEach(types, func(entityID EntityID, components []Component) {
+			count++
+		})
+	}
+	if allocs := testing.AllocsPerRun(10, iterate); allocs != 0 {
+		t.Errorf("iterating made %v allocations, want 0", allocs)
+	}
+
+	if err := world.EnableArchetypeStorage(); err != nil {
+		t.Fatalf("EnableArchetypeStorage: %v", err)
+	}
+	if allocs := testing.AllocsPerRun(10, iterate); allocs != 0 {
+		t.Errorf("iterating archetypes made %v allocations, want 0", allocs)
+	}
+}
+
+func BenchmarkGetEntitiesWithComponent(b *testing.B) {
+	world := newIterationWorld(5000)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for _, entityID := range world.GetEntitiesWithComponent("transform") {
+			_ = world.GetComponent(entityID, "transform")
+		}
+	}
+}
+
+func BenchmarkForEachWithComponent(b *testing.B) {
+	world := newIterationWorld(5000)
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		world.ForEachWithComponent("transform", func(entityID EntityID, component Component) {})
+	}
+}
+
+func BenchmarkForEach(b *testing.B) {
+	world := newIterationWorld(5000)
+	types := []string{"transform", "mesh"}
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		world.ForEach(types, func(entityID EntityID, components []Component) {})
+	}
+}
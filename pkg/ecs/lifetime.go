@@ -0,0 +1,59 @@
+package ecs
+
+// LifetimeComponent destroys its entity after Remaining seconds
+type LifetimeComponent struct {
+	Remaining float64
+	// OnExpire is called when the lifetime runs out, before the entity is destroyed
+	OnExpire func(world *World, entityID EntityID) `json:"-"`
+
+	expired bool
+}
+
+func (l *LifetimeComponent) GetType() string {
+	return "lifetime"
+}
+
+// NewLifetimeComponent creates a lifetime component expiring after seconds
+func NewLifetimeComponent(seconds float64) *LifetimeComponent {
+	return &LifetimeComponent{
+		Remaining: seconds,
+	}
+}
+
+// LifetimeSystem counts down lifetime components and destroys their
+// entities through the command buffer when they expire
+type LifetimeSystem struct{}
+
+// NewLifetimeSystem creates a lifetime system
+func NewLifetimeSystem() *LifetimeSystem {
+	return &LifetimeSystem{}
+}
+
+// Update subtracts deltaTime from every lifetime and queues expired entities
+// for destruction
+func (s *LifetimeSystem) Update(deltaTime float64, world *World) {
+	for _, entityID := range world.GetEntitiesWithComponent("lifetime") {
+		lifetime, ok := world.GetComponent(entityID, "lifetime").(*LifetimeComponent)
+		if !ok || lifetime.expired {
+			continue
+		}
+
+		lifetime.Remaining -= deltaTime
+		if lifetime.Remaining > 0 {
+			continue
+		}
+
+		// Commands only run after all systems, so mark the lifetime to
+		// expire once if the buffer is flushed late
+		lifetime.expired = true
+		if lifetime.OnExpire != nil {
+			lifetime.OnExpire(world, entityID)
+		}
+		world.DeferDestroy(entityID)
+	}
+}
+
+// GetName returns the system name
+func (s *LifetimeSystem) GetName() string {
+	return "LifetimeSystem"
+}
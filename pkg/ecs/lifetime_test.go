@@ -0,0 +1,68 @@
+package ecs
+
+import (
+	"testing"
+)
+
+func TestLifetimeDestroysEntity(t *testing.T) {
+	world := NewWorld()
+	world.AddSystem(NewLifetimeSystem())
+
+	expired := 0
+	entityID := world.CreateEntity()
+	lifetime := NewLifetimeComponent(0.1)
+	lifetime.OnExpire = func(w *World, id EntityID) {
+		// The entity still exists when the callback runs
+		if id != entityID || !w.HasComponent(id, "lifetime") {
+			t.Errorf("OnExpire(%d) ran without its entity", id)
+		}
+		expired++
+	}
+	world.AddComponent(entityID, lifetime)
+	survivor := world.CreateEntity()
+	world.AddComponent(survivor, NewLifetimeComponent(1))
+
+	// Five 60 Hz ticks are short of 0.1 seconds
+	for i := 0; i < 5; i++ {
+		world.Update(1.0 / 60)
+	}
+	if world.GetComponent(entityID, "lifetime") == nil {
+		t.Fatal("entity destroyed before its lifetime ran out")
+	}
+
+	for i := 0; i < 2; i++ {
+		world.Update(1.0 / 60)
+	}
+	if world.GetComponent(entityID, "lifetime") != nil {
+		t.Error("entity still exists after its lifetime")
+	}
+	if expired != 1 {
+		t.Errorf("OnExpire called %d times, want 1", expired)
+	}
+	if world.GetComponent(survivor, "lifetime") == nil {
+		t.Error("entity with a longer lifetime was destroyed")
+	}
+}
+
+func TestLifetimeExpiresOnceBeforeFlush(t *testing.T) {
+	world := NewWorld()
+	system := NewLifetimeSystem()
+
+	expired := 0
+	lifetime := NewLifetimeComponent(0.1)
+	lifetime.OnExpire = func(w *World, id EntityID) { expired++ }
+	entityID := world.CreateEntity()
+	world.AddComponent(entityID, lifetime)
+
+	// Updating again before the commands are flushed doesn't expire twice
+	system.Update(0.2, world)
+	system.Update(0.2, world)
+	if expired != 1 {
+		t.Errorf("OnExpire called %d times, want 1", expired)
+	}
+
+	world.FlushCommands()
+	if world.GetEntityCount() != 0 {
+		t.Errorf("GetEntityCount() = %d after the flush, want 0", world.GetEntityCount())
+	}
+}
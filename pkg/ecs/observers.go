@@ -0,0 +1,92 @@
+package ecs
+
+// ComponentListener is called after a component is added to or removed from an entity
+type ComponentListener func(entityID EntityID, component Component)
+
+// registeredListener is a component listener and the ID that removes it
+type registeredListener struct {
+	id       uint64
+	listener ComponentListener
+}
+
+// componentEvent is a component change waiting to be dispatched to listeners
+type componentEvent struct {
+	listeners []registeredListener
+	entityID  EntityID
+	component Component
+}
+
+// OnComponentAdded registers a listener called whenever a component of the
+// given type is added to an entity. Replacing a component reports the old
+// one as removed and the new one as added. It returns a function that
+// removes the listener.
+func (w *World) OnComponentAdded(componentType string, listener ComponentListener) func() {
+	return w.addComponentListener(w.componentAdded, componentType, listener)
+}
+
+// OnComponentRemoved registers a listener called whenever a component of the
+// given type is removed from an entity, including when the entity is destroyed.
+// Load replaces the whole world and doesn't notify. It returns a function
+// that removes the listener.
+func (w *World) OnComponentRemoved(componentType string, listener ComponentListener) func() {
+	return w.addComponentListener(w.componentRemoved, componentType, listener)
+}
+
+// addComponentListener registers a listener for a type and returns a function
+// that removes it
+func (w *World) addComponentListener(listeners map[string][]registeredListener, componentType string, listener ComponentListener) func() {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.nextListenerID++
+	id := w.nextListenerID
+	listeners[componentType] = append(listeners[componentType], registeredListener{id: id, listener: listener})
+
+	return func() {
+		w.mutex.Lock()
+		defer w.mutex.Unlock()
+
+		// Queued events keep the slice they were queued with, so build a new one
+		var kept []registeredListener
+		for _, registered := range listeners[componentType] {
+			if registered.id != id {
+				kept = append(kept, registered)
+			}
+		}
+		if len(kept) == 0 {
+			delete(listeners, componentType)
+		} else {
+			listeners[componentType] = kept
+		}
+	}
+}
+
+// queueComponentEventLocked records a change for the type's listeners, if it
+// has any; the caller must hold the write lock
+func (w *World) queueComponentEventLocked(listeners map[string][]registeredListener, entityID EntityID, component Component) {
+	registered := listeners[component.GetType()]
+	if len(registered) == 0 {
+		return
+	}
+
+	w.componentEvents = append(w.componentEvents, componentEvent{
+		listeners: registered,
+		entityID:  entityID,
+		component: component,
+	})
+}
+
+// dispatchComponentEvents calls the listeners of queued changes. It must be
+// called without the lock held, so listeners can use the world freely.
+func (w *World) dispatchComponentEvents() {
+	w.mutex.Lock()
+	events := w.componentEvents
+	w.componentEvents = nil
+	w.mutex.Unlock()
+
+	for _, event := range events {
+		for _, registered := range event.listeners {
+			registered.listener(event.entityID, event.component)
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// componentLog records the components passed to a listener
+type componentLog struct {
+	entities   []EntityID
+	components []Component
+}
+
+func (l *componentLog) listen(entityID EntityID, component Component) {
+	l.entities = append(l.entities, entityID)
+	l.components = append(l.components, component)
+}
+
+func TestComponentAddedFiresOncePerAdd(t *testing.T) {
+	world := NewWorld()
+	var added componentLog
+	world.OnComponentAdded("transform", added.listen)
+
+	first := world.CreateEntity()
+	second := world.CreateEntity()
+	transform := NewTransformComponent(mgl32.Vec3{}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1})
+	world.AddComponent(first, transform)
+	world.AddComponent(second, NewTransformComponent(mgl32.Vec3{}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}))
+
+	// Other types don't notify
+	world.AddComponent(first, NewMeshComponent("cube"))
+
+	if len(added.entities) != 2 || added.entities[0] != first || added.entities[1] != second {
+		t.Fatalf("added listener saw %v, want [%d %d]", added.entities, first, second)
+	}
+	if added.components[0] != transform {
+		t.Errorf("added listener got %v, want the added component", added.components[0])
+	}
+}
+
+func TestComponentReplacedReportsRemoveAndAdd(t *testing.T) {
+	world := NewWorld()
+	var added, removed componentLog
+	world.OnComponentAdded("mesh", added.listen)
+	world.OnComponentRemoved("mesh", removed.listen)
+
+	entityID := world.CreateEntity()
+	previous := NewMeshComponent("cube")
+	replacement := NewMeshComponent("sphere")
+	world.AddComponent(entityID, previous)
+	world.AddComponent(entityID, replacement)
+
+	if len(added.components) != 2 || added.components[1] != replacement {
+		t.Errorf("added listener got %v, want both meshes", added.components)
+	}
+	if len(removed.components) != 1 || removed.components[0] != previous {
+		t.Errorf("removed listener got %v, want the replaced mesh", removed.components)
+	}
+}
+
+func TestComponentRemovedFires(t *testing.T) {
+	world := NewWorld()
+	var removedTransforms, removedMeshes componentLog
+	world.OnComponentRemoved("transform", removedTransforms.listen)
+	world.OnComponentRemoved("mesh", removedMeshes.listen)
+
+	entityID := world.CreateEntity()
+	mesh := NewMeshComponent("cube")
+	world.AddComponent(entityID, NewTransformComponent(mgl32.Vec3{}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}))
+	world.AddComponent(entityID, mesh)
+
+	world.RemoveComponent(entityID, "mesh")
+	if len(removedMeshes.components) != 1 || removedMeshes.components[0] != mesh {
+		t.Fatalf("RemoveComponent notified %v, want the mesh", removedMeshes.components)
+	}
+
+	// Removing a missing component doesn't notify
+	world.RemoveComponent(entityID, "mesh")
+	if len(removedMeshes.components) != 1 {
+		t.Errorf("removing a missing mesh notified again")
+	}
+
+	// Destroying reports each remaining component
+	world.DestroyEntity(entityID)
+	if len(removedTransforms.entities) != 1 || removedTransforms.entities[0] != entityID {
+		t.Errorf("DestroyEntity notified %v, want [%d]", removedTransforms.entities, entityID)
+	}
+	if len(removedMeshes.components) != 1 {
+		t.Errorf("DestroyEntity notified the already removed mesh")
+	}
+}
+
+func TestComponentListenersRunOutsideLock(t *testing.T) {
+	world := NewWorld()
+
+	// A listener that reads and writes the world would deadlock under the lock
+	world.OnComponentAdded("transform", func(entityID EntityID, component Component) {
+		if !world.HasComponent(entityID, "transform") {
+			t.Error("listener ran before the component was added")
+		}
+		world.AddComponent(entityID, NewMeshComponent("cube"))
+	})
+	world.OnComponentRemoved("transform", func(entityID EntityID, component Component) {
+		world.CreateEntity()
+	})
+
+	entityID := world.CreateEntity()
+	world.AddComponent(entityID, NewTransformComponent(mgl32.Vec3{}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}))
+	if !world.HasComponent(entityID, "mesh") {
+		t.Error("mesh added by the listener is missing")
+	}
+
+	world.DestroyEntity(entityID)
+	if count := world.GetEntityCount(); count != 1 {
+		t.Errorf("GetEntityCount() = %d, want the entity created by the listener", count)
+	}
+}
+
+func TestRemovingComponentListener(t *testing.T) {
+	world := NewWorld()
+	var kept, dropped, removed componentLog
+	world.OnComponentAdded("mesh", kept.listen)
+	stop := world.OnComponentAdded("mesh", dropped.listen)
+	stopRemoved := world.OnComponentRemoved("mesh", removed.listen)
+
+	first := world.CreateEntity()
+	world.AddComponent(first, NewMeshComponent("cube"))
+	stop()
+	stopRemoved()
+	// Removing twice is harmless
+	stop()
+
+	second := world.CreateEntity()
+	world.AddComponent(second, NewMeshComponent("cube"))
+	world.RemoveComponent(first, "mesh")
+
+	if len(kept.entities) != 2 {
+		t.Errorf("remaining listener saw %v, want both adds", kept.entities)
+	}
+	if len(dropped.entities) != 1 || dropped.entities[0] != first {
+		t.Errorf("removed added listener saw %v, want only [%d]", dropped.entities, first)
+	}
+	if len(removed.entities) != 0 {
+		t.Errorf("removed removal listener saw %v, want nothing", removed.entities)
+	}
+}
@@ -0,0 +1,45 @@
+package ecs
+
+import (
+	"sort"
+)
+
+// Entities are kept in creation order alongside the entity map so queries
+// return them in the same order on every call. IDs only grow, so creation
+// order is ID order and the slice stays sorted.
+
+// GetEntities returns all entities in creation order
+func (w *World) GetEntities() []EntityID {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	entities := make([]EntityID, len(w.order))
+	copy(entities, w.order)
+	return entities
+}
+
+// appendOrderLocked records a new entity; the caller must hold the write lock
+func (w *World) appendOrderLocked(entityID EntityID) {
+	w.order = append(w.order, entityID)
+}
+
+// removeOrderLocked removes an entity keeping the others in order; the caller must hold the write lock
+func (w *World) removeOrderLocked(entityID EntityID) {
+	index := sort.Search(len(w.order), func(i int) bool {
+		return w.order[i] >= entityID
+	})
+	if index < len(w.order) && w.order[index] == entityID {
+		w.order = append(w.order[:index], w.order[index+1:]...)
+	}
+}
+
+// rebuildOrderLocked recreates the order from the entity map; the caller must hold the write lock
+func (w *World) rebuildOrderLocked() {
+	w.order = make([]EntityID, 0, len(w.entities))
+	for entityID := range w.entities {
+		w.order = append(w.order, entityID)
+	}
+	sort.Slice(w.order, func(i, j int) bool {
+		return w.order[i] < w.order[j]
+	})
+}
@@ -0,0 +1,72 @@
+package ecs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// newOrderedWorld creates a world with ten entities, every other one with a
+// transform, returning the entities in creation order
+func newOrderedWorld() (*World, []EntityID) {
+	world := NewWorld()
+	var created []EntityID
+	for i := 0; i < 10; i++ {
+		entityID := world.CreateEntity()
+		world.AddComponent(entityID, NewTagComponent())
+		if i%2 == 0 {
+			world.AddComponent(entityID, NewTransformComponent(mgl32.Vec3{}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}))
+		}
+		created = append(created, entityID)
+	}
+	return world, created
+}
+
+func TestQueriesReturnCreationOrder(t *testing.T) {
+	world, created := newOrderedWorld()
+	withTransform := []EntityID{created[0], created[2], created[4], created[6], created[8]}
+
+	for call := 0; call < 100; call++ {
+		if got := world.GetEntities(); !reflect.DeepEqual(got, created) {
+			t.Fatalf("call %d: GetEntities() = %v, want %v", call, got, created)
+		}
+		if got := world.GetEntitiesWithComponent("transform"); !reflect.DeepEqual(got, withTransform) {
+			t.Fatalf("call %d: GetEntitiesWithComponent() = %v, want %v", call, got, withTransform)
+		}
+
+		var visited []EntityID
+		world.ForEach([]string{"tag", "transform"}, func(entityID EntityID, components []Component) {
+			visited = append(visited, entityID)
+		})
+		if !reflect.DeepEqual(visited, withTransform) {
+			t.Fatalf("call %d: ForEach visited %v, want %v", call, visited, withTransform)
+		}
+	}
+}
+
+func TestOrderStableAfterRemoval(t *testing.T) {
+	world, created := newOrderedWorld()
+
+	world.DestroyEntity(created[3])
+	world.DestroyEntity(created[0])
+	added := world.CreateEntity()
+
+	want := append([]EntityID{created[1], created[2]}, created[4:]...)
+	want = append(want, added)
+	if got := world.GetEntities(); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetEntities() = %v, want %v", got, want)
+	}
+}
+
+func TestOrderSurvivesSnapshot(t *testing.T) {
+	world, created := newOrderedWorld()
+
+	snapshot := world.Snapshot()
+	restored := NewWorld()
+	restored.Restore(snapshot)
+
+	if got := restored.GetEntities(); !reflect.DeepEqual(got, created) {
+		t.Errorf("GetEntities() after Restore = %v, want %v", got, created)
+	}
+}
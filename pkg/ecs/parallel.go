@@ -0,0 +1,127 @@
+package ecs
+
+import (
+	"sync"
+)
+
+// ComponentAccess declares the component types a system reads and writes
+type ComponentAccess struct {
+	Reads  []string
+	Writes []string
+}
+
+// conflicts reports whether two systems touch a component type that at
+// least one of them writes
+func (a ComponentAccess) conflicts(other ComponentAccess) bool {
+	return overlaps(a.Writes, other.Writes) || overlaps(a.Writes, other.Reads) || overlaps(a.Reads, other.Writes)
+}
+
+// overlaps reports whether two type lists share a type
+func overlaps(a, b []string) bool {
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// AccessSystem is implemented by systems that declare their component
+// access. With parallelism enabled, consecutive systems of a stage whose
+// access doesn't conflict run at the same time. Systems that don't declare
+// access always run alone, so they keep working unchanged.
+type AccessSystem interface {
+	System
+	GetComponentAccess() ComponentAccess
+}
+
+// SetParallelism sets the number of worker goroutines Update runs
+// non-conflicting systems on. 1 or less runs every system sequentially,
+// which is the default.
+//
+// Systems running in parallel may call any World method, but must only
+// change the fields of components they declared as written, and must not
+// share other state, including the world's Rand, without their own
+// synchronization.
+func (w *World) SetParallelism(workers int) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.workers = workers
+}
+
+// systemBatchesLocked splits the run order into groups that can run together:
+// consecutive systems of the same stage that declare access which conflicts
+// with no other system of the group. Groups run in order, so any two
+// conflicting systems keep their relative order. The caller must hold the
+// lock.
+func (w *World) systemBatchesLocked() [][]System {
+	var batches [][]System
+	var batch []System
+	var batchAccess []ComponentAccess
+	batchStage := ""
+
+	flush := func() {
+		if len(batch) > 0 {
+			batches = append(batches, batch)
+		}
+		batch, batchAccess = nil, nil
+	}
+
+	for _, scheduled := range w.schedule {
+		accessSystem, declared := scheduled.system.(AccessSystem)
+		if !declared {
+			flush()
+			batches = append(batches, []System{scheduled.system})
+			continue
+		}
+
+		access := accessSystem.GetComponentAccess()
+		if scheduled.stage != batchStage || conflictsWithAny(access, batchAccess) {
+			flush()
+		}
+		batch = append(batch, scheduled.system)
+		batchAccess = append(batchAccess, access)
+		batchStage = scheduled.stage
+	}
+	flush()
+
+	return batches
+}
+
+// conflictsWithAny reports whether access conflicts with any of others
+func conflictsWithAny(access ComponentAccess, others []ComponentAccess) bool {
+	for _, other := range others {
+		if access.conflicts(other) {
+			return true
+		}
+	}
+	return false
+}
+
+// runBatches runs each batch's systems on up to workers goroutines and
+// waits for the batch to finish before starting the next
+func runBatches(batches [][]System, workers int, deltaTime float64, w *World) {
+	for _, batch := range batches {
+		if len(batch) == 1 {
+			batch[0].Update(deltaTime, w)
+			continue
+		}
+
+		var wg sync.WaitGroup
+		slots := make(chan struct{}, workers)
+		for _, system := range batch {
+			slots <- struct{}{}
+			wg.Add(1)
+			go func(system System) {
+				defer wg.Done()
+				defer func() { <-slots }()
+
+				system.Update(deltaTime, w)
+			}(system)
+		}
+		wg.Wait()
+	}
+}
@@ -0,0 +1,189 @@
+package ecs
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// accessSystem runs a function as a system with declared component access
+type accessSystem struct {
+	funcSystem
+	access ComponentAccess
+}
+
+func (s *accessSystem) GetComponentAccess() ComponentAccess {
+	return s.access
+}
+
+// newAccessSystem creates a system reading and writing the given types
+func newAccessSystem(name string, reads, writes []string, update func(world *World)) *accessSystem {
+	return &accessSystem{
+		funcSystem: funcSystem{name: name, update: update},
+		access:     ComponentAccess{Reads: reads, Writes: writes},
+	}
+}
+
+// overlapMeter tracks how many systems run at once
+type overlapMeter struct {
+	running atomic.Int32
+	most    atomic.Int32
+}
+
+// run counts a system as running for a short while
+func (m *overlapMeter) run(world *World) {
+	running := m.running.Add(1)
+	for {
+		most := m.most.Load()
+		if running <= most || m.most.CompareAndSwap(most, running) {
+			break
+		}
+	}
+	time.Sleep(5 * time.Millisecond)
+	m.running.Add(-1)
+}
+
+func TestComponentAccessConflicts(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b ComponentAccess
+		want bool
+	}{
+		{"shared reads", ComponentAccess{Reads: []string{"transform"}}, ComponentAccess{Reads: []string{"transform"}}, false},
+		{"different writes", ComponentAccess{Writes: []string{"transform"}}, ComponentAccess{Writes: []string{"mesh"}}, false},
+		{"same write", ComponentAccess{Writes: []string{"transform"}}, ComponentAccess{Writes: []string{"transform"}}, true},
+		{"write and read", ComponentAccess{Writes: []string{"transform"}}, ComponentAccess{Reads: []string{"transform"}}, true},
+		{"read and write", ComponentAccess{Reads: []string{"physics"}}, ComponentAccess{Writes: []string{"mesh", "physics"}}, true},
+		{"nothing declared", ComponentAccess{}, ComponentAccess{}, false},
+	}
+	for _, test := range tests {
+		if got := test.a.conflicts(test.b); got != test.want {
+			t.Errorf("%s: conflicts() = %v, want %v", test.name, got, test.want)
+		}
+		if got := test.b.conflicts(test.a); got != test.want {
+			t.Errorf("%s: reversed conflicts() = %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestSystemBatches(t *testing.T) {
+	noop := func(world *World) {}
+	world := NewWorld()
+	world.AddSystem(newAccessSystem("move", []string{"velocity"}, []string{"transform"}, noop))
+	world.AddSystem(newAccessSystem("animate", nil, []string{"sprite"}, noop))
+	world.AddSystem(newAccessSystem("follow", []string{"transform"}, []string{"camera"}, noop))
+	world.AddSystem(&funcSystem{name: "legacy", update: noop})
+	world.AddSystem(newAccessSystem("sound", nil, []string{"audio"}, noop))
+	world.AddSystemToStage(StageRender, newAccessSystem("draw", []string{"transform"}, nil, noop))
+
+	world.mutex.Lock()
+	batches := world.systemBatchesLocked()
+	world.mutex.Unlock()
+
+	var got [][]string
+	for _, batch := range batches {
+		var names []string
+		for _, system := range batch {
+			names = append(names, system.GetName())
+		}
+		got = append(got, names)
+	}
+
+	// follow reads what move writes, legacy declares nothing and draw is in
+	// a later stage
+	want := [][]string{{"move", "animate"}, {"follow"}, {"legacy"}, {"sound"}, {"draw"}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("batches = %v, want %v", got, want)
+	}
+}
+
+func TestNonConflictingSystemsRunConcurrently(t *testing.T) {
+	world := NewWorld()
+	world.SetParallelism(2)
+
+	// Each system waits for the other to start, which only happens if both
+	// are running at once
+	var started sync.WaitGroup
+	started.Add(2)
+	var timedOut atomic.Bool
+	meet := func(world *World) {
+		started.Done()
+		done := make(chan struct{})
+		go func() {
+			started.Wait()
+			close(done)
+		}()
+		select {
+		case <-done:
+		case <-time.After(time.Second):
+			timedOut.Store(true)
+		}
+	}
+	world.AddSystem(newAccessSystem("move", []string{"velocity"}, []string{"transform"}, meet))
+	world.AddSystem(newAccessSystem("animate", []string{"animation"}, []string{"sprite"}, meet))
+
+	world.Update(1.0 / 60)
+	if timedOut.Load() {
+		t.Error("non-conflicting systems didn't run at the same time")
+	}
+}
+
+func TestConflictingSystemsSerialize(t *testing.T) {
+	tests := []struct {
+		name string
+		// declared gives the first system access that conflicts with the
+		// second; otherwise it declares none
+		declared bool
+	}{
+		{"same write", true},
+		{"undeclared access", false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var meter overlapMeter
+			var mutex sync.Mutex
+			var order []string
+			record := func(name string) func(world *World) {
+				return func(world *World) {
+					meter.run(world)
+					mutex.Lock()
+					order = append(order, name)
+					mutex.Unlock()
+				}
+			}
+
+			world := NewWorld()
+			world.SetParallelism(4)
+			if test.declared {
+				world.AddSystem(newAccessSystem("first", nil, []string{"transform"}, record("first")))
+			} else {
+				world.AddSystem(&funcSystem{name: "first", update: record("first")})
+			}
+			world.AddSystem(newAccessSystem("second", nil, []string{"transform"}, record("second")))
+			world.AddSystem(newAccessSystem("third", []string{"transform"}, nil, record("third")))
+
+			world.Update(1.0 / 60)
+
+			if most := meter.most.Load(); most != 1 {
+				t.Errorf("%d conflicting systems ran at once, want 1", most)
+			}
+			if want := []string{"first", "second", "third"}; !reflect.DeepEqual(order, want) {
+				t.Errorf("systems ran in order %v, want %v", order, want)
+			}
+		})
+	}
+}
+
+func TestParallelismDefaultsToSequential(t *testing.T) {
+	var meter overlapMeter
+	world := NewWorld()
+	world.AddSystem(newAccessSystem("move", nil, []string{"transform"}, meter.run))
+	world.AddSystem(newAccessSystem("animate", nil, []string{"sprite"}, meter.run))
+
+	world.Update(1.0 / 60)
+	if most := meter.most.Load(); most != 1 {
+		t.Errorf("%d systems ran at once without parallelism, want 1", most)
+	}
+}
@@ -0,0 +1,220 @@
+package ecs
+
+import (
+	"math"
+	"math/rand"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// DefaultMaxParticles is the pool size of emitters that don't set MaxParticles
+const DefaultMaxParticles = 1000
+
+// Particle is a single live particle of an emitter, in world space
+type Particle struct {
+	Position mgl32.Vec2
+	Velocity mgl32.Vec2
+	// Age is the time since the particle spawned, in seconds
+	Age float64
+}
+
+// ParticleEmitterComponent spawns particles at the entity's transform.
+// Particles live in a pool sized by MaxParticles, so emitting doesn't
+// allocate once the pool is full.
+type ParticleEmitterComponent struct {
+	// TextureID is drawn for each particle; solid squares are drawn when empty
+	TextureID string
+	// Rate is the number of particles spawned per second
+	Rate float64
+	// Lifetime is how long each particle lives, in seconds
+	Lifetime float64
+
+	// Color and size are interpolated from start to end over a particle's life
+	StartColor mgl32.Vec4
+	EndColor   mgl32.Vec4
+	StartSize  float32
+	EndSize    float32
+
+	// Velocity is the initial velocity, rotated by a random angle of up to
+	// Spread radians either way for each particle
+	Velocity mgl32.Vec2
+	Spread   float32
+	Gravity  mgl32.Vec2
+
+	MaxParticles int
+	Emitting     bool
+
+	particles []Particle
+	live      int
+	// spawnDebt carries fractional particles between frames
+	spawnDebt float64
+}
+
+func (p *ParticleEmitterComponent) GetType() string {
+	return "particle_emitter"
+}
+
+// NewParticleEmitterComponent creates an emitting particle emitter with
+// white particles that fade out over their lifetime
+func NewParticleEmitterComponent(rate, lifetime float64, velocity mgl32.Vec2, spread float32) *ParticleEmitterComponent {
+	return &ParticleEmitterComponent{
+		Rate:         rate,
+		Lifetime:     lifetime,
+		StartColor:   mgl32.Vec4{1, 1, 1, 1},
+		EndColor:     mgl32.Vec4{1, 1, 1, 0},
+		StartSize:    0.1,
+		EndSize:      0.1,
+		Velocity:     velocity,
+		Spread:       spread,
+		MaxParticles: DefaultMaxParticles,
+		Emitting:     true,
+	}
+}
+
+// Particles returns the live particles. The slice is reused by the next
+// Advance, so callers must not keep it.
+func (p *ParticleEmitterComponent) Particles() []Particle {
+	return p.particles[:p.live]
+}
+
+// ParticleColor returns the color of a particle at its age
+func (p *ParticleEmitterComponent) ParticleColor(particle Particle) mgl32.Vec4 {
+	t := p.lifeFraction(particle)
+	return p.StartColor.Add(p.EndColor.Sub(p.StartColor).Mul(t))
+}
+
+// ParticleSize returns the size of a particle at its age
+func (p *ParticleEmitterComponent) ParticleSize(particle Particle) float32 {
+	t := p.lifeFraction(particle)
+	return p.StartSize + (p.EndSize-p.StartSize)*t
+}
+
+// Clone copies the emitter's settings. The clone gets its own pool and
+// starts with no live particles.
+func (p *ParticleEmitterComponent) Clone() Component {
+	clone := *p
+	clone.particles = nil
+	clone.Clear()
+	return &clone
+}
+
+// snapshot copies the emitter along with its live particles
+func (p *ParticleEmitterComponent) snapshot() Component {
+	clone := *p
+	clone.particles = append([]Particle(nil), p.particles...)
+	return &clone
+}
+
+// Clear removes all live particles
+func (p *ParticleEmitterComponent) Clear() {
+	p.live = 0
+	p.spawnDebt = 0
+}
+
+// Advance ages and moves the live particles by deltaTime, recycles those at
+// the end of their lifetime, then spawns new ones at origin. Random spread
+// is drawn from rng.
+func (p *ParticleEmitterComponent) Advance(deltaTime float64, origin mgl32.Vec2, rng *rand.Rand) {
+	p.reserve()
+
+	gravity := p.Gravity.Mul(float32(deltaTime))
+	for i := 0; i < p.live; {
+		particle := &p.particles[i]
+		particle.Age += deltaTime
+		if particle.Age >= p.Lifetime {
+			// Recycle by moving the last live particle into this slot
+			p.live--
+			p.particles[i] = p.particles[p.live]
+			continue
+		}
+
+		particle.Velocity = particle.Velocity.Add(gravity)
+		particle.Position = particle.Position.Add(particle.Velocity.Mul(float32(deltaTime)))
+		i++
+	}
+
+	if !p.Emitting || p.Rate <= 0 || p.Lifetime <= 0 {
+		p.spawnDebt = 0
+		return
+	}
+
+	p.spawnDebt += deltaTime * p.Rate
+	count := int(p.spawnDebt)
+	p.spawnDebt -= float64(count)
+
+	for ; count > 0 && p.live < len(p.particles); count-- {
+		p.particles[p.live] = Particle{
+			Position: origin,
+			Velocity: p.spawnVelocity(rng),
+		}
+		p.live++
+	}
+}
+
+// reserve makes sure the pool holds MaxParticles particles
+func (p *ParticleEmitterComponent) reserve() {
+	capacity := p.MaxParticles
+	if capacity <= 0 {
+		capacity = DefaultMaxParticles
+	}
+	if len(p.particles) == capacity {
+		return
+	}
+
+	particles := make([]Particle, capacity)
+	p.live = copy(particles, p.particles[:p.live])
+	p.particles = particles
+}
+
+// spawnVelocity returns Velocity rotated by a random angle within Spread
+func (p *ParticleEmitterComponent) spawnVelocity(rng *rand.Rand) mgl32.Vec2 {
+	if p.Spread == 0 {
+		return p.Velocity
+	}
+
+	angle := float64(p.Spread) * (rng.Float64()*2 - 1)
+	sin := float32(math.Sin(angle))
+	cos := float32(math.Cos(angle))
+	return mgl32.Vec2{
+		p.Velocity.X()*cos - p.Velocity.Y()*sin,
+		p.Velocity.X()*sin + p.Velocity.Y()*cos,
+	}
+}
+
+// lifeFraction returns how far through its lifetime a particle is, from 0 to 1
+func (p *ParticleEmitterComponent) lifeFraction(particle Particle) float32 {
+	if p.Lifetime <= 0 {
+		return 1
+	}
+	return float32(math.Min(particle.Age/p.Lifetime, 1))
+}
+
+// ParticleSystem advances the particle emitters of all entities with a
+// transform each frame, drawing spread from the world's random generator
+type ParticleSystem struct{}
+
+// NewParticleSystem creates a particle system
+func NewParticleSystem() *ParticleSystem {
+	return &ParticleSystem{}
+}
+
+// Update advances every emitter by deltaTime, spawning at its transform's position
+func (s *ParticleSystem) Update(deltaTime float64, world *World) {
+	rng := world.Rand()
+	world.ForEach([]string{"particle_emitter", "transform"}, func(entityID EntityID, components []Component) {
+		emitter, ok := components[0].(*ParticleEmitterComponent)
+		if !ok {
+			return
+		}
+		transform, ok := components[1].(*TransformComponent)
+		if !ok {
+			return
+		}
+		emitter.Advance(deltaTime, transform.Position.Vec2(), rng)
+	})
+}
+
+// GetName returns the system name
+func (s *ParticleSystem) GetName() string {
+	return "ParticleSystem"
+}
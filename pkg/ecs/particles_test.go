@@ -0,0 +1,131 @@
+package ecs
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestParticleSpawnCount(t *testing.T) {
+	emitter := NewParticleEmitterComponent(10, 5, mgl32.Vec2{0, 1}, 0)
+	rng := rand.New(rand.NewSource(1))
+
+	// Ten a second is two and a half per quarter second; the remainder carries over
+	for i, want := range []int{2, 5, 7, 10} {
+		emitter.Advance(0.25, mgl32.Vec2{}, rng)
+		if got := len(emitter.Particles()); got != want {
+			t.Errorf("after %d ticks: %d particles, want %d", i+1, got, want)
+		}
+	}
+
+	// Stopping emission keeps the live particles
+	emitter.Emitting = false
+	emitter.Advance(0.25, mgl32.Vec2{}, rng)
+	if got := len(emitter.Particles()); got != 10 {
+		t.Errorf("after stopping: %d particles, want 10", got)
+	}
+}
+
+func TestParticlesDieAtEndOfLifetime(t *testing.T) {
+	emitter := NewParticleEmitterComponent(4, 1, mgl32.Vec2{0, 1}, 0)
+	rng := rand.New(rand.NewSource(1))
+
+	// One particle spawns each quarter second
+	emitter.Advance(0.25, mgl32.Vec2{}, rng)
+	emitter.Emitting = false
+	emitter.Advance(0.5, mgl32.Vec2{}, rng)
+	if got := len(emitter.Particles()); got != 1 {
+		t.Fatalf("%d particles at half their lifetime, want 1", got)
+	}
+
+	emitter.Advance(0.5, mgl32.Vec2{}, rng)
+	if got := len(emitter.Particles()); got != 0 {
+		t.Errorf("%d particles after their lifetime, want 0", got)
+	}
+}
+
+func TestParticleMotion(t *testing.T) {
+	emitter := NewParticleEmitterComponent(1, 10, mgl32.Vec2{2, 0}, 0)
+	emitter.Gravity = mgl32.Vec2{0, -4}
+	rng := rand.New(rand.NewSource(1))
+
+	emitter.Advance(1, mgl32.Vec2{1, 1}, rng)
+	emitter.Emitting = false
+	emitter.Advance(0.5, mgl32.Vec2{}, rng)
+
+	particle := emitter.Particles()[0]
+	if particle.Velocity != (mgl32.Vec2{2, -2}) {
+		t.Errorf("Velocity = %v, want (2, -2)", particle.Velocity)
+	}
+	if particle.Position != (mgl32.Vec2{2, 0}) {
+		t.Errorf("Position = %v, want (2, 0)", particle.Position)
+	}
+}
+
+func TestParticleSpread(t *testing.T) {
+	emitter := NewParticleEmitterComponent(100, 10, mgl32.Vec2{0, 1}, 0.5)
+	emitter.Advance(1, mgl32.Vec2{}, rand.New(rand.NewSource(1)))
+
+	// Spread rotates the velocity, so every speed stays 1 within the cone
+	for _, particle := range emitter.Particles() {
+		if speed := particle.Velocity.Len(); mgl32.Abs(speed-1) > 1e-5 {
+			t.Fatalf("particle speed = %v, want 1", speed)
+		}
+		if particle.Velocity.Y() < float32(math.Cos(0.5))-1e-5 {
+			t.Fatalf("particle velocity %v outside the 0.5 radian cone", particle.Velocity)
+		}
+	}
+}
+
+func TestParticleColorAndSize(t *testing.T) {
+	emitter := NewParticleEmitterComponent(1, 2, mgl32.Vec2{}, 0)
+	emitter.StartSize = 1
+	emitter.EndSize = 3
+
+	halfway := Particle{Age: 1}
+	if got := emitter.ParticleColor(halfway); got != (mgl32.Vec4{1, 1, 1, 0.5}) {
+		t.Errorf("ParticleColor() = %v, want half faded", got)
+	}
+	if got := emitter.ParticleSize(halfway); got != 2 {
+		t.Errorf("ParticleSize() = %v, want 2", got)
+	}
+}
+
+func TestParticlePool(t *testing.T) {
+	emitter := NewParticleEmitterComponent(100, 10, mgl32.Vec2{0, 1}, 0.5)
+	emitter.MaxParticles = 20
+	rng := rand.New(rand.NewSource(1))
+
+	// The pool caps the live particles
+	emitter.Advance(1, mgl32.Vec2{}, rng)
+	if got := len(emitter.Particles()); got != 20 {
+		t.Errorf("%d particles, want the pool size 20", got)
+	}
+
+	// A warm pool doesn't allocate
+	if allocs := testing.AllocsPerRun(10, func() {
+		emitter.Advance(0.1, mgl32.Vec2{}, rng)
+	}); allocs != 0 {
+		t.Errorf("Advance made %v allocations, want 0", allocs)
+	}
+}
+
+func TestParticleSystemSpawnsAtTransform(t *testing.T) {
+	world := NewWorld()
+	world.AddSystem(NewParticleSystem())
+
+	emitter := NewParticleEmitterComponent(2, 10, mgl32.Vec2{}, 0)
+	world.NewEntity().
+		WithTransform(mgl32.Vec3{3, 4, 5}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}).
+		With(emitter).
+		Build()
+
+	world.Update(0.5)
+
+	particles := emitter.Particles()
+	if len(particles) != 1 || particles[0].Position != (mgl32.Vec2{3, 4}) {
+		t.Errorf("particles = %v, want one at the transform's (3, 4)", particles)
+	}
+}
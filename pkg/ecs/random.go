@@ -0,0 +1,95 @@
+package ecs
+
+import (
+	"math/rand"
+)
+
+// DefaultSeed seeds the random generator of new worlds
+const DefaultSeed = 1
+
+// randomSource is a SplitMix64 generator. Its whole state is one integer,
+// so the world can save and restore it exactly.
+type randomSource struct {
+	state uint64
+}
+
+func (s *randomSource) Uint64() uint64 {
+	s.state += 0x9e3779b97f4a7c15
+	z := s.state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+func (s *randomSource) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+func (s *randomSource) Seed(seed int64) {
+	s.state = uint64(seed)
+}
+
+// newRandom creates a generator and its source from a seed
+func newRandom(seed uint64) (*rand.Rand, *randomSource) {
+	source := &randomSource{state: seed}
+	return rand.New(source), source
+}
+
+// Rand returns the world's random generator. Systems should draw from it
+// instead of math/rand so a world with the same seed and inputs replays
+// identically; its state is saved and loaded with the world. Like the rest
+// of Update, it must not be used from several goroutines at once, and
+// Rand.Read keeps bytes outside the saved state.
+func (w *World) Rand() *rand.Rand {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	return w.random
+}
+
+// SetSeed restarts the world's random sequence from seed
+func (w *World) SetSeed(seed uint64) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.setRandomLocked(RandomState{Seed: seed, State: seed})
+}
+
+// RandomState is the position of a world's random sequence
+type RandomState struct {
+	// Seed is the seed last set with SetSeed
+	Seed uint64
+	// State is the generator's state after the values drawn so far
+	State uint64
+}
+
+// GetRandomState returns where the world's random sequence is
+func (w *World) GetRandomState() RandomState {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	return RandomState{Seed: w.seed, State: w.randomSource.state}
+}
+
+// SetRandomState resumes the world's random sequence from a state returned
+// by GetRandomState. Generators held from Rand keep the old sequence.
+func (w *World) SetRandomState(state RandomState) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.setRandomLocked(state)
+}
+
+// setRandomLocked replaces the world's generator. Callers must hold the write lock.
+func (w *World) setRandomLocked(state RandomState) {
+	w.seed = state.Seed
+	w.random, w.randomSource = newRandom(state.State)
+}
+
+// Seed returns the seed last set with SetSeed
+func (w *World) Seed() uint64 {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	return w.seed
+}
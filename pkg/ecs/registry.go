@@ -0,0 +1,70 @@
+package ecs
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ComponentFactory creates an empty component of a registered type
+type ComponentFactory func() Component
+
+var (
+	componentFactories = make(map[string]ComponentFactory)
+	registryMutex      sync.RWMutex
+)
+
+func init() {
+	RegisterComponentType("transform", func() Component { return &TransformComponent{} })
+	RegisterComponentType("mesh", func() Component { return &MeshComponent{} })
+	RegisterComponentType("physics", func() Component { return &PhysicsComponent{} })
+	RegisterComponentType("trigger_zone", func() Component { return &TriggerZoneComponent{} })
+	RegisterComponentType("audio", func() Component { return &AudioComponent{} })
+	RegisterComponentType("tag", func() Component { return &TagComponent{} })
+	RegisterComponentType("property_block", func() Component { return NewPropertyBlockComponent() })
+	RegisterComponentType("sprite", func() Component { return &SpriteComponent{} })
+	RegisterComponentType("nine_slice", func() Component { return &NineSliceComponent{} })
+	RegisterComponentType("tilemap", func() Component { return &TilemapComponent{} })
+	RegisterComponentType("animation", func() Component { return &AnimationComponent{} })
+	RegisterComponentType("particle_emitter", func() Component { return &ParticleEmitterComponent{} })
+	RegisterComponentType("lifetime", func() Component { return &LifetimeComponent{} })
+	RegisterComponentType("state_machine", func() Component { return &StateMachineComponent{} })
+	RegisterComponentType("camera_follow", func() Component { return &CameraFollowComponent{} })
+	RegisterComponentType("material", func() Component { return &MaterialComponent{} })
+	RegisterComponentType("audio_listener", func() Component { return &AudioListenerComponent{} })
+	RegisterComponentType("spatial_audio", func() Component { return &SpatialAudioComponent{} })
+}
+
+// RegisterComponentType registers a factory so components of the type can be
+// decoded by name. Custom components must be registered before loading a world.
+func RegisterComponentType(componentType string, factory ComponentFactory) {
+	registryMutex.Lock()
+	defer registryMutex.Unlock()
+
+	componentFactories[componentType] = factory
+}
+
+// NewComponent creates an empty component of a registered type
+func NewComponent(componentType string) (Component, error) {
+	registryMutex.RLock()
+	factory, exists := componentFactories[componentType]
+	registryMutex.RUnlock()
+
+	if !exists {
+		return nil, fmt.Errorf("component type %s is not registered", componentType)
+	}
+	return factory(), nil
+}
+
+// RegisteredComponentTypes returns the names of all registered component types
+func RegisteredComponentTypes() []string {
+	registryMutex.RLock()
+	defer registryMutex.RUnlock()
+
+	types := make([]string, 0, len(componentFactories))
+	for componentType := range componentFactories {
+		types = append(types, componentType)
+	}
+	sort.Strings(types)
+	return types
+}
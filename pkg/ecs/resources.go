@@ -0,0 +1,40 @@
+package ecs
+
+import (
+	"reflect"
+)
+
+// SetResource stores a world-wide value such as game time or the score,
+// replacing any resource of the same type. Use GetResource to read it back.
+func (w *World) SetResource(resource interface{}) {
+	if resource == nil {
+		return
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.resources[reflect.TypeOf(resource)] = resource
+}
+
+// GetResource returns the world's resource of type T. Methods can't have
+// type parameters, so this is a function taking the world.
+func GetResource[T any](w *World) (T, bool) {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	resource, exists := w.resources[reflect.TypeOf((*T)(nil)).Elem()]
+	if !exists {
+		var zero T
+		return zero, false
+	}
+	return resource.(T), true
+}
+
+// RemoveResource removes the world's resource of type T
+func RemoveResource[T any](w *World) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	delete(w.resources, reflect.TypeOf((*T)(nil)).Elem())
+}
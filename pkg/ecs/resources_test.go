@@ -0,0 +1,43 @@
+package ecs
+
+import (
+	"testing"
+)
+
+type score struct {
+	Points int
+}
+
+func TestResources(t *testing.T) {
+	world := NewWorld()
+
+	if _, ok := GetResource[score](world); ok {
+		t.Fatal("GetResource found a score before one was set")
+	}
+
+	world.SetResource(score{Points: 10})
+	got, ok := GetResource[score](world)
+	if !ok || got.Points != 10 {
+		t.Errorf("GetResource() = %+v, %v; want {Points:10}, true", got, ok)
+	}
+
+	// Setting the same type replaces it
+	world.SetResource(score{Points: 25})
+	if got, _ := GetResource[score](world); got.Points != 25 {
+		t.Errorf("GetResource() after overwrite = %+v, want {Points:25}", got)
+	}
+
+	// Pointers are stored under their own type
+	world.SetResource(&score{Points: 3})
+	if got, ok := GetResource[*score](world); !ok || got.Points != 3 {
+		t.Errorf("GetResource[*score]() = %v, %v; want Points 3", got, ok)
+	}
+	if got, _ := GetResource[score](world); got.Points != 25 {
+		t.Error("storing a *score replaced the score")
+	}
+
+	RemoveResource[score](world)
+	if _, ok := GetResource[score](world); ok {
+		t.Error("GetResource found a score after RemoveResource")
+	}
+}
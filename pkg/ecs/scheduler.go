@@ -0,0 +1,127 @@
+package ecs
+
+import (
+	"sort"
+)
+
+// Default stages, in the order they run
+const (
+	StagePreUpdate  = "pre_update"
+	StageUpdate     = "update"
+	StagePostUpdate = "post_update"
+	StageRender     = "render"
+)
+
+// DefaultStages is the stage order of a new world
+var DefaultStages = []string{StagePreUpdate, StageUpdate, StagePostUpdate, StageRender}
+
+// PrioritizedSystem is implemented by systems that need to run before or
+// after others in the same stage. Lower priorities run first; systems
+// without a priority count as 0.
+type PrioritizedSystem interface {
+	System
+	GetPriority() int
+}
+
+// scheduledSystem is a system with its position in the schedule
+type scheduledSystem struct {
+	system   System
+	stage    string
+	priority int
+	order    int
+}
+
+// AddSystemToStage adds a system to a stage. Stages that don't exist yet are
+// appended after the existing ones.
+func (w *World) AddSystemToStage(stage string, system System) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.addSystemLocked(stage, system)
+}
+
+// SetStages replaces the stage order. Stages of already added systems that
+// are missing from the list are appended after it.
+func (w *World) SetStages(stages ...string) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.stages = append([]string(nil), stages...)
+	for _, scheduled := range w.schedule {
+		w.ensureStageLocked(scheduled.stage)
+	}
+	w.rebuildScheduleLocked()
+}
+
+// GetStages returns the stage order
+func (w *World) GetStages() []string {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	return append([]string(nil), w.stages...)
+}
+
+// addSystemLocked schedules a system; the caller must hold the write lock
+func (w *World) addSystemLocked(stage string, system System) {
+	w.ensureStageLocked(stage)
+
+	priority := 0
+	if prioritized, ok := system.(PrioritizedSystem); ok {
+		priority = prioritized.GetPriority()
+	}
+
+	w.schedule = append(w.schedule, scheduledSystem{
+		system:   system,
+		stage:    stage,
+		priority: priority,
+		order:    w.nextSystemOrder,
+	})
+	w.nextSystemOrder++
+	w.rebuildScheduleLocked()
+}
+
+// removeSystemLocked unschedules the first system with the given name; the caller must hold the write lock
+func (w *World) removeSystemLocked(systemName string) {
+	for i, scheduled := range w.schedule {
+		if scheduled.system.GetName() == systemName {
+			w.schedule = append(w.schedule[:i], w.schedule[i+1:]...)
+			break
+		}
+	}
+	w.rebuildScheduleLocked()
+}
+
+// ensureStageLocked appends a stage if it doesn't exist; the caller must hold the write lock
+func (w *World) ensureStageLocked(stage string) {
+	for _, existing := range w.stages {
+		if existing == stage {
+			return
+		}
+	}
+	w.stages = append(w.stages, stage)
+}
+
+// rebuildScheduleLocked sorts systems by stage, priority and insertion order
+// into the run order used by Update; the caller must hold the write lock
+func (w *World) rebuildScheduleLocked() {
+	stageIndex := make(map[string]int, len(w.stages))
+	for i, stage := range w.stages {
+		stageIndex[stage] = i
+	}
+
+	sort.SliceStable(w.schedule, func(i, j int) bool {
+		a, b := w.schedule[i], w.schedule[j]
+		if stageIndex[a.stage] != stageIndex[b.stage] {
+			return stageIndex[a.stage] < stageIndex[b.stage]
+		}
+		if a.priority != b.priority {
+			return a.priority < b.priority
+		}
+		return a.order < b.order
+	})
+
+	w.systems = w.systems[:0]
+	for _, scheduled := range w.schedule {
+		w.systems = append(w.systems, scheduled.system)
+	}
+}
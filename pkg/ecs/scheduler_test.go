@@ -0,0 +1,91 @@
+package ecs
+
+import (
+	"reflect"
+	"testing"
+)
+
+// recordingSystem appends its name to a shared log when it runs
+type recordingSystem struct {
+	name     string
+	priority int
+	log      *[]string
+}
+
+func (s *recordingSystem) Update(deltaTime float64, world *World) {
+	*s.log = append(*s.log, s.name)
+}
+
+func (s *recordingSystem) GetName() string {
+	return s.name
+}
+
+func (s *recordingSystem) GetPriority() int {
+	return s.priority
+}
+
+func TestStagesRunInOrder(t *testing.T) {
+	world := NewWorld()
+	var log []string
+
+	// Added in reverse of the stage order
+	world.AddSystemToStage(StageRender, &recordingSystem{name: "render", log: &log})
+	world.AddSystemToStage(StagePostUpdate, &recordingSystem{name: "post", log: &log})
+	world.AddSystem(&recordingSystem{name: "update", log: &log})
+	world.AddSystemToStage(StagePreUpdate, &recordingSystem{name: "pre", log: &log})
+
+	world.Update(1.0 / 60.0)
+	if want := []string{"pre", "update", "post", "render"}; !reflect.DeepEqual(log, want) {
+		t.Errorf("run order = %v, want %v", log, want)
+	}
+}
+
+func TestPriorityWithinStage(t *testing.T) {
+	world := NewWorld()
+	var log []string
+
+	world.AddSystemToStage(StagePreUpdate, &recordingSystem{name: "input", priority: 10, log: &log})
+	world.AddSystem(&recordingSystem{name: "ai", priority: 5, log: &log})
+	world.AddSystem(&recordingSystem{name: "movement", priority: -1, log: &log})
+	world.AddSystem(&recordingSystem{name: "animation", priority: 5, log: &log})
+	world.AddSystem(&recordingSystem{name: "audio", log: &log})
+
+	world.Update(1.0 / 60.0)
+
+	// Priority orders a stage, and equal priorities keep their add order,
+	// but no priority moves a system out of its stage
+	want := []string{"input", "movement", "audio", "ai", "animation"}
+	if !reflect.DeepEqual(log, want) {
+		t.Errorf("run order = %v, want %v", log, want)
+	}
+}
+
+func TestCustomStages(t *testing.T) {
+	world := NewWorld()
+	var log []string
+
+	world.AddSystemToStage("late", &recordingSystem{name: "late", log: &log})
+	world.AddSystemToStage("physics", &recordingSystem{name: "physics", log: &log})
+	world.AddSystem(&recordingSystem{name: "update", log: &log})
+
+	// New stages are appended in the order they are first used
+	world.Update(0)
+	if want := []string{"update", "late", "physics"}; !reflect.DeepEqual(log, want) {
+		t.Errorf("run order = %v, want %v", log, want)
+	}
+
+	// Stages left out of SetStages go after the listed ones
+	world.SetStages("physics", StageUpdate)
+	log = nil
+	world.Update(0)
+	if want := []string{"physics", "update", "late"}; !reflect.DeepEqual(log, want) {
+		t.Errorf("run order after SetStages = %v, want %v", log, want)
+	}
+
+	world.RemoveSystem("physics")
+	log = nil
+	world.Update(0)
+	if want := []string{"update", "late"}; !reflect.DeepEqual(log, want) {
+		t.Errorf("run order after RemoveSystem = %v, want %v", log, want)
+	}
+}
@@ -0,0 +1,153 @@
+package ecs
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+)
+
+// worldState is the serialized form of a world's entities and random state
+type worldState struct {
+	NextEntityID EntityID      `json:"nextEntityID"`
+	Entities     []entityState `json:"entities"`
+	Seed         uint64        `json:"seed"`
+	RandomState  uint64        `json:"randomState"`
+}
+
+// entityState is the serialized form of an entity
+type entityState struct {
+	ID         EntityID                   `json:"id"`
+	Active     bool                       `json:"active"`
+	Components map[string]json.RawMessage `json:"components"`
+}
+
+// Save writes the world's entities, components and random state as JSON.
+// Systems are not saved.
+func (w *World) Save(writer io.Writer) error {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	state := worldState{
+		NextEntityID: w.nextEntityID,
+		Entities:     make([]entityState, 0, len(w.entities)),
+		Seed:         w.seed,
+		RandomState:  w.randomSource.state,
+	}
+
+	for _, entity := range w.entities {
+		entry := entityState{
+			ID:         entity.ID,
+			Active:     entity.Active,
+			Components: make(map[string]json.RawMessage, len(entity.Components)),
+		}
+
+		for componentType, component := range entity.Components {
+			data, err := json.Marshal(component)
+			if err != nil {
+				return fmt.Errorf("entity %d component %s: %w", entity.ID, componentType, err)
+			}
+			entry.Components[componentType] = data
+		}
+
+		state.Entities = append(state.Entities, entry)
+	}
+
+	// Save in creation order so identical worlds produce identical output
+	sort.Slice(state.Entities, func(i, j int) bool {
+		return state.Entities[i].ID < state.Entities[j].ID
+	})
+
+	return json.NewEncoder(writer).Encode(state)
+}
+
+// Load replaces the world's entities and components with ones read by Save.
+// Systems are kept. On error the world is left unchanged.
+func (w *World) Load(reader io.Reader) error {
+	state, err := DecodeWorldState(reader)
+	if err != nil {
+		return err
+	}
+
+	w.ApplyState(state)
+	return nil
+}
+
+// WorldState is a stream written by Save, decoded and ready to apply. Its
+// components are used as they are, so apply it to one world only once.
+type WorldState struct {
+	state    worldState
+	entities map[EntityID]*Entity
+}
+
+// DecodeWorldState reads a stream written by Save without touching any world
+func DecodeWorldState(reader io.Reader) (*WorldState, error) {
+	var state worldState
+	if err := json.NewDecoder(reader).Decode(&state); err != nil {
+		return nil, err
+	}
+
+	entities := make(map[EntityID]*Entity, len(state.Entities))
+	for _, entry := range state.Entities {
+		entity := &Entity{
+			ID:         entry.ID,
+			Components: make(map[string]Component, len(entry.Components)),
+			Active:     entry.Active,
+		}
+
+		for componentType, data := range entry.Components {
+			component, err := NewComponent(componentType)
+			if err != nil {
+				return nil, fmt.Errorf("entity %d: %w", entry.ID, err)
+			}
+			if err := json.Unmarshal(data, component); err != nil {
+				return nil, fmt.Errorf("entity %d component %s: %w", entry.ID, componentType, err)
+			}
+			entity.Components[componentType] = component
+		}
+
+		entities[entity.ID] = entity
+	}
+
+	return &WorldState{state: state, entities: entities}, nil
+}
+
+// ApplyState replaces the world's entities, components and random state with
+// a decoded state. Systems are kept.
+func (w *World) ApplyState(decoded *WorldState) {
+	state, entities := decoded.state, decoded.entities
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.replaceEntitiesLocked(entities, state.NextEntityID, RandomState{Seed: state.Seed, State: state.RandomState})
+}
+
+// replaceEntitiesLocked swaps in a new set of entities and rebuilds the
+// indexes over them. The caller must hold the write lock.
+func (w *World) replaceEntitiesLocked(entities map[EntityID]*Entity, nextEntityID EntityID, random RandomState) {
+	// Tag components held outside the world must stop updating its index
+	for entityID, entity := range w.entities {
+		for _, component := range entity.Components {
+			w.unbindTagsLocked(entityID, component)
+		}
+	}
+
+	w.entities = entities
+	w.rebuildOrderLocked()
+	w.rebuildArchetypesLocked()
+	w.nextEntityID = max(nextEntityID, 1)
+	w.setRandomLocked(random)
+	w.components = make(map[string][]componentEntry)
+	w.versions = make(map[string]map[EntityID]uint64)
+	w.tagIndex = make(map[string][]EntityID)
+	for _, entityID := range w.order {
+		for componentType, component := range entities[entityID].Components {
+			w.components[componentType] = append(w.components[componentType], componentEntry{entityID, component})
+
+			// Loaded components count as changed
+			w.markChangedLocked(entityID, componentType)
+			w.bindTagsLocked(entityID, component)
+		}
+	}
+}
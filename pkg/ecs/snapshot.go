@@ -0,0 +1,63 @@
+package ecs
+
+// WorldSnapshot is an in-memory copy of a world's entities, components,
+// next entity ID and random state
+type WorldSnapshot struct {
+	entities     map[EntityID]*Entity
+	nextEntityID EntityID
+	random       RandomState
+}
+
+// snapshotter is implemented by components whose Clone leaves out state a
+// snapshot must keep, such as an emitter's live particles
+type snapshotter interface {
+	snapshot() Component
+}
+
+// snapshotComponent returns a deep copy of a component for a snapshot
+func snapshotComponent(component Component) Component {
+	if snapshotter, ok := component.(snapshotter); ok {
+		return snapshotter.snapshot()
+	}
+	return CloneComponent(component)
+}
+
+// copyEntities deep-copies entities and their components
+func copyEntities(entities map[EntityID]*Entity) map[EntityID]*Entity {
+	copied := make(map[EntityID]*Entity, len(entities))
+	for entityID, entity := range entities {
+		components := make(map[string]Component, len(entity.Components))
+		for componentType, component := range entity.Components {
+			components[componentType] = snapshotComponent(component)
+		}
+		copied[entityID] = &Entity{ID: entity.ID, Components: components, Active: entity.Active}
+	}
+	return copied
+}
+
+// Snapshot captures the world's entities, components, next entity ID and
+// random state, for undo, rollback or debugging. Components are deep-copied
+// with CloneComponent, so callbacks and unexported state are kept and later
+// changes to the world don't affect the snapshot.
+func (w *World) Snapshot() *WorldSnapshot {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	return &WorldSnapshot{
+		entities:     copyEntities(w.entities),
+		nextEntityID: w.nextEntityID,
+		random:       RandomState{Seed: w.seed, State: w.randomSource.state},
+	}
+}
+
+// Restore replaces the world's state with a snapshot. The snapshot is copied
+// again, so it can be restored any number of times. Systems, resources and
+// listeners are kept.
+func (w *World) Restore(snapshot *WorldSnapshot) {
+	entities := copyEntities(snapshot.entities)
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.replaceEntitiesLocked(entities, snapshot.nextEntityID, snapshot.random)
+}
@@ -0,0 +1,202 @@
+package ecs
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// newSnapshotWorld returns a seeded world with a few tagged, moved entities
+func newSnapshotWorld() (*World, []EntityID) {
+	world := NewWorld()
+	world.SetSeed(7)
+
+	var created []EntityID
+	for i := 0; i < 3; i++ {
+		created = append(created, world.NewEntity().
+			WithTransform(mgl32.Vec3{float32(i), 0, 0}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}).
+			WithTag("crate").
+			Build())
+	}
+	draws(world, 5)
+	return world, created
+}
+
+// saved returns the world as Save writes it
+func saved(t *testing.T, world *World) []byte {
+	t.Helper()
+	var buffer bytes.Buffer
+	if err := world.Save(&buffer); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	return buffer.Bytes()
+}
+
+func TestRestoreUndoesMutations(t *testing.T) {
+	world, created := newSnapshotWorld()
+	before := saved(t, world)
+	snapshot := world.Snapshot()
+
+	// What the world would do next, had it not been touched
+	reference := NewWorld()
+	reference.Restore(snapshot)
+	wantDraws := draws(reference, 5)
+	wantNext := reference.CreateEntity()
+
+	// Mutate everything the snapshot covers
+	transform := world.GetComponent(created[0], "transform").(*TransformComponent)
+	transform.Position = mgl32.Vec3{100, 100, 100}
+	world.DestroyEntity(created[1])
+	world.AddComponent(created[2], NewTagComponent("moved"))
+	world.CreateEntity()
+	draws(world, 3)
+
+	world.Restore(snapshot)
+
+	if after := saved(t, world); !bytes.Equal(after, before) {
+		t.Errorf("Save() after Restore = %s, want %s", after, before)
+	}
+	if got := world.GetEntities(); !reflect.DeepEqual(got, created) {
+		t.Errorf("GetEntities() = %v, want %v", got, created)
+	}
+	for i, entityID := range created {
+		transform, ok := world.GetComponent(entityID, "transform").(*TransformComponent)
+		if !ok {
+			t.Fatalf("entity %d lost its transform", entityID)
+		}
+		if want := (mgl32.Vec3{float32(i), 0, 0}); transform.Position != want {
+			t.Errorf("entity %d position = %v, want %v", entityID, transform.Position, want)
+		}
+	}
+	if got := world.GetEntitiesByTag("crate"); len(got) != 3 {
+		t.Errorf("GetEntitiesByTag(crate) = %v, want all three entities", got)
+	}
+	if got := world.GetEntitiesByTag("moved"); len(got) != 0 {
+		t.Errorf("GetEntitiesByTag(moved) = %v, want none", got)
+	}
+
+	// The random sequence and entity IDs carry on from the snapshot
+	if got := draws(world, 5); !reflect.DeepEqual(got, wantDraws) {
+		t.Errorf("draws after Restore = %v, want %v", got, wantDraws)
+	}
+	if got := world.CreateEntity(); got != wantNext {
+		t.Errorf("CreateEntity() after Restore = %d, want %d", got, wantNext)
+	}
+}
+
+func TestRestoreSnapshotTwice(t *testing.T) {
+	world, created := newSnapshotWorld()
+	snapshot := world.Snapshot()
+
+	// Changing restored components must not change the snapshot
+	for i := 0; i < 2; i++ {
+		world.Restore(snapshot)
+		transform := world.GetComponent(created[0], "transform").(*TransformComponent)
+		if want := (mgl32.Vec3{0, 0, 0}); transform.Position != want {
+			t.Errorf("Restore %d: position = %v, want %v", i+1, transform.Position, want)
+		}
+		transform.Position = mgl32.Vec3{5, 5, 5}
+		world.GetComponent(created[1], "tag").(*TagComponent).AddTag("moved")
+	}
+}
+
+// particleState is what a test compares of an emitter
+type particleState struct {
+	Current     string
+	TimeInState float64
+	Particles   []Particle
+}
+
+func TestRestoreStateMachineAndEmitterMidRun(t *testing.T) {
+	world := NewWorld()
+	world.SetSeed(3)
+
+	var entered []string
+	machine := NewStateMachineComponent("idle")
+	for _, name := range []string{"idle", "run"} {
+		name := name
+		machine.AddState(name, State{Enter: func(world *World, entityID EntityID) {
+			entered = append(entered, name)
+		}})
+	}
+	machine.AddTransition("idle", "run", func(world *World, entityID EntityID) bool {
+		current := world.GetComponent(entityID, "state_machine").(*StateMachineComponent)
+		return current.TimeInState >= 1
+	})
+
+	entityID := world.NewEntity().
+		WithTransform(mgl32.Vec3{}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}).
+		Build()
+	world.AddComponent(entityID, machine)
+	world.AddComponent(entityID, NewParticleEmitterComponent(8, 2, mgl32.Vec2{0, 1}, 0.5))
+
+	machines, particles := NewStateMachineSystem(), NewParticleSystem()
+	step := func(frames int) particleState {
+		for i := 0; i < frames; i++ {
+			machines.Update(0.25, world)
+			particles.Update(0.25, world)
+		}
+		machine := world.GetComponent(entityID, "state_machine").(*StateMachineComponent)
+		emitter := world.GetComponent(entityID, "particle_emitter").(*ParticleEmitterComponent)
+		return particleState{
+			Current:     machine.Current,
+			TimeInState: machine.TimeInState,
+			Particles:   append([]Particle(nil), emitter.Particles()...),
+		}
+	}
+
+	// Halfway to the transition, with particles in flight
+	atSnapshot := step(3)
+	snapshot := world.Snapshot()
+	want := step(4)
+	wantEntered := append([]string(nil), entered...)
+	if want.Current != "run" {
+		t.Fatalf("Current = %q after 7 frames, want run", want.Current)
+	}
+
+	world.Restore(snapshot)
+	entered = entered[:1]
+	if got := step(0); !reflect.DeepEqual(got, atSnapshot) {
+		t.Errorf("after Restore = %+v, want %+v", got, atSnapshot)
+	}
+
+	// The restored machine keeps its callbacks and the emitter its particles,
+	// so the run replays the same way
+	if got := step(4); !reflect.DeepEqual(got, want) {
+		t.Errorf("after replaying = %+v, want %+v", got, want)
+	}
+	if !reflect.DeepEqual(entered, wantEntered) {
+		t.Errorf("entered = %v, want %v", entered, wantEntered)
+	}
+}
+
+func TestRestoreUnbindsOldTagComponents(t *testing.T) {
+	world, created := newSnapshotWorld()
+	snapshot := world.Snapshot()
+
+	// A tag component kept across the restore belongs to no entity anymore
+	old := world.GetComponent(created[0], "tag").(*TagComponent)
+	world.DestroyEntity(created[0])
+	world.Restore(snapshot)
+	held := world.GetComponent(created[1], "tag").(*TagComponent)
+	world.Restore(snapshot)
+
+	old.AddTag("ghost")
+	held.AddTag("ghost")
+	if got := world.GetEntitiesByTag("ghost"); len(got) != 0 {
+		t.Errorf("tagging components from before Restore indexed %v", got)
+	}
+	held.RemoveTag("crate")
+	if got := world.GetEntitiesByTag("crate"); !reflect.DeepEqual(got, created) {
+		t.Errorf("GetEntitiesByTag(crate) = %v after untagging an old component, want %v", got, created)
+	}
+
+	// The restored components still update the index
+	current := world.GetComponent(created[1], "tag").(*TagComponent)
+	current.AddTag("live")
+	if got := world.GetEntitiesByTag("live"); !reflect.DeepEqual(got, []EntityID{created[1]}) {
+		t.Errorf("GetEntitiesByTag(live) = %v, want [%d]", got, created[1])
+	}
+}
@@ -0,0 +1,161 @@
+package ecs
+
+// State holds the callbacks of one state of a state machine. Any of them
+// may be nil.
+type State struct {
+	Enter  func(world *World, entityID EntityID)
+	Update func(world *World, entityID EntityID, deltaTime float64)
+	Exit   func(world *World, entityID EntityID)
+}
+
+// Transition moves a state machine from one state to another when its
+// condition holds or its event is triggered
+type Transition struct {
+	From string
+	To   string
+	// Condition is checked every frame when set
+	Condition func(world *World, entityID EntityID) bool
+	// Event fires the transition when triggered while in From
+	Event string
+}
+
+// StateMachineComponent drives an entity through named states. The
+// StateMachineSystem enters the initial state on the first frame, then each
+// frame updates the current state and takes at most one transition, so a
+// state is always updated at least once between being entered and exited.
+// Transitions are checked in the order they were added, events first.
+// Only Current and TimeInState are saved with the world; states and
+// transitions have to be added again after loading.
+type StateMachineComponent struct {
+	Current string
+	// TimeInState is the time since the current state was entered, in seconds
+	TimeInState float64
+
+	states      map[string]State
+	transitions []Transition
+	events      []string
+	started     bool
+}
+
+func (s *StateMachineComponent) GetType() string {
+	return "state_machine"
+}
+
+// NewStateMachineComponent creates a state machine starting in initial
+func NewStateMachineComponent(initial string) *StateMachineComponent {
+	return &StateMachineComponent{
+		Current: initial,
+		states:  make(map[string]State),
+	}
+}
+
+// Clone copies the state machine, including its states, transitions and
+// queued events
+func (s *StateMachineComponent) Clone() Component {
+	clone := *s
+	clone.states = make(map[string]State, len(s.states))
+	for name, state := range s.states {
+		clone.states[name] = state
+	}
+	clone.transitions = append([]Transition(nil), s.transitions...)
+	clone.events = append([]string(nil), s.events...)
+	return &clone
+}
+
+// AddState registers the callbacks of a state, replacing any previous ones
+func (s *StateMachineComponent) AddState(name string, state State) {
+	if s.states == nil {
+		s.states = make(map[string]State)
+	}
+	s.states[name] = state
+}
+
+// AddTransition adds a transition taken when condition returns true in from
+func (s *StateMachineComponent) AddTransition(from, to string, condition func(world *World, entityID EntityID) bool) {
+	s.transitions = append(s.transitions, Transition{From: from, To: to, Condition: condition})
+}
+
+// AddEventTransition adds a transition taken when event is triggered in from
+func (s *StateMachineComponent) AddEventTransition(from, event, to string) {
+	s.transitions = append(s.transitions, Transition{From: from, To: to, Event: event})
+}
+
+// Trigger queues an event for the next update. Events that match no
+// transition of the state current at that time are dropped.
+func (s *StateMachineComponent) Trigger(event string) {
+	s.events = append(s.events, event)
+}
+
+// Step advances the state machine by one frame; StateMachineSystem calls it
+// for every entity
+func (s *StateMachineComponent) Step(world *World, entityID EntityID, deltaTime float64) {
+	if !s.started {
+		s.started = true
+		s.TimeInState = 0
+		if state := s.states[s.Current]; state.Enter != nil {
+			state.Enter(world, entityID)
+		}
+		return
+	}
+
+	s.TimeInState += deltaTime
+	if state := s.states[s.Current]; state.Update != nil {
+		state.Update(world, entityID, deltaTime)
+	}
+
+	next, ok := s.nextState(world, entityID)
+	s.events = s.events[:0]
+	if !ok {
+		return
+	}
+
+	if state := s.states[s.Current]; state.Exit != nil {
+		state.Exit(world, entityID)
+	}
+	s.Current = next
+	s.TimeInState = 0
+	if state := s.states[s.Current]; state.Enter != nil {
+		state.Enter(world, entityID)
+	}
+}
+
+// nextState returns the target of the first transition to take, if any
+func (s *StateMachineComponent) nextState(world *World, entityID EntityID) (string, bool) {
+	for _, event := range s.events {
+		for _, transition := range s.transitions {
+			if transition.From == s.Current && transition.Event != "" && transition.Event == event {
+				return transition.To, true
+			}
+		}
+	}
+
+	for _, transition := range s.transitions {
+		if transition.From == s.Current && transition.Condition != nil && transition.Condition(world, entityID) {
+			return transition.To, true
+		}
+	}
+	return "", false
+}
+
+// StateMachineSystem steps the state machines of all entities each frame.
+// Callbacks run outside the world lock, so they may change the world.
+type StateMachineSystem struct{}
+
+// NewStateMachineSystem creates a state machine system
+func NewStateMachineSystem() *StateMachineSystem {
+	return &StateMachineSystem{}
+}
+
+// Update steps every state machine by deltaTime
+func (s *StateMachineSystem) Update(deltaTime float64, world *World) {
+	for _, entityID := range world.GetEntitiesWithComponent("state_machine") {
+		if machine, ok := world.GetComponent(entityID, "state_machine").(*StateMachineComponent); ok {
+			machine.Step(world, entityID, deltaTime)
+		}
+	}
+}
+
+// GetName returns the system name
+func (s *StateMachineSystem) GetName() string {
+	return "StateMachineSystem"
+}
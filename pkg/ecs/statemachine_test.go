@@ -0,0 +1,123 @@
+package ecs
+
+import (
+	"reflect"
+	"testing"
+)
+
+// loggedState returns a state that appends its callbacks to log
+func loggedState(name string, log *[]string) State {
+	return State{
+		Enter: func(world *World, entityID EntityID) {
+			*log = append(*log, "enter "+name)
+		},
+		Update: func(world *World, entityID EntityID, deltaTime float64) {
+			*log = append(*log, "update "+name)
+		},
+		Exit: func(world *World, entityID EntityID) {
+			*log = append(*log, "exit "+name)
+		},
+	}
+}
+
+func TestStateMachineConditionTransition(t *testing.T) {
+	world := NewWorld()
+	world.AddSystem(NewStateMachineSystem())
+
+	var log []string
+	playerNear := false
+	machine := NewStateMachineComponent("patrol")
+	machine.AddState("patrol", loggedState("patrol", &log))
+	machine.AddState("chase", loggedState("chase", &log))
+	machine.AddTransition("patrol", "chase", func(world *World, entityID EntityID) bool {
+		return playerNear
+	})
+	world.AddComponent(world.CreateEntity(), machine)
+
+	world.Update(0.1)
+	world.Update(0.1)
+	playerNear = true
+	world.Update(0.1)
+	world.Update(0.1)
+
+	want := []string{
+		"enter patrol",
+		"update patrol",
+		"update patrol", "exit patrol", "enter chase",
+		"update chase",
+	}
+	if !reflect.DeepEqual(log, want) {
+		t.Errorf("callbacks ran as %v, want %v", log, want)
+	}
+	if machine.Current != "chase" {
+		t.Errorf("Current = %q, want chase", machine.Current)
+	}
+}
+
+func TestStateMachineTakesOneTransitionPerFrame(t *testing.T) {
+	world := NewWorld()
+	var log []string
+	always := func(world *World, entityID EntityID) bool { return true }
+
+	machine := NewStateMachineComponent("a")
+	for _, name := range []string{"a", "b", "c"} {
+		machine.AddState(name, loggedState(name, &log))
+	}
+	machine.AddTransition("a", "b", always)
+	machine.AddTransition("b", "c", always)
+
+	// b is updated before it can be left
+	for i := 0; i < 3; i++ {
+		machine.Step(world, 0, 0.1)
+	}
+
+	want := []string{
+		"enter a",
+		"update a", "exit a", "enter b",
+		"update b", "exit b", "enter c",
+	}
+	if !reflect.DeepEqual(log, want) {
+		t.Errorf("callbacks ran as %v, want %v", log, want)
+	}
+}
+
+func TestStateMachineEvents(t *testing.T) {
+	world := NewWorld()
+	machine := NewStateMachineComponent("idle")
+	machine.AddEventTransition("idle", "hit", "hurt")
+	machine.AddEventTransition("hurt", "heal", "idle")
+	machine.Step(world, 0, 0)
+
+	// An event with no transition from the current state is dropped
+	machine.Trigger("heal")
+	machine.Step(world, 0, 0.1)
+	if machine.Current != "idle" {
+		t.Fatalf("Current = %q after an unmatched event, want idle", machine.Current)
+	}
+
+	machine.Trigger("hit")
+	machine.Step(world, 0, 0.1)
+	if machine.Current != "hurt" || machine.TimeInState != 0 {
+		t.Fatalf("Current = %q, TimeInState = %v; want hurt, 0", machine.Current, machine.TimeInState)
+	}
+
+	// The dropped heal doesn't come back
+	machine.Step(world, 0, 0.25)
+	if machine.Current != "hurt" || machine.TimeInState != 0.25 {
+		t.Errorf("Current = %q, TimeInState = %v; want hurt, 0.25", machine.Current, machine.TimeInState)
+	}
+}
+
+func TestStateMachineEventsBeforeConditions(t *testing.T) {
+	world := NewWorld()
+	machine := NewStateMachineComponent("idle")
+	machine.AddTransition("idle", "walk", func(world *World, entityID EntityID) bool { return true })
+	machine.AddEventTransition("idle", "jump", "air")
+	machine.Step(world, 0, 0)
+
+	machine.Trigger("jump")
+	machine.Step(world, 0, 0.1)
+	if machine.Current != "air" {
+		t.Errorf("Current = %q, want the event's air", machine.Current)
+	}
+}
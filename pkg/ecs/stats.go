@@ -0,0 +1,56 @@
+package ecs
+
+import (
+	"sort"
+)
+
+// WorldStats is a snapshot of the world's size
+type WorldStats struct {
+	Entities   int
+	Components int
+	Systems    int
+	// ComponentCounts maps each component type in use to its number of instances
+	ComponentCounts map[string]int
+}
+
+// GetComponentCount returns the number of components of a type
+func (w *World) GetComponentCount(componentType string) int {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	return len(w.components[componentType])
+}
+
+// GetComponentTypes returns the sorted component types that have at least one instance
+func (w *World) GetComponentTypes() []string {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	types := make([]string, 0, len(w.components))
+	for componentType, components := range w.components {
+		if len(components) > 0 {
+			types = append(types, componentType)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Stats returns the number of entities, components and systems in the world
+func (w *World) Stats() WorldStats {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	stats := WorldStats{
+		Entities:        len(w.entities),
+		Systems:         len(w.systems),
+		ComponentCounts: make(map[string]int, len(w.components)),
+	}
+	for componentType, components := range w.components {
+		if len(components) == 0 {
+			continue
+		}
+		stats.ComponentCounts[componentType] = len(components)
+		stats.Components += len(components)
+	}
+	return stats
+}
@@ -0,0 +1,45 @@
+package ecs
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestWorldStats(t *testing.T) {
+	world := NewWorld()
+	for i := 0; i < 3; i++ {
+		entityID := world.CreateEntity()
+		world.AddComponent(entityID, NewTransformComponent(mgl32.Vec3{}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}))
+		if i < 2 {
+			world.AddComponent(entityID, NewMeshComponent("cube"))
+		}
+	}
+	lonely := world.CreateEntity()
+	world.AddComponent(lonely, NewTagComponent("empty"))
+	world.AddSystem(&recordingSystem{name: "noop", log: new([]string)})
+
+	// A type whose last instance was removed is no longer reported
+	world.RemoveComponent(lonely, "tag")
+
+	if got := world.GetComponentCount("transform"); got != 3 {
+		t.Errorf("GetComponentCount(transform) = %d, want 3", got)
+	}
+	if got := world.GetComponentCount("physics"); got != 0 {
+		t.Errorf("GetComponentCount(physics) = %d, want 0", got)
+	}
+	if got, want := world.GetComponentTypes(), []string{"mesh", "transform"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetComponentTypes() = %v, want %v", got, want)
+	}
+
+	want := WorldStats{
+		Entities:        4,
+		Components:      5,
+		Systems:         1,
+		ComponentCounts: map[string]int{"transform": 3, "mesh": 2},
+	}
+	if got := world.Stats(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Stats() = %+v, want %+v", got, want)
+	}
+}
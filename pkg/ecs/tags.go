@@ -0,0 +1,174 @@
+package ecs
+
+import (
+	"sort"
+)
+
+// tagBinding links a tag component to the world indexing its tags
+type tagBinding struct {
+	world    *World
+	entityID EntityID
+}
+
+// GetEntitiesByTag returns the entities tagged with tag, in creation order.
+// Tags must be changed through AddTag and RemoveTag to stay indexed.
+func (w *World) GetEntitiesByTag(tag string) []EntityID {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	entities := make([]EntityID, len(w.tagIndex[tag]))
+	copy(entities, w.tagIndex[tag])
+	return entities
+}
+
+// RemoveTag removes all occurrences of a tag and returns true if it was
+// present. The remaining tags go in a new slice, since Tags may share its
+// array with the caller of NewTagComponent or another component.
+func (t *TagComponent) RemoveTag(tag string) bool {
+	if !t.HasTag(tag) {
+		return false
+	}
+
+	kept := make([]string, 0, len(t.Tags)-1)
+	for _, existing := range t.Tags {
+		if existing != tag {
+			kept = append(kept, existing)
+		}
+	}
+	t.Tags = kept
+
+	if t.binding.world != nil {
+		t.binding.world.tagChanged(t.binding.entityID, tag, false)
+	}
+	return true
+}
+
+// Clone copies the tags. The clone belongs to no entity until it is added to one.
+func (t *TagComponent) Clone() Component {
+	return &TagComponent{Tags: append([]string(nil), t.Tags...)}
+}
+
+// HasAllTags returns true if the component has every given tag
+func (t *TagComponent) HasAllTags(tags ...string) bool {
+	for _, tag := range tags {
+		if !t.HasTag(tag) {
+			return false
+		}
+	}
+	return true
+}
+
+// HasAnyTags returns true if the component has at least one of the given tags
+func (t *TagComponent) HasAnyTags(tags ...string) bool {
+	for _, tag := range tags {
+		if t.HasTag(tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// TagListener is called after a tag is added to or removed from an entity
+type TagListener func(entityID EntityID, tag string, added bool)
+
+// OnTagChanged registers a listener for tags changed through AddTag and RemoveTag.
+// Adding or removing the whole tag component doesn't notify.
+func (w *World) OnTagChanged(listener TagListener) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.tagListeners = append(w.tagListeners, listener)
+}
+
+// tagChange is a tag added to or removed from an entity during iteration
+type tagChange struct {
+	entityID EntityID
+	tag      string
+	added    bool
+}
+
+// tagChanged updates a tag's index and notifies listeners outside the lock.
+// While a ForEach holds the read lock, possibly on this goroutine, taking the
+// write lock would deadlock, so the change is queued for the iteration's end.
+func (w *World) tagChanged(entityID EntityID, tag string, added bool) {
+	w.iterationMutex.Lock()
+	if w.iterations > 0 {
+		w.pendingTags = append(w.pendingTags, tagChange{entityID: entityID, tag: tag, added: added})
+		w.iterationMutex.Unlock()
+		return
+	}
+	w.iterationMutex.Unlock()
+
+	w.mutex.Lock()
+	if added {
+		w.indexTagLocked(entityID, tag)
+	} else {
+		w.unindexTagLocked(entityID, tag)
+	}
+	listeners := make([]TagListener, len(w.tagListeners))
+	copy(listeners, w.tagListeners)
+	w.mutex.Unlock()
+
+	for _, listener := range listeners {
+		listener(entityID, tag, added)
+	}
+}
+
+// indexTagLocked inserts an entity into a tag's sorted index; the caller must hold the write lock
+func (w *World) indexTagLocked(entityID EntityID, tag string) {
+	entities := w.tagIndex[tag]
+	index := sort.Search(len(entities), func(i int) bool {
+		return entities[i] >= entityID
+	})
+	if index < len(entities) && entities[index] == entityID {
+		return
+	}
+
+	entities = append(entities, 0)
+	copy(entities[index+1:], entities[index:])
+	entities[index] = entityID
+	w.tagIndex[tag] = entities
+}
+
+// unindexTagLocked removes an entity from a tag's index; the caller must hold the write lock
+func (w *World) unindexTagLocked(entityID EntityID, tag string) {
+	entities := w.tagIndex[tag]
+	index := sort.Search(len(entities), func(i int) bool {
+		return entities[i] >= entityID
+	})
+	if index == len(entities) || entities[index] != entityID {
+		return
+	}
+
+	entities = append(entities[:index], entities[index+1:]...)
+	if len(entities) == 0 {
+		delete(w.tagIndex, tag)
+	} else {
+		w.tagIndex[tag] = entities
+	}
+}
+
+// bindTagsLocked indexes a tag component added to an entity; the caller must hold the write lock
+func (w *World) bindTagsLocked(entityID EntityID, component Component) {
+	tags, ok := component.(*TagComponent)
+	if !ok {
+		return
+	}
+
+	tags.binding = tagBinding{world: w, entityID: entityID}
+	for _, tag := range tags.Tags {
+		w.indexTagLocked(entityID, tag)
+	}
+}
+
+// unbindTagsLocked unindexes a tag component leaving an entity; the caller must hold the write lock
+func (w *World) unbindTagsLocked(entityID EntityID, component Component) {
+	tags, ok := component.(*TagComponent)
+	if !ok {
+		return
+	}
+
+	tags.binding = tagBinding{}
+	for _, tag := range tags.Tags {
+		w.unindexTagLocked(entityID, tag)
+	}
+}
@@ -0,0 +1,206 @@
+package ecs
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestTagIndex(t *testing.T) {
+	world := NewWorld()
+	player := world.CreateEntity()
+	goblin := world.CreateEntity()
+	orc := world.CreateEntity()
+
+	// Tags present when the component is added are indexed
+	world.AddComponent(orc, NewTagComponent("enemy"))
+	world.AddComponent(goblin, NewTagComponent("enemy", "small"))
+	world.AddComponent(player, NewTagComponent())
+
+	if got, want := world.GetEntitiesByTag("enemy"), []EntityID{goblin, orc}; !reflect.DeepEqual(got, want) {
+		t.Errorf("enemies = %v, want %v", got, want)
+	}
+
+	// AddTag and RemoveTag update the index
+	world.GetComponent(player, "tag").(*TagComponent).AddTag("enemy")
+	if got, want := world.GetEntitiesByTag("enemy"), []EntityID{player, goblin, orc}; !reflect.DeepEqual(got, want) {
+		t.Errorf("enemies after AddTag = %v, want %v", got, want)
+	}
+	world.GetComponent(goblin, "tag").(*TagComponent).RemoveTag("small")
+	if got := world.GetEntitiesByTag("small"); len(got) != 0 {
+		t.Errorf("small after RemoveTag = %v, want none", got)
+	}
+
+	// Removing the component or destroying the entity unindexes its tags
+	world.RemoveComponent(orc, "tag")
+	world.DestroyEntity(player)
+	if got, want := world.GetEntitiesByTag("enemy"), []EntityID{goblin}; !reflect.DeepEqual(got, want) {
+		t.Errorf("enemies after removal = %v, want %v", got, want)
+	}
+}
+
+func TestTagIndexIgnoresDuplicates(t *testing.T) {
+	world := NewWorld()
+	entityID := world.CreateEntity()
+	world.AddComponent(entityID, NewTagComponent("enemy"))
+
+	tags := world.GetComponent(entityID, "tag").(*TagComponent)
+	tags.AddTag("enemy")
+	if got := world.GetEntitiesByTag("enemy"); len(got) != 1 {
+		t.Errorf("enemies after tagging twice = %v, want one entry", got)
+	}
+
+	// RemoveTag removes every occurrence, so the index is cleared too
+	tags.RemoveTag("enemy")
+	if tags.HasTag("enemy") || len(world.GetEntitiesByTag("enemy")) != 0 {
+		t.Error("tag still present after RemoveTag")
+	}
+}
+
+func TestTagIndexReplacedComponent(t *testing.T) {
+	world := NewWorld()
+	entityID := world.CreateEntity()
+	old := NewTagComponent("enemy")
+	world.AddComponent(entityID, old)
+	world.AddComponent(entityID, NewTagComponent("friend"))
+
+	if got := world.GetEntitiesByTag("enemy"); len(got) != 0 {
+		t.Errorf("replaced component's tags still indexed: %v", got)
+	}
+	// The replaced component no longer updates the index
+	old.AddTag("boss")
+	if got := world.GetEntitiesByTag("boss"); len(got) != 0 {
+		t.Errorf("tag added to a detached component was indexed: %v", got)
+	}
+}
+
+func TestRemoveTag(t *testing.T) {
+	tags := NewTagComponent("burning", "wet")
+
+	if !tags.RemoveTag("burning") {
+		t.Error("RemoveTag of an existing tag returned false")
+	}
+	if tags.HasTag("burning") {
+		t.Error("tag still present after RemoveTag")
+	}
+	if tags.RemoveTag("frozen") {
+		t.Error("RemoveTag of a missing tag returned true")
+	}
+	if !reflect.DeepEqual(tags.Tags, []string{"wet"}) {
+		t.Errorf("Tags = %v, want [wet]", tags.Tags)
+	}
+}
+
+func TestTagChangesDontWriteSharedSlice(t *testing.T) {
+	// A scene loader builds every instance's tags from the same slice
+	shared := make([]string, 3, 8)
+	copy(shared, []string{"enemy", "flying", "boss"})
+	first := NewTagComponent(shared...)
+	second := NewTagComponent(shared...)
+
+	first.RemoveTag("enemy")
+	first.AddTag("ally")
+	second.AddTag("burning")
+
+	if want := []string{"enemy", "flying", "boss"}; !reflect.DeepEqual(shared, want) {
+		t.Errorf("shared slice = %v, want %v", shared, want)
+	}
+	if want := []string{"flying", "boss", "ally"}; !reflect.DeepEqual(first.Tags, want) {
+		t.Errorf("first.Tags = %v, want %v", first.Tags, want)
+	}
+	if want := []string{"enemy", "flying", "boss", "burning"}; !reflect.DeepEqual(second.Tags, want) {
+		t.Errorf("second.Tags = %v, want %v", second.Tags, want)
+	}
+}
+
+func TestHasAllAndAnyTags(t *testing.T) {
+	tags := NewTagComponent("enemy", "flying")
+
+	tests := []struct {
+		tags     []string
+		all, any bool
+	}{
+		{[]string{"enemy", "flying"}, true, true},
+		{[]string{"enemy", "boss"}, false, true},
+		{[]string{"boss"}, false, false},
+		{nil, true, false},
+	}
+
+	for _, test := range tests {
+		if got := tags.HasAllTags(test.tags...); got != test.all {
+			t.Errorf("HasAllTags(%v) = %v, want %v", test.tags, got, test.all)
+		}
+		if got := tags.HasAnyTags(test.tags...); got != test.any {
+			t.Errorf("HasAnyTags(%v) = %v, want %v", test.tags, got, test.any)
+		}
+	}
+}
+
+func TestTagChangeNotifications(t *testing.T) {
+	world := NewWorld()
+	entityID := world.CreateEntity()
+	world.AddComponent(entityID, NewTagComponent())
+
+	type change struct {
+		tag   string
+		added bool
+	}
+	var changes []change
+	world.OnTagChanged(func(changed EntityID, tag string, added bool) {
+		if changed != entityID {
+			t.Errorf("notified for entity %d, want %d", changed, entityID)
+		}
+		changes = append(changes, change{tag, added})
+	})
+
+	tags := world.GetComponent(entityID, "tag").(*TagComponent)
+	tags.AddTag("burning")
+	tags.AddTag("burning")
+	tags.RemoveTag("burning")
+	tags.RemoveTag("burning")
+
+	// Only actual changes notify
+	want := []change{{"burning", true}, {"burning", false}}
+	if !reflect.DeepEqual(changes, want) {
+		t.Errorf("changes = %v, want %v", changes, want)
+	}
+}
+
+func TestTagChangesDuringForEach(t *testing.T) {
+	world := NewWorld()
+	for i := 0; i < 3; i++ {
+		world.AddComponent(world.CreateEntity(), NewTagComponent("enemy"))
+	}
+
+	notified := 0
+	world.OnTagChanged(func(entityID EntityID, tag string, added bool) {
+		notified++
+	})
+
+	// Changing tags from inside the callback used to deadlock on the write lock
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		world.ForEachWithComponent("tag", func(entityID EntityID, component Component) {
+			tags := component.(*TagComponent)
+			tags.RemoveTag("enemy")
+			tags.AddTag("ally")
+		})
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("changing tags inside ForEachWithComponent deadlocked")
+	}
+
+	// The index and listeners catch up once the iteration finishes
+	if got := world.GetEntitiesByTag("enemy"); len(got) != 0 {
+		t.Errorf("GetEntitiesByTag(enemy) = %v, want none", got)
+	}
+	if got := world.GetEntitiesByTag("ally"); len(got) != 3 {
+		t.Errorf("GetEntitiesByTag(ally) = %v, want all 3 entities", got)
+	}
+	if notified != 6 {
+		t.Errorf("listeners notified %d times, want 6", notified)
+	}
+}
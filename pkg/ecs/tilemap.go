@@ -0,0 +1,118 @@
+package ecs
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// EmptyTile marks a tilemap cell with no tile
+const EmptyTile = -1
+
+// TilemapComponent is a grid of tiles drawn from an atlas at the entity's
+// transform. Cell (0, 0) is the bottom-left tile, with its bottom-left corner
+// at the transform position; X grows right and Y grows up.
+type TilemapComponent struct {
+	AtlasID string
+	// AtlasColumns and AtlasRows give the grid of tiles in the atlas; tile
+	// index i is cell i of that grid, row by row from the top-left
+	AtlasColumns int
+	AtlasRows    int
+
+	Width    int
+	Height   int
+	TileSize mgl32.Vec2
+	// Tiles holds the tile index of every cell, row by row from the bottom
+	Tiles []int
+	// SolidTiles lists the tile indices that block movement
+	SolidTiles []int
+}
+
+func (t *TilemapComponent) GetType() string {
+	return "tilemap"
+}
+
+// NewTilemapComponent creates an empty tilemap of width by height cells
+func NewTilemapComponent(atlasID string, atlasColumns, atlasRows, width, height int, tileSize mgl32.Vec2) *TilemapComponent {
+	tiles := make([]int, width*height)
+	for i := range tiles {
+		tiles[i] = EmptyTile
+	}
+
+	return &TilemapComponent{
+		AtlasID:      atlasID,
+		AtlasColumns: atlasColumns,
+		AtlasRows:    atlasRows,
+		Width:        width,
+		Height:       height,
+		TileSize:     tileSize,
+		Tiles:        tiles,
+	}
+}
+
+// InBounds reports whether a cell lies inside the map
+func (t *TilemapComponent) InBounds(x, y int) bool {
+	return x >= 0 && x < t.Width && y >= 0 && y < t.Height && y*t.Width+x < len(t.Tiles)
+}
+
+// GetTile returns the tile index of a cell, or EmptyTile outside the map
+func (t *TilemapComponent) GetTile(x, y int) int {
+	if !t.InBounds(x, y) {
+		return EmptyTile
+	}
+	return t.Tiles[y*t.Width+x]
+}
+
+// SetTile sets the tile index of a cell. Cells outside the map are ignored.
+func (t *TilemapComponent) SetTile(x, y, tile int) {
+	if !t.InBounds(x, y) {
+		return
+	}
+	t.Tiles[y*t.Width+x] = tile
+}
+
+// IsSolid reports whether the tile of a cell is one of SolidTiles
+func (t *TilemapComponent) IsSolid(x, y int) bool {
+	tile := t.GetTile(x, y)
+	if tile == EmptyTile {
+		return false
+	}
+	for _, solid := range t.SolidTiles {
+		if solid == tile {
+			return true
+		}
+	}
+	return false
+}
+
+// WorldToTile returns the cell containing a world position, for a map whose
+// transform is at origin. The cell may lie outside the map.
+func (t *TilemapComponent) WorldToTile(origin, position mgl32.Vec2) (int, int) {
+	if t.TileSize.X() <= 0 || t.TileSize.Y() <= 0 {
+		return 0, 0
+	}
+	local := position.Sub(origin)
+	x := int(math.Floor(float64(local.X() / t.TileSize.X())))
+	y := int(math.Floor(float64(local.Y() / t.TileSize.Y())))
+	return x, y
+}
+
+// TileToWorld returns the bottom-left corner of a cell in world space, for a
+// map whose transform is at origin
+func (t *TilemapComponent) TileToWorld(origin mgl32.Vec2, x, y int) mgl32.Vec2 {
+	return origin.Add(mgl32.Vec2{float32(x) * t.TileSize.X(), float32(y) * t.TileSize.Y()})
+}
+
+// TileRect returns the atlas rectangle (minU, minV, maxU, maxV) of a tile
+// index, with (0, 0) at the top-left of the atlas
+func (t *TilemapComponent) TileRect(tile int) mgl32.Vec4 {
+	if t.AtlasColumns <= 0 || t.AtlasRows <= 0 {
+		return mgl32.Vec4{0, 0, 1, 1}
+	}
+
+	width := 1 / float32(t.AtlasColumns)
+	height := 1 / float32(t.AtlasRows)
+	u := float32(tile%t.AtlasColumns) * width
+	v := float32(tile/t.AtlasColumns) * height
+	return mgl32.Vec4{u, v, u + width, v + height}
+}
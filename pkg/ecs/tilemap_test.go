@@ -0,0 +1,91 @@
+package ecs
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestTilemapGetAndSetTile(t *testing.T) {
+	tilemap := NewTilemapComponent("tiles", 4, 4, 3, 2, mgl32.Vec2{1, 1})
+
+	if got := tilemap.GetTile(1, 1); got != EmptyTile {
+		t.Errorf("new map GetTile(1, 1) = %d, want EmptyTile", got)
+	}
+
+	tilemap.SetTile(2, 1, 7)
+	if got := tilemap.GetTile(2, 1); got != 7 {
+		t.Errorf("GetTile(2, 1) = %d, want 7", got)
+	}
+	// Rows are stored from the bottom
+	if got := tilemap.Tiles[1*3+2]; got != 7 {
+		t.Errorf("Tiles[5] = %d, want 7", got)
+	}
+
+	// Cells outside the map are ignored
+	for _, cell := range [][2]int{{-1, 0}, {3, 0}, {0, 2}, {0, -1}} {
+		tilemap.SetTile(cell[0], cell[1], 1)
+		if got := tilemap.GetTile(cell[0], cell[1]); got != EmptyTile {
+			t.Errorf("GetTile(%d, %d) = %d outside the map, want EmptyTile", cell[0], cell[1], got)
+		}
+	}
+}
+
+func TestTilemapIsSolid(t *testing.T) {
+	tilemap := NewTilemapComponent("tiles", 4, 4, 2, 1, mgl32.Vec2{1, 1})
+	tilemap.SolidTiles = []int{3}
+	tilemap.SetTile(0, 0, 3)
+	tilemap.SetTile(1, 0, 2)
+
+	if !tilemap.IsSolid(0, 0) || tilemap.IsSolid(1, 0) || tilemap.IsSolid(5, 0) {
+		t.Errorf("IsSolid = %v, %v, %v; want true, false, false",
+			tilemap.IsSolid(0, 0), tilemap.IsSolid(1, 0), tilemap.IsSolid(5, 0))
+	}
+}
+
+func TestTilemapCoordinates(t *testing.T) {
+	tilemap := NewTilemapComponent("tiles", 4, 4, 10, 10, mgl32.Vec2{2, 0.5})
+	origin := mgl32.Vec2{-4, 1}
+
+	tests := []struct {
+		position mgl32.Vec2
+		x, y     int
+	}{
+		{mgl32.Vec2{-4, 1}, 0, 0},
+		{mgl32.Vec2{-2.5, 1.75}, 0, 1},
+		{mgl32.Vec2{0, 1}, 2, 0},
+		// Left of and below the origin
+		{mgl32.Vec2{-4.5, 0.9}, -1, -1},
+	}
+	for _, test := range tests {
+		if x, y := tilemap.WorldToTile(origin, test.position); x != test.x || y != test.y {
+			t.Errorf("WorldToTile(%v) = (%d, %d), want (%d, %d)", test.position, x, y, test.x, test.y)
+		}
+	}
+
+	if got, want := tilemap.TileToWorld(origin, 3, 2), (mgl32.Vec2{2, 2}); got != want {
+		t.Errorf("TileToWorld(3, 2) = %v, want %v", got, want)
+	}
+
+	// A cell's corner maps back to the cell
+	for y := 0; y < 3; y++ {
+		for x := 0; x < 3; x++ {
+			if gotX, gotY := tilemap.WorldToTile(origin, tilemap.TileToWorld(origin, x, y)); gotX != x || gotY != y {
+				t.Errorf("round trip of (%d, %d) gave (%d, %d)", x, y, gotX, gotY)
+			}
+		}
+	}
+}
+
+func TestTilemapTileRect(t *testing.T) {
+	tilemap := NewTilemapComponent("tiles", 4, 2, 1, 1, mgl32.Vec2{1, 1})
+
+	if got, want := tilemap.TileRect(5), (mgl32.Vec4{0.25, 0.5, 0.5, 1}); got != want {
+		t.Errorf("TileRect(5) = %v, want %v", got, want)
+	}
+
+	tilemap.AtlasColumns = 0
+	if got := tilemap.TileRect(5); got != (mgl32.Vec4{0, 0, 1, 1}) {
+		t.Errorf("TileRect() without an atlas grid = %v, want the whole texture", got)
+	}
+}
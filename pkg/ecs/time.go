@@ -0,0 +1,79 @@
+package ecs
+
+// Time tracks the world's clock. Update scales each frame's delta by
+// TimeScale before passing it to systems, so gameplay can run in slow motion
+// or be frozen with a scale of 0 while systems keep running.
+type Time struct {
+	// TimeScale multiplies the frame time; negative values count as 0
+	TimeScale float64
+
+	// Delta and Elapsed are scaled; RealDelta and RealElapsed are not
+	Delta       float64
+	Elapsed     float64
+	RealDelta   float64
+	RealElapsed float64
+
+	// Frame is the number of Update calls so far
+	Frame uint64
+}
+
+// newTime creates a clock running at normal speed
+func newTime() *Time {
+	return &Time{TimeScale: 1}
+}
+
+// scale returns TimeScale with negative values counted as 0
+func (t *Time) scale() float64 {
+	if t.TimeScale < 0 {
+		return 0
+	}
+	return t.TimeScale
+}
+
+// advance adds a frame of real time and returns the scaled delta
+func (t *Time) advance(realDelta float64) float64 {
+	scale := t.scale()
+
+	t.RealDelta = realDelta
+	t.RealElapsed += realDelta
+	t.Delta = realDelta * scale
+	t.Elapsed += t.Delta
+	t.Frame++
+	return t.Delta
+}
+
+// Time returns the world's clock. Systems can read it during Update and set
+// TimeScale to change the speed of later frames.
+func (w *World) Time() *Time {
+	return w.time
+}
+
+// GetTime returns a copy of the world's clock, taken under the world's lock
+func (w *World) GetTime() Time {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	return *w.time
+}
+
+// SetTime replaces the world's clock, for example with one saved by GetTime
+func (w *World) SetTime(clock Time) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	*w.time = clock
+}
+
+// SetTimeScale sets the scale later Updates apply to the frame time, taken
+// under the world's lock
+func (w *World) SetTimeScale(scale float64) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.time.TimeScale = scale
+}
+
+// GetTimeScale returns the scale the next Update applies to the frame time,
+// with negative values counted as 0
+func (w *World) GetTimeScale() float64 {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	return w.time.scale()
+}
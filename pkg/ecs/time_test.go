@@ -0,0 +1,74 @@
+package ecs
+
+import (
+	"testing"
+)
+
+// deltaRecorder is a system that records the delta times it is updated with
+type deltaRecorder struct {
+	deltas []float64
+}
+
+func (r *deltaRecorder) Update(deltaTime float64, world *World) {
+	r.deltas = append(r.deltas, deltaTime)
+}
+
+func (r *deltaRecorder) GetName() string {
+	return "deltaRecorder"
+}
+
+func TestTimeScaleHalvesScaledTime(t *testing.T) {
+	world := NewWorld()
+	recorder := &deltaRecorder{}
+	world.AddSystem(recorder)
+	world.SetTimeScale(0.5)
+
+	for i := 0; i < 4; i++ {
+		world.Update(0.25)
+	}
+
+	clock := world.GetTime()
+	if clock.Elapsed != 0.5 || clock.RealElapsed != 1 {
+		t.Errorf("Elapsed = %v, RealElapsed = %v; want 0.5, 1", clock.Elapsed, clock.RealElapsed)
+	}
+	if clock.Delta != 0.125 || clock.RealDelta != 0.25 {
+		t.Errorf("Delta = %v, RealDelta = %v; want 0.125, 0.25", clock.Delta, clock.RealDelta)
+	}
+	if clock.Frame != 4 {
+		t.Errorf("Frame = %d, want 4", clock.Frame)
+	}
+	for i, delta := range recorder.deltas {
+		if delta != 0.125 {
+			t.Errorf("update %d: system got delta %v, want 0.125", i, delta)
+		}
+	}
+}
+
+func TestZeroTimeScaleFreezesGameplay(t *testing.T) {
+	world := NewWorld()
+	recorder := &deltaRecorder{}
+	world.AddSystem(recorder)
+
+	world.Update(0.5)
+	world.SetTimeScale(0)
+	world.Update(0.5)
+	world.Update(0.5)
+
+	// Systems still run, with no time passing
+	if len(recorder.deltas) != 3 || recorder.deltas[1] != 0 || recorder.deltas[2] != 0 {
+		t.Errorf("systems got deltas %v, want [0.5 0 0]", recorder.deltas)
+	}
+	if clock := world.GetTime(); clock.Elapsed != 0.5 || clock.RealElapsed != 1.5 || clock.Frame != 3 {
+		t.Errorf("clock = %+v, want 0.5 s scaled and 1.5 s real over 3 frames", clock)
+	}
+
+	// Negative scales count as 0
+	world.SetTimeScale(-2)
+	if got := world.GetTimeScale(); got != 0 {
+		t.Errorf("GetTimeScale() = %v for a negative scale, want 0", got)
+	}
+	world.Update(0.5)
+	if delta := recorder.deltas[len(recorder.deltas)-1]; delta != 0 {
+		t.Errorf("negative TimeScale gave delta %v, want 0", delta)
+	}
+}
@@ -0,0 +1,246 @@
+package ecs
+
+import (
+	"encoding/json"
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// transformState is the position, rotation and scale of a transform
+type transformState struct {
+	position mgl32.Vec3
+	rotation mgl32.Quat
+	scale    mgl32.Vec3
+}
+
+// state returns the transform's current position, rotation and scale
+func (t *TransformComponent) state() transformState {
+	return transformState{t.Position, t.rotation(), t.Scale}
+}
+
+// matrixCache holds a transform's model matrix and the values it was built from
+type matrixCache struct {
+	matrix mgl32.Mat4
+	// builtFrom is the state the matrix was built from
+	builtFrom transformState
+	// dirty is set by the setters; built is false until the first build
+	dirty bool
+	built bool
+}
+
+// previousState holds the state a transform moved from and to in its last
+// fixed step
+type previousState struct {
+	from, to transformState
+	saved    bool
+}
+
+// SetPosition sets the position and marks the model matrix for rebuilding
+func (t *TransformComponent) SetPosition(position mgl32.Vec3) {
+	t.Position = position
+	t.dirty = true
+}
+
+// SetRotation sets the rotation from Euler angles in radians, applied in
+// Y (yaw), X (pitch), Z (roll) order, and marks the model matrix for rebuilding
+func (t *TransformComponent) SetRotation(rotation mgl32.Vec3) {
+	t.Quaternion = eulerToQuat(rotation)
+	t.Rotation = rotation
+	t.syncedRotation = rotation
+	t.dirty = true
+}
+
+// SetScale sets the scale and marks the model matrix for rebuilding
+func (t *TransformComponent) SetScale(scale mgl32.Vec3) {
+	t.Scale = scale
+	t.dirty = true
+}
+
+// LocalMatrix builds Translate3D(Position) * Quaternion * Scale3D(Scale).
+// This is the canonical model matrix; ModelMatrix returns a cached copy of it.
+func (t *TransformComponent) LocalMatrix() mgl32.Mat4 {
+	translation := mgl32.Translate3D(t.Position.X(), t.Position.Y(), t.Position.Z())
+	rotation := t.rotation().Mat4()
+	scale := mgl32.Scale3D(t.Scale.X(), t.Scale.Y(), t.Scale.Z())
+
+	return translation.Mul4(rotation).Mul4(scale)
+}
+
+// WorldMatrix places the transform in its parent's space, returning
+// parent * LocalMatrix. Pass the identity matrix for a root transform.
+func (t *TransformComponent) WorldMatrix(parent mgl32.Mat4) mgl32.Mat4 {
+	return parent.Mul4(t.ModelMatrix())
+}
+
+// ModelMatrix returns LocalMatrix, cached and rebuilt only after a setter ran
+// or a field was written directly
+func (t *TransformComponent) ModelMatrix() mgl32.Mat4 {
+	current := t.state()
+	if t.built && !t.dirty && current == t.builtFrom {
+		return t.matrix
+	}
+
+	t.matrix = t.LocalMatrix()
+	t.builtFrom = current
+	t.dirty = false
+	t.built = true
+	return t.matrix
+}
+
+// IsDirty returns true if the model matrix will be rebuilt on the next ModelMatrix call
+func (t *TransformComponent) IsDirty() bool {
+	return !t.built || t.dirty || t.state() != t.builtFrom
+}
+
+// StepTo moves the transform to position as the result of a fixed step,
+// keeping the state it moved from so rendering can draw between the two.
+// The engine calls it for physics entities after each physics step.
+func (t *TransformComponent) StepTo(position mgl32.Vec3) {
+	from := t.state()
+	t.SetPosition(position)
+	t.previous = previousState{
+		from:  from,
+		to:    t.state(),
+		saved: true,
+	}
+}
+
+// interpolating reports whether the transform is still where its last
+// StepTo left it, so there is a step to interpolate along
+func (t *TransformComponent) interpolating() bool {
+	return t.previous.saved && t.previous.to == t.state()
+}
+
+// InterpolatedPosition returns the position alpha of the way through the
+// last StepTo. It is the current position if the transform was moved any
+// other way since.
+func (t *TransformComponent) InterpolatedPosition(alpha float32) mgl32.Vec3 {
+	if !t.interpolating() {
+		return t.Position
+	}
+	from := t.previous.from.position
+	return from.Add(t.Position.Sub(from).Mul(alpha))
+}
+
+// InterpolatedMatrix returns the model matrix of the transform alpha of the
+// way through the last StepTo, so rendering can draw between fixed steps.
+// It is ModelMatrix if the transform was moved any other way since.
+func (t *TransformComponent) InterpolatedMatrix(alpha float32) mgl32.Mat4 {
+	if !t.interpolating() || alpha >= 1 || t.previous.from == t.previous.to {
+		return t.ModelMatrix()
+	}
+
+	from := &TransformComponent{
+		Position:   t.previous.from.position,
+		Quaternion: t.previous.from.rotation,
+		Scale:      t.previous.from.scale,
+	}
+	return SlerpTransform(from, t, alpha).LocalMatrix()
+}
+
+// rotation returns Quaternion normalized, treating the zero value as no
+// rotation. A Rotation written directly since the last setter call wins.
+func (t *TransformComponent) rotation() mgl32.Quat {
+	if t.Rotation != t.syncedRotation {
+		return eulerToQuat(t.Rotation)
+	}
+	if t.Quaternion == (mgl32.Quat{}) {
+		return mgl32.QuatIdent()
+	}
+	return t.Quaternion.Normalize()
+}
+
+// EulerAngles returns the rotation as pitch, yaw and roll in radians, applied
+// in Y (yaw), X (pitch), Z (roll) order. Near straight up or down, where yaw
+// and roll turn about the same axis, the roll is folded into the yaw.
+func (t *TransformComponent) EulerAngles() mgl32.Vec3 {
+	return quatToEuler(t.rotation())
+}
+
+// SetRotationQuat sets the rotation from a quaternion and marks the model
+// matrix for rebuilding
+func (t *TransformComponent) SetRotationQuat(rotation mgl32.Quat) {
+	t.Quaternion = rotation.Normalize()
+	t.Rotation = quatToEuler(t.Quaternion)
+	t.syncedRotation = t.Rotation
+	t.dirty = true
+}
+
+// RotateBy rotates the transform by angle radians about a world-space axis
+func (t *TransformComponent) RotateBy(axis mgl32.Vec3, angle float32) {
+	if axis.Len() == 0 {
+		return
+	}
+	t.SetRotationQuat(mgl32.QuatRotate(angle, axis.Normalize()).Mul(t.rotation()))
+}
+
+// SlerpTransform blends two transforms: positions and scales are
+// interpolated linearly and rotations along the shortest arc
+func SlerpTransform(from, to *TransformComponent, amount float32) *TransformComponent {
+	transform := &TransformComponent{
+		Position: from.Position.Add(to.Position.Sub(from.Position).Mul(amount)),
+		Scale:    from.Scale.Add(to.Scale.Sub(from.Scale).Mul(amount)),
+	}
+	transform.SetRotationQuat(mgl32.QuatSlerp(from.rotation(), to.rotation(), amount))
+	return transform
+}
+
+// UnmarshalJSON decodes a transform and syncs its two rotation fields.
+// Saves made before the rotation was stored as a quaternion only have the
+// Euler "Rotation".
+func (t *TransformComponent) UnmarshalJSON(data []byte) error {
+	type plainTransform TransformComponent
+	var decoded plainTransform
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	*t = TransformComponent(decoded)
+	switch {
+	case t.Quaternion == (mgl32.Quat{}):
+		t.SetRotation(t.Rotation)
+	case sameRotation(eulerToQuat(t.Rotation), t.Quaternion):
+		// Keep the saved values rather than recomputing them
+		t.syncedRotation = t.Rotation
+		t.dirty = true
+	default:
+		t.SetRotationQuat(t.Quaternion)
+	}
+	return nil
+}
+
+// sameRotation reports whether two quaternions rotate alike, allowing for
+// rounding; q and -q are the same rotation
+func sameRotation(a, b mgl32.Quat) bool {
+	return math.Abs(float64(a.Normalize().Dot(b.Normalize()))) > 1-1e-6
+}
+
+// eulerToQuat builds RotateY(yaw) * RotateX(pitch) * RotateZ(roll) from
+// pitch, yaw and roll: roll is applied to the vertices first and yaw last
+func eulerToQuat(rotation mgl32.Vec3) mgl32.Quat {
+	return mgl32.QuatRotate(rotation.Y(), mgl32.Vec3{0, 1, 0}).
+		Mul(mgl32.QuatRotate(rotation.X(), mgl32.Vec3{1, 0, 0})).
+		Mul(mgl32.QuatRotate(rotation.Z(), mgl32.Vec3{0, 0, 1}))
+}
+
+// quatToEuler decomposes a unit quaternion into the pitch, yaw and roll of
+// RotateY(yaw) * RotateX(pitch) * RotateZ(roll)
+func quatToEuler(rotation mgl32.Quat) mgl32.Vec3 {
+	m := rotation.Mat4()
+
+	// Element (1, 2) of the rotation matrix is -sin(pitch)
+	sinPitch := math.Max(-1, math.Min(1, float64(-m.At(1, 2))))
+	pitch := math.Asin(sinPitch)
+
+	var yaw, roll float64
+	if math.Abs(sinPitch) < 0.9999 {
+		yaw = math.Atan2(float64(m.At(0, 2)), float64(m.At(2, 2)))
+		roll = math.Atan2(float64(m.At(1, 0)), float64(m.At(1, 1)))
+	} else {
+		// Gimbal lock: only yaw + roll is defined
+		yaw = math.Atan2(float64(-m.At(2, 0)), float64(m.At(0, 0)))
+	}
+
+	return mgl32.Vec3{float32(pitch), float32(yaw), float32(roll)}
+}
@@ -0,0 +1,231 @@
+package ecs
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestModelMatrixCache(t *testing.T) {
+	transform := NewTransformComponent(mgl32.Vec3{1, 2, 3}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1})
+	if !transform.IsDirty() {
+		t.Error("new transform isn't dirty before its first build")
+	}
+
+	built := transform.ModelMatrix()
+	if transform.IsDirty() {
+		t.Error("transform still dirty after ModelMatrix")
+	}
+
+	// Plant a marker in the cache: it comes back only if nothing is rebuilt
+	marker := mgl32.Scale3D(7, 7, 7)
+	transform.matrix = marker
+	if got := transform.ModelMatrix(); got != marker {
+		t.Fatalf("unchanged transform rebuilt its matrix: got %v", got)
+	}
+
+	transform.SetScale(mgl32.Vec3{1, 1, 1})
+	if !transform.IsDirty() {
+		t.Error("setter didn't mark the transform dirty")
+	}
+	if got := transform.ModelMatrix(); got != built {
+		t.Errorf("ModelMatrix() after a setter = %v, want %v", got, built)
+	}
+}
+
+func TestModelMatrixCacheSeesFieldWrites(t *testing.T) {
+	transform := NewTransformComponent(mgl32.Vec3{}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1})
+	transform.ModelMatrix()
+
+	transform.Position[0] = 4
+	if !transform.IsDirty() {
+		t.Error("direct field write didn't invalidate the cache")
+	}
+	if got, want := transform.ModelMatrix(), mgl32.Translate3D(4, 0, 0); got != want {
+		t.Errorf("ModelMatrix() = %v, want %v", got, want)
+	}
+}
+
+func TestLocalMatrix(t *testing.T) {
+	transform := NewTransformComponent(mgl32.Vec3{1, -2, 3}, mgl32.Vec3{0.4, -1.1, 0.7}, mgl32.Vec3{2, 3, 0.5})
+
+	// Built by hand: scale, then roll, pitch and yaw, then translate
+	want := mgl32.Translate3D(1, -2, 3).
+		Mul4(mgl32.HomogRotate3DY(-1.1)).
+		Mul4(mgl32.HomogRotate3DX(0.4)).
+		Mul4(mgl32.HomogRotate3DZ(0.7)).
+		Mul4(mgl32.Scale3D(2, 3, 0.5))
+
+	if got := transform.LocalMatrix(); !got.ApproxEqualThreshold(want, 1e-5) {
+		t.Errorf("LocalMatrix() = %v, want %v", got, want)
+	}
+}
+
+func TestWorldMatrix(t *testing.T) {
+	transform := NewTransformComponent(mgl32.Vec3{1, 0, 0}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1})
+	parent := mgl32.Translate3D(0, 5, 0).Mul4(mgl32.Scale3D(2, 2, 2))
+
+	if got := transform.WorldMatrix(mgl32.Ident4()); got != transform.LocalMatrix() {
+		t.Errorf("WorldMatrix(identity) = %v, want LocalMatrix", got)
+	}
+
+	// The child's offset is scaled by its parent
+	origin := transform.WorldMatrix(parent).Mul4x1(mgl32.Vec4{0, 0, 0, 1}).Vec3()
+	if !vecNear(origin, mgl32.Vec3{2, 5, 0}) {
+		t.Errorf("child origin in world space = %v, want (2, 5, 0)", origin)
+	}
+}
+
+func TestEulerQuaternionRoundTrip(t *testing.T) {
+	rotations := []mgl32.Vec3{
+		{},
+		{0.3, 0, 0},
+		{0, -2.5, 0},
+		{0, 0, 1.2},
+		{0.4, -1.1, 0.7},
+		{-1.2, 3, -0.2},
+	}
+
+	for _, rotation := range rotations {
+		transform := NewTransformComponent(mgl32.Vec3{}, rotation, mgl32.Vec3{1, 1, 1})
+		if got := transform.EulerAngles(); !vecNear(got, rotation) {
+			t.Errorf("round trip of %v gave %v", rotation, got)
+		}
+	}
+
+	// Straight up folds the roll into the yaw but keeps the orientation
+	locked := NewTransformComponent(mgl32.Vec3{}, mgl32.Vec3{math.Pi / 2, 0.5, 0.3}, mgl32.Vec3{1, 1, 1})
+	want := locked.LocalMatrix()
+	locked.SetRotation(locked.EulerAngles())
+	got := locked.LocalMatrix()
+	for axis := 0; axis < 3; axis++ {
+		if !vecNear(got.Col(axis).Vec3(), want.Col(axis).Vec3()) {
+			t.Errorf("gimbal-locked round trip moved axis %d to %v, want %v", axis, got.Col(axis).Vec3(), want.Col(axis).Vec3())
+		}
+	}
+}
+
+func TestZeroQuaternionIsNoRotation(t *testing.T) {
+	transform := &TransformComponent{Position: mgl32.Vec3{1, 2, 3}, Scale: mgl32.Vec3{1, 1, 1}}
+	if got, want := transform.LocalMatrix(), mgl32.Translate3D(1, 2, 3); !got.ApproxEqualThreshold(want, 1e-6) {
+		t.Errorf("LocalMatrix() = %v, want %v", got, want)
+	}
+	if got := transform.EulerAngles(); got != (mgl32.Vec3{}) {
+		t.Errorf("EulerAngles() = %v, want zero", got)
+	}
+}
+
+func TestTransformLoadsEulerRotation(t *testing.T) {
+	// Saves made before the quaternion field held Euler angles
+	var transform TransformComponent
+	data := `{"Position":[1,2,3],"Rotation":[0,1.5707964,0],"Scale":[1,1,1]}`
+	if err := json.Unmarshal([]byte(data), &transform); err != nil {
+		t.Fatal(err)
+	}
+	if got := transform.EulerAngles(); !vecNear(got, mgl32.Vec3{0, math.Pi / 2, 0}) {
+		t.Errorf("EulerAngles() = %v, want a quarter turn of yaw", got)
+	}
+
+	// Current saves round trip the quaternion
+	saved, err := json.Marshal(&transform)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var loaded TransformComponent
+	if err := json.Unmarshal(saved, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	if loaded.Quaternion != transform.Quaternion || loaded.Position != transform.Position {
+		t.Errorf("loaded %+v, want %+v", loaded, transform)
+	}
+}
+
+func TestRotationStaysInSyncWithQuaternion(t *testing.T) {
+	// consistent checks that the deprecated Euler field and the quaternion
+	// describe the same rotation
+	consistent := func(step string, transform *TransformComponent) {
+		t.Helper()
+		if !sameRotation(eulerToQuat(transform.Rotation), transform.Quaternion) {
+			t.Errorf("after %s Rotation %v disagrees with Quaternion %v", step, transform.Rotation, transform.Quaternion)
+		}
+		if !vecNear(transform.Rotation, transform.EulerAngles()) {
+			t.Errorf("after %s Rotation = %v, EulerAngles() = %v", step, transform.Rotation, transform.EulerAngles())
+		}
+	}
+
+	transform := NewTransformComponent(mgl32.Vec3{}, mgl32.Vec3{0.3, -0.4, 0.2}, mgl32.Vec3{1, 1, 1})
+	consistent("NewTransformComponent", transform)
+
+	transform.SetRotation(mgl32.Vec3{-0.5, 1.2, 0.1})
+	consistent("SetRotation", transform)
+
+	transform.SetRotationQuat(mgl32.QuatRotate(0.8, mgl32.Vec3{1, 1, 0}.Normalize()))
+	consistent("SetRotationQuat", transform)
+
+	transform.RotateBy(mgl32.Vec3{0, 0, 1}, 0.6)
+	consistent("RotateBy", transform)
+
+	saved, err := json.Marshal(transform)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var loaded TransformComponent
+	if err := json.Unmarshal(saved, &loaded); err != nil {
+		t.Fatal(err)
+	}
+	consistent("UnmarshalJSON", &loaded)
+
+	// A direct write to the deprecated field still rotates the transform
+	transform.Rotation = mgl32.Vec3{0, math.Pi / 2, 0}
+	if got := transform.ModelMatrix().Mul4x1(mgl32.Vec4{0, 0, -1, 0}).Vec3(); !vecNear(got, mgl32.Vec3{-1, 0, 0}) {
+		t.Errorf("writing Rotation turned forward to %v, want -X", got)
+	}
+	if !vecNear(transform.EulerAngles(), transform.Rotation) {
+		t.Errorf("EulerAngles() = %v after writing Rotation %v", transform.EulerAngles(), transform.Rotation)
+	}
+}
+
+func TestRotateBy(t *testing.T) {
+	transform := NewTransformComponent(mgl32.Vec3{}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1})
+	transform.RotateBy(mgl32.Vec3{0, 2, 0}, math.Pi/2)
+	if got := transform.EulerAngles(); !vecNear(got, mgl32.Vec3{0, math.Pi / 2, 0}) {
+		t.Errorf("EulerAngles() = %v, want a quarter turn of yaw", got)
+	}
+
+	// A zero axis is ignored
+	transform.RotateBy(mgl32.Vec3{}, 1)
+	if got := transform.EulerAngles(); !vecNear(got, mgl32.Vec3{0, math.Pi / 2, 0}) {
+		t.Errorf("zero axis changed the rotation to %v", got)
+	}
+}
+
+func TestSlerpTransform(t *testing.T) {
+	from := NewTransformComponent(mgl32.Vec3{0, 0, 0}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1})
+	to := NewTransformComponent(mgl32.Vec3{4, 2, 0}, mgl32.Vec3{0, math.Pi / 2, 0}, mgl32.Vec3{3, 3, 3})
+
+	tests := []struct {
+		amount   float32
+		position mgl32.Vec3
+		rotation mgl32.Vec3
+		scale    mgl32.Vec3
+	}{
+		{0, mgl32.Vec3{0, 0, 0}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}},
+		{0.5, mgl32.Vec3{2, 1, 0}, mgl32.Vec3{0, math.Pi / 4, 0}, mgl32.Vec3{2, 2, 2}},
+		{1, mgl32.Vec3{4, 2, 0}, mgl32.Vec3{0, math.Pi / 2, 0}, mgl32.Vec3{3, 3, 3}},
+	}
+
+	for _, tt := range tests {
+		got := SlerpTransform(from, to, tt.amount)
+		if !vecNear(got.Position, tt.position) {
+			t.Errorf("SlerpTransform(%v) Position = %v, want %v", tt.amount, got.Position, tt.position)
+		}
+		if !vecNear(got.EulerAngles(), tt.rotation) {
+			t.Errorf("SlerpTransform(%v) rotation = %v, want %v", tt.amount, got.EulerAngles(), tt.rotation)
+		}
+		if !vecNear(got.Scale, tt.scale) {
+			t.Errorf("SlerpTransform(%v) Scale = %v, want %v", tt.amount, got.Scale, tt.scale)
+		}
+	}
+}
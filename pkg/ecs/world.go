@@ -1,10 +1,13 @@
 package ecs
 
 import (
+	"math/rand"
+	"reflect"
 	"sync"
 )
 
-// EntityID represents a unique entity identifier
+// EntityID represents a unique entity identifier. IDs start at 1, so the
+// zero ID never names an entity and is returned when none was created.
 type EntityID uint64
 
 // Component represents a component interface
@@ -21,10 +24,61 @@ type System interface {
 // World represents the ECS world
 type World struct {
 	entities     map[EntityID]*Entity
-	components   map[string][]Component
+	order        []EntityID
+	components   map[string][]componentEntry
 	systems      []System
 	nextEntityID EntityID
 	mutex        sync.RWMutex
+
+	// System scheduling; systems holds the resulting run order
+	stages          []string
+	schedule        []scheduledSystem
+	nextSystemOrder int
+	// workers is the number of goroutines running non-conflicting systems
+	workers int
+
+	// Change detection
+	version  uint64
+	versions map[string]map[EntityID]uint64
+
+	// Entities by tag, kept sorted by ID
+	tagIndex     map[string][]EntityID
+	tagListeners []TagListener
+
+	// ForEach calls in progress, the tag changes made while their callbacks
+	// run (see tagChanged), and spare component slices for ForEach to reuse
+	iterations       int
+	pendingTags      []tagChange
+	componentBuffers [][]Component
+	iterationMutex   sync.Mutex
+
+	// Component listeners by type, and changes waiting to be dispatched
+	componentAdded   map[string][]registeredListener
+	componentRemoved map[string][]registeredListener
+	componentEvents  []componentEvent
+	nextListenerID   uint64
+
+	// Scaled and real time fed to systems
+	time *Time
+
+	// Optional archetype index used by ForEach; nil when disabled
+	archetypes *archetypeStorage
+
+	// Seeded random generator, saved with the world
+	seed         uint64
+	random       *rand.Rand
+	randomSource *randomSource
+
+	// World-wide values keyed by type
+	resources map[reflect.Type]interface{}
+
+	// Named entity builders used by Spawn
+	prefabs map[string]func(w *World) EntityID
+
+	// Deferred structural changes, with their own lock so systems can queue
+	// them while holding query results
+	commands     []func(w *World)
+	commandMutex sync.Mutex
 }
 
 // Entity represents a game entity
@@ -36,10 +90,25 @@ type Entity struct {
 
 // NewWorld creates a new ECS world
 func NewWorld() *World {
+	random, randomSource := newRandom(DefaultSeed)
 	return &World{
-		entities:   make(map[EntityID]*Entity),
-		components: make(map[string][]Component),
-		systems:    make([]System, 0),
+		entities:     make(map[EntityID]*Entity),
+		nextEntityID: 1,
+		components:   make(map[string][]componentEntry),
+		systems:      make([]System, 0),
+		stages:       append([]string(nil), DefaultStages...),
+		versions:     make(map[string]map[EntityID]uint64),
+		prefabs:      make(map[string]func(w *World) EntityID),
+		resources:    make(map[reflect.Type]interface{}),
+		tagIndex:     make(map[string][]EntityID),
+		time:         newTime(),
+
+		seed:         DefaultSeed,
+		random:       random,
+		randomSource: randomSource,
+
+		componentAdded:   make(map[string][]registeredListener),
+		componentRemoved: make(map[string][]registeredListener),
 	}
 }
 
@@ -48,6 +117,11 @@ func (w *World) CreateEntity() EntityID {
 	w.mutex.Lock()
 	defer w.mutex.Unlock()
 
+	return w.createEntityLocked()
+}
+
+// createEntityLocked creates a new entity; the caller must hold the write lock
+func (w *World) createEntityLocked() EntityID {
 	entityID := w.nextEntityID
 	w.nextEntityID++
 
@@ -58,51 +132,117 @@ func (w *World) CreateEntity() EntityID {
 	}
 
 	w.entities[entityID] = entity
+	w.appendOrderLocked(entityID)
 	return entityID
 }
 
 // DestroyEntity destroys an entity
 func (w *World) DestroyEntity(entityID EntityID) {
+	defer w.dispatchComponentEvents()
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.destroyEntityLocked(entityID)
+}
+
+// DestroyEntities destroys several entities under a single lock, so other
+// goroutines never observe some of them destroyed and others not. Listeners
+// are called once all of them are gone. Unknown IDs are ignored.
+func (w *World) DestroyEntities(entityIDs ...EntityID) {
+	defer w.dispatchComponentEvents()
 	w.mutex.Lock()
 	defer w.mutex.Unlock()
 
+	for _, entityID := range entityIDs {
+		w.destroyEntityLocked(entityID)
+	}
+}
+
+// destroyEntityLocked removes an entity and its components; the caller must
+// hold the write lock
+func (w *World) destroyEntityLocked(entityID EntityID) {
 	if entity, exists := w.entities[entityID]; exists {
 		// Remove all components
-		for componentType := range entity.Components {
-			w.removeComponentFromList(entityID, componentType)
+		for componentType, component := range entity.Components {
+			w.unbindTagsLocked(entityID, component)
+			w.removeComponentFromList(componentType, entityID)
+			w.forgetVersionLocked(entityID, componentType)
+			w.queueComponentEventLocked(w.componentRemoved, entityID, component)
 		}
 
 		// Remove entity
 		delete(w.entities, entityID)
+		w.removeOrderLocked(entityID)
+		w.syncArchetypeLocked(entityID)
 	}
 }
 
 // AddComponent adds a component to an entity
 func (w *World) AddComponent(entityID EntityID, component Component) {
+	defer w.dispatchComponentEvents()
 	w.mutex.Lock()
 	defer w.mutex.Unlock()
 
+	w.addComponentLocked(entityID, component)
+}
+
+// Configure adds several components to an entity under a single lock,
+// so other goroutines never observe the entity partially configured
+func (w *World) Configure(entityID EntityID, components ...Component) {
+	defer w.dispatchComponentEvents()
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for _, component := range components {
+		w.addComponentLocked(entityID, component)
+	}
+}
+
+// addComponentLocked adds a component to an entity; the caller must hold the write lock
+func (w *World) addComponentLocked(entityID EntityID, component Component) {
 	if entity, exists := w.entities[entityID]; exists {
 		componentType := component.GetType()
+
+		// Replace an existing component of the same type in place, so the
+		// component list holds exactly one entry per entity
+		previous, replacing := entity.Components[componentType]
+		if replacing {
+			w.unbindTagsLocked(entityID, previous)
+			w.replaceComponentInList(componentType, entityID, component)
+			w.queueComponentEventLocked(w.componentRemoved, entityID, previous)
+		} else {
+			w.components[componentType] = append(w.components[componentType], componentEntry{entityID, component})
+		}
 		entity.Components[componentType] = component
 
-		// Add to component list
-		if w.components[componentType] == nil {
-			w.components[componentType] = make([]Component, 0)
+		// Replacing keeps the archetype; a new type moves the entity
+		if replacing {
+			w.replaceArchetypeComponentLocked(entityID, component)
+		} else {
+			w.syncArchetypeLocked(entityID)
 		}
-		w.components[componentType] = append(w.components[componentType], component)
+		w.bindTagsLocked(entityID, component)
+		w.queueComponentEventLocked(w.componentAdded, entityID, component)
+
+		// A newly added component counts as changed
+		w.markChangedLocked(entityID, componentType)
 	}
 }
 
 // RemoveComponent removes a component from an entity
 func (w *World) RemoveComponent(entityID EntityID, componentType string) {
+	defer w.dispatchComponentEvents()
 	w.mutex.Lock()
 	defer w.mutex.Unlock()
 
 	if entity, exists := w.entities[entityID]; exists {
-		if _, hasComponent := entity.Components[componentType]; hasComponent {
+		if component, hasComponent := entity.Components[componentType]; hasComponent {
+			w.unbindTagsLocked(entityID, component)
 			delete(entity.Components, componentType)
-			w.removeComponentFromList(entityID, componentType)
+			w.syncArchetypeLocked(entityID)
+			w.removeComponentFromList(componentType, entityID)
+			w.forgetVersionLocked(entityID, componentType)
+			w.queueComponentEventLocked(w.componentRemoved, entityID, component)
 		}
 	}
 }
@@ -118,26 +258,55 @@ func (w *World) GetComponent(entityID EntityID, componentType string) Component
 	return nil
 }
 
-// GetEntitiesWithComponent gets all entities that have a specific component
+// HasComponent returns true if the entity exists and has the component
+func (w *World) HasComponent(entityID EntityID, componentType string) bool {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	if entity, exists := w.entities[entityID]; exists {
+		_, hasComponent := entity.Components[componentType]
+		return hasComponent
+	}
+	return false
+}
+
+// HasComponents returns true if the entity exists and has all the components
+func (w *World) HasComponents(entityID EntityID, componentTypes ...string) bool {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	entity, exists := w.entities[entityID]
+	if !exists {
+		return false
+	}
+	for _, componentType := range componentTypes {
+		if _, hasComponent := entity.Components[componentType]; !hasComponent {
+			return false
+		}
+	}
+	return true
+}
+
+// GetEntitiesWithComponent gets all entities that have a specific component, in creation order
 func (w *World) GetEntitiesWithComponent(componentType string) []EntityID {
 	w.mutex.RLock()
 	defer w.mutex.RUnlock()
 
 	var entities []EntityID
-	for entityID, entity := range w.entities {
-		if _, hasComponent := entity.Components[componentType]; hasComponent {
+	for _, entityID := range w.order {
+		if _, hasComponent := w.entities[entityID].Components[componentType]; hasComponent {
 			entities = append(entities, entityID)
 		}
 	}
 	return entities
 }
 
-// AddSystem adds a system to the world
+// AddSystem adds a system to the update stage
 func (w *World) AddSystem(system System) {
 	w.mutex.Lock()
 	defer w.mutex.Unlock()
 
-	w.systems = append(w.systems, system)
+	w.addSystemLocked(StageUpdate, system)
 }
 
 // RemoveSystem removes a system from the world
@@ -145,24 +314,34 @@ func (w *World) RemoveSystem(systemName string) {
 	w.mutex.Lock()
 	defer w.mutex.Unlock()
 
-	for i, system := range w.systems {
-		if system.GetName() == systemName {
-			w.systems = append(w.systems[:i], w.systems[i+1:]...)
-			break
-		}
-	}
+	w.removeSystemLocked(systemName)
 }
 
-// Update updates all systems
+// Update advances the world's clock and runs all systems, stage by stage,
+// with the delta time scaled by the clock's TimeScale. See SetParallelism
+// for running systems concurrently.
 func (w *World) Update(deltaTime float64) {
-	w.mutex.RLock()
+	w.mutex.Lock()
+	deltaTime = w.time.advance(deltaTime)
+	workers := w.workers
+	var batches [][]System
 	systems := make([]System, len(w.systems))
 	copy(systems, w.systems)
-	w.mutex.RUnlock()
+	if workers > 1 {
+		batches = w.systemBatchesLocked()
+	}
+	w.mutex.Unlock()
 
-	for _, system := range systems {
-		system.Update(deltaTime, w)
+	if workers > 1 {
+		runBatches(batches, workers, deltaTime, w)
+	} else {
+		for _, system := range systems {
+			system.Update(deltaTime, w)
+		}
 	}
+
+	// Apply structural changes queued by systems
+	w.FlushCommands()
 }
 
 // GetEntityCount returns the number of entities
@@ -179,16 +358,38 @@ func (w *World) GetSystemCount() int {
 	return len(w.systems)
 }
 
-// removeComponentFromList removes a component from the component list
-func (w *World) removeComponentFromList(entityID EntityID, componentType string) {
-	if components, exists := w.components[componentType]; exists {
-		for i, component := range components {
-			// This is a simplified removal - in a real implementation,
-			// you'd want to store entity references with components
-			if component != nil {
-				w.components[componentType] = append(components[:i], components[i+1:]...)
-				break
-			}
+// componentEntry is a component in the component list and its entity.
+// Entries are matched by entity, since components may be values that can't
+// be compared.
+type componentEntry struct {
+	entityID  EntityID
+	component Component
+}
+
+// removeComponentFromList removes an entity's component from the component list
+func (w *World) removeComponentFromList(componentType string, entityID EntityID) {
+	components := w.components[componentType]
+	for i, existing := range components {
+		if existing.entityID == entityID {
+			components = append(components[:i], components[i+1:]...)
+			break
+		}
+	}
+
+	if len(components) == 0 {
+		delete(w.components, componentType)
+	} else {
+		w.components[componentType] = components
+	}
+}
+
+// replaceComponentInList swaps an entity's component in the component list for another
+func (w *World) replaceComponentInList(componentType string, entityID EntityID, component Component) {
+	for i, existing := range w.components[componentType] {
+		if existing.entityID == entityID {
+			w.components[componentType][i].component = component
+			return
 		}
 	}
+	w.components[componentType] = append(w.components[componentType], componentEntry{entityID, component})
 }
@@ -0,0 +1,137 @@
+package ecs
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestHasComponent(t *testing.T) {
+	world := NewWorld()
+	entityID := world.CreateEntity()
+	world.AddComponent(entityID, NewTransformComponent(mgl32.Vec3{}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}))
+	world.AddComponent(entityID, NewMeshComponent("cube"))
+
+	tests := []struct {
+		name     string
+		entityID EntityID
+		types    []string
+		want     bool
+	}{
+		{"present", entityID, []string{"transform"}, true},
+		{"all present", entityID, []string{"transform", "mesh"}, true},
+		{"absent", entityID, []string{"physics"}, false},
+		{"one of several absent", entityID, []string{"transform", "physics"}, false},
+		{"nonexistent entity", 999, []string{"transform"}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := world.HasComponents(test.entityID, test.types...); got != test.want {
+				t.Errorf("HasComponents(%v) = %v, want %v", test.types, got, test.want)
+			}
+			if len(test.types) == 1 {
+				if got := world.HasComponent(test.entityID, test.types[0]); got != test.want {
+					t.Errorf("HasComponent(%q) = %v, want %v", test.types[0], got, test.want)
+				}
+			}
+		})
+	}
+
+	// No types is vacuously true, but only for an entity that exists
+	if !world.HasComponents(entityID) || world.HasComponents(999) {
+		t.Error("HasComponents() with no types should report whether the entity exists")
+	}
+}
+
+func TestAddComponentReplacesExisting(t *testing.T) {
+	world := NewWorld()
+	entityID := world.CreateEntity()
+
+	first := NewTransformComponent(mgl32.Vec3{1, 0, 0}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1})
+	second := NewTransformComponent(mgl32.Vec3{2, 0, 0}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1})
+	world.AddComponent(entityID, first)
+	world.AddComponent(entityID, second)
+
+	if got := len(world.components["transform"]); got != 1 {
+		t.Fatalf("transform list has %d entries, want 1", got)
+	}
+	if world.components["transform"][0].component != second {
+		t.Error("transform list kept the replaced component")
+	}
+	if world.GetComponent(entityID, "transform") != second {
+		t.Error("GetComponent didn't return the replacement")
+	}
+
+	// Removal leaves the list empty rather than dangling
+	world.RemoveComponent(entityID, "transform")
+	if got := len(world.components["transform"]); got != 0 {
+		t.Errorf("transform list has %d entries after removal, want 0", got)
+	}
+}
+
+func TestRemoveComponentKeepsOthers(t *testing.T) {
+	world := NewWorld()
+	var transforms []*TransformComponent
+	for i := 0; i < 3; i++ {
+		entityID := world.CreateEntity()
+		transform := NewTransformComponent(mgl32.Vec3{float32(i), 0, 0}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1})
+		world.AddComponent(entityID, transform)
+		transforms = append(transforms, transform)
+	}
+
+	world.RemoveComponent(world.GetEntities()[1], "transform")
+
+	list := world.components["transform"]
+	if len(list) != 2 || list[0].component != transforms[0] || list[1].component != transforms[2] {
+		t.Errorf("transform list = %v, want the first and last transforms", list)
+	}
+}
+
+// inventoryComponent is a value component that can't be compared with ==
+type inventoryComponent struct {
+	Items []string
+}
+
+func (inventoryComponent) GetType() string {
+	return "inventory"
+}
+
+func TestValueComponentsWithSlices(t *testing.T) {
+	world := NewWorld()
+	first, second := world.CreateEntity(), world.CreateEntity()
+	world.AddComponent(first, inventoryComponent{Items: []string{"sword"}})
+	world.AddComponent(second, inventoryComponent{Items: []string{"shield"}})
+
+	// Replacing and removing match by entity rather than comparing values
+	world.AddComponent(first, inventoryComponent{Items: []string{"sword", "bow"}})
+	if got := world.GetComponent(first, "inventory").(inventoryComponent).Items; len(got) != 2 {
+		t.Errorf("replaced inventory = %v, want two items", got)
+	}
+	if got := world.GetComponentCount("inventory"); got != 2 {
+		t.Errorf("GetComponentCount(inventory) = %d after replacing, want 2", got)
+	}
+
+	world.RemoveComponent(first, "inventory")
+	world.DestroyEntity(second)
+	if got := world.GetComponentCount("inventory"); got != 0 {
+		t.Errorf("GetComponentCount(inventory) = %d after removing both, want 0", got)
+	}
+}
+
+func TestEntityIDsStartAtOne(t *testing.T) {
+	world := NewWorld()
+	if first := world.CreateEntity(); first != 1 {
+		t.Errorf("first entity ID = %d, want 1 so 0 never names an entity", first)
+	}
+
+	// Loading an empty world saved with IDs from 0 keeps 0 free
+	empty := NewWorld()
+	if err := empty.Load(strings.NewReader(`{"nextEntityID":0,"entities":[]}`)); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if id := empty.CreateEntity(); id == 0 {
+		t.Error("entity created after Load got ID 0")
+	}
+}
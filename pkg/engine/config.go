@@ -0,0 +1,87 @@
+package engine
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Defaults applied to zero-valued EngineConfig fields
+const (
+	DefaultTitle  = "Jigxel Engine"
+	DefaultWidth  = 800
+	DefaultHeight = 600
+)
+
+// DefaultClearColor is the background color used when none is configured
+var DefaultClearColor = mgl32.Vec4{0.2, 0.3, 0.3, 1.0}
+
+// EngineConfig describes how the engine creates its window. Start from
+// DefaultEngineConfig, which matches NewEngine; in the zero config the window
+// is fixed size and cleared to transparent black. An empty title or a
+// non-positive size falls back to the defaults above.
+type EngineConfig struct {
+	Title  string
+	Width  int
+	Height int
+	// Resizable lets the user resize the window
+	Resizable bool
+	VSync     bool
+	// MSAASamples is the number of multisample antialiasing samples, 0 to disable
+	MSAASamples int
+	// ClearColor is the background color
+	ClearColor mgl32.Vec4
+	// Fullscreen starts the window fullscreen on the primary monitor
+	Fullscreen bool
+	// Headless skips the window, GL context, renderer and input; the window
+	// fields above are ignored
+	Headless bool
+}
+
+// DefaultEngineConfig returns the config NewEngine uses: a resizable
+// DefaultWidth by DefaultHeight window cleared to DefaultClearColor
+func DefaultEngineConfig() EngineConfig {
+	return EngineConfig{
+		Title:      DefaultTitle,
+		Width:      DefaultWidth,
+		Height:     DefaultHeight,
+		Resizable:  true,
+		ClearColor: DefaultClearColor,
+	}
+}
+
+// withDefaults returns a copy of the config with an empty title, a
+// non-positive size or negative sample count replaced by defaults
+func (c EngineConfig) withDefaults() EngineConfig {
+	if c.Title == "" {
+		c.Title = DefaultTitle
+	}
+	if c.Width <= 0 {
+		c.Width = DefaultWidth
+	}
+	if c.Height <= 0 {
+		c.Height = DefaultHeight
+	}
+	if c.MSAASamples < 0 {
+		c.MSAASamples = 0
+	}
+	return c
+}
+
+// NewEngineWithConfig creates a new game engine instance from a config
+func NewEngineWithConfig(config EngineConfig) *Engine {
+	config = config.withDefaults()
+
+	e := &Engine{
+		title:  config.Title,
+		width:  config.Width,
+		height: config.Height,
+		config: config,
+		vsync:  config.VSync,
+
+		clearColor: config.ClearColor,
+
+		timestep:     newFixedTimestep(DefaultFixedTimestep, DefaultMaxFrameTime),
+		maxDeltaTime: DefaultMaxDeltaTime,
+	}
+	e.scenes = NewSceneManager(e)
+	return e
+}
@@ -0,0 +1,91 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestNewEngineConfigMatchesOldDefaults(t *testing.T) {
+	e := NewEngine("Game", 640, 480)
+
+	// NewEngine used to open a resizable window with GLFW's defaults and
+	// clear to (0.2, 0.3, 0.3)
+	want := DefaultEngineConfig()
+	want.Title, want.Width, want.Height = "Game", 640, 480
+	if e.config != want {
+		t.Errorf("config = %+v, want %+v", e.config, want)
+	}
+	if !e.config.Resizable {
+		t.Error("NewEngine window is fixed size, want resizable")
+	}
+	if got := e.GetClearColor(); got != (mgl32.Vec4{0.2, 0.3, 0.3, 1.0}) {
+		t.Errorf("GetClearColor() = %v, want (0.2, 0.3, 0.3, 1)", got)
+	}
+	if e.IsVSync() || e.IsHeadless() {
+		t.Errorf("IsVSync() = %v, IsHeadless() = %v; want both false", e.IsVSync(), e.IsHeadless())
+	}
+	if e.GetFixedTimestep() != DefaultFixedTimestep {
+		t.Errorf("GetFixedTimestep() = %v, want %v", e.GetFixedTimestep(), DefaultFixedTimestep)
+	}
+}
+
+func TestDefaultConfigMatchesNewEngine(t *testing.T) {
+	got := NewEngineWithConfig(DefaultEngineConfig())
+	want := NewEngine(DefaultTitle, DefaultWidth, DefaultHeight)
+	if got.config != want.config || got.GetClearColor() != want.GetClearColor() {
+		t.Errorf("default config = %+v, want %+v", got.config, want.config)
+	}
+}
+
+func TestConfigDefaults(t *testing.T) {
+	// Only the title, size and sample count fall back; the zero window is
+	// fixed size and clears to transparent black
+	got := EngineConfig{MSAASamples: -4}.withDefaults()
+	want := EngineConfig{
+		Title:  DefaultTitle,
+		Width:  DefaultWidth,
+		Height: DefaultHeight,
+	}
+	if got != want {
+		t.Errorf("withDefaults() = %+v, want %+v", got, want)
+	}
+
+	// Set fields are kept
+	config := EngineConfig{Title: "Game", Width: 1920, Height: 1080, MSAASamples: 4, VSync: true, ClearColor: mgl32.Vec4{0, 0, 1, 1}}
+	if got := config.withDefaults(); got != config {
+		t.Errorf("withDefaults() = %+v, want it unchanged", got)
+	}
+}
+
+func TestNewEngineWithConfigKeepsCallerConfig(t *testing.T) {
+	config := EngineConfig{ClearColor: mgl32.Vec4{0, 0, 1, 1}}
+	e := NewEngineWithConfig(config)
+	if config != (EngineConfig{ClearColor: mgl32.Vec4{0, 0, 1, 1}}) {
+		t.Errorf("NewEngineWithConfig changed the caller's config to %+v", config)
+	}
+
+	// Later changes on either side stay apart
+	config.ClearColor[0] = 1
+	e.SetClearColor(0, 1, 0, 1)
+	if e.config.ClearColor != (mgl32.Vec4{0, 0, 1, 1}) || config.ClearColor != (mgl32.Vec4{1, 0, 1, 1}) {
+		t.Errorf("engine config %v and caller config %v share a clear color", e.config.ClearColor, config.ClearColor)
+	}
+	if e.config.Title != DefaultTitle || e.width != DefaultWidth || e.height != DefaultHeight {
+		t.Errorf("engine window = %q %dx%d, want the defaults", e.config.Title, e.width, e.height)
+	}
+}
+
+func TestSetClearColor(t *testing.T) {
+	e := NewEngineWithConfig(EngineConfig{ClearColor: mgl32.Vec4{0, 0, 1, 1}})
+	if got := e.GetClearColor(); got != (mgl32.Vec4{0, 0, 1, 1}) {
+		t.Errorf("configured clear color = %v, want blue", got)
+	}
+
+	// render applies the stored color before each clear, so a change
+	// takes effect on the next frame
+	e.SetClearColor(0.1, 0.2, 0.3, 0.4)
+	if got, want := e.GetClearColor(), (mgl32.Vec4{0.1, 0.2, 0.3, 0.4}); got != want {
+		t.Errorf("GetClearColor() = %v, want %v", got, want)
+	}
+}
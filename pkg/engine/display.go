@@ -0,0 +1,195 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// windowRefreshRate returns the refresh rate of the monitor showing the window.
+// It is a variable so the GLFW lookup can be replaced without a display.
+var windowRefreshRate = func(window *glfw.Window) int {
+	monitor := windowMonitor(window)
+	if monitor == nil {
+		return 0
+	}
+
+	mode := monitor.GetVideoMode()
+	if mode == nil {
+		return 0
+	}
+	return mode.RefreshRate
+}
+
+// windowMonitor returns the monitor the window is on, falling back to the primary monitor
+func windowMonitor(window *glfw.Window) *glfw.Monitor {
+	if window == nil {
+		return glfw.GetPrimaryMonitor()
+	}
+
+	// Fullscreen windows know their monitor
+	if monitor := window.GetMonitor(); monitor != nil {
+		return monitor
+	}
+
+	// Windowed mode: pick the monitor containing the window's center
+	x, y := window.GetPos()
+	width, height := window.GetSize()
+	centerX := x + width/2
+	centerY := y + height/2
+
+	for _, monitor := range glfw.GetMonitors() {
+		mode := monitor.GetVideoMode()
+		if mode == nil {
+			continue
+		}
+
+		monitorX, monitorY := monitor.GetPos()
+		if centerX >= monitorX && centerX < monitorX+mode.Width &&
+			centerY >= monitorY && centerY < monitorY+mode.Height {
+			return monitor
+		}
+	}
+
+	return glfw.GetPrimaryMonitor()
+}
+
+// GetMonitorRefreshRate returns the refresh rate in Hz of the monitor showing the window, or 0 if unknown
+func (e *Engine) GetMonitorRefreshRate() int {
+	if e.config.Headless {
+		return 0
+	}
+	if !e.refreshRateValid {
+		e.refreshRate = windowRefreshRate(e.window)
+		e.refreshRateValid = true
+	}
+	return e.refreshRate
+}
+
+// invalidateRefreshRate makes the next GetMonitorRefreshRate look the rate up again
+func (e *Engine) invalidateRefreshRate() {
+	e.refreshRateValid = false
+}
+
+// SetVSync enables or disables waiting for the monitor's vertical blank on buffer swaps
+func (e *Engine) SetVSync(enabled bool) {
+	e.vsync = enabled
+	if e.window == nil {
+		return
+	}
+
+	if enabled {
+		glfw.SwapInterval(1)
+	} else {
+		glfw.SwapInterval(0)
+	}
+}
+
+// IsVSync returns true if VSync is enabled
+func (e *Engine) IsVSync() bool {
+	return e.vsync
+}
+
+// SetTargetFPS caps the frame rate in software; 0 means uncapped, or the
+// monitor refresh rate when VSync is on
+func (e *Engine) SetTargetFPS(fps int) {
+	if fps < 0 {
+		fps = 0
+	}
+	e.targetFPS = fps
+}
+
+// GetTargetFPS returns the frame rate cap; with VSync and no explicit
+// target this is the monitor refresh rate, and 0 means uncapped
+func (e *Engine) GetTargetFPS() int {
+	return effectiveTargetFPS(e.vsync, e.targetFPS, e.GetMonitorRefreshRate())
+}
+
+// effectiveTargetFPS resolves the frame rate cap from the explicit target and refresh rate
+func effectiveTargetFPS(vsync bool, targetFPS, refreshRate int) int {
+	if targetFPS > 0 {
+		return targetFPS
+	}
+	if vsync && refreshRate > 0 {
+		return refreshRate
+	}
+	return 0
+}
+
+// frameSleep returns how long to sleep so a frame that took elapsed seconds
+// lasts 1/targetFPS seconds, or 0 if uncapped or already late
+func frameSleep(targetFPS int, elapsed float64) time.Duration {
+	if targetFPS <= 0 {
+		return 0
+	}
+
+	remaining := 1/float64(targetFPS) - elapsed
+	if remaining <= 0 {
+		return 0
+	}
+	return time.Duration(remaining * float64(time.Second))
+}
+
+// paceFrame sleeps the rest of the frame to hold the frame rate cap. With
+// VSync on and no explicit target the cap is the cached refresh rate, so the
+// sleep only matters if the driver ignores the swap interval.
+func (e *Engine) paceFrame(frameStart float64) {
+	if sleep := frameSleep(e.GetTargetFPS(), glfw.GetTime()-frameStart); sleep > 0 {
+		time.Sleep(sleep)
+	}
+}
+
+// windowGeometry is the windowed position and size restored when leaving fullscreen
+type windowGeometry struct {
+	x, y          int
+	width, height int
+}
+
+// SetFullscreen switches the window between windowed mode and fullscreen on
+// the monitor it is on. The framebuffer callback picks up the size change.
+func (e *Engine) SetFullscreen(fullscreen bool) {
+	if e.window == nil || fullscreen == e.fullscreen {
+		return
+	}
+
+	if fullscreen {
+		monitor := windowMonitor(e.window)
+		if monitor == nil {
+			return
+		}
+		mode := monitor.GetVideoMode()
+		if mode == nil {
+			return
+		}
+
+		// Remember the windowed geometry to restore later
+		x, y := e.window.GetPos()
+		width, height := e.window.GetSize()
+		e.windowed = windowGeometry{x: x, y: y, width: width, height: height}
+
+		e.window.SetMonitor(monitor, 0, 0, mode.Width, mode.Height, mode.RefreshRate)
+	} else {
+		geometry := e.windowedGeometry()
+		e.window.SetMonitor(nil, geometry.x, geometry.y, geometry.width, geometry.height, 0)
+	}
+
+	e.fullscreen = fullscreen
+	e.invalidateRefreshRate()
+
+	// Changing monitors can reset the swap interval
+	e.SetVSync(e.vsync)
+}
+
+// IsFullscreen returns true if the window is fullscreen
+func (e *Engine) IsFullscreen() bool {
+	return e.fullscreen
+}
+
+// windowedGeometry returns the geometry to restore when leaving fullscreen,
+// falling back to the initial size if none was saved
+func (e *Engine) windowedGeometry() windowGeometry {
+	if e.windowed.width <= 0 || e.windowed.height <= 0 {
+		return windowGeometry{width: e.width, height: e.height}
+	}
+	return e.windowed
+}
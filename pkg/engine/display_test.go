@@ -0,0 +1,168 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// stubRefreshRate makes the monitor report rate Hz for the rest of the test
+func stubRefreshRate(t *testing.T, rate int) {
+	t.Helper()
+
+	original := windowRefreshRate
+	windowRefreshRate = func(window *glfw.Window) int {
+		return rate
+	}
+	t.Cleanup(func() {
+		windowRefreshRate = original
+	})
+}
+
+func TestRefreshRateIsDefaultCap(t *testing.T) {
+	stubRefreshRate(t, 144)
+	e := NewEngineWithConfig(EngineConfig{VSync: true})
+
+	if got := e.GetMonitorRefreshRate(); got != 144 {
+		t.Errorf("GetMonitorRefreshRate() = %d, want 144", got)
+	}
+	if got := e.GetTargetFPS(); got != 144 {
+		t.Errorf("GetTargetFPS() with VSync = %d, want the 144 Hz refresh rate", got)
+	}
+
+	// An explicit target wins over the refresh rate
+	e.SetTargetFPS(30)
+	if got := e.GetTargetFPS(); got != 30 {
+		t.Errorf("GetTargetFPS() = %d, want the explicit 30", got)
+	}
+
+	// Without VSync and a target the frame rate is uncapped
+	e.SetTargetFPS(0)
+	e.SetVSync(false)
+	if got := e.GetTargetFPS(); got != 0 {
+		t.Errorf("GetTargetFPS() without VSync = %d, want 0", got)
+	}
+}
+
+func TestUnknownRefreshRateIsUncapped(t *testing.T) {
+	stubRefreshRate(t, 0)
+	e := NewEngineWithConfig(EngineConfig{VSync: true})
+
+	if got := e.GetTargetFPS(); got != 0 {
+		t.Errorf("GetTargetFPS() = %d, want 0 when the refresh rate is unknown", got)
+	}
+}
+
+func TestRefreshRateIsCached(t *testing.T) {
+	rate, lookups := 60, 0
+	original := windowRefreshRate
+	windowRefreshRate = func(window *glfw.Window) int {
+		lookups++
+		return rate
+	}
+	t.Cleanup(func() {
+		windowRefreshRate = original
+	})
+	e := NewEngineWithConfig(EngineConfig{VSync: true})
+
+	// Pacing every frame doesn't query the monitor again
+	for i := 0; i < 3; i++ {
+		e.GetTargetFPS()
+	}
+	if lookups != 1 {
+		t.Errorf("refresh rate looked up %d times, want 1", lookups)
+	}
+
+	// Moving to a 144 Hz monitor is picked up on the next lookup
+	rate = 144
+	e.invalidateRefreshRate()
+	if got := e.GetTargetFPS(); got != 144 {
+		t.Errorf("GetTargetFPS() after moving monitor = %d, want 144", got)
+	}
+	if lookups != 2 {
+		t.Errorf("refresh rate looked up %d times, want 2", lookups)
+	}
+}
+
+func TestHeadlessHasNoRefreshRate(t *testing.T) {
+	stubRefreshRate(t, 60)
+	e := NewHeadlessEngine()
+
+	if got := e.GetMonitorRefreshRate(); got != 0 {
+		t.Errorf("headless GetMonitorRefreshRate() = %d, want 0", got)
+	}
+}
+
+func TestFrameSleep(t *testing.T) {
+	tests := []struct {
+		name      string
+		targetFPS int
+		elapsed   float64
+		want      time.Duration
+	}{
+		{"uncapped", 0, 0.001, 0},
+		{"half the frame left", 50, 0.010, 10 * time.Millisecond},
+		{"just started", 100, 0, 10 * time.Millisecond},
+		{"exactly on time", 100, 0.010, 0},
+		{"late", 60, 0.030, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := frameSleep(test.targetFPS, test.elapsed)
+			if diff := got - test.want; diff < -time.Microsecond || diff > time.Microsecond {
+				t.Errorf("frameSleep(%d, %v) = %v, want %v", test.targetFPS, test.elapsed, got, test.want)
+			}
+		})
+	}
+}
+
+func TestEffectiveTargetFPS(t *testing.T) {
+	tests := []struct {
+		vsync                  bool
+		targetFPS, refreshRate int
+		want                   int
+	}{
+		{false, 0, 60, 0},
+		{false, 30, 60, 30},
+		{true, 0, 75, 75},
+		{true, 120, 75, 120},
+		{true, 0, 0, 0},
+	}
+
+	for _, test := range tests {
+		if got := effectiveTargetFPS(test.vsync, test.targetFPS, test.refreshRate); got != test.want {
+			t.Errorf("effectiveTargetFPS(%v, %d, %d) = %d, want %d", test.vsync, test.targetFPS, test.refreshRate, got, test.want)
+		}
+	}
+}
+
+func TestWindowedGeometry(t *testing.T) {
+	e := NewEngineWithConfig(EngineConfig{Width: 1024, Height: 768})
+
+	// Nothing saved yet: restore to the configured size
+	if got, want := e.windowedGeometry(), (windowGeometry{width: 1024, height: 768}); got != want {
+		t.Errorf("windowedGeometry() = %+v, want %+v", got, want)
+	}
+
+	// The geometry saved on entering fullscreen is restored
+	e.windowed = windowGeometry{x: 40, y: 60, width: 640, height: 480}
+	if got := e.windowedGeometry(); got != e.windowed {
+		t.Errorf("windowedGeometry() = %+v, want the saved %+v", got, e.windowed)
+	}
+
+	// A degenerate saved size, as reported by a minimized window, isn't used
+	e.windowed = windowGeometry{x: 40, y: 60}
+	if got, want := e.windowedGeometry(), (windowGeometry{width: 1024, height: 768}); got != want {
+		t.Errorf("windowedGeometry() = %+v, want %+v", got, want)
+	}
+}
+
+func TestFullscreenWithoutWindow(t *testing.T) {
+	e := NewHeadlessEngine()
+	e.SetFullscreen(true)
+	if e.IsFullscreen() {
+		t.Error("an engine without a window went fullscreen")
+	}
+}
@@ -3,6 +3,7 @@ package engine
 import (
 	"log"
 	"runtime"
+	"sync/atomic"
 
 	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
 	"github.com/aminasadiam/jigxel-engine/pkg/graphics"
@@ -10,6 +11,7 @@ import (
 	"github.com/aminasadiam/jigxel-engine/pkg/physics"
 	"github.com/go-gl/gl/v4.1-core/gl"
 	"github.com/go-gl/glfw/v3.3/glfw"
+	"github.com/go-gl/mathgl/mgl32"
 )
 
 // Engine represents the main game engine
@@ -18,8 +20,47 @@ type Engine struct {
 	title    string
 	width    int
 	height   int
-	running  bool
 	lastTime float64
+	config   EngineConfig
+
+	// Cleared by Stop, possibly from another goroutine, to end Run
+	running atomic.Bool
+
+	// Background color applied every frame
+	clearColor mgl32.Vec4
+
+	// Frame pacing; the refresh rate is looked up once and cached until
+	// the window changes monitor or display mode
+	vsync            bool
+	targetFPS        int
+	refreshRate      int
+	refreshRateValid bool
+
+	// Display mode
+	fullscreen bool
+	windowed   windowGeometry
+
+	// Fixed physics timestep
+	timestep *fixedTimestep
+	alpha    float64
+
+	// Frame time clamp and pause flag applied by Step
+	maxDeltaTime float64
+	paused       bool
+
+	// Frame statistics
+	frameStats    frameStats
+	titleShowsFPS bool
+	titleElapsed  float64
+
+	// Draw physics bodies and contacts as debug lines
+	physicsDebugDraw bool
+
+	// Called with the new framebuffer size after a resize
+	resizeListeners []ResizeListener
+
+	// Scene stack; the default world is used while it is empty
+	scenes *SceneManager
 
 	// Systems
 	ecs      *ecs.World
@@ -28,18 +69,21 @@ type Engine struct {
 	physics  *physics.World
 }
 
-// NewEngine creates a new game engine instance
+// NewEngine creates a new resizable game engine instance
 func NewEngine(title string, width, height int) *Engine {
-	return &Engine{
-		title:   title,
-		width:   width,
-		height:  height,
-		running: false,
-	}
+	config := DefaultEngineConfig()
+	config.Title = title
+	config.Width = width
+	config.Height = height
+	return NewEngineWithConfig(config)
 }
 
 // Init initializes the game engine and all its systems
 func (e *Engine) Init() error {
+	if e.config.Headless {
+		return e.initHeadless()
+	}
+
 	// Lock the main thread for OpenGL
 	runtime.LockOSThread()
 
@@ -53,6 +97,10 @@ func (e *Engine) Init() error {
 	glfw.WindowHint(glfw.ContextVersionMinor, 1)
 	glfw.WindowHint(glfw.OpenGLProfile, glfw.OpenGLCoreProfile)
 	glfw.WindowHint(glfw.OpenGLForwardCompatible, glfw.True)
+	glfw.WindowHint(glfw.Resizable, glfwBool(e.config.Resizable))
+	if e.config.MSAASamples > 0 {
+		glfw.WindowHint(glfw.Samples, e.config.MSAASamples)
+	}
 
 	// Create window
 	window, err := glfw.CreateWindow(e.width, e.height, e.title, nil, nil)
@@ -65,6 +113,9 @@ func (e *Engine) Init() error {
 	// Make the window's context current
 	e.window.MakeContextCurrent()
 
+	// Apply the swap interval now that a context exists
+	e.SetVSync(e.vsync)
+
 	// Initialize OpenGL
 	if err := gl.Init(); err != nil {
 		return err
@@ -73,6 +124,10 @@ func (e *Engine) Init() error {
 	// Set viewport
 	gl.Viewport(0, 0, int32(e.width), int32(e.height))
 
+	if e.config.MSAASamples > 0 {
+		gl.Enable(gl.MULTISAMPLE)
+	}
+
 	// Initialize systems
 	e.ecs = ecs.NewWorld()
 	e.renderer = graphics.NewRenderer()
@@ -83,6 +138,9 @@ func (e *Engine) Init() error {
 	if err := e.renderer.Init(); err != nil {
 		return err
 	}
+	e.scenes.trackMeshBounds(e.ecs)
+	framebufferWidth, framebufferHeight := e.window.GetFramebufferSize()
+	e.renderer.SetViewportSize(framebufferWidth, framebufferHeight)
 
 	// Initialize input manager
 	if err := e.input.Init(); err != nil {
@@ -92,57 +150,119 @@ func (e *Engine) Init() error {
 	// Set up window callbacks
 	e.setupCallbacks()
 
+	// Switch after the callbacks so the renderer sees the fullscreen size
+	if e.config.Fullscreen {
+		e.SetFullscreen(true)
+	}
+
 	log.Println("Engine initialized successfully")
 	return nil
 }
 
-// Run starts the main game loop
+// Run starts the main game loop. A headless engine steps at the fixed
+// timestep until Stop is called.
 func (e *Engine) Run() {
-	e.running = true
+	e.running.Store(true)
+	if e.config.Headless {
+		e.runHeadless()
+		return
+	}
 	e.lastTime = glfw.GetTime()
 
-	for !e.window.ShouldClose() && e.running {
+	for !e.window.ShouldClose() && e.running.Load() {
 		currentTime := glfw.GetTime()
 		deltaTime := currentTime - e.lastTime
 		e.lastTime = currentTime
 
-		// Update systems
-		e.update(deltaTime)
+		// Snapshot last frame's input, then gather this frame's events
+		e.input.Update()
+		glfw.PollEvents()
 
-		// Render
-		e.render()
+		// Update and render
+		e.Step(deltaTime)
 
-		// Poll events and swap buffers
-		glfw.PollEvents()
+		// Swap buffers
 		e.window.SwapBuffers()
+
+		// Cap the frame rate when VSync isn't doing it
+		e.paceFrame(currentTime)
+	}
+}
+
+// Step runs one frame of deltaTime seconds: it records frame timing, updates
+// physics and the ECS, and renders unless the engine is headless. Run calls
+// it every frame; tests and servers can call it directly with controlled
+// frame times. The frame time is clamped to the max delta time, and nothing
+// updates while paused.
+func (e *Engine) Step(deltaTime float64) {
+	// Track the real frame timing
+	e.recordFrame(deltaTime)
+
+	// Update systems
+	if !e.paused {
+		e.update(clampDeltaTime(deltaTime, e.maxDeltaTime))
+	}
+
+	// Render
+	if !e.config.Headless {
+		e.render()
 	}
 }
 
 // update updates all engine systems
 func (e *Engine) update(deltaTime float64) {
-	// Update input
-	e.input.Update()
+	// Step physics at a fixed rate
+	e.stepPhysics(deltaTime)
+
+	// Update the active scene, or the default world without one
+	if scene := e.scenes.Current(); scene != nil {
+		scene.Update(deltaTime)
+	} else {
+		e.ecs.Update(deltaTime)
+	}
+}
 
-	// Update physics
-	e.physics.Update(deltaTime)
+// stepPhysics runs as many fixed physics steps as the frame time allows and
+// returns the number of steps taken. The frame time is scaled by the active
+// world's TimeScale, so physics slows down and freezes along with systems.
+func (e *Engine) stepPhysics(frameTime float64) int {
+	steps, alpha := e.timestep.advance(frameTime * e.GetECS().GetTimeScale())
+	for i := 0; i < steps; i++ {
+		e.GetPhysics().Update(e.timestep.step)
+		e.syncPhysicsTransforms()
+	}
 
-	// Update ECS world
-	e.ecs.Update(deltaTime)
+	e.alpha = alpha
+	return steps
 }
 
 // render renders the current frame
 func (e *Engine) render() {
-	// Clear the screen
+	// Clear the screen. The clear color must be set before gl.Clear, which
+	// uses whatever color is current at the time of the call.
+	gl.ClearColor(e.clearColor[0], e.clearColor[1], e.clearColor[2], e.clearColor[3])
 	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
-	gl.ClearColor(0.2, 0.3, 0.3, 1.0)
+
+	// Queue physics debug lines before the renderer draws its debug pass
+	if e.physicsDebugDraw {
+		e.renderer.DrawLines(PhysicsDebugLines(e.GetPhysics()))
+	}
+
+	// Upload assets decoded in the background since the last frame
+	e.renderer.ProcessUploads(0)
 
 	// Render the scene
-	e.renderer.Render(e.ecs)
+	e.renderer.SetInterpolationAlpha(float32(e.alpha))
+	if scene := e.scenes.Current(); scene != nil {
+		scene.Render(e.renderer)
+	} else {
+		e.renderer.Render(e.ecs)
+	}
 }
 
 // Shutdown cleans up the engine and all its resources
 func (e *Engine) Shutdown() {
-	e.running = false
+	e.running.Store(false)
 
 	// Shutdown systems
 	if e.renderer != nil {
@@ -153,7 +273,9 @@ func (e *Engine) Shutdown() {
 	if e.window != nil {
 		e.window.Destroy()
 	}
-	glfw.Terminate()
+	if !e.config.Headless {
+		glfw.Terminate()
+	}
 
 	log.Println("Engine shutdown complete")
 }
@@ -162,15 +284,65 @@ func (e *Engine) Shutdown() {
 func (e *Engine) setupCallbacks() {
 	e.window.SetFramebufferSizeCallback(func(w *glfw.Window, width int, height int) {
 		gl.Viewport(0, 0, int32(width), int32(height))
-		e.width = width
-		e.height = height
+		e.resize(width, height)
 	})
 
-	e.window.SetKeyCallback(func(w *glfw.Window, key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
-		if key == glfw.KeyEscape && action == glfw.Press {
-			e.window.SetShouldClose(true)
-		}
+	// Moving the window or plugging in a monitor can change the refresh rate
+	e.window.SetPosCallback(func(w *glfw.Window, x int, y int) {
+		e.invalidateRefreshRate()
+	})
+	glfw.SetMonitorCallback(func(monitor *glfw.Monitor, event glfw.PeripheralEvent) {
+		e.invalidateRefreshRate()
 	})
+
+	// Replaces the input manager's key callback, so keyCallback forwards to it
+	e.window.SetKeyCallback(e.keyCallback)
+}
+
+// keyCallback passes key events to the input manager and closes the window
+// on Escape
+func (e *Engine) keyCallback(w *glfw.Window, key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
+	if e.input != nil {
+		e.input.HandleKey(key, scancode, action, mods)
+	}
+
+	if key == glfw.KeyEscape && action == glfw.Press && e.window != nil {
+		e.window.SetShouldClose(true)
+	}
+}
+
+// ResizeListener is called with the new framebuffer size in pixels
+type ResizeListener func(width, height int)
+
+// OnResize registers a listener called whenever the framebuffer is resized,
+// after the renderer's viewport and camera aspect have been updated. A
+// minimized window reports a size of zero.
+func (e *Engine) OnResize(listener ResizeListener) {
+	e.resizeListeners = append(e.resizeListeners, listener)
+}
+
+// resize records a new framebuffer size, passes it to the renderer and
+// notifies resize listeners
+func (e *Engine) resize(width, height int) {
+	e.width = width
+	e.height = height
+	if e.renderer != nil {
+		e.renderer.SetViewportSize(width, height)
+	}
+
+	for _, listener := range e.resizeListeners {
+		listener(width, height)
+	}
+}
+
+// SetClearColor sets the background color the screen is cleared to each frame
+func (e *Engine) SetClearColor(r, g, b, a float32) {
+	e.clearColor = mgl32.Vec4{r, g, b, a}
+}
+
+// GetClearColor returns the background color
+func (e *Engine) GetClearColor() mgl32.Vec4 {
+	return e.clearColor
 }
 
 // GetWindow returns the GLFW window
@@ -178,22 +350,42 @@ func (e *Engine) GetWindow() *glfw.Window {
 	return e.window
 }
 
-// GetECS returns the ECS world
+// GetECS returns the world of the active scene, or the default world if no scene is active
 func (e *Engine) GetECS() *ecs.World {
+	if scene := e.scenes.Current(); scene != nil {
+		return scene.GetWorld()
+	}
 	return e.ecs
 }
 
-// GetRenderer returns the renderer
+// GetScenes returns the scene stack
+func (e *Engine) GetScenes() *SceneManager {
+	return e.scenes
+}
+
+// GetRenderer returns the renderer, or nil for a headless engine
 func (e *Engine) GetRenderer() *graphics.Renderer {
 	return e.renderer
 }
 
-// GetInput returns the input manager
+// GetInput returns the input manager, or nil for a headless engine
 func (e *Engine) GetInput() *input.Manager {
 	return e.input
 }
 
-// GetPhysics returns the physics world
+// GetPhysics returns the physics world of the active scene, or the default
+// physics world if no scene is active
 func (e *Engine) GetPhysics() *physics.World {
+	if world := e.scenes.currentPhysics(); world != nil {
+		return world
+	}
 	return e.physics
 }
+
+// glfwBool converts a bool to a GLFW hint value
+func glfwBool(value bool) int {
+	if value {
+		return glfw.True
+	}
+	return glfw.False
+}
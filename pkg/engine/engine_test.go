@@ -0,0 +1,110 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/aminasadiam/jigxel-engine/pkg/graphics"
+	"github.com/aminasadiam/jigxel-engine/pkg/input"
+	"github.com/aminasadiam/jigxel-engine/pkg/physics"
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// deltaRecorder is a system that records the delta times it is updated with
+type deltaRecorder struct {
+	deltas []float64
+}
+
+func (r *deltaRecorder) Update(deltaTime float64, world *ecs.World) {
+	r.deltas = append(r.deltas, deltaTime)
+}
+
+func (r *deltaRecorder) GetName() string {
+	return "deltaRecorder"
+}
+
+func TestStepUpdatesSystemsAndPhysics(t *testing.T) {
+	e := newHeadlessTestEngine(t)
+	e.SetFixedTimestep(1.0 / 32)
+	body := physics.NewRigidBody(1, physics.Vector2{X: 0, Y: 0}, 1, 1, 1)
+	e.GetPhysics().AddBody(body)
+	recorder := &deltaRecorder{}
+	e.GetECS().AddSystem(recorder)
+
+	// Headless steps skip rendering, so no GL context is needed
+	e.Step(2.0 / 32)
+	e.Step(3.0 / 32)
+
+	if len(recorder.deltas) != 2 || !near(recorder.deltas[0], 2.0/32) || !near(recorder.deltas[1], 3.0/32) {
+		t.Errorf("systems saw deltas %v, want [0.0625 0.09375]", recorder.deltas)
+	}
+
+	// Five fixed steps of free fall
+	if want := -9.81 * 5 / 32; !near(body.Velocity.Y, want) {
+		t.Errorf("body velocity = %v, want %v after five steps of gravity", body.Velocity.Y, want)
+	}
+}
+
+func TestResizeNotifiesListeners(t *testing.T) {
+	e := newHeadlessTestEngine(t)
+	e.renderer = graphics.NewRenderer()
+
+	var sizes [][2]int
+	e.OnResize(func(width, height int) {
+		// The renderer is updated before listeners run
+		if aspect := e.renderer.GetCamera().Aspect; height > 0 && aspect != float32(width)/float32(height) {
+			t.Errorf("listener saw camera aspect %v for %dx%d", aspect, width, height)
+		}
+		sizes = append(sizes, [2]int{width, height})
+	})
+	calls := 0
+	e.OnResize(func(width, height int) {
+		calls++
+	})
+
+	e.resize(1280, 720)
+	e.resize(0, 0)
+
+	if len(sizes) != 2 || sizes[0] != [2]int{1280, 720} || sizes[1] != [2]int{0, 0} {
+		t.Errorf("listener got sizes %v, want [[1280 720] [0 0]]", sizes)
+	}
+	if calls != 2 {
+		t.Errorf("second listener called %d times, want 2", calls)
+	}
+	if e.width != 0 || e.height != 0 {
+		t.Errorf("engine size = %dx%d, want the last resize", e.width, e.height)
+	}
+
+	// A minimized window keeps the last aspect
+	if aspect := e.renderer.GetCamera().Aspect; aspect != 1280.0/720.0 {
+		t.Errorf("camera aspect = %v, want %v", aspect, 1280.0/720.0)
+	}
+}
+
+func TestKeyCallbackForwardsToInput(t *testing.T) {
+	e := newHeadlessTestEngine(t)
+	e.input = input.NewManager(nil)
+	e.input.BindAction("jump", glfw.KeySpace)
+
+	// Events reach the manager through the callback the engine installs
+	e.input.Update()
+	e.keyCallback(nil, glfw.KeySpace, 0, glfw.Press, 0)
+	if !e.input.IsActionPressed("jump") || !e.input.IsActionJustPressed("jump") {
+		t.Error("pressing a bound key through the engine didn't trigger its action")
+	}
+
+	e.input.Update()
+	e.keyCallback(nil, glfw.KeySpace, 0, glfw.Repeat, 0)
+	if !e.input.IsKeyRepeating(glfw.KeySpace) {
+		t.Error("a repeat through the engine didn't reach the manager")
+	}
+	if e.input.IsActionJustPressed("jump") {
+		t.Error("a repeat fired the action as just pressed")
+	}
+
+	e.input.Update()
+	e.keyCallback(nil, glfw.KeySpace, 0, glfw.Release, 0)
+	if e.input.IsActionPressed("jump") || !e.input.IsActionJustReleased("jump") {
+		t.Error("releasing the key through the engine didn't release the action")
+	}
+}
@@ -0,0 +1,51 @@
+package engine
+
+import (
+	"log"
+	"time"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/aminasadiam/jigxel-engine/pkg/physics"
+)
+
+// NewHeadlessEngine creates an engine without a window, GL context, renderer
+// or input, for servers and tests. It runs the ECS and physics worlds only.
+func NewHeadlessEngine() *Engine {
+	config := DefaultEngineConfig()
+	config.Headless = true
+	return NewEngineWithConfig(config)
+}
+
+// IsHeadless returns true if the engine runs without a window
+func (e *Engine) IsHeadless() bool {
+	return e.config.Headless
+}
+
+// initHeadless creates the worlds that don't need a GL context
+func (e *Engine) initHeadless() error {
+	e.ecs = ecs.NewWorld()
+	e.physics = physics.NewWorld()
+
+	log.Println("Engine initialized headless")
+	return nil
+}
+
+// runHeadless steps the simulation at the fixed timestep in real time until Stop is called
+func (e *Engine) runHeadless() {
+	start := time.Now()
+	for steps := 1; e.running.Load(); steps++ {
+		e.Step(e.timestep.step)
+
+		// Schedule against the start time so sleep overshoot doesn't accumulate
+		next := start.Add(time.Duration(float64(steps) * e.timestep.step * float64(time.Second)))
+		if sleep := time.Until(next); sleep > 0 {
+			time.Sleep(sleep)
+		}
+	}
+}
+
+// Stop makes Run return after the current frame. It is safe to call from
+// any goroutine.
+func (e *Engine) Stop() {
+	e.running.Store(false)
+}
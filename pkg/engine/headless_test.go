@@ -0,0 +1,108 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/aminasadiam/jigxel-engine/pkg/physics"
+)
+
+// stopAfter is a system that stops the engine after a number of updates
+type stopAfter struct {
+	engine  *Engine
+	updates int
+	limit   int
+}
+
+func (s *stopAfter) Update(deltaTime float64, world *ecs.World) {
+	s.updates++
+	if s.updates == s.limit {
+		s.engine.Stop()
+	}
+}
+
+func (s *stopAfter) GetName() string {
+	return "stopAfter"
+}
+
+func TestHeadlessInit(t *testing.T) {
+	e := newHeadlessTestEngine(t)
+
+	if !e.IsHeadless() {
+		t.Error("IsHeadless() = false")
+	}
+	if e.GetECS() == nil || e.GetPhysics() == nil {
+		t.Fatal("headless engine has no ECS or physics world")
+	}
+	if e.GetWindow() != nil || e.GetRenderer() != nil || e.GetInput() != nil {
+		t.Error("headless engine created a window, renderer or input manager")
+	}
+}
+
+func TestHeadlessPhysicsAdvances(t *testing.T) {
+	e := newHeadlessTestEngine(t)
+	body := physics.NewRigidBody(1, physics.Vector2{X: 0, Y: 10}, 1, 1, 1)
+	e.GetPhysics().AddBody(body)
+
+	stepFrames(e, 30)
+
+	if body.Position.Y >= 10 || body.Velocity.Y >= 0 {
+		t.Errorf("after 30 steps body is at %v moving %v, want it falling", body.Position, body.Velocity)
+	}
+}
+
+func TestHeadlessRunUntilStop(t *testing.T) {
+	e := newHeadlessTestEngine(t)
+	body := physics.NewRigidBody(1, physics.Vector2{X: 0, Y: 10}, 1, 1, 1)
+	e.GetPhysics().AddBody(body)
+
+	stopper := &stopAfter{engine: e, limit: 5}
+	e.GetECS().AddSystem(stopper)
+
+	// Run returns once the system stops the engine
+	e.Run()
+
+	if stopper.updates != 5 {
+		t.Errorf("systems updated %d times, want 5", stopper.updates)
+	}
+	if body.Position.Y >= 10 {
+		t.Errorf("body at %v, want physics to have run", body.Position)
+	}
+}
+
+// started is a system that signals once it has been updated
+type started chan struct{}
+
+func (s started) Update(deltaTime float64, world *ecs.World) {
+	select {
+	case s <- struct{}{}:
+	default:
+	}
+}
+
+func (s started) GetName() string {
+	return "started"
+}
+
+// TestHeadlessStopFromAnotherGoroutine stops a running engine from outside
+// the loop. Run it with -race.
+func TestHeadlessStopFromAnotherGoroutine(t *testing.T) {
+	e := newHeadlessTestEngine(t)
+	running := make(started, 1)
+	e.GetECS().AddSystem(running)
+
+	done := make(chan struct{})
+	go func() {
+		e.Run()
+		close(done)
+	}()
+
+	<-running
+	e.Stop()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Run didn't return after Stop")
+	}
+}
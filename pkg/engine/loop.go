@@ -0,0 +1,137 @@
+package engine
+
+import (
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// DefaultFixedTimestep is the default physics step in seconds
+const DefaultFixedTimestep = 1.0 / 60.0
+
+// DefaultMaxFrameTime caps the frame time fed to the accumulator so a lag
+// spike can't trigger an ever-growing number of physics steps
+const DefaultMaxFrameTime = 0.25
+
+// DefaultMaxDeltaTime is the longest frame time passed to systems, so a
+// breakpoint or window drag doesn't turn into one huge update
+const DefaultMaxDeltaTime = 0.1
+
+// clampDeltaTime limits a raw frame time to [0, maxDeltaTime]
+func clampDeltaTime(deltaTime, maxDeltaTime float64) float64 {
+	if deltaTime > maxDeltaTime {
+		return maxDeltaTime
+	}
+	if deltaTime < 0 {
+		return 0
+	}
+	return deltaTime
+}
+
+// fixedTimestep accumulates variable frame times into fixed-size steps
+type fixedTimestep struct {
+	step         float64
+	maxFrameTime float64
+	accumulator  float64
+}
+
+// newFixedTimestep creates an accumulator with the given step and frame time cap
+func newFixedTimestep(step, maxFrameTime float64) *fixedTimestep {
+	return &fixedTimestep{
+		step:         step,
+		maxFrameTime: maxFrameTime,
+	}
+}
+
+// advance adds a frame's time and returns how many fixed steps to run and
+// the interpolation alpha between the last two steps
+func (f *fixedTimestep) advance(frameTime float64) (int, float64) {
+	if frameTime > f.maxFrameTime {
+		frameTime = f.maxFrameTime
+	}
+	if frameTime < 0 {
+		frameTime = 0
+	}
+
+	f.accumulator += frameTime
+
+	steps := 0
+	for f.accumulator >= f.step {
+		f.accumulator -= f.step
+		steps++
+	}
+
+	return steps, f.accumulator / f.step
+}
+
+// SetFixedTimestep sets the physics step in seconds
+func (e *Engine) SetFixedTimestep(step float64) {
+	if step <= 0 {
+		return
+	}
+	e.timestep.step = step
+}
+
+// GetFixedTimestep returns the physics step in seconds
+func (e *Engine) GetFixedTimestep() float64 {
+	return e.timestep.step
+}
+
+// SetMaxFrameTime sets the longest frame time the loop will simulate
+func (e *Engine) SetMaxFrameTime(maxFrameTime float64) {
+	if maxFrameTime <= 0 {
+		return
+	}
+	e.timestep.maxFrameTime = maxFrameTime
+}
+
+// SetMaxDeltaTime sets the longest frame time Step passes on to physics and systems
+func (e *Engine) SetMaxDeltaTime(maxDeltaTime float64) {
+	if maxDeltaTime <= 0 {
+		return
+	}
+	e.maxDeltaTime = maxDeltaTime
+}
+
+// GetMaxDeltaTime returns the longest frame time Step passes on
+func (e *Engine) GetMaxDeltaTime() float64 {
+	return e.maxDeltaTime
+}
+
+// SetPaused freezes physics and systems while paused; the last frame keeps rendering
+func (e *Engine) SetPaused(paused bool) {
+	e.paused = paused
+}
+
+// syncPhysicsTransforms steps the transforms of entities with an active
+// physics component to their bodies in the x-y plane, so rendering can
+// interpolate between the last two physics steps
+func (e *Engine) syncPhysicsTransforms() {
+	world := e.GetECS()
+	world.ForEach([]string{"physics", "transform"}, func(entityID ecs.EntityID, components []ecs.Component) {
+		component, ok := components[0].(*ecs.PhysicsComponent)
+		if !ok || !component.Active {
+			return
+		}
+		transform, ok := components[1].(*ecs.TransformComponent)
+		if !ok {
+			return
+		}
+		body := e.GetPhysics().GetBody(component.BodyID)
+		if body == nil {
+			return
+		}
+
+		transform.StepTo(mgl32.Vec3{float32(body.Position.X), float32(body.Position.Y), transform.Position.Z()})
+	})
+}
+
+// IsPaused returns true if updates are frozen
+func (e *Engine) IsPaused() bool {
+	return e.paused
+}
+
+// GetInterpolationAlpha returns how far the current frame is between the
+// last physics step and the next, in [0, 1)
+func (e *Engine) GetInterpolationAlpha() float64 {
+	return e.alpha
+}
@@ -0,0 +1,182 @@
+package engine
+
+import (
+	"math"
+	"testing"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/aminasadiam/jigxel-engine/pkg/physics"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestFixedTimestepCapsHugeDelta(t *testing.T) {
+	timestep := newFixedTimestep(0.125, 1)
+
+	// A ten second stall only simulates the capped second
+	steps, alpha := timestep.advance(10)
+	if steps != 8 {
+		t.Errorf("steps = %d, want 8", steps)
+	}
+	if alpha < 0 || alpha >= 1 {
+		t.Errorf("alpha = %v, want it in [0, 1)", alpha)
+	}
+
+	// Negative frame times are ignored
+	if steps, _ := timestep.advance(-1); steps != 0 {
+		t.Errorf("negative frame time ran %d steps", steps)
+	}
+}
+
+func TestFixedTimestepAccumulates(t *testing.T) {
+	timestep := newFixedTimestep(0.1, 0.25)
+
+	frames := []struct {
+		frameTime float64
+		steps     int
+		alpha     float64
+	}{
+		{0.05, 0, 0.5},
+		{0.05, 1, 0},
+		{0.23, 2, 0.3},
+		{0.08, 1, 0.1},
+	}
+	for i, frame := range frames {
+		steps, alpha := timestep.advance(frame.frameTime)
+		if steps != frame.steps || math.Abs(alpha-frame.alpha) > 1e-9 {
+			t.Errorf("frame %d: advance(%v) = %d, %v; want %d, %v", i, frame.frameTime, steps, alpha, frame.steps, frame.alpha)
+		}
+	}
+}
+
+func TestStepPhysicsIsCapped(t *testing.T) {
+	e := newWindowlessTestEngine()
+	e.SetFixedTimestep(0.125)
+	e.SetMaxFrameTime(0.5)
+
+	if steps := e.stepPhysics(60); steps != 4 {
+		t.Errorf("stepPhysics(60) ran %d steps, want 4", steps)
+	}
+}
+
+func TestStepPhysicsFollowsTimeScale(t *testing.T) {
+	e := newHeadlessTestEngine(t)
+	body := physics.NewRigidBody(1, physics.Vector2{X: 0, Y: 10}, 1, 1, 1)
+	e.GetPhysics().AddBody(body)
+
+	// A frozen world freezes its physics too
+	e.GetECS().SetTimeScale(0)
+	stepFrames(e, 30)
+	if body.Position != (physics.Vector2{X: 0, Y: 10}) || body.Velocity != (physics.Vector2{}) {
+		t.Errorf("physics ran at TimeScale 0: body at %v moving %v", body.Position, body.Velocity)
+	}
+
+	// At half speed two frames make one fixed step
+	e.GetECS().SetTimeScale(0.5)
+	if got := e.GetECS().GetTimeScale(); got != 0.5 {
+		t.Fatalf("GetTimeScale() = %v, want 0.5", got)
+	}
+	if steps := e.stepPhysics(e.GetFixedTimestep()) + e.stepPhysics(e.GetFixedTimestep()); steps != 1 {
+		t.Errorf("two frames at TimeScale 0.5 ran %d physics steps, want 1", steps)
+	}
+	if body.Velocity.Y >= 0 {
+		t.Errorf("body velocity %v after resuming, want it falling", body.Velocity)
+	}
+}
+
+func TestPhysicsTransformsInterpolate(t *testing.T) {
+	e := newWindowlessTestEngine()
+	e.GetPhysics().SetGravity(physics.Vector2{})
+	body := physics.NewRigidBody(1, physics.Vector2{X: 0, Y: 0}, 1, 1, 1)
+	body.Velocity = physics.Vector2{X: 60, Y: 0}
+	e.GetPhysics().AddBody(body)
+
+	entityID := e.GetECS().NewEntity().
+		WithTransform(mgl32.Vec3{0, 0, 5}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}).
+		With(ecs.NewPhysicsComponent(1, 1)).
+		Build()
+	transform := e.GetECS().GetComponent(entityID, "transform").(*ecs.TransformComponent)
+
+	// One and a half steps: the body moved one unit, the frame is half way to the next step
+	e.stepPhysics(1.5 / 60)
+
+	if got, want := transform.Position, (mgl32.Vec3{1, 0, 5}); got != want {
+		t.Errorf("transform position = %v, want %v", got, want)
+	}
+	if alpha := e.GetInterpolationAlpha(); math.Abs(alpha-0.5) > 1e-9 {
+		t.Errorf("alpha = %v, want 0.5", alpha)
+	}
+	if got, want := transform.InterpolatedPosition(float32(e.GetInterpolationAlpha())), (mgl32.Vec3{0.5, 0, 5}); got.Sub(want).Len() > 1e-5 {
+		t.Errorf("interpolated position = %v, want %v", got, want)
+	}
+}
+
+func TestClampDeltaTime(t *testing.T) {
+	tests := []struct {
+		deltaTime float64
+		want      float64
+	}{
+		{0.016, 0.016},
+		{0.1, 0.1},
+		{5, 0.1},
+		{-1, 0},
+	}
+	for _, test := range tests {
+		if got := clampDeltaTime(test.deltaTime, 0.1); got != test.want {
+			t.Errorf("clampDeltaTime(%v, 0.1) = %v, want %v", test.deltaTime, got, test.want)
+		}
+	}
+}
+
+func TestStepClampsHugeDelta(t *testing.T) {
+	e := newHeadlessTestEngine(t)
+	e.SetMaxDeltaTime(0.05)
+	e.SetMaxFrameTime(10)
+	recorder := &deltaRecorder{}
+	e.GetECS().AddSystem(recorder)
+	body := physics.NewRigidBody(1, physics.Vector2{X: 0, Y: 0}, 1, 1, 1)
+	e.GetPhysics().AddBody(body)
+
+	// A breakpoint-sized frame only simulates the clamped time
+	e.Step(30)
+
+	if len(recorder.deltas) != 1 || recorder.deltas[0] != 0.05 {
+		t.Errorf("systems saw deltas %v, want [0.05]", recorder.deltas)
+	}
+	if steps := math.Round(-body.Velocity.Y / (9.81 * e.GetFixedTimestep())); steps != 3 {
+		t.Errorf("physics ran %v steps, want 3 for 0.05 s", steps)
+	}
+
+	// Non-positive limits are ignored
+	e.SetMaxDeltaTime(0)
+	if got := e.GetMaxDeltaTime(); got != 0.05 {
+		t.Errorf("GetMaxDeltaTime() = %v, want 0.05", got)
+	}
+}
+
+func TestPausedStepDoesNotUpdate(t *testing.T) {
+	e := newHeadlessTestEngine(t)
+	recorder := &deltaRecorder{}
+	e.GetECS().AddSystem(recorder)
+	body := physics.NewRigidBody(1, physics.Vector2{X: 0, Y: 10}, 1, 1, 1)
+	e.GetPhysics().AddBody(body)
+
+	e.SetPaused(true)
+	stepFrames(e, 10)
+	if body.Position != (physics.Vector2{X: 0, Y: 10}) || body.Velocity != (physics.Vector2{}) {
+		t.Errorf("paused physics moved the body to %v at %v", body.Position, body.Velocity)
+	}
+	if len(recorder.deltas) != 0 {
+		t.Errorf("paused steps updated systems %d times", len(recorder.deltas))
+	}
+
+	// Paused frames still count for frame stats
+	if got := e.FrameStats(); !near(got.LastMs, e.GetFixedTimestep()*1000) {
+		t.Errorf("FrameStats().LastMs = %v, want paused frames recorded", got.LastMs)
+	}
+
+	e.SetPaused(false)
+	stepFrames(e, 1)
+	if len(recorder.deltas) != 1 || body.Velocity.Y >= 0 {
+		t.Errorf("resumed step didn't update: deltas %v, velocity %v", recorder.deltas, body.Velocity)
+	}
+}
@@ -0,0 +1,92 @@
+package engine
+
+import (
+	"math"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/graphics"
+	"github.com/aminasadiam/jigxel-engine/pkg/physics"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Physics debug colors
+var (
+	debugDynamicColor  = mgl32.Vec3{0, 1, 0}
+	debugStaticColor   = mgl32.Vec3{0.5, 0.5, 0.5}
+	debugInactiveColor = mgl32.Vec3{0.2, 0.2, 0.2}
+	debugContactColor  = mgl32.Vec3{1, 0, 0}
+)
+
+// debugNormalLength is the length of drawn contact normals in world units
+const debugNormalLength = 0.5
+
+// SetPhysicsDebugDraw toggles drawing physics bodies and contacts as debug lines
+func (e *Engine) SetPhysicsDebugDraw(enabled bool) {
+	e.physicsDebugDraw = enabled
+}
+
+// IsPhysicsDebugDraw returns true if physics debug drawing is enabled
+func (e *Engine) IsPhysicsDebugDraw() bool {
+	return e.physicsDebugDraw
+}
+
+// PhysicsDebugLines builds debug lines for a physics world in the z = 0
+// plane: each body's box, colored by whether it is dynamic, static or
+// inactive, and the normal of each contact from the last step
+func PhysicsDebugLines(world *physics.World) []graphics.DebugLine {
+	bodies := world.GetBodies()
+	byID := make(map[uint64]*physics.RigidBody, len(bodies))
+
+	var lines []graphics.DebugLine
+	for _, body := range bodies {
+		byID[body.ID] = body
+
+		color := debugDynamicColor
+		if !body.Active {
+			color = debugInactiveColor
+		} else if body.IsStatic() {
+			color = debugStaticColor
+		}
+
+		min, max := bodyBounds(body)
+		lines = append(lines, graphics.RectLines(min, max, 0, color)...)
+	}
+
+	for _, contact := range world.GetLastStepResult().Contacts {
+		bodyA, bodyB := byID[contact.BodyA], byID[contact.BodyB]
+		if bodyA == nil || bodyB == nil {
+			continue
+		}
+
+		point := contactPoint(bodyA, bodyB)
+		normal := mgl32.Vec3{float32(contact.Normal.X), float32(contact.Normal.Y), 0}
+		lines = append(lines, graphics.DebugLine{
+			Start: point,
+			End:   point.Add(normal.Mul(debugNormalLength)),
+			Color: debugContactColor,
+		})
+	}
+	return lines
+}
+
+// bodyBounds returns the corners of a body's box
+func bodyBounds(body *physics.RigidBody) (mgl32.Vec2, mgl32.Vec2) {
+	halfWidth := body.Width / 2
+	halfHeight := body.Height / 2
+	return mgl32.Vec2{float32(body.Position.X - halfWidth), float32(body.Position.Y - halfHeight)},
+		mgl32.Vec2{float32(body.Position.X + halfWidth), float32(body.Position.Y + halfHeight)}
+}
+
+// contactPoint returns the center of the region shared by two boxes, or the
+// point between them if the solver already pushed them apart
+func contactPoint(bodyA, bodyB *physics.RigidBody) mgl32.Vec3 {
+	minA, maxA := bodyBounds(bodyA)
+	minB, maxB := bodyBounds(bodyB)
+
+	var point mgl32.Vec3
+	for axis := 0; axis < 2; axis++ {
+		low := math.Max(float64(minA[axis]), float64(minB[axis]))
+		high := math.Min(float64(maxA[axis]), float64(maxB[axis]))
+		point[axis] = float32((low + high) / 2)
+	}
+	return point
+}
@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/graphics"
+	"github.com/aminasadiam/jigxel-engine/pkg/physics"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestPhysicsDebugLinesBodies(t *testing.T) {
+	world := physics.NewWorld()
+	world.AddBody(physics.NewRigidBody(1, physics.Vector2{X: 0, Y: 0}, 2, 1, 0))
+	dynamic := physics.NewRigidBody(2, physics.Vector2{X: 10, Y: 10}, 1, 2, 1)
+	world.AddBody(dynamic)
+	inactive := physics.NewRigidBody(3, physics.Vector2{X: -10, Y: 0}, 1, 1, 1)
+	inactive.Active = false
+	world.AddBody(inactive)
+
+	lines := PhysicsDebugLines(world)
+	if len(lines) != 12 {
+		t.Fatalf("got %d lines, want 4 per body", len(lines))
+	}
+
+	want := map[mgl32.Vec3][]graphics.DebugLine{
+		debugStaticColor:   graphics.RectLines(mgl32.Vec2{-1, -0.5}, mgl32.Vec2{1, 0.5}, 0, debugStaticColor),
+		debugDynamicColor:  graphics.RectLines(mgl32.Vec2{9.5, 9}, mgl32.Vec2{10.5, 11}, 0, debugDynamicColor),
+		debugInactiveColor: graphics.RectLines(mgl32.Vec2{-10.5, -0.5}, mgl32.Vec2{-9.5, 0.5}, 0, debugInactiveColor),
+	}
+	got := make(map[mgl32.Vec3][]graphics.DebugLine)
+	for _, line := range lines {
+		got[line.Color] = append(got[line.Color], line)
+	}
+	for color, box := range want {
+		if len(got[color]) != 4 {
+			t.Errorf("%d lines in color %v, want 4", len(got[color]), color)
+			continue
+		}
+		for i := range box {
+			if got[color][i] != box[i] {
+				t.Errorf("color %v edge %d = %+v, want %+v", color, i, got[color][i], box[i])
+			}
+		}
+	}
+}
+
+func TestPhysicsDebugLinesContacts(t *testing.T) {
+	world := physics.NewWorld()
+	world.SetGravity(physics.Vector2{})
+	world.AddBody(physics.NewRigidBody(1, physics.Vector2{X: 0, Y: 0}, 4, 1, 0))
+	world.AddBody(physics.NewRigidBody(2, physics.Vector2{X: 0, Y: 0.9}, 1, 1, 1))
+	world.Update(1.0 / 60.0)
+
+	var contacts []graphics.DebugLine
+	for _, line := range PhysicsDebugLines(world) {
+		if line.Color == debugContactColor {
+			contacts = append(contacts, line)
+		}
+	}
+	if len(contacts) != 1 {
+		t.Fatalf("got %d contact lines, want 1", len(contacts))
+	}
+
+	// The normal starts between the boxes and points up out of the ground
+	normal := contacts[0].End.Sub(contacts[0].Start)
+	if contacts[0].Start.X() != 0 || contacts[0].Start.Y() < 0.3 || contacts[0].Start.Y() > 0.6 {
+		t.Errorf("contact drawn at %v, want between the boxes", contacts[0].Start)
+	}
+	if normal.Sub(mgl32.Vec3{0, debugNormalLength, 0}).Len() > 1e-6 {
+		t.Errorf("contact normal = %v, want (0, %v, 0)", normal, debugNormalLength)
+	}
+}
+
+func TestContactPointSeparatedBoxes(t *testing.T) {
+	bodyA := physics.NewRigidBody(1, physics.Vector2{X: 0, Y: 0}, 2, 2, 1)
+	bodyB := physics.NewRigidBody(2, physics.Vector2{X: 3, Y: 0}, 2, 2, 1)
+
+	// With a gap the point lies midway across it
+	if got := contactPoint(bodyA, bodyB); got != (mgl32.Vec3{1.5, 0, 0}) {
+		t.Errorf("contactPoint() = %v, want (1.5, 0, 0)", got)
+	}
+}
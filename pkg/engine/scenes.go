@@ -0,0 +1,159 @@
+package engine
+
+import (
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/aminasadiam/jigxel-engine/pkg/graphics"
+	"github.com/aminasadiam/jigxel-engine/pkg/physics"
+)
+
+// Scene is a self-contained game state such as a menu, level or pause screen.
+// Only the scene on top of the stack is updated and rendered.
+type Scene interface {
+	// OnEnter is called when the scene is pushed onto the stack
+	OnEnter(engine *Engine)
+	// OnExit is called when the scene is popped or replaced
+	OnExit(engine *Engine)
+	Update(deltaTime float64)
+	Render(renderer *graphics.Renderer)
+	// GetWorld returns the ECS world owned by the scene
+	GetWorld() *ecs.World
+}
+
+// BaseScene is a Scene with its own world that updates and renders it.
+// Embed it to only override the methods a scene needs.
+type BaseScene struct {
+	World *ecs.World
+}
+
+// NewBaseScene creates a scene with an empty world
+func NewBaseScene() *BaseScene {
+	return &BaseScene{World: ecs.NewWorld()}
+}
+
+// OnEnter does nothing by default
+func (s *BaseScene) OnEnter(engine *Engine) {}
+
+// OnExit does nothing by default
+func (s *BaseScene) OnExit(engine *Engine) {}
+
+// Update runs the world's systems
+func (s *BaseScene) Update(deltaTime float64) {
+	s.World.Update(deltaTime)
+}
+
+// Render draws the world
+func (s *BaseScene) Render(renderer *graphics.Renderer) {
+	renderer.Render(s.World)
+}
+
+// GetWorld returns the scene's world
+func (s *BaseScene) GetWorld() *ecs.World {
+	return s.World
+}
+
+// SceneManager holds the stack of active scenes. Each scene on the stack has
+// its own physics world, created when it is pushed and dropped when it is
+// popped, so body IDs in one scene's world never collide with another's.
+type SceneManager struct {
+	engine *Engine
+	stack  []sceneEntry
+
+	// Worlds on the stack whose mesh bounds the renderer fills in
+	trackedWorlds map[*ecs.World]*trackedWorld
+}
+
+// trackedWorld is a world whose mesh bounds are tracked, with the number of
+// scenes on the stack using it
+type trackedWorld struct {
+	scenes  int
+	untrack func()
+}
+
+// sceneEntry is a scene on the stack and its physics world
+type sceneEntry struct {
+	scene   Scene
+	physics *physics.World
+}
+
+// NewSceneManager creates an empty scene stack for the engine
+func NewSceneManager(engine *Engine) *SceneManager {
+	return &SceneManager{engine: engine, trackedWorlds: make(map[*ecs.World]*trackedWorld)}
+}
+
+// Push puts a scene on top of the stack with a new physics world; the scene
+// below stays but is paused
+func (m *SceneManager) Push(scene Scene) {
+	m.trackMeshBounds(scene.GetWorld())
+	m.stack = append(m.stack, sceneEntry{scene: scene, physics: physics.NewWorld()})
+	scene.OnEnter(m.engine)
+}
+
+// trackMeshBounds has the renderer fill in mesh bounds for a scene's world,
+// once however many scenes on the stack use it. Headless engines have no
+// renderer to track them.
+func (m *SceneManager) trackMeshBounds(world *ecs.World) {
+	if m.engine == nil || m.engine.renderer == nil || world == nil {
+		return
+	}
+	if tracked, exists := m.trackedWorlds[world]; exists {
+		tracked.scenes++
+		return
+	}
+	m.trackedWorlds[world] = &trackedWorld{scenes: 1, untrack: m.engine.renderer.TrackMeshBounds(world)}
+}
+
+// untrackMeshBounds stops filling in mesh bounds for a popped scene's world
+// once no scene on the stack uses it, so the manager doesn't keep it alive
+func (m *SceneManager) untrackMeshBounds(world *ecs.World) {
+	tracked, exists := m.trackedWorlds[world]
+	if !exists {
+		return
+	}
+	tracked.scenes--
+	if tracked.scenes == 0 {
+		tracked.untrack()
+		delete(m.trackedWorlds, world)
+	}
+}
+
+// Pop removes and returns the top scene, or nil if the stack is empty
+func (m *SceneManager) Pop() Scene {
+	if len(m.stack) == 0 {
+		return nil
+	}
+
+	top := m.stack[len(m.stack)-1]
+	m.stack[len(m.stack)-1] = sceneEntry{}
+	m.stack = m.stack[:len(m.stack)-1]
+	top.scene.OnExit(m.engine)
+	m.untrackMeshBounds(top.scene.GetWorld())
+	return top.scene
+}
+
+// Replace swaps the top scene for another and returns the old one
+func (m *SceneManager) Replace(scene Scene) Scene {
+	previous := m.Pop()
+	m.Push(scene)
+	return previous
+}
+
+// Current returns the top scene, or nil if the stack is empty
+func (m *SceneManager) Current() Scene {
+	if len(m.stack) == 0 {
+		return nil
+	}
+	return m.stack[len(m.stack)-1].scene
+}
+
+// currentPhysics returns the physics world of the top scene, or nil if the stack is empty
+func (m *SceneManager) currentPhysics() *physics.World {
+	if len(m.stack) == 0 {
+		return nil
+	}
+	return m.stack[len(m.stack)-1].physics
+}
+
+// Len returns the number of scenes on the stack
+func (m *SceneManager) Len() int {
+	return len(m.stack)
+}
@@ -0,0 +1,164 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/aminasadiam/jigxel-engine/pkg/graphics"
+	"github.com/aminasadiam/jigxel-engine/pkg/physics"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// loggingScene records its lifecycle calls in a shared log
+type loggingScene struct {
+	*BaseScene
+	name string
+	log  *[]string
+}
+
+func newLoggingScene(name string, log *[]string) *loggingScene {
+	return &loggingScene{BaseScene: NewBaseScene(), name: name, log: log}
+}
+
+func (s *loggingScene) OnEnter(engine *Engine) {
+	*s.log = append(*s.log, "enter "+s.name)
+}
+
+func (s *loggingScene) OnExit(engine *Engine) {
+	*s.log = append(*s.log, "exit "+s.name)
+}
+
+func (s *loggingScene) Update(deltaTime float64) {
+	*s.log = append(*s.log, "update "+s.name)
+}
+
+func TestSceneStack(t *testing.T) {
+	e := newWindowlessTestEngine()
+	scenes := e.GetScenes()
+	var log []string
+
+	menu := newLoggingScene("menu", &log)
+	level := newLoggingScene("level", &log)
+	pause := newLoggingScene("pause", &log)
+
+	scenes.Push(menu)
+	scenes.Replace(level)
+	scenes.Push(pause)
+	if scenes.Current() != pause || scenes.Len() != 2 {
+		t.Fatalf("top = %v with %d scenes, want pause over level", scenes.Current(), scenes.Len())
+	}
+	if e.GetECS() != pause.GetWorld() {
+		t.Error("GetECS() isn't the top scene's world")
+	}
+
+	// Only the top scene is current
+	scenes.Current().Update(1.0 / 60.0)
+
+	if popped := scenes.Pop(); popped != pause {
+		t.Errorf("Pop() = %v, want pause", popped)
+	}
+	scenes.Current().Update(1.0 / 60.0)
+	scenes.Pop()
+
+	want := []string{
+		"enter menu", "exit menu", "enter level", "enter pause",
+		"update pause",
+		"exit pause",
+		"update level",
+		"exit level",
+	}
+	if !reflect.DeepEqual(log, want) {
+		t.Errorf("log = %v, want %v", log, want)
+	}
+
+	if scenes.Pop() != nil {
+		t.Error("Pop() on an empty stack returned a scene")
+	}
+	if scenes.Current() != nil {
+		t.Error("Current() on an empty stack returned a scene")
+	}
+}
+
+func TestScenesHaveTheirOwnPhysics(t *testing.T) {
+	e := newHeadlessTestEngine(t)
+	e.GetPhysics().SetGravity(physics.Vector2{})
+	defaultPhysics := e.GetPhysics()
+	defaultPhysics.AddBody(physics.NewRigidBody(1, physics.Vector2{X: 0, Y: 0}, 1, 1, 1))
+
+	// The level reuses body ID 1 without touching the default world's body
+	level := NewBaseScene()
+	e.GetScenes().Push(level)
+	levelPhysics := e.GetPhysics()
+	if levelPhysics == defaultPhysics {
+		t.Fatal("pushed scene shares the default physics world")
+	}
+	levelPhysics.SetGravity(physics.Vector2{})
+	body := physics.NewRigidBody(1, physics.Vector2{X: 0, Y: 0}, 1, 1, 1)
+	body.Velocity = physics.Vector2{X: 60, Y: 0}
+	levelPhysics.AddBody(body)
+
+	entity := level.World.CreateEntity()
+	level.World.AddComponent(entity, ecs.NewTransformComponent(mgl32.Vec3{}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}))
+	level.World.AddComponent(entity, &ecs.PhysicsComponent{BodyID: 1, Active: true})
+
+	e.Step(DefaultFixedTimestep)
+
+	// The level's body moved and its entity followed it
+	transform := level.World.GetComponent(entity, "transform").(*ecs.TransformComponent)
+	if transform.Position == (mgl32.Vec3{}) {
+		t.Error("scene entity didn't follow its scene's physics body")
+	}
+	if got := defaultPhysics.GetBody(1).Position; got != (physics.Vector2{}) {
+		t.Errorf("default body moved to %v while a scene was active", got)
+	}
+
+	e.GetScenes().Pop()
+	if e.GetPhysics() != defaultPhysics {
+		t.Error("GetPhysics() after popping isn't the default physics world")
+	}
+}
+
+func TestPushedScenesTrackMeshBounds(t *testing.T) {
+	e := newHeadlessTestEngine(t)
+	e.renderer = graphics.NewRenderer()
+	scenes := e.GetScenes()
+
+	level := NewBaseScene()
+	scenes.Push(level)
+	scenes.Pop()
+	scenes.Push(level)
+
+	// Pushing the same world again doesn't register a second listener
+	if tracked := scenes.trackedWorlds[level.World]; tracked == nil || tracked.scenes != 1 || len(scenes.trackedWorlds) != 1 {
+		t.Errorf("tracked worlds = %v, want only the level's", scenes.trackedWorlds)
+	}
+}
+
+func TestPoppedScenesAreUntracked(t *testing.T) {
+	e := newHeadlessTestEngine(t)
+	e.renderer = graphics.NewRenderer()
+	scenes := e.GetScenes()
+
+	menu := NewBaseScene()
+	scenes.Push(menu)
+	for i := 0; i < 100; i++ {
+		scenes.Push(NewBaseScene())
+		scenes.Replace(NewBaseScene())
+		scenes.Pop()
+	}
+	if len(scenes.trackedWorlds) != 1 || scenes.trackedWorlds[menu.World] == nil {
+		t.Errorf("%d tracked worlds after pushing and popping, want only the menu's", len(scenes.trackedWorlds))
+	}
+
+	// A world on the stack twice stays tracked until both scenes are gone
+	scenes.Push(menu)
+	scenes.Pop()
+	if scenes.trackedWorlds[menu.World] == nil {
+		t.Error("popping one of two scenes sharing a world stopped tracking it")
+	}
+	scenes.Pop()
+	if len(scenes.trackedWorlds) != 0 {
+		t.Errorf("%d tracked worlds after emptying the stack, want 0", len(scenes.trackedWorlds))
+	}
+}
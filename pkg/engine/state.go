@@ -0,0 +1,110 @@
+package engine
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/aminasadiam/jigxel-engine/pkg/graphics"
+	"github.com/aminasadiam/jigxel-engine/pkg/physics"
+)
+
+// engineState is the serialized form of the engine
+type engineState struct {
+	World   json.RawMessage  `json:"world"`
+	Physics json.RawMessage  `json:"physics"`
+	Camera  *graphics.Camera `json:"camera,omitempty"`
+	// Time is the world's clock, including its time scale
+	Time *ecs.Time `json:"time,omitempty"`
+	// Random is where the world's random sequence was
+	Random *ecs.RandomState `json:"random,omitempty"`
+}
+
+// SaveState writes the active ECS world with its clock and random state, the
+// active physics bodies and the camera into one stream
+func (e *Engine) SaveState(writer io.Writer) error {
+	var state engineState
+
+	clock := e.GetECS().GetTime()
+	state.Time = &clock
+	random := e.GetECS().GetRandomState()
+	state.Random = &random
+
+	var worldBuffer bytes.Buffer
+	if err := e.GetECS().Save(&worldBuffer); err != nil {
+		return fmt.Errorf("failed to save world: %w", err)
+	}
+	state.World = worldBuffer.Bytes()
+
+	var physicsBuffer bytes.Buffer
+	if err := e.GetPhysics().Save(&physicsBuffer); err != nil {
+		return fmt.Errorf("failed to save physics: %w", err)
+	}
+	state.Physics = physicsBuffer.Bytes()
+
+	if e.renderer != nil {
+		state.Camera = e.renderer.GetCamera()
+	}
+
+	return json.NewEncoder(writer).Encode(state)
+}
+
+// LoadState restores a stream written by SaveState. Physics components refer
+// to bodies by ID, so they pick up the loaded bodies as they are; those whose
+// body is missing are deactivated. If any part of the stream fails to decode,
+// nothing is changed.
+func (e *Engine) LoadState(reader io.Reader) error {
+	var state engineState
+	if err := json.NewDecoder(reader).Decode(&state); err != nil {
+		return err
+	}
+
+	// Decode both worlds before applying either, so a bad physics section
+	// can't leave the ECS world loaded from the new state and physics from
+	// the old one
+	world, err := ecs.DecodeWorldState(bytes.NewReader(state.World))
+	if err != nil {
+		return fmt.Errorf("failed to load world: %w", err)
+	}
+	bodies, err := physics.DecodeWorldState(bytes.NewReader(state.Physics))
+	if err != nil {
+		return fmt.Errorf("failed to load physics: %w", err)
+	}
+
+	e.GetECS().ApplyState(world)
+	e.GetPhysics().ApplyState(bodies)
+
+	if state.Camera != nil && e.renderer != nil {
+		e.renderer.SetCamera(state.Camera)
+	}
+
+	if state.Time != nil {
+		e.GetECS().SetTime(*state.Time)
+	}
+	if state.Random != nil {
+		e.GetECS().SetRandomState(*state.Random)
+	}
+
+	e.deactivateOrphanedPhysics()
+	return nil
+}
+
+// deactivateOrphanedPhysics deactivates physics components whose body no
+// longer exists. It doesn't reactivate components whose body came back.
+func (e *Engine) deactivateOrphanedPhysics() {
+	world := e.GetECS()
+	for _, entityID := range world.GetEntitiesWithComponent("physics") {
+		component, ok := world.GetComponent(entityID, "physics").(*ecs.PhysicsComponent)
+		if !ok {
+			continue
+		}
+
+		if e.GetPhysics().GetBody(component.BodyID) == nil {
+			log.Printf("Entity %d references missing physics body %d", entityID, component.BodyID)
+			component.Active = false
+		}
+	}
+}
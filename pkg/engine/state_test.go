@@ -0,0 +1,213 @@
+package engine
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/aminasadiam/jigxel-engine/pkg/graphics"
+	"github.com/aminasadiam/jigxel-engine/pkg/physics"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// newWindowlessTestEngine creates an engine with a world, physics and a
+// camera but no window
+func newWindowlessTestEngine() *Engine {
+	e := NewEngine("test", 800, 600)
+	e.ecs = ecs.NewWorld()
+	e.physics = physics.NewWorld()
+	e.renderer = graphics.NewRenderer()
+	return e
+}
+
+// stepPhysicsFrames steps the engine's physics count frames of 1/60 second
+func stepPhysicsFrames(e *Engine, count int) {
+	for i := 0; i < count; i++ {
+		e.GetPhysics().Update(1.0 / 60.0)
+	}
+}
+
+// newHeadlessTestEngine creates and initializes a headless engine
+func newHeadlessTestEngine(t *testing.T) *Engine {
+	t.Helper()
+
+	e := NewHeadlessEngine()
+	if err := e.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	return e
+}
+
+// stepFrames steps the engine count frames of one fixed timestep each
+func stepFrames(e *Engine, count int) {
+	for i := 0; i < count; i++ {
+		e.Step(e.GetFixedTimestep())
+	}
+}
+
+// bodyStates returns the position and velocity of the bodies with the given IDs
+func bodyStates(e *Engine, ids ...uint64) map[uint64][2]physics.Vector2 {
+	states := make(map[uint64][2]physics.Vector2)
+	for _, id := range ids {
+		if body := e.GetPhysics().GetBody(id); body != nil {
+			states[body.ID] = [2]physics.Vector2{body.Position, body.Velocity}
+		}
+	}
+	return states
+}
+
+// transformPositions returns the position of every transform
+func transformPositions(e *Engine) map[ecs.EntityID]mgl32.Vec3 {
+	positions := make(map[ecs.EntityID]mgl32.Vec3)
+	world := e.GetECS()
+	for _, entityID := range world.GetEntitiesWithComponent("transform") {
+		positions[entityID] = world.GetComponent(entityID, "transform").(*ecs.TransformComponent).Position
+	}
+	return positions
+}
+
+func TestSaveAndLoadStateMidSimulation(t *testing.T) {
+	original := newWindowlessTestEngine()
+	original.GetPhysics().AddBody(physics.NewRigidBody(1, physics.Vector2{X: 0, Y: 0}, 10, 1, 0))
+	original.GetPhysics().AddBody(physics.NewRigidBody(2, physics.Vector2{X: 0, Y: 8}, 1, 1, 1))
+	original.GetECS().NewEntity().
+		WithTransform(mgl32.Vec3{0, 8, 0}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}).
+		With(ecs.NewPhysicsComponent(2, 1)).
+		WithTag("crate").
+		Build()
+
+	stepPhysicsFrames(original, 30)
+
+	var saved bytes.Buffer
+	if err := original.SaveState(&saved); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+	savedBodies := bodyStates(original, 1, 2)
+	savedTransforms := transformPositions(original)
+
+	// Keep simulating the original past the save point
+	stepPhysicsFrames(original, 30)
+
+	restored := newWindowlessTestEngine()
+	if err := restored.LoadState(&saved); err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+
+	if got := bodyStates(restored, 1, 2); !reflect.DeepEqual(got, savedBodies) {
+		t.Errorf("loaded bodies = %v, want %v", got, savedBodies)
+	}
+	if got := transformPositions(restored); !reflect.DeepEqual(got, savedTransforms) {
+		t.Errorf("loaded transforms = %v, want %v", got, savedTransforms)
+	}
+	if got := restored.GetECS().GetEntitiesWithComponent("tag"); len(got) != 1 {
+		t.Errorf("%d tagged entities after loading, want 1", len(got))
+	}
+
+	// The restored simulation continues exactly as the original did
+	stepPhysicsFrames(restored, 30)
+	if got, want := bodyStates(restored, 1, 2), bodyStates(original, 1, 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("bodies after resuming = %v, want %v", got, want)
+	}
+}
+
+func TestSaveAndLoadStateKeepsTheClock(t *testing.T) {
+	original := newHeadlessTestEngine(t)
+	original.GetECS().SetTimeScale(0.5)
+	stepFrames(original, 30)
+
+	var saved bytes.Buffer
+	if err := original.SaveState(&saved); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+	savedTime := original.GetECS().GetTime()
+
+	restored := newHeadlessTestEngine(t)
+	if err := restored.LoadState(&saved); err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+
+	if got := restored.GetECS().GetTime(); got != savedTime {
+		t.Errorf("loaded time = %+v, want %+v", got, savedTime)
+	}
+	if got := restored.GetECS().GetTimeScale(); got != 0.5 {
+		t.Errorf("loaded GetTimeScale() = %v, want 0.5", got)
+	}
+}
+
+func TestLoadStateLeavesEngineUnchangedOnError(t *testing.T) {
+	e := newHeadlessTestEngine(t)
+	entityID := e.GetECS().NewEntity().With(ecs.NewTagComponent("crate")).Build()
+	e.GetPhysics().AddBody(physics.NewRigidBody(1, physics.Vector2{X: 0, Y: 10}, 1, 1, 1))
+	e.GetECS().Time().TimeScale = 0.5
+
+	// An empty world that would load, followed by physics that won't
+	bad := `{"world":{"nextEntityID":1,"entities":[]},"physics":{"bodies":"broken"},"time":{"TimeScale":2}}`
+	if err := e.LoadState(strings.NewReader(bad)); err == nil {
+		t.Fatal("LoadState of broken physics succeeded")
+	}
+
+	if e.GetECS().GetComponent(entityID, "tag") == nil {
+		t.Error("the world was loaded even though physics failed")
+	}
+	if e.GetPhysics().GetBody(1) == nil {
+		t.Error("physics bodies changed even though physics failed")
+	}
+	if got := e.GetECS().GetTime().TimeScale; got != 0.5 {
+		t.Errorf("TimeScale = %v, want the old 0.5", got)
+	}
+}
+
+func TestLoadStateDeactivatesOrphanedPhysics(t *testing.T) {
+	e := newWindowlessTestEngine()
+	entityID := e.GetECS().NewEntity().With(ecs.NewPhysicsComponent(7, 1)).Build()
+
+	var saved bytes.Buffer
+	if err := e.SaveState(&saved); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+	if err := e.LoadState(&saved); err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+
+	component := e.GetECS().GetComponent(entityID, "physics").(*ecs.PhysicsComponent)
+	if component.Active {
+		t.Error("physics component without a body is still active")
+	}
+}
+
+func TestSaveAndLoadStateReplaysRandomSequence(t *testing.T) {
+	original := newHeadlessTestEngine(t)
+	original.GetECS().SetSeed(1234)
+	for i := 0; i < 20; i++ {
+		original.GetECS().Rand().Int63()
+	}
+
+	var saved bytes.Buffer
+	if err := original.SaveState(&saved); err != nil {
+		t.Fatalf("SaveState: %v", err)
+	}
+	want := make([]int64, 10)
+	for i := range want {
+		want[i] = original.GetECS().Rand().Int63()
+	}
+
+	// Loading over an engine that has drawn a different sequence resumes
+	// the saved one
+	restored := newHeadlessTestEngine(t)
+	restored.GetECS().SetSeed(99)
+	restored.GetECS().Rand().Int63()
+	if err := restored.LoadState(&saved); err != nil {
+		t.Fatalf("LoadState: %v", err)
+	}
+
+	if got := restored.GetECS().Seed(); got != 1234 {
+		t.Errorf("Seed() = %d, want 1234", got)
+	}
+	for i := range want {
+		if got := restored.GetECS().Rand().Int63(); got != want[i] {
+			t.Fatalf("draw %d after loading = %d, want %d", i, got, want[i])
+		}
+	}
+}
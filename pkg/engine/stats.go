@@ -0,0 +1,105 @@
+package engine
+
+import (
+	"fmt"
+)
+
+// FrameStatsWindow is the number of frames averaged by FrameStats
+const FrameStatsWindow = 120
+
+// titleRefreshInterval is how often, in seconds, the FPS title is refreshed
+const titleRefreshInterval = 0.5
+
+// FrameStats summarizes recent frame timing
+type FrameStats struct {
+	FPS       float64
+	AverageMs float64
+	LastMs    float64
+	MinMs     float64
+	MaxMs     float64
+}
+
+// frameStats keeps a rolling window of frame times in a fixed-size ring
+// buffer so recording a frame never allocates
+type frameStats struct {
+	samples [FrameStatsWindow]float64
+	next    int
+	count   int
+	sum     float64
+	last    float64
+}
+
+// record adds a frame time in seconds
+func (s *frameStats) record(frameTime float64) {
+	if s.count == len(s.samples) {
+		s.sum -= s.samples[s.next]
+	} else {
+		s.count++
+	}
+
+	s.samples[s.next] = frameTime
+	s.sum += frameTime
+	s.next = (s.next + 1) % len(s.samples)
+	s.last = frameTime
+}
+
+// stats returns the summary of the recorded window
+func (s *frameStats) stats() FrameStats {
+	if s.count == 0 {
+		return FrameStats{}
+	}
+
+	min, max := s.samples[0], s.samples[0]
+	for i := 1; i < s.count; i++ {
+		if s.samples[i] < min {
+			min = s.samples[i]
+		}
+		if s.samples[i] > max {
+			max = s.samples[i]
+		}
+	}
+
+	average := s.sum / float64(s.count)
+	stats := FrameStats{
+		AverageMs: average * 1000,
+		LastMs:    s.last * 1000,
+		MinMs:     min * 1000,
+		MaxMs:     max * 1000,
+	}
+	if average > 0 {
+		stats.FPS = 1 / average
+	}
+	return stats
+}
+
+// FrameStats returns the frame timing over the last FrameStatsWindow frames
+func (e *Engine) FrameStats() FrameStats {
+	return e.frameStats.stats()
+}
+
+// SetTitleShowsFPS appends the current FPS to the window title when enabled
+func (e *Engine) SetTitleShowsFPS(show bool) {
+	e.titleShowsFPS = show
+	e.titleElapsed = 0
+	if !show && e.window != nil {
+		e.window.SetTitle(e.title)
+	}
+}
+
+// recordFrame records the frame time and refreshes the FPS title if enabled
+func (e *Engine) recordFrame(frameTime float64) {
+	e.frameStats.record(frameTime)
+
+	if !e.titleShowsFPS || e.window == nil {
+		return
+	}
+
+	e.titleElapsed += frameTime
+	if e.titleElapsed < titleRefreshInterval {
+		return
+	}
+	e.titleElapsed = 0
+
+	stats := e.frameStats.stats()
+	e.window.SetTitle(fmt.Sprintf("%s - %.0f FPS (%.2f ms)", e.title, stats.FPS, stats.AverageMs))
+}
@@ -0,0 +1,54 @@
+package engine
+
+import (
+	"math"
+	"testing"
+)
+
+// near reports whether two floats are equal within rounding error
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestFrameStats(t *testing.T) {
+	var stats frameStats
+	if got := stats.stats(); got != (FrameStats{}) {
+		t.Errorf("empty stats = %+v, want zero", got)
+	}
+
+	for _, frameTime := range []float64{0.010, 0.020, 0.030, 0.020} {
+		stats.record(frameTime)
+	}
+
+	got := stats.stats()
+	want := FrameStats{FPS: 50, AverageMs: 20, LastMs: 20, MinMs: 10, MaxMs: 30}
+	if !near(got.FPS, want.FPS) || !near(got.AverageMs, want.AverageMs) || !near(got.LastMs, want.LastMs) ||
+		!near(got.MinMs, want.MinMs) || !near(got.MaxMs, want.MaxMs) {
+		t.Errorf("stats = %+v, want %+v", got, want)
+	}
+}
+
+func TestFrameStatsWindow(t *testing.T) {
+	var stats frameStats
+
+	// A slow start falls out of the window once enough frames follow
+	stats.record(1)
+	for i := 0; i < FrameStatsWindow; i++ {
+		stats.record(0.01)
+	}
+
+	got := stats.stats()
+	if !near(got.AverageMs, 10) || !near(got.MaxMs, 10) || !near(got.FPS, 100) {
+		t.Errorf("stats = %+v, want only the last %d frames of 10 ms", got, FrameStatsWindow)
+	}
+}
+
+func TestEngineRecordsFrameStats(t *testing.T) {
+	e := NewEngine("test", 800, 600)
+	e.recordFrame(0.025)
+	e.recordFrame(0.015)
+
+	if got := e.FrameStats(); !near(got.AverageMs, 20) || !near(got.LastMs, 15) {
+		t.Errorf("FrameStats() = %+v, want a 20 ms average and 15 ms last frame", got)
+	}
+}
@@ -0,0 +1,37 @@
+package engine
+
+import (
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/aminasadiam/jigxel-engine/pkg/physics"
+)
+
+// TilemapBodies returns static bodies covering the solid cells of a tilemap
+// whose transform is at origin. Horizontal runs of solid cells share one
+// body. Bodies are numbered from firstID and are not added to any world.
+func TilemapBodies(tilemap *ecs.TilemapComponent, origin physics.Vector2, firstID uint64) []*physics.RigidBody {
+	tileWidth := float64(tilemap.TileSize.X())
+	tileHeight := float64(tilemap.TileSize.Y())
+
+	var bodies []*physics.RigidBody
+	for y := 0; y < tilemap.Height; y++ {
+		for x := 0; x < tilemap.Width; {
+			if !tilemap.IsSolid(x, y) {
+				x++
+				continue
+			}
+
+			start := x
+			for x < tilemap.Width && tilemap.IsSolid(x, y) {
+				x++
+			}
+
+			width := float64(x-start) * tileWidth
+			center := physics.Vector2{
+				X: origin.X + float64(start)*tileWidth + width/2,
+				Y: origin.Y + (float64(y)+0.5)*tileHeight,
+			}
+			bodies = append(bodies, physics.NewRigidBody(firstID+uint64(len(bodies)), center, width, tileHeight, 0))
+		}
+	}
+	return bodies
+}
@@ -0,0 +1,44 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/aminasadiam/jigxel-engine/pkg/physics"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestTilemapBodies(t *testing.T) {
+	tilemap := ecs.NewTilemapComponent("tiles", 2, 2, 5, 2, mgl32.Vec2{2, 1})
+	tilemap.SolidTiles = []int{1}
+	// Row 0: a run of three solid cells, then a gap; row 1: one solid cell
+	for x := 0; x < 3; x++ {
+		tilemap.SetTile(x, 0, 1)
+	}
+	tilemap.SetTile(4, 0, 0)
+	tilemap.SetTile(4, 1, 1)
+
+	bodies := TilemapBodies(tilemap, physics.Vector2{X: 10, Y: 0}, 100)
+	if len(bodies) != 2 {
+		t.Fatalf("got %d bodies, want 2", len(bodies))
+	}
+
+	tests := []struct {
+		id            uint64
+		center        physics.Vector2
+		width, height float64
+	}{
+		{100, physics.Vector2{X: 13, Y: 0.5}, 6, 1},
+		{101, physics.Vector2{X: 19, Y: 1.5}, 2, 1},
+	}
+	for i, test := range tests {
+		body := bodies[i]
+		if body.ID != test.id || body.Position != test.center || body.Width != test.width || body.Height != test.height {
+			t.Errorf("body %d = ID %d at %v, %vx%v; want ID %d at %v, %vx%v", i,
+				body.ID, body.Position, body.Width, body.Height, test.id, test.center, test.width, test.height)
+		}
+		if !body.IsStatic() {
+			t.Errorf("body %d is not static", i)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package engine
+
+import (
+	"sort"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/aminasadiam/jigxel-engine/pkg/physics"
+)
+
+// TriggerBodyIDBase is the first body ID used for the sensor bodies of
+// trigger zones. A zone's body has ID TriggerBodyIDBase plus the zone's
+// entity ID, so game bodies must use IDs below it.
+const TriggerBodyIDBase uint64 = 1 << 62
+
+// TriggerSystem turns the physics contacts of trigger zones into ECS
+// callbacks. It keeps a static sensor body in the physics world for each
+// zone, matching the zone's transform, and each update compares the
+// entities the sensor touched after the last physics step with those of the
+// previous update to fire enter, stay and exit callbacks. An entity that
+// passes through a zone within a single frame's physics steps is missed.
+type TriggerSystem struct {
+	physics *physics.World
+	// overlaps holds the entities inside each zone, by zone entity
+	overlaps map[ecs.EntityID]map[ecs.EntityID]bool
+}
+
+// NewTriggerSystem creates a trigger system using the contacts of a physics world
+func NewTriggerSystem(world *physics.World) *TriggerSystem {
+	return &TriggerSystem{
+		physics:  world,
+		overlaps: make(map[ecs.EntityID]map[ecs.EntityID]bool),
+	}
+}
+
+// TriggerBodyID returns the ID of the sensor body of a zone entity
+func TriggerBodyID(zoneID ecs.EntityID) uint64 {
+	return TriggerBodyIDBase + uint64(zoneID)
+}
+
+// Update fires the callbacks of every zone and moves the sensors to their
+// zones for the next physics step. For each zone, exits fire before enters
+// and stays, each in entity ID order. Callbacks run outside the world lock.
+func (s *TriggerSystem) Update(deltaTime float64, world *ecs.World) {
+	touching := s.touchingEntities(world)
+
+	zones := world.GetEntitiesWithComponent("trigger_zone")
+	active := make(map[ecs.EntityID]bool, len(zones))
+	for _, zoneID := range zones {
+		zone, ok := world.GetComponent(zoneID, "trigger_zone").(*ecs.TriggerZoneComponent)
+		if !ok {
+			continue
+		}
+		transform, ok := world.GetComponent(zoneID, "transform").(*ecs.TransformComponent)
+		if !ok {
+			continue
+		}
+		active[zoneID] = true
+		s.syncSensor(zoneID, zone, transform)
+
+		current := touching[zoneID]
+		previous := s.overlaps[zoneID]
+		s.overlaps[zoneID] = current
+
+		for _, other := range sortedEntities(previous) {
+			if !current[other] && zone.OnExit != nil {
+				zone.OnExit(world, zoneID, other)
+			}
+		}
+		for _, other := range sortedEntities(current) {
+			switch {
+			case !previous[other] && zone.OnEnter != nil:
+				zone.OnEnter(world, zoneID, other)
+			case previous[other] && zone.OnStay != nil:
+				zone.OnStay(world, zoneID, other)
+			}
+		}
+	}
+
+	// Forget zones that were destroyed or lost their component
+	for zoneID := range s.overlaps {
+		if !active[zoneID] {
+			delete(s.overlaps, zoneID)
+			s.physics.RemoveBody(TriggerBodyID(zoneID))
+		}
+	}
+}
+
+// GetName returns the system name
+func (s *TriggerSystem) GetName() string {
+	return "TriggerSystem"
+}
+
+// syncSensor creates or moves the sensor body of a zone
+func (s *TriggerSystem) syncSensor(zoneID ecs.EntityID, zone *ecs.TriggerZoneComponent, transform *ecs.TransformComponent) {
+	center := physics.Vector2{
+		X: float64(transform.Position.X() + zone.Offset.X()),
+		Y: float64(transform.Position.Y() + zone.Offset.Y()),
+	}
+	width, height := float64(zone.Size.X()), float64(zone.Size.Y())
+
+	body := s.physics.GetBody(TriggerBodyID(zoneID))
+	if body == nil {
+		body = physics.NewRigidBody(TriggerBodyID(zoneID), center, width, height, 0)
+		body.Sensor = true
+		s.physics.AddBody(body)
+		return
+	}
+
+	body.Position = center
+	body.Width = width
+	body.Height = height
+}
+
+// touchingEntities returns the entities whose bodies touched each zone's
+// sensor after the last physics step, by zone entity. A zone never touches
+// its own entity.
+func (s *TriggerSystem) touchingEntities(world *ecs.World) map[ecs.EntityID]map[ecs.EntityID]bool {
+	entities := make(map[uint64]ecs.EntityID)
+	for _, entityID := range world.GetEntitiesWithComponent("physics") {
+		if component, ok := world.GetComponent(entityID, "physics").(*ecs.PhysicsComponent); ok {
+			entities[component.BodyID] = entityID
+		}
+	}
+
+	touching := make(map[ecs.EntityID]map[ecs.EntityID]bool)
+	for _, contact := range s.physics.GetLastStepResult().Contacts {
+		sensor, other := contact.BodyA, contact.BodyB
+		if other >= TriggerBodyIDBase {
+			sensor, other = other, sensor
+		}
+		if sensor < TriggerBodyIDBase || other >= TriggerBodyIDBase {
+			// Not a zone, or two zones touching
+			continue
+		}
+
+		zoneID := ecs.EntityID(sensor - TriggerBodyIDBase)
+		entityID, owned := entities[other]
+		if !owned || entityID == zoneID {
+			continue
+		}
+		if touching[zoneID] == nil {
+			touching[zoneID] = make(map[ecs.EntityID]bool)
+		}
+		touching[zoneID][entityID] = true
+	}
+	return touching
+}
+
+// sortedEntities returns the entities of a set in ID order
+func sortedEntities(set map[ecs.EntityID]bool) []ecs.EntityID {
+	entities := make([]ecs.EntityID, 0, len(set))
+	for entityID := range set {
+		entities = append(entities, entityID)
+	}
+	sort.Slice(entities, func(i, j int) bool {
+		return entities[i] < entities[j]
+	})
+	return entities
+}
@@ -0,0 +1,83 @@
+package engine
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/aminasadiam/jigxel-engine/pkg/physics"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestTriggerZoneEnterStayExit(t *testing.T) {
+	e := newHeadlessTestEngine(t)
+	e.GetPhysics().SetGravity(physics.Vector2{})
+	world := e.GetECS()
+	world.AddSystem(NewTriggerSystem(e.GetPhysics()))
+
+	var events []string
+	zone := ecs.NewTriggerZoneComponent(mgl32.Vec2{2, 2})
+	zone.OnEnter = func(world *ecs.World, zone, other ecs.EntityID) {
+		events = append(events, fmt.Sprintf("enter %d", other))
+	}
+	zone.OnStay = func(world *ecs.World, zone, other ecs.EntityID) {
+		events = append(events, fmt.Sprintf("stay %d", other))
+	}
+	zone.OnExit = func(world *ecs.World, zone, other ecs.EntityID) {
+		events = append(events, fmt.Sprintf("exit %d", other))
+	}
+	world.NewEntity().
+		WithTransform(mgl32.Vec3{5, 0, 0}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}).
+		With(zone).
+		Build()
+
+	// A body moving one unit a frame overlaps the zone from x = 4 to 6
+	body := physics.NewRigidBody(1, physics.Vector2{X: 0, Y: 0}, 1, 1, 1)
+	body.Velocity = physics.Vector2{X: 60, Y: 0}
+	e.GetPhysics().AddBody(body)
+	mover := world.NewEntity().
+		WithTransform(mgl32.Vec3{}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}).
+		With(ecs.NewPhysicsComponent(1, 1)).
+		Build()
+
+	stepFrames(e, 10)
+
+	want := []string{
+		fmt.Sprintf("enter %d", mover),
+		fmt.Sprintf("stay %d", mover),
+		fmt.Sprintf("stay %d", mover),
+		fmt.Sprintf("exit %d", mover),
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %v, want %v", events, want)
+	}
+
+	// The sensor didn't slow the body down
+	if body.Velocity.X != 60 {
+		t.Errorf("body velocity = %v, want it to pass through", body.Velocity)
+	}
+}
+
+func TestTriggerZoneRemovedWithEntity(t *testing.T) {
+	e := newHeadlessTestEngine(t)
+	world := e.GetECS()
+	world.AddSystem(NewTriggerSystem(e.GetPhysics()))
+
+	zoneID := world.NewEntity().
+		WithTransform(mgl32.Vec3{}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}).
+		With(ecs.NewTriggerZoneComponent(mgl32.Vec2{1, 1})).
+		Build()
+
+	stepFrames(e, 1)
+	sensor := e.GetPhysics().GetBody(TriggerBodyID(zoneID))
+	if sensor == nil || !sensor.Sensor || !sensor.IsStatic() {
+		t.Fatalf("zone sensor = %+v, want a static sensor body", sensor)
+	}
+
+	world.DestroyEntity(zoneID)
+	stepFrames(e, 1)
+	if e.GetPhysics().GetBody(TriggerBodyID(zoneID)) != nil {
+		t.Error("sensor body outlived its zone")
+	}
+}
@@ -0,0 +1,117 @@
+package graphics
+
+import (
+	"fmt"
+	"image"
+	"os"
+)
+
+// Async loads read and decode files on a worker goroutine, then queue the
+// GL upload, which must run on the thread owning the context. The engine
+// runs queued uploads each frame with ProcessUploads. Each load returns a
+// channel that receives the result once the asset is registered.
+
+// LoadTextureAsync loads an image file in the background and registers it
+// as a texture under id on the next ProcessUploads
+func (r *Renderer) LoadTextureAsync(id, filepath string) <-chan error {
+	result := make(chan error, 1)
+
+	go func() {
+		rgba, err := decodeImageFile(filepath)
+		if err != nil {
+			result <- err
+			return
+		}
+
+		r.queueUpload(func() {
+			result <- r.CreateTexture(id, rgba)
+		})
+	}()
+
+	return result
+}
+
+// LoadOBJAsync parses an OBJ file in the background and registers it as a
+// mesh under id on the next ProcessUploads
+func (r *Renderer) LoadOBJAsync(id, filepath string) <-chan error {
+	result := make(chan error, 1)
+
+	go func() {
+		data, err := parseOBJFile(filepath)
+		if err != nil {
+			result <- err
+			return
+		}
+
+		r.queueUpload(func() {
+			result <- r.CreateMesh(id, data.Vertices, data.Indices, PositionNormalTexCoordLayout())
+		})
+	}()
+
+	return result
+}
+
+// ProcessUploads runs up to limit queued uploads, or all of them when limit
+// is zero or less, and returns how many ran. It must be called on the GL
+// thread.
+func (r *Renderer) ProcessUploads(limit int) int {
+	r.uploadMutex.Lock()
+	count := len(r.pendingUploads)
+	if limit > 0 && limit < count {
+		count = limit
+	}
+	uploads := append([]func(){}, r.pendingUploads[:count]...)
+	r.pendingUploads = r.pendingUploads[count:]
+	r.uploadMutex.Unlock()
+
+	for _, upload := range uploads {
+		upload()
+	}
+	return count
+}
+
+// PendingUploads returns the number of decoded assets waiting to be uploaded
+func (r *Renderer) PendingUploads() int {
+	r.uploadMutex.Lock()
+	defer r.uploadMutex.Unlock()
+
+	return len(r.pendingUploads)
+}
+
+// queueUpload adds an upload for the next ProcessUploads
+func (r *Renderer) queueUpload(upload func()) {
+	r.uploadMutex.Lock()
+	defer r.uploadMutex.Unlock()
+
+	r.pendingUploads = append(r.pendingUploads, upload)
+}
+
+// decodeImageFile reads and decodes an image file into tightly packed RGBA
+func decodeImageFile(filepath string) (*image.RGBA, error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	img, _, err := image.Decode(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", filepath, err)
+	}
+	return toRGBA(img), nil
+}
+
+// parseOBJFile reads and parses an OBJ file
+func parseOBJFile(filepath string) (*OBJData, error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	data, err := ParseOBJ(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", filepath, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,25 @@
+//go:build gl
+
+package graphics
+
+import (
+	"testing"
+)
+
+func TestAsyncTextureUploadedByPump(t *testing.T) {
+	renderer := newGLRenderer(t)
+	result := renderer.LoadTextureAsync("tile", writeTestPNG(t))
+	waitForUploads(t, renderer, 1)
+
+	if count := renderer.ProcessUploads(0); count != 1 {
+		t.Fatalf("ProcessUploads() = %d, want 1", count)
+	}
+	if err := <-result; err != nil {
+		t.Fatalf("LoadTextureAsync: %v", err)
+	}
+
+	texture, exists := renderer.GetTexture("tile")
+	if !exists || texture.ID == 0 || texture.Width != 2 || texture.Height != 2 {
+		t.Errorf("texture = %+v, %v; want a registered 2x2 texture", texture, exists)
+	}
+}
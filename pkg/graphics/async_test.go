@@ -0,0 +1,125 @@
+package graphics
+
+import (
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// writeTestPNG writes a small image to a temporary file and returns its path
+func writeTestPNG(t *testing.T) string {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(1, 0, color.RGBA{R: 255, A: 255})
+	path := filepath.Join(t.TempDir(), "tile.png")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	if err := png.Encode(file, img); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// waitForUploads waits until count uploads are queued
+func waitForUploads(t *testing.T, renderer *Renderer, count int) {
+	t.Helper()
+
+	deadline := time.Now().Add(5 * time.Second)
+	for renderer.PendingUploads() < count {
+		if time.Now().After(deadline) {
+			t.Fatalf("%d uploads queued, want %d", renderer.PendingUploads(), count)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestAsyncLoadDefersUpload(t *testing.T) {
+	renderer := NewRenderer()
+	objPath := filepath.Join(t.TempDir(), "triangle.obj")
+	if err := os.WriteFile(objPath, []byte("v 0 0 0\nv 1 0 0\nv 0 1 0\nf 1 2 3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	textureResult := renderer.LoadTextureAsync("tile", writeTestPNG(t))
+	meshResult := renderer.LoadOBJAsync("triangle", objPath)
+
+	// Decoding finishes on the workers while this goroutine never pumps
+	waitForUploads(t, renderer, 2)
+
+	// Nothing is registered or reported until the uploads run on the GL thread
+	select {
+	case err := <-textureResult:
+		t.Errorf("texture result arrived before its upload: %v", err)
+	case err := <-meshResult:
+		t.Errorf("mesh result arrived before its upload: %v", err)
+	default:
+	}
+	if _, exists := renderer.GetTexture("tile"); exists {
+		t.Error("texture registered before its upload")
+	}
+	if _, exists := renderer.GetMesh("triangle"); exists {
+		t.Error("mesh registered before its upload")
+	}
+}
+
+func TestAsyncLoadErrorsSkipUpload(t *testing.T) {
+	renderer := NewRenderer()
+	dir := t.TempDir()
+	badPNG := filepath.Join(dir, "bad.png")
+	if err := os.WriteFile(badPNG, []byte("not an image"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	results := []<-chan error{
+		renderer.LoadTextureAsync("missing", filepath.Join(dir, "missing.png")),
+		renderer.LoadTextureAsync("bad", badPNG),
+		renderer.LoadOBJAsync("missing", filepath.Join(dir, "missing.obj")),
+	}
+
+	// Failed decodes report straight away without queuing an upload
+	for i, result := range results {
+		select {
+		case err := <-result:
+			if err == nil {
+				t.Errorf("load %d succeeded", i)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("load %d never reported", i)
+		}
+	}
+	if pending := renderer.PendingUploads(); pending != 0 {
+		t.Errorf("PendingUploads() = %d, want 0", pending)
+	}
+}
+
+func TestProcessUploadsLimit(t *testing.T) {
+	renderer := NewRenderer()
+	var ran []int
+	for i := 0; i < 5; i++ {
+		renderer.queueUpload(func() { ran = append(ran, i) })
+	}
+
+	// Uploads run in the order they were queued, at most limit a call
+	if count := renderer.ProcessUploads(2); count != 2 || len(ran) != 2 || ran[1] != 1 {
+		t.Errorf("ProcessUploads(2) = %d, ran %v; want 2, [0 1]", count, ran)
+	}
+	if pending := renderer.PendingUploads(); pending != 3 {
+		t.Errorf("PendingUploads() = %d, want 3", pending)
+	}
+
+	// No limit drains the queue
+	if count := renderer.ProcessUploads(0); count != 3 || len(ran) != 5 || ran[4] != 4 {
+		t.Errorf("ProcessUploads(0) = %d, ran %v; want 3, [0 1 2 3 4]", count, ran)
+	}
+	if count := renderer.ProcessUploads(0); count != 0 {
+		t.Errorf("ProcessUploads() on an empty queue = %d, want 0", count)
+	}
+}
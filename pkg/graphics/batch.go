@@ -0,0 +1,163 @@
+package graphics
+
+import (
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// SpriteVertexStride is the number of floats per sprite vertex (position(2), texcoord(2), color(4))
+const SpriteVertexStride = 8
+
+// DefaultSpriteBatchCapacity is the default number of quads a batch holds before flushing
+const DefaultSpriteBatchCapacity = 1024
+
+// FullTextureUVs are the texture coordinates covering a whole texture,
+// in bottom-left, bottom-right, top-right, top-left order
+var FullTextureUVs = [4]mgl32.Vec2{{0, 1}, {1, 1}, {1, 0}, {0, 0}}
+
+// SpriteBatch accumulates textured quads into a single dynamic vertex buffer
+// and draws them with one call per texture change or full batch
+type SpriteBatch struct {
+	capacity int
+	vertices []float32
+	texture  *Texture
+	flushes  int
+
+	// submit draws the accumulated quads; replaced when running without GL
+	submit func(texture *Texture, vertices []float32, quadCount int)
+
+	VAO uint32
+	VBO uint32
+	EBO uint32
+}
+
+// NewSpriteBatch creates a sprite batch holding up to capacity quads.
+// The batch draws nothing until its GL buffers are created with InitGL.
+func NewSpriteBatch(capacity int) *SpriteBatch {
+	if capacity <= 0 {
+		capacity = DefaultSpriteBatchCapacity
+	}
+
+	return &SpriteBatch{
+		capacity: capacity,
+		vertices: make([]float32, 0, capacity*4*SpriteVertexStride),
+		submit:   func(texture *Texture, vertices []float32, quadCount int) {},
+	}
+}
+
+// InitGL creates the dynamic vertex buffer and the static quad index buffer
+func (b *SpriteBatch) InitGL() {
+	gl.GenVertexArrays(1, &b.VAO)
+	gl.GenBuffers(1, &b.VBO)
+	gl.GenBuffers(1, &b.EBO)
+
+	gl.BindVertexArray(b.VAO)
+
+	gl.BindBuffer(gl.ARRAY_BUFFER, b.VBO)
+	gl.BufferData(gl.ARRAY_BUFFER, b.capacity*4*SpriteVertexStride*4, nil, gl.DYNAMIC_DRAW)
+
+	// Every quad uses the same two-triangle pattern
+	indices := make([]uint32, 0, b.capacity*6)
+	for i := 0; i < b.capacity; i++ {
+		base := uint32(i * 4)
+		indices = append(indices, base, base+1, base+2, base+2, base+3, base)
+	}
+	gl.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, b.EBO)
+	gl.BufferData(gl.ELEMENT_ARRAY_BUFFER, len(indices)*4, gl.Ptr(indices), gl.STATIC_DRAW)
+
+	stride := int32(SpriteVertexStride * 4)
+
+	// Position attribute
+	gl.VertexAttribPointer(0, 2, gl.FLOAT, false, stride, gl.PtrOffset(0))
+	gl.EnableVertexAttribArray(0)
+
+	// Texcoord attribute
+	gl.VertexAttribPointer(1, 2, gl.FLOAT, false, stride, gl.PtrOffset(2*4))
+	gl.EnableVertexAttribArray(1)
+
+	// Color attribute
+	gl.VertexAttribPointer(2, 4, gl.FLOAT, false, stride, gl.PtrOffset(4*4))
+	gl.EnableVertexAttribArray(2)
+
+	gl.BindVertexArray(0)
+
+	b.submit = b.drawGL
+}
+
+// Begin starts a new frame of batching
+func (b *SpriteBatch) Begin() {
+	b.vertices = b.vertices[:0]
+	b.texture = nil
+	b.flushes = 0
+}
+
+// Draw adds a quad with corners and texture coordinates in bottom-left,
+// bottom-right, top-right, top-left order
+func (b *SpriteBatch) Draw(texture *Texture, corners [4]mgl32.Vec2, uvs [4]mgl32.Vec2, color mgl32.Vec4) {
+	// Quads sharing a draw call must share a texture
+	if b.texture != nil && b.texture.ID != texture.ID {
+		b.Flush()
+	}
+	if b.QuadCount() >= b.capacity {
+		b.Flush()
+	}
+
+	b.texture = texture
+	for i := 0; i < 4; i++ {
+		b.vertices = append(b.vertices,
+			corners[i][0], corners[i][1],
+			uvs[i][0], uvs[i][1],
+			color[0], color[1], color[2], color[3],
+		)
+	}
+}
+
+// End flushes any remaining quads
+func (b *SpriteBatch) End() {
+	b.Flush()
+}
+
+// Flush draws the accumulated quads and empties the batch
+func (b *SpriteBatch) Flush() {
+	quadCount := b.QuadCount()
+	if quadCount == 0 {
+		return
+	}
+
+	b.submit(b.texture, b.vertices, quadCount)
+	b.flushes++
+	b.vertices = b.vertices[:0]
+}
+
+// QuadCount returns the number of quads waiting to be drawn
+func (b *SpriteBatch) QuadCount() int {
+	return len(b.vertices) / (4 * SpriteVertexStride)
+}
+
+// Vertices returns the accumulated vertex data
+func (b *SpriteBatch) Vertices() []float32 {
+	return b.vertices
+}
+
+// FlushCount returns the number of batches drawn since Begin
+func (b *SpriteBatch) FlushCount() int {
+	return b.flushes
+}
+
+// Delete frees the batch's GL buffers
+func (b *SpriteBatch) Delete() {
+	gl.DeleteVertexArrays(1, &b.VAO)
+	gl.DeleteBuffers(1, &b.VBO)
+	gl.DeleteBuffers(1, &b.EBO)
+}
+
+// drawGL uploads the vertices into the dynamic buffer and draws them
+func (b *SpriteBatch) drawGL(texture *Texture, vertices []float32, quadCount int) {
+	texture.Bind(0)
+
+	gl.BindVertexArray(b.VAO)
+	gl.BindBuffer(gl.ARRAY_BUFFER, b.VBO)
+	gl.BufferSubData(gl.ARRAY_BUFFER, 0, len(vertices)*4, gl.Ptr(vertices))
+	gl.DrawElements(gl.TRIANGLES, int32(quadCount*6), gl.UNSIGNED_INT, gl.PtrOffset(0))
+	gl.BindVertexArray(0)
+}
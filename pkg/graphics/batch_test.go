@@ -0,0 +1,111 @@
+package graphics
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// submission is one draw call made by a sprite batch
+type submission struct {
+	textureID uint32
+	quads     int
+	vertices  []float32
+}
+
+// recordSubmissions replaces a batch's GL draw with one that records each call
+func recordSubmissions(batch *SpriteBatch) *[]submission {
+	var submissions []submission
+	batch.submit = func(texture *Texture, vertices []float32, quadCount int) {
+		submissions = append(submissions, submission{
+			textureID: texture.ID,
+			quads:     quadCount,
+			vertices:  append([]float32(nil), vertices...),
+		})
+	}
+	return &submissions
+}
+
+// unitQuad returns the corners of a unit square with its bottom-left at (x, y)
+func unitQuad(x, y float32) [4]mgl32.Vec2 {
+	return [4]mgl32.Vec2{{x, y}, {x + 1, y}, {x + 1, y + 1}, {x, y + 1}}
+}
+
+func TestSpriteBatchFlushesOnTextureSwitch(t *testing.T) {
+	batch := NewSpriteBatch(16)
+	submissions := recordSubmissions(batch)
+	grass, stone := &Texture{ID: 1}, &Texture{ID: 2}
+	white := mgl32.Vec4{1, 1, 1, 1}
+
+	batch.Begin()
+	batch.Draw(grass, unitQuad(0, 0), FullTextureUVs, white)
+	batch.Draw(grass, unitQuad(1, 0), FullTextureUVs, white)
+	batch.Draw(stone, unitQuad(2, 0), FullTextureUVs, white)
+	batch.Draw(grass, unitQuad(3, 0), FullTextureUVs, white)
+	batch.End()
+
+	var got []uint32
+	for _, s := range *submissions {
+		got = append(got, s.textureID, uint32(s.quads))
+	}
+	// Pairs of texture ID and quad count
+	if want := []uint32{1, 2, 2, 1, 1, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("draws = %v, want %v", got, want)
+	}
+	if batch.FlushCount() != 3 {
+		t.Errorf("FlushCount() = %d, want 3", batch.FlushCount())
+	}
+	if batch.QuadCount() != 0 {
+		t.Errorf("%d quads left after End", batch.QuadCount())
+	}
+}
+
+func TestSpriteBatchFlushesAtCapacity(t *testing.T) {
+	batch := NewSpriteBatch(2)
+	submissions := recordSubmissions(batch)
+	texture := &Texture{ID: 1}
+
+	batch.Begin()
+	for i := 0; i < 5; i++ {
+		batch.Draw(texture, unitQuad(float32(i), 0), FullTextureUVs, mgl32.Vec4{1, 1, 1, 1})
+	}
+	batch.End()
+
+	var quads []int
+	for _, s := range *submissions {
+		quads = append(quads, s.quads)
+	}
+	if want := []int{2, 2, 1}; !reflect.DeepEqual(quads, want) {
+		t.Errorf("quads per draw = %v, want %v", quads, want)
+	}
+}
+
+func TestSpriteBatchVertices(t *testing.T) {
+	batch := NewSpriteBatch(16)
+	texture := &Texture{ID: 1}
+	uvs := [4]mgl32.Vec2{{0, 0.5}, {0.5, 0.5}, {0.5, 0}, {0, 0}}
+
+	batch.Begin()
+	batch.Draw(texture, unitQuad(0, 0), FullTextureUVs, mgl32.Vec4{1, 0, 0, 1})
+	batch.Draw(texture, unitQuad(5, 2), uvs, mgl32.Vec4{0, 0, 1, 0.5})
+
+	want := []float32{
+		// First quad: position, texcoord, color per corner
+		0, 0, 0, 1, 1, 0, 0, 1,
+		1, 0, 1, 1, 1, 0, 0, 1,
+		1, 1, 1, 0, 1, 0, 0, 1,
+		0, 1, 0, 0, 1, 0, 0, 1,
+		// Second quad
+		5, 2, 0, 0.5, 0, 0, 1, 0.5,
+		6, 2, 0.5, 0.5, 0, 0, 1, 0.5,
+		6, 3, 0.5, 0, 0, 0, 1, 0.5,
+		5, 3, 0, 0, 0, 0, 1, 0.5,
+	}
+	if got := batch.Vertices(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Vertices() = %v, want %v", got, want)
+	}
+	if batch.QuadCount() != 2 {
+		t.Errorf("QuadCount() = %d, want 2", batch.QuadCount())
+	}
+}
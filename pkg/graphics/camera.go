@@ -0,0 +1,87 @@
+package graphics
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Camera represents a perspective or orthographic camera
+type Camera struct {
+	Position mgl32.Vec3
+	Target   mgl32.Vec3
+	Up       mgl32.Vec3
+	FOV      float32 // Vertical field of view in degrees
+	Near     float32
+	Far      float32
+	Aspect   float32
+
+	// Orthographic projects through the Left, Right, Bottom and Top view
+	// bounds instead of FOV and Aspect
+	Orthographic bool
+	Left         float32
+	Right        float32
+	Bottom       float32
+	Top          float32
+}
+
+// NewCamera creates a new camera looking from position at target
+func NewCamera(position, target mgl32.Vec3, fov, aspect float32) *Camera {
+	return &Camera{
+		Position: position,
+		Target:   target,
+		Up:       mgl32.Vec3{0, 1, 0},
+		FOV:      fov,
+		Near:     0.1,
+		Far:      100.0,
+		Aspect:   aspect,
+	}
+}
+
+// NewOrthographicCamera creates a camera looking from position at target
+// through a box of the given view-space bounds, without perspective
+func NewOrthographicCamera(position, target mgl32.Vec3, left, right, bottom, top, near, far float32) *Camera {
+	return &Camera{
+		Position:     position,
+		Target:       target,
+		Up:           mgl32.Vec3{0, 1, 0},
+		Near:         near,
+		Far:          far,
+		Aspect:       (right - left) / (top - bottom),
+		Orthographic: true,
+		Left:         left,
+		Right:        right,
+		Bottom:       bottom,
+		Top:          top,
+	}
+}
+
+// NewDefaultCamera creates a camera at (0, 0, 3) looking at the origin
+func NewDefaultCamera() *Camera {
+	return NewCamera(mgl32.Vec3{0, 0, 3}, mgl32.Vec3{0, 0, 0}, 45.0, 800.0/600.0)
+}
+
+// Forward returns the normalized direction the camera is looking in
+func (c *Camera) Forward() mgl32.Vec3 {
+	forward := c.Target.Sub(c.Position)
+	if forward.Len() == 0 {
+		return mgl32.Vec3{0, 0, -1}
+	}
+	return forward.Normalize()
+}
+
+// SetForward points the camera along a direction, keeping its position
+func (c *Camera) SetForward(forward mgl32.Vec3) {
+	c.Target = c.Position.Add(forward)
+}
+
+// ViewMatrix returns the view matrix
+func (c *Camera) ViewMatrix() mgl32.Mat4 {
+	return mgl32.LookAtV(c.Position, c.Target, c.Up)
+}
+
+// ProjectionMatrix returns the projection matrix
+func (c *Camera) ProjectionMatrix() mgl32.Mat4 {
+	if c.Orthographic {
+		return mgl32.Ortho(c.Left, c.Right, c.Bottom, c.Top, c.Near, c.Far)
+	}
+	return mgl32.Perspective(mgl32.DegToRad(c.FOV), c.Aspect, c.Near, c.Far)
+}
@@ -0,0 +1,81 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestCameraMatrices(t *testing.T) {
+	tests := []struct {
+		name             string
+		position, target mgl32.Vec3
+		fov, aspect      float32
+	}{
+		{"default", mgl32.Vec3{0, 0, 3}, mgl32.Vec3{}, 45, 800.0 / 600.0},
+		{"above and to the side", mgl32.Vec3{5, 4, -2}, mgl32.Vec3{1, 0, 1}, 60, 16.0 / 9.0},
+		{"narrow and square", mgl32.Vec3{-3, 1, 7}, mgl32.Vec3{0, 1, 0}, 20, 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			camera := NewCamera(test.position, test.target, test.fov, test.aspect)
+
+			view := mgl32.LookAtV(test.position, test.target, mgl32.Vec3{0, 1, 0})
+			if got := camera.ViewMatrix(); got != view {
+				t.Errorf("ViewMatrix() = %v, want %v", got, view)
+			}
+
+			projection := mgl32.Perspective(mgl32.DegToRad(test.fov), test.aspect, 0.1, 100)
+			if got := camera.ProjectionMatrix(); got != projection {
+				t.Errorf("ProjectionMatrix() = %v, want %v", got, projection)
+			}
+
+			// The target lies straight ahead of the camera
+			target := camera.ViewMatrix().Mul4x1(test.target.Vec4(1))
+			if mgl32.Abs(target.X()) > 1e-5 || mgl32.Abs(target.Y()) > 1e-5 || target.Z() >= 0 {
+				t.Errorf("target is at %v in view space, want on the -Z axis", target)
+			}
+		})
+	}
+}
+
+func TestOrthographicCameraProjection(t *testing.T) {
+	camera := NewOrthographicCamera(mgl32.Vec3{0, 0, 5}, mgl32.Vec3{}, -4, 4, -3, 3, 0.1, 10)
+
+	want := mgl32.Ortho(-4, 4, -3, 3, 0.1, 10)
+	if got := camera.ProjectionMatrix(); got != want {
+		t.Errorf("ProjectionMatrix() = %v, want %v", got, want)
+	}
+	if camera.Aspect != 8.0/6.0 {
+		t.Errorf("Aspect = %v, want %v", camera.Aspect, 8.0/6.0)
+	}
+}
+
+func TestProjectionKinds(t *testing.T) {
+	perspective := NewDefaultCamera()
+	orthographic := NewOrthographicCamera(mgl32.Vec3{0, 0, 5}, mgl32.Vec3{}, -4, 4, -3, 3, 0.1, 10)
+
+	// A perspective projection copies -z into w for the perspective divide;
+	// an orthographic one leaves w at 1
+	if p := perspective.ProjectionMatrix(); p.At(3, 2) != -1 || p.At(3, 3) != 0 {
+		t.Errorf("perspective camera's w row = %v, want (0, 0, -1, 0)", p.Row(3))
+	}
+	if p := orthographic.ProjectionMatrix(); p.At(3, 2) != 0 || p.At(3, 3) != 1 {
+		t.Errorf("orthographic camera's w row = %v, want (0, 0, 0, 1)", p.Row(3))
+	}
+
+	// Points keep their size with distance only without perspective
+	near := orthographic.ProjectionMatrix().Mul4x1(mgl32.Vec4{1, 1, -1, 1})
+	far := orthographic.ProjectionMatrix().Mul4x1(mgl32.Vec4{1, 1, -9, 1})
+	if near.X() != far.X() || near.Y() != far.Y() {
+		t.Errorf("orthographic projection moved a point from %v to %v with depth", near.Vec2(), far.Vec2())
+	}
+
+	// Toggling the flag switches the projection
+	perspective.Orthographic = true
+	perspective.Left, perspective.Right, perspective.Bottom, perspective.Top = -1, 1, -1, 1
+	if got, want := perspective.ProjectionMatrix(), mgl32.Ortho(-1, 1, -1, 1, 0.1, 100); got != want {
+		t.Errorf("ProjectionMatrix() after switching = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,191 @@
+package graphics
+
+import (
+	"fmt"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// debugVertexStride is the number of floats per debug vertex (position(3), color(3))
+const debugVertexStride = 6
+
+// DebugLine represents a colored line segment drawn for debugging
+type DebugLine struct {
+	Start mgl32.Vec3
+	End   mgl32.Vec3
+	Color mgl32.Vec3
+}
+
+// DrawLine queues a debug line to be drawn on the next Render
+func (r *Renderer) DrawLine(start, end, color mgl32.Vec3) {
+	r.debugLines = append(r.debugLines, DebugLine{Start: start, End: end, Color: color})
+}
+
+// DrawLines queues several debug lines to be drawn on the next Render
+func (r *Renderer) DrawLines(lines []DebugLine) {
+	r.debugLines = append(r.debugLines, lines...)
+}
+
+// RectLines builds the outline of a rectangle from min to max in the plane z
+func RectLines(min, max mgl32.Vec2, z float32, color mgl32.Vec3) []DebugLine {
+	corners := [4]mgl32.Vec3{
+		{min.X(), min.Y(), z},
+		{max.X(), min.Y(), z},
+		{max.X(), max.Y(), z},
+		{min.X(), max.Y(), z},
+	}
+
+	lines := make([]DebugLine, 4)
+	for i := range corners {
+		lines[i] = DebugLine{Start: corners[i], End: corners[(i+1)%4], Color: color}
+	}
+	return lines
+}
+
+// BoxLines builds the 12 edges of an axis-aligned box from min to max
+func BoxLines(min, max mgl32.Vec3, color mgl32.Vec3) []DebugLine {
+	bottom := RectLines(min.Vec2(), max.Vec2(), min.Z(), color)
+	top := RectLines(min.Vec2(), max.Vec2(), max.Z(), color)
+
+	lines := append(bottom, top...)
+	for _, edge := range bottom {
+		end := edge.Start
+		end[2] = max.Z()
+		lines = append(lines, DebugLine{Start: edge.Start, End: end, Color: color})
+	}
+	return lines
+}
+
+// DrawMeshNormals queues a debug line along each vertex normal of a mesh
+func (r *Renderer) DrawMeshNormals(meshID string, transform mgl32.Mat4, length float32) error {
+	mesh, exists := r.GetMesh(meshID)
+	if !exists {
+		return fmt.Errorf("mesh %s not found", meshID)
+	}
+
+	lines, err := MeshNormalLines(mesh, transform, length, mgl32.Vec3{0, 1, 1})
+	if err != nil {
+		return err
+	}
+
+	r.debugLines = append(r.debugLines, lines...)
+	return nil
+}
+
+// MeshNormalLines builds a line from each vertex of a mesh along its normal
+func MeshNormalLines(mesh *Mesh, transform mgl32.Mat4, length float32, color mgl32.Vec3) ([]DebugLine, error) {
+	if mesh.NormalOffset < 0 || mesh.Stride <= 0 {
+		return nil, fmt.Errorf("mesh has no normals")
+	}
+
+	// Normals are transformed by the inverse transpose to survive non-uniform scale
+	normalMatrix := transform.Mat3().Inv().Transpose()
+
+	lines := make([]DebugLine, 0, len(mesh.Vertices)/mesh.Stride)
+	for i := 0; i+mesh.Stride <= len(mesh.Vertices); i += mesh.Stride {
+		position := mgl32.Vec3{mesh.Vertices[i], mesh.Vertices[i+1], mesh.Vertices[i+2]}
+		offset := i + mesh.NormalOffset
+		normal := mgl32.Vec3{mesh.Vertices[offset], mesh.Vertices[offset+1], mesh.Vertices[offset+2]}
+
+		start := transform.Mul4x1(position.Vec4(1)).Vec3()
+		direction := normalMatrix.Mul3x1(normal)
+		if direction.Len() == 0 {
+			continue
+		}
+
+		lines = append(lines, DebugLine{
+			Start: start,
+			End:   start.Add(direction.Normalize().Mul(length)),
+			Color: color,
+		})
+	}
+	return lines, nil
+}
+
+// createDebugPipeline creates the shader and dynamic buffer used for debug lines
+func (r *Renderer) createDebugPipeline() error {
+	vertexShaderSource := `
+		#version 410 core
+		layout (location = 0) in vec3 aPos;
+		layout (location = 1) in vec3 aColor;
+		
+		out vec3 ourColor;
+		
+		uniform mat4 view;
+		uniform mat4 projection;
+		
+		void main()
+		{
+			gl_Position = projection * view * vec4(aPos, 1.0);
+			ourColor = aColor;
+		}
+	` + "\x00"
+
+	fragmentShaderSource := `
+		#version 410 core
+		out vec4 FragColor;
+		in vec3 ourColor;
+		
+		void main()
+		{
+			FragColor = vec4(ourColor, 1.0);
+		}
+	` + "\x00"
+
+	shader, err := NewShader(vertexShaderSource, fragmentShaderSource)
+	if err != nil {
+		return err
+	}
+	r.registerShader("debug", shader)
+
+	gl.GenVertexArrays(1, &r.debugVAO)
+	gl.GenBuffers(1, &r.debugVBO)
+
+	gl.BindVertexArray(r.debugVAO)
+	gl.BindBuffer(gl.ARRAY_BUFFER, r.debugVBO)
+
+	// Position attribute
+	gl.VertexAttribPointer(0, 3, gl.FLOAT, false, debugVertexStride*4, gl.PtrOffset(0))
+	gl.EnableVertexAttribArray(0)
+
+	// Color attribute
+	gl.VertexAttribPointer(1, 3, gl.FLOAT, false, debugVertexStride*4, gl.PtrOffset(3*4))
+	gl.EnableVertexAttribArray(1)
+
+	gl.BindVertexArray(0)
+	return nil
+}
+
+// renderDebug draws and clears the queued debug lines
+func (r *Renderer) renderDebug() {
+	if len(r.debugLines) == 0 {
+		return
+	}
+
+	shader, exists := r.lookupShader("debug")
+	if !exists {
+		r.debugLines = r.debugLines[:0]
+		return
+	}
+
+	vertices := make([]float32, 0, len(r.debugLines)*2*debugVertexStride)
+	for _, line := range r.debugLines {
+		vertices = append(vertices,
+			line.Start[0], line.Start[1], line.Start[2], line.Color[0], line.Color[1], line.Color[2],
+			line.End[0], line.End[1], line.End[2], line.Color[0], line.Color[1], line.Color[2],
+		)
+	}
+
+	shader.Use()
+	shader.SetMat4("projection", r.camera.ProjectionMatrix())
+	shader.SetMat4("view", r.camera.ViewMatrix())
+
+	gl.BindVertexArray(r.debugVAO)
+	gl.BindBuffer(gl.ARRAY_BUFFER, r.debugVBO)
+	gl.BufferData(gl.ARRAY_BUFFER, len(vertices)*4, gl.Ptr(vertices), gl.STREAM_DRAW)
+	gl.DrawArrays(gl.LINES, 0, int32(len(r.debugLines)*2))
+	gl.BindVertexArray(0)
+
+	r.debugLines = r.debugLines[:0]
+}
@@ -0,0 +1,76 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// vecNear reports whether two vectors are equal within rounding error
+func vecNear(a, b mgl32.Vec3) bool {
+	return a.Sub(b).Len() < 1e-5
+}
+
+func TestMeshNormalLines(t *testing.T) {
+	// Position(3), normal(3) for three vertices; the last normal isn't unit length
+	mesh := &Mesh{
+		Vertices: []float32{
+			0, 0, 0, 0, 0, 1,
+			1, 0, 0, 1, 0, 0,
+			0, 2, 0, 0, 3, 0,
+		},
+		Stride:       6,
+		NormalOffset: 3,
+	}
+	color := mgl32.Vec3{1, 0, 0}
+
+	tests := []struct {
+		name      string
+		transform mgl32.Mat4
+		want      []DebugLine
+	}{
+		{
+			name:      "identity",
+			transform: mgl32.Ident4(),
+			want: []DebugLine{
+				{Start: mgl32.Vec3{0, 0, 0}, End: mgl32.Vec3{0, 0, 0.5}, Color: color},
+				{Start: mgl32.Vec3{1, 0, 0}, End: mgl32.Vec3{1.5, 0, 0}, Color: color},
+				{Start: mgl32.Vec3{0, 2, 0}, End: mgl32.Vec3{0, 2.5, 0}, Color: color},
+			},
+		},
+		{
+			name:      "translated and scaled",
+			transform: mgl32.Translate3D(10, 0, 0).Mul4(mgl32.Scale3D(2, 4, 1)),
+			want: []DebugLine{
+				{Start: mgl32.Vec3{10, 0, 0}, End: mgl32.Vec3{10, 0, 0.5}, Color: color},
+				{Start: mgl32.Vec3{12, 0, 0}, End: mgl32.Vec3{12.5, 0, 0}, Color: color},
+				{Start: mgl32.Vec3{10, 8, 0}, End: mgl32.Vec3{10, 8.5, 0}, Color: color},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			lines, err := MeshNormalLines(mesh, test.transform, 0.5, color)
+			if err != nil {
+				t.Fatalf("MeshNormalLines: %v", err)
+			}
+			if len(lines) != len(test.want) {
+				t.Fatalf("got %d lines, want %d", len(lines), len(test.want))
+			}
+			for i, line := range lines {
+				want := test.want[i]
+				if !vecNear(line.Start, want.Start) || !vecNear(line.End, want.End) || line.Color != want.Color {
+					t.Errorf("line %d = %+v, want %+v", i, line, want)
+				}
+			}
+		})
+	}
+}
+
+func TestMeshNormalLinesWithoutNormals(t *testing.T) {
+	mesh := &Mesh{Vertices: []float32{0, 0, 0, 1, 1, 1}, Stride: 6, NormalOffset: -1}
+	if _, err := MeshNormalLines(mesh, mgl32.Ident4(), 1, mgl32.Vec3{}); err == nil {
+		t.Error("MeshNormalLines succeeded for a mesh without normals")
+	}
+}
@@ -0,0 +1,79 @@
+package graphics
+
+import (
+	"math"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// FollowStep returns the position a following camera moves to from current
+// when tracking goal over deltaTime seconds. Along each axis the camera
+// stays put while goal is within deadzone, and otherwise closes the
+// distance to the deadzone edge by a fraction that depends only on
+// smoothing and elapsed time, so the motion is the same at any frame rate.
+func FollowStep(current, goal, deadzone mgl32.Vec3, smoothing, deltaTime float64) mgl32.Vec3 {
+	// Move only far enough to bring the goal back inside the deadzone
+	var desired mgl32.Vec3
+	for i := 0; i < 3; i++ {
+		difference := goal[i] - current[i]
+		switch {
+		case difference > deadzone[i]:
+			desired[i] = goal[i] - deadzone[i]
+		case difference < -deadzone[i]:
+			desired[i] = goal[i] + deadzone[i]
+		default:
+			desired[i] = current[i]
+		}
+	}
+
+	if smoothing <= 0 {
+		return desired
+	}
+	factor := float32(1 - math.Exp(-smoothing*deltaTime))
+	return current.Add(desired.Sub(current).Mul(factor))
+}
+
+// CameraFollowSystem moves a renderer's camera or sprite view after the
+// target of the first camera follow component each frame
+type CameraFollowSystem struct {
+	renderer *Renderer
+}
+
+// NewCameraFollowSystem creates a camera follow system driving renderer's view
+func NewCameraFollowSystem(renderer *Renderer) *CameraFollowSystem {
+	return &CameraFollowSystem{renderer: renderer}
+}
+
+// Update moves the view towards the target position plus offset
+func (s *CameraFollowSystem) Update(deltaTime float64, world *ecs.World) {
+	for _, entityID := range world.GetEntitiesWithComponent("camera_follow") {
+		follow, ok := world.GetComponent(entityID, "camera_follow").(*ecs.CameraFollowComponent)
+		if !ok {
+			continue
+		}
+		transform, ok := world.GetComponent(follow.Target, "transform").(*ecs.TransformComponent)
+		if !ok {
+			continue
+		}
+
+		goal := transform.Position.Add(follow.Offset)
+		if follow.Sprite {
+			current := s.renderer.spriteViewCenter.Vec3(0)
+			s.renderer.spriteViewCenter = FollowStep(current, goal, follow.Deadzone, follow.Smoothing, deltaTime).Vec2()
+			return
+		}
+
+		// Keep the camera looking in the same direction while it moves
+		camera := s.renderer.GetCamera()
+		position := FollowStep(camera.Position, goal, follow.Deadzone, follow.Smoothing, deltaTime)
+		camera.Target = camera.Target.Add(position.Sub(camera.Position))
+		camera.Position = position
+		return
+	}
+}
+
+// GetName returns the system name
+func (s *CameraFollowSystem) GetName() string {
+	return "CameraFollowSystem"
+}
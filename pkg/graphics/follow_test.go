@@ -0,0 +1,107 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestFollowStepConverges(t *testing.T) {
+	goal := mgl32.Vec3{10, -4, 2}
+	position := mgl32.Vec3{}
+
+	previous := goal.Sub(position).Len()
+	for i := 0; i < 120; i++ {
+		position = FollowStep(position, goal, mgl32.Vec3{}, 5, 1.0/60)
+		distance := goal.Sub(position).Len()
+		if distance > previous {
+			t.Fatalf("tick %d: distance grew from %v to %v", i, previous, distance)
+		}
+		previous = distance
+	}
+	if distance := goal.Sub(position).Len(); distance > 0.01 {
+		t.Errorf("after two seconds the camera is %v from the goal, want within 0.01", distance)
+	}
+
+	// No smoothing snaps straight to the goal
+	if got := FollowStep(mgl32.Vec3{}, goal, mgl32.Vec3{}, 0, 1.0/60); got != goal {
+		t.Errorf("unsmoothed FollowStep() = %v, want %v", got, goal)
+	}
+}
+
+func TestFollowStepFrameRateIndependent(t *testing.T) {
+	goal := mgl32.Vec3{8, 0, 0}
+
+	slow := FollowStep(mgl32.Vec3{}, goal, mgl32.Vec3{}, 3, 0.1)
+	fast := mgl32.Vec3{}
+	for i := 0; i < 10; i++ {
+		fast = FollowStep(fast, goal, mgl32.Vec3{}, 3, 0.01)
+	}
+
+	if !vecNear(slow, fast) {
+		t.Errorf("one 0.1 s step reached %v, ten 0.01 s steps reached %v", slow, fast)
+	}
+}
+
+func TestFollowStepDeadzone(t *testing.T) {
+	deadzone := mgl32.Vec3{2, 1, 0}
+
+	// Inside the deadzone the camera stays put
+	if got := FollowStep(mgl32.Vec3{}, mgl32.Vec3{1.5, -1, 0}, deadzone, 0, 1); got != (mgl32.Vec3{}) {
+		t.Errorf("FollowStep() inside the deadzone = %v, want no movement", got)
+	}
+
+	// Outside it the camera moves just far enough to bring the goal back to the edge
+	if got, want := FollowStep(mgl32.Vec3{}, mgl32.Vec3{5, -3, 0}, deadzone, 0, 1), (mgl32.Vec3{3, -2, 0}); got != want {
+		t.Errorf("FollowStep() outside the deadzone = %v, want %v", got, want)
+	}
+}
+
+func TestCameraFollowSystem(t *testing.T) {
+	renderer := NewRenderer()
+	camera := renderer.GetCamera()
+	camera.Position = mgl32.Vec3{0, 0, 10}
+	camera.Target = mgl32.Vec3{0, 0, 0}
+
+	world := ecs.NewWorld()
+	world.AddSystem(NewCameraFollowSystem(renderer))
+	target := world.NewEntity().
+		WithTransform(mgl32.Vec3{4, 2, 0}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}).
+		Build()
+	world.AddComponent(world.CreateEntity(), ecs.NewCameraFollowComponent(target, mgl32.Vec3{0, 0, 10}, 10))
+
+	for i := 0; i < 180; i++ {
+		world.Update(1.0 / 60)
+	}
+
+	if want := (mgl32.Vec3{4, 2, 10}); camera.Position.Sub(want).Len() > 1e-3 {
+		t.Errorf("camera Position = %v, want %v", camera.Position, want)
+	}
+	// The view direction is kept
+	if direction := camera.Target.Sub(camera.Position); !vecNear(direction, mgl32.Vec3{0, 0, -10}) {
+		t.Errorf("camera looks along %v, want (0, 0, -10)", direction)
+	}
+}
+
+func TestCameraFollowSystemSprite(t *testing.T) {
+	renderer := NewRenderer()
+	world := ecs.NewWorld()
+	world.AddSystem(NewCameraFollowSystem(renderer))
+	target := world.NewEntity().
+		WithTransform(mgl32.Vec3{3, -1, 0}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}).
+		Build()
+	follow := ecs.NewCameraFollowComponent(target, mgl32.Vec3{1, 0, 0}, 0)
+	follow.Sprite = true
+	world.AddComponent(world.CreateEntity(), follow)
+	cameraPosition := renderer.GetCamera().Position
+
+	world.Update(1.0 / 60)
+
+	if renderer.spriteViewCenter != (mgl32.Vec2{4, -1}) {
+		t.Errorf("sprite view center = %v, want (4, -1)", renderer.spriteViewCenter)
+	}
+	if renderer.GetCamera().Position != cameraPosition {
+		t.Error("sprite follow moved the 3D camera")
+	}
+}
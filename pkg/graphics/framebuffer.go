@@ -0,0 +1,157 @@
+package graphics
+
+import (
+	"fmt"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/go-gl/gl/v4.1-core/gl"
+)
+
+// Framebuffer is an off-screen render target with a color texture and an
+// optional depth attachment
+type Framebuffer struct {
+	ID    uint32
+	color *Texture
+	// depth is the depth renderbuffer, or 0 without a depth attachment
+	depth uint32
+}
+
+// NewFramebuffer creates a framebuffer of the given size. A depth attachment
+// is needed to render meshes with depth testing.
+func NewFramebuffer(width, height int, withDepth bool) (*Framebuffer, error) {
+	if err := validateFramebufferSize(width, height); err != nil {
+		return nil, err
+	}
+
+	framebuffer := &Framebuffer{color: &Texture{}}
+	gl.GenFramebuffers(1, &framebuffer.ID)
+	gl.GenTextures(1, &framebuffer.color.ID)
+	if withDepth {
+		gl.GenRenderbuffers(1, &framebuffer.depth)
+	}
+
+	gl.BindTexture(gl.TEXTURE_2D, framebuffer.color.ID)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
+	gl.BindTexture(gl.TEXTURE_2D, 0)
+
+	framebuffer.allocate(int32(width), int32(height))
+
+	gl.BindFramebuffer(gl.FRAMEBUFFER, framebuffer.ID)
+	gl.FramebufferTexture2D(gl.FRAMEBUFFER, gl.COLOR_ATTACHMENT0, gl.TEXTURE_2D, framebuffer.color.ID, 0)
+	if withDepth {
+		gl.FramebufferRenderbuffer(gl.FRAMEBUFFER, gl.DEPTH_ATTACHMENT, gl.RENDERBUFFER, framebuffer.depth)
+	}
+	status := gl.CheckFramebufferStatus(gl.FRAMEBUFFER)
+	gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+
+	if status != gl.FRAMEBUFFER_COMPLETE {
+		framebuffer.Delete()
+		return nil, fmt.Errorf("framebuffer is incomplete: status 0x%x", status)
+	}
+	return framebuffer, nil
+}
+
+// Bind directs rendering into the framebuffer and sets the viewport to its size
+func (f *Framebuffer) Bind() {
+	gl.BindFramebuffer(gl.FRAMEBUFFER, f.ID)
+	gl.Viewport(0, 0, f.color.Width, f.color.Height)
+}
+
+// Unbind directs rendering back to the window. The caller restores the
+// window's viewport.
+func (f *Framebuffer) Unbind() {
+	gl.BindFramebuffer(gl.FRAMEBUFFER, 0)
+}
+
+// ColorTexture returns the GL name of the color attachment
+func (f *Framebuffer) ColorTexture() uint32 {
+	return f.color.ID
+}
+
+// Texture returns the color attachment, for drawing it as a sprite
+func (f *Framebuffer) Texture() *Texture {
+	return f.color
+}
+
+// HasDepth returns true if the framebuffer has a depth attachment
+func (f *Framebuffer) HasDepth() bool {
+	return f.depth != 0
+}
+
+// Size returns the framebuffer dimensions in pixels
+func (f *Framebuffer) Size() (int, int) {
+	return int(f.color.Width), int(f.color.Height)
+}
+
+// Resize reallocates the attachments at a new size; their contents are lost
+func (f *Framebuffer) Resize(width, height int) error {
+	if err := validateFramebufferSize(width, height); err != nil {
+		return err
+	}
+	if int32(width) == f.color.Width && int32(height) == f.color.Height {
+		return nil
+	}
+
+	f.allocate(int32(width), int32(height))
+	return nil
+}
+
+// Delete frees the framebuffer and its attachments
+func (f *Framebuffer) Delete() {
+	gl.DeleteFramebuffers(1, &f.ID)
+	gl.DeleteTextures(1, &f.color.ID)
+	if f.depth != 0 {
+		gl.DeleteRenderbuffers(1, &f.depth)
+	}
+}
+
+// allocate sizes the attachment storage and records the new dimensions
+func (f *Framebuffer) allocate(width, height int32) {
+	gl.BindTexture(gl.TEXTURE_2D, f.color.ID)
+	gl.TexImage2D(gl.TEXTURE_2D, 0, gl.RGBA, width, height, 0, gl.RGBA, gl.UNSIGNED_BYTE, nil)
+	gl.BindTexture(gl.TEXTURE_2D, 0)
+
+	if f.depth != 0 {
+		gl.BindRenderbuffer(gl.RENDERBUFFER, f.depth)
+		gl.RenderbufferStorage(gl.RENDERBUFFER, gl.DEPTH_COMPONENT24, width, height)
+		gl.BindRenderbuffer(gl.RENDERBUFFER, 0)
+	}
+
+	f.color.Width = width
+	f.color.Height = height
+}
+
+// validateFramebufferSize checks that framebuffer dimensions are positive
+func validateFramebufferSize(width, height int) error {
+	if width <= 0 || height <= 0 {
+		return fmt.Errorf("invalid framebuffer size %dx%d", width, height)
+	}
+	return nil
+}
+
+// RenderToFramebuffer renders a world into a framebuffer, using the
+// framebuffer's size for the viewport and camera aspect, then restores the
+// window's viewport and the camera aspect
+func (r *Renderer) RenderToFramebuffer(framebuffer *Framebuffer, world *ecs.World) {
+	viewportWidth, viewportHeight := r.viewportWidth, r.viewportHeight
+	var aspect float32
+	if r.camera != nil {
+		aspect = r.camera.Aspect
+	}
+
+	framebuffer.Bind()
+	r.SetViewportSize(framebuffer.Size())
+	gl.Clear(gl.COLOR_BUFFER_BIT | gl.DEPTH_BUFFER_BIT)
+
+	r.Render(world)
+
+	framebuffer.Unbind()
+	r.SetViewportSize(viewportWidth, viewportHeight)
+	if r.camera != nil {
+		r.camera.Aspect = aspect
+	}
+	gl.Viewport(0, 0, int32(viewportWidth), int32(viewportHeight))
+}
@@ -0,0 +1,50 @@
+//go:build gl
+
+package graphics
+
+import (
+	"testing"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+)
+
+// colorTextureSize reads the size of a framebuffer's color texture back from GL
+func colorTextureSize(framebuffer *Framebuffer) (int32, int32) {
+	var width, height int32
+	gl.BindTexture(gl.TEXTURE_2D, framebuffer.ColorTexture())
+	gl.GetTexLevelParameteriv(gl.TEXTURE_2D, 0, gl.TEXTURE_WIDTH, &width)
+	gl.GetTexLevelParameteriv(gl.TEXTURE_2D, 0, gl.TEXTURE_HEIGHT, &height)
+	gl.BindTexture(gl.TEXTURE_2D, 0)
+	return width, height
+}
+
+func TestFramebufferResize(t *testing.T) {
+	newGLRenderer(t)
+
+	framebuffer, err := NewFramebuffer(128, 64, true)
+	if err != nil {
+		t.Fatalf("NewFramebuffer: %v", err)
+	}
+	defer framebuffer.Delete()
+
+	if framebuffer.ID == 0 || framebuffer.ColorTexture() == 0 || !framebuffer.HasDepth() {
+		t.Errorf("ID = %d, color = %d, depth %v; want all attachments", framebuffer.ID, framebuffer.ColorTexture(), framebuffer.HasDepth())
+	}
+	if width, height := colorTextureSize(framebuffer); width != 128 || height != 64 {
+		t.Errorf("color texture is %dx%d, want 128x64", width, height)
+	}
+
+	if err := framebuffer.Resize(32, 16); err != nil {
+		t.Fatalf("Resize: %v", err)
+	}
+	if width, height := colorTextureSize(framebuffer); width != 32 || height != 16 {
+		t.Errorf("color texture is %dx%d after resize, want 32x16", width, height)
+	}
+
+	framebuffer.Bind()
+	status := gl.CheckFramebufferStatus(gl.FRAMEBUFFER)
+	framebuffer.Unbind()
+	if status != gl.FRAMEBUFFER_COMPLETE {
+		t.Errorf("framebuffer status after resize = 0x%x, want complete", status)
+	}
+}
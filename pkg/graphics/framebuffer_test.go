@@ -0,0 +1,61 @@
+package graphics
+
+import (
+	"testing"
+)
+
+func TestValidateFramebufferSize(t *testing.T) {
+	tests := []struct {
+		width, height int
+		valid         bool
+	}{
+		{256, 128, true},
+		{1, 1, true},
+		{0, 128, false},
+		{256, 0, false},
+		{-1, 64, false},
+	}
+
+	for _, test := range tests {
+		err := validateFramebufferSize(test.width, test.height)
+		if (err == nil) != test.valid {
+			t.Errorf("validateFramebufferSize(%d, %d) = %v, want valid %v", test.width, test.height, err, test.valid)
+		}
+	}
+}
+
+func TestFramebufferBookkeeping(t *testing.T) {
+	// Built by hand; none of these paths touch GL
+	framebuffer := &Framebuffer{ID: 3, color: &Texture{ID: 7, Width: 320, Height: 240}, depth: 9}
+
+	if got := framebuffer.ColorTexture(); got != 7 {
+		t.Errorf("ColorTexture() = %d, want 7", got)
+	}
+	if framebuffer.Texture() != framebuffer.color {
+		t.Error("Texture() isn't the color attachment")
+	}
+	if !framebuffer.HasDepth() {
+		t.Error("HasDepth() = false with a depth renderbuffer")
+	}
+	if (&Framebuffer{color: &Texture{}}).HasDepth() {
+		t.Error("HasDepth() = true without a depth renderbuffer")
+	}
+	if width, height := framebuffer.Size(); width != 320 || height != 240 {
+		t.Errorf("Size() = %dx%d, want 320x240", width, height)
+	}
+}
+
+func TestFramebufferResizeKeepsSizeOnError(t *testing.T) {
+	framebuffer := &Framebuffer{color: &Texture{Width: 320, Height: 240}}
+
+	if err := framebuffer.Resize(0, 100); err == nil {
+		t.Error("Resize to zero width succeeded")
+	}
+	// Resizing to the current size is a no-op
+	if err := framebuffer.Resize(320, 240); err != nil {
+		t.Errorf("Resize to the same size: %v", err)
+	}
+	if width, height := framebuffer.Size(); width != 320 || height != 240 {
+		t.Errorf("Size() = %dx%d after rejected resizes, want 320x240", width, height)
+	}
+}
@@ -0,0 +1,107 @@
+package graphics
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Plane represents the plane Normal·p + D = 0 with a unit normal pointing inside
+type Plane struct {
+	Normal mgl32.Vec3
+	D      float32
+}
+
+// Distance returns the signed distance from the plane to a point
+func (p Plane) Distance(point mgl32.Vec3) float32 {
+	return p.Normal.Dot(point) + p.D
+}
+
+// Frustum is the set of six planes bounding the visible volume
+// in left, right, bottom, top, near, far order
+type Frustum [6]Plane
+
+// FrustumFromMatrix extracts the frustum planes of a view-projection matrix
+func FrustumFromMatrix(viewProjection mgl32.Mat4) Frustum {
+	row0 := viewProjection.Row(0)
+	row1 := viewProjection.Row(1)
+	row2 := viewProjection.Row(2)
+	row3 := viewProjection.Row(3)
+
+	planes := [6]mgl32.Vec4{
+		row3.Add(row0), // Left
+		row3.Sub(row0), // Right
+		row3.Add(row1), // Bottom
+		row3.Sub(row1), // Top
+		row3.Add(row2), // Near
+		row3.Sub(row2), // Far
+	}
+
+	var frustum Frustum
+	for i, plane := range planes {
+		normal := plane.Vec3()
+		length := normal.Len()
+		if length == 0 {
+			continue
+		}
+		frustum[i] = Plane{Normal: normal.Mul(1 / length), D: plane.W() / length}
+	}
+	return frustum
+}
+
+// ContainsSphere returns false only if the sphere is entirely outside the frustum
+func (f Frustum) ContainsSphere(center mgl32.Vec3, radius float32) bool {
+	for _, plane := range f {
+		if plane.Distance(center) < -radius {
+			return false
+		}
+	}
+	return true
+}
+
+// Frustum returns the camera's view frustum
+func (c *Camera) Frustum() Frustum {
+	return FrustumFromMatrix(c.ProjectionMatrix().Mul4(c.ViewMatrix()))
+}
+
+// boundingRadius returns the largest distance of a vertex position from the origin
+func boundingRadius(vertices []float32, stride int) float32 {
+	var radius float32
+	for i := 0; i+3 <= len(vertices); i += stride {
+		length := mgl32.Vec3{vertices[i], vertices[i+1], vertices[i+2]}.Len()
+		if length > radius {
+			radius = length
+		}
+	}
+	return radius
+}
+
+// boundingBox returns the corners of the box enclosing all vertex positions
+func boundingBox(vertices []float32, stride int) (mgl32.Vec3, mgl32.Vec3) {
+	if len(vertices) < 3 {
+		return mgl32.Vec3{}, mgl32.Vec3{}
+	}
+
+	min := mgl32.Vec3{vertices[0], vertices[1], vertices[2]}
+	max := min
+	for i := stride; i+3 <= len(vertices); i += stride {
+		for axis := 0; axis < 3; axis++ {
+			if value := vertices[i+axis]; value < min[axis] {
+				min[axis] = value
+			} else if value > max[axis] {
+				max[axis] = value
+			}
+		}
+	}
+	return min, max
+}
+
+// maxScale returns the largest absolute component of a scale
+func maxScale(scale mgl32.Vec3) float32 {
+	largest := mgl32.Abs(scale.X())
+	if y := mgl32.Abs(scale.Y()); y > largest {
+		largest = y
+	}
+	if z := mgl32.Abs(scale.Z()); z > largest {
+		largest = z
+	}
+	return largest
+}
@@ -0,0 +1,98 @@
+package graphics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// newFrustumTestCamera returns a camera at the origin looking down -Z with a
+// 90 degree square view from 1 to 10 units, so each side plane is at 45 degrees
+func newFrustumTestCamera() *Camera {
+	camera := NewCamera(mgl32.Vec3{}, mgl32.Vec3{0, 0, -1}, 90, 1)
+	camera.Near = 1
+	camera.Far = 10
+	return camera
+}
+
+func TestFrustumPlanes(t *testing.T) {
+	frustum := newFrustumTestCamera().Frustum()
+	diagonal := float32(math.Sqrt2 / 2)
+
+	want := Frustum{
+		{Normal: mgl32.Vec3{diagonal, 0, -diagonal}},  // Left
+		{Normal: mgl32.Vec3{-diagonal, 0, -diagonal}}, // Right
+		{Normal: mgl32.Vec3{0, diagonal, -diagonal}},  // Bottom
+		{Normal: mgl32.Vec3{0, -diagonal, -diagonal}}, // Top
+		{Normal: mgl32.Vec3{0, 0, -1}, D: -1},         // Near
+		{Normal: mgl32.Vec3{0, 0, 1}, D: 10},          // Far
+	}
+	for i, plane := range frustum {
+		if plane.Normal.Sub(want[i].Normal).Len() > 1e-4 || mgl32.Abs(plane.D-want[i].D) > 1e-4 {
+			t.Errorf("plane %d = %+v, want %+v", i, plane, want[i])
+		}
+	}
+}
+
+func TestFrustumContainsSphere(t *testing.T) {
+	frustum := newFrustumTestCamera().Frustum()
+
+	tests := []struct {
+		name   string
+		center mgl32.Vec3
+		radius float32
+		want   bool
+	}{
+		{"straight ahead", mgl32.Vec3{0, 0, -5}, 0.5, true},
+		{"behind the camera", mgl32.Vec3{0, 0, 5}, 1, false},
+		{"closer than near", mgl32.Vec3{0, 0, -0.2}, 0.5, false},
+		{"straddling near", mgl32.Vec3{0, 0, -0.8}, 0.5, true},
+		{"beyond far", mgl32.Vec3{0, 0, -12}, 1, false},
+		{"straddling far", mgl32.Vec3{0, 0, -10.5}, 1, true},
+		{"off to the left", mgl32.Vec3{-8, 0, -5}, 1, false},
+		{"poking in from the left", mgl32.Vec3{-5.5, 0, -5}, 1, true},
+		{"above", mgl32.Vec3{0, 8, -5}, 1, false},
+		{"below", mgl32.Vec3{0, -8, -5}, 1, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := frustum.ContainsSphere(test.center, test.radius); got != test.want {
+				t.Errorf("ContainsSphere(%v, %v) = %v, want %v", test.center, test.radius, got, test.want)
+			}
+		})
+	}
+}
+
+func TestBoundingRadius(t *testing.T) {
+	// Position(3), color(3)
+	vertices := []float32{
+		1, 0, 0, 9, 9, 9,
+		0, -3, 4, 9, 9, 9,
+		-2, 0, 0, 9, 9, 9,
+	}
+	if got := boundingRadius(vertices, 6); got != 5 {
+		t.Errorf("boundingRadius() = %v, want 5", got)
+	}
+
+	min, max := boundingBox(vertices, 6)
+	if min != (mgl32.Vec3{-2, -3, 0}) || max != (mgl32.Vec3{1, 0, 4}) {
+		t.Errorf("boundingBox() = %v, %v; want (-2, -3, 0), (1, 0, 4)", min, max)
+	}
+}
+
+func TestCubeBounds(t *testing.T) {
+	vertices, _ := CubeData()
+
+	// The corners of a unit cube are sqrt(3)/2 from its center
+	want := float32(math.Sqrt(3) / 2)
+	if got := boundingRadius(vertices, OBJVertexStride); mgl32.Abs(got-want) > 1e-6 {
+		t.Errorf("boundingRadius() = %v, want %v", got, want)
+	}
+
+	min, max := boundingBox(vertices, OBJVertexStride)
+	if min != (mgl32.Vec3{-0.5, -0.5, -0.5}) || max != (mgl32.Vec3{0.5, 0.5, 0.5}) {
+		t.Errorf("boundingBox() = %v, %v; want (-0.5, -0.5, -0.5), (0.5, 0.5, 0.5)", min, max)
+	}
+}
@@ -0,0 +1,51 @@
+//go:build gl
+
+package graphics
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// newGLRenderer opens a hidden window with an OpenGL 4.1 context and returns
+// an initialized renderer drawing to it. The test is skipped where no
+// context can be created, such as on a machine without a display.
+func newGLRenderer(t testing.TB) *Renderer {
+	t.Helper()
+
+	// GL calls must stay on the thread that owns the context
+	runtime.LockOSThread()
+	t.Cleanup(runtime.UnlockOSThread)
+
+	if err := glfw.Init(); err != nil {
+		t.Skipf("no OpenGL context: %v", err)
+	}
+	t.Cleanup(glfw.Terminate)
+
+	glfw.WindowHint(glfw.Visible, glfw.False)
+	glfw.WindowHint(glfw.ContextVersionMajor, 4)
+	glfw.WindowHint(glfw.ContextVersionMinor, 1)
+	glfw.WindowHint(glfw.OpenGLProfile, glfw.OpenGLCoreProfile)
+	glfw.WindowHint(glfw.OpenGLForwardCompatible, glfw.True)
+
+	window, err := glfw.CreateWindow(64, 64, "test", nil, nil)
+	if err != nil {
+		t.Skipf("no OpenGL context: %v", err)
+	}
+	t.Cleanup(window.Destroy)
+	window.MakeContextCurrent()
+
+	if err := gl.Init(); err != nil {
+		t.Fatalf("gl.Init: %v", err)
+	}
+
+	renderer := NewRenderer()
+	if err := renderer.Init(); err != nil {
+		t.Fatalf("Init: %v", err)
+	}
+	t.Cleanup(renderer.Shutdown)
+	return renderer
+}
@@ -0,0 +1,123 @@
+package graphics
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"unsafe"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+)
+
+// maxGLErrors bounds how many queued errors CheckGLError drains, since
+// glGetError keeps failing without a current context
+const maxGLErrors = 16
+
+// glErrorName returns the name of a glGetError code
+func glErrorName(code uint32) string {
+	switch code {
+	case gl.NO_ERROR:
+		return "GL_NO_ERROR"
+	case gl.INVALID_ENUM:
+		return "GL_INVALID_ENUM"
+	case gl.INVALID_VALUE:
+		return "GL_INVALID_VALUE"
+	case gl.INVALID_OPERATION:
+		return "GL_INVALID_OPERATION"
+	case gl.INVALID_FRAMEBUFFER_OPERATION:
+		return "GL_INVALID_FRAMEBUFFER_OPERATION"
+	case gl.OUT_OF_MEMORY:
+		return "GL_OUT_OF_MEMORY"
+	case gl.STACK_UNDERFLOW:
+		return "GL_STACK_UNDERFLOW"
+	case gl.STACK_OVERFLOW:
+		return "GL_STACK_OVERFLOW"
+	default:
+		return fmt.Sprintf("GL error 0x%04X", code)
+	}
+}
+
+// CheckGLError drains the GL error queue and returns the errors found, tagged
+// with where the check was made, or nil if there were none
+func CheckGLError(tag string) error {
+	var names []string
+	for i := 0; i < maxGLErrors; i++ {
+		code := gl.GetError()
+		if code == gl.NO_ERROR {
+			break
+		}
+		names = append(names, glErrorName(code))
+	}
+
+	if len(names) == 0 {
+		return nil
+	}
+	return fmt.Errorf("%s: %s", tag, strings.Join(names, ", "))
+}
+
+// SetDebug enables checking for GL errors after resource creation and each
+// render pass, logging any found. Where the driver supports KHR_debug, GL
+// debug messages are logged too. It must be called on the GL thread after Init.
+func (r *Renderer) SetDebug(enabled bool) {
+	r.debug = enabled
+
+	if !hasGLExtension("GL_KHR_debug") {
+		return
+	}
+	if enabled {
+		gl.Enable(gl.DEBUG_OUTPUT)
+		gl.Enable(gl.DEBUG_OUTPUT_SYNCHRONOUS)
+		gl.DebugMessageCallback(logGLDebugMessage, nil)
+	} else {
+		gl.Disable(gl.DEBUG_OUTPUT)
+	}
+}
+
+// IsDebug returns true if GL error checking is enabled
+func (r *Renderer) IsDebug() bool {
+	return r.debug
+}
+
+// checkGLError logs any GL errors when debugging is enabled
+func (r *Renderer) checkGLError(tag string) {
+	if !r.debug {
+		return
+	}
+	if err := CheckGLError(tag); err != nil {
+		log.Println(err)
+	}
+}
+
+// logGLDebugMessage logs a KHR_debug message, skipping notifications
+func logGLDebugMessage(source, messageType, id, severity uint32, length int32, message string, userParam unsafe.Pointer) {
+	if severity == gl.DEBUG_SEVERITY_NOTIFICATION {
+		return
+	}
+	log.Printf("GL debug (%s): %s", glDebugSeverityName(severity), message)
+}
+
+// glDebugSeverityName returns a short name for a debug message severity
+func glDebugSeverityName(severity uint32) string {
+	switch severity {
+	case gl.DEBUG_SEVERITY_HIGH:
+		return "high"
+	case gl.DEBUG_SEVERITY_MEDIUM:
+		return "medium"
+	case gl.DEBUG_SEVERITY_LOW:
+		return "low"
+	default:
+		return "notification"
+	}
+}
+
+// hasGLExtension reports whether the current context supports an extension
+func hasGLExtension(name string) bool {
+	var count int32
+	gl.GetIntegerv(gl.NUM_EXTENSIONS, &count)
+	for i := int32(0); i < count; i++ {
+		if gl.GoStr(gl.GetStringi(gl.EXTENSIONS, uint32(i))) == name {
+			return true
+		}
+	}
+	return false
+}
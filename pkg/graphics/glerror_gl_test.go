@@ -0,0 +1,32 @@
+//go:build gl
+
+package graphics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+)
+
+func TestCheckGLError(t *testing.T) {
+	newGLRenderer(t)
+	if err := CheckGLError("setup"); err != nil {
+		t.Fatalf("CheckGLError() = %v before any bad call", err)
+	}
+
+	// Binding to a made-up target is an invalid enum
+	gl.BindBuffer(0x1234, 0)
+	err := CheckGLError("bind")
+	if err == nil {
+		t.Fatal("CheckGLError() = nil after an invalid enum")
+	}
+	if !strings.HasPrefix(err.Error(), "bind: ") || !strings.Contains(err.Error(), "GL_INVALID_ENUM") {
+		t.Errorf("CheckGLError() = %q, want the tag and GL_INVALID_ENUM", err)
+	}
+
+	// The queue was drained
+	if err := CheckGLError("after"); err != nil {
+		t.Errorf("CheckGLError() = %v after draining", err)
+	}
+}
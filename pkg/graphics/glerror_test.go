@@ -0,0 +1,47 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+)
+
+func TestGLErrorName(t *testing.T) {
+	tests := []struct {
+		code uint32
+		want string
+	}{
+		{gl.NO_ERROR, "GL_NO_ERROR"},
+		{gl.INVALID_ENUM, "GL_INVALID_ENUM"},
+		{gl.INVALID_VALUE, "GL_INVALID_VALUE"},
+		{gl.INVALID_OPERATION, "GL_INVALID_OPERATION"},
+		{gl.INVALID_FRAMEBUFFER_OPERATION, "GL_INVALID_FRAMEBUFFER_OPERATION"},
+		{gl.OUT_OF_MEMORY, "GL_OUT_OF_MEMORY"},
+		{gl.STACK_UNDERFLOW, "GL_STACK_UNDERFLOW"},
+		{gl.STACK_OVERFLOW, "GL_STACK_OVERFLOW"},
+		// Unknown codes are shown in hex
+		{0x1234, "GL error 0x1234"},
+	}
+	for _, test := range tests {
+		if got := glErrorName(test.code); got != test.want {
+			t.Errorf("glErrorName(0x%04X) = %q, want %q", test.code, got, test.want)
+		}
+	}
+}
+
+func TestGLDebugSeverityName(t *testing.T) {
+	tests := []struct {
+		severity uint32
+		want     string
+	}{
+		{gl.DEBUG_SEVERITY_HIGH, "high"},
+		{gl.DEBUG_SEVERITY_MEDIUM, "medium"},
+		{gl.DEBUG_SEVERITY_LOW, "low"},
+		{gl.DEBUG_SEVERITY_NOTIFICATION, "notification"},
+	}
+	for _, test := range tests {
+		if got := glDebugSeverityName(test.severity); got != test.want {
+			t.Errorf("glDebugSeverityName(0x%04X) = %q, want %q", test.severity, got, test.want)
+		}
+	}
+}
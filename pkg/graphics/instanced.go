@@ -0,0 +1,124 @@
+package graphics
+
+import (
+	"fmt"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// AttribInstanceModel is the first of the four attribute locations holding
+// the per-instance model matrix, one column each
+const AttribInstanceModel uint32 = 4
+
+// InstanceLayout returns the layout of the instance buffer: one column-major
+// model matrix per instance, read as four vec4 attributes
+func InstanceLayout() VertexLayout {
+	layout := VertexLayout{Stride: 16}
+	for column := 0; column < 4; column++ {
+		layout.Attributes = append(layout.Attributes, VertexAttribute{
+			Name:     fmt.Sprintf("model%d", column),
+			Location: AttribInstanceModel + uint32(column),
+			Size:     4,
+			Offset:   column * 4,
+		})
+	}
+	return layout
+}
+
+// RenderInstanced draws a mesh once per model matrix with a single draw call,
+// using the default material. It draws immediately, so call it while
+// rendering a frame, for example from a scene's Render. Instances aren't
+// frustum culled.
+func (r *Renderer) RenderInstanced(meshID string, modelMatrices []mgl32.Mat4) error {
+	mesh, exists := r.GetMesh(meshID)
+	if !exists {
+		return fmt.Errorf("mesh %s not found", meshID)
+	}
+	shader, exists := r.lookupShader("instanced")
+	if !exists {
+		return fmt.Errorf("instanced shader not initialized")
+	}
+	if len(modelMatrices) == 0 || r.camera == nil {
+		return nil
+	}
+
+	// Mat4 is 16 column-major floats, matching the instance layout
+	gl.BindBuffer(gl.ARRAY_BUFFER, r.instanceVBO)
+	gl.BufferData(gl.ARRAY_BUFFER, len(modelMatrices)*16*4, gl.Ptr(&modelMatrices[0][0]), gl.STREAM_DRAW)
+	gl.BindBuffer(gl.ARRAY_BUFFER, 0)
+
+	if !mesh.instanced {
+		r.attachInstanceBuffer(mesh)
+	}
+
+	applyPolygonMode(r.polygonMode)
+	r.beginShader(shader)
+	material, _ := r.GetMaterial(DefaultMaterialID)
+	r.applyMaterial(shader, material, nil)
+	shader.SetBool("lit", mesh.NormalOffset >= 0)
+
+	count := int32(len(modelMatrices))
+	gl.BindVertexArray(mesh.VAO)
+	if mesh.EBO != 0 {
+		gl.DrawElementsInstanced(gl.TRIANGLES, mesh.IndexCount, gl.UNSIGNED_INT, gl.PtrOffset(0), count)
+	} else {
+		gl.DrawArraysInstanced(gl.TRIANGLES, 0, mesh.VertexCount, count)
+	}
+	gl.BindVertexArray(0)
+	applyPolygonMode(PolygonFill)
+	r.checkGLError("instanced " + meshID)
+	return nil
+}
+
+// attachInstanceBuffer points a mesh's instance attributes at the shared
+// instance buffer, advancing once per instance
+func (r *Renderer) attachInstanceBuffer(mesh *Mesh) {
+	layout := InstanceLayout()
+	stride := int32(layout.Stride * 4)
+
+	gl.BindVertexArray(mesh.VAO)
+	gl.BindBuffer(gl.ARRAY_BUFFER, r.instanceVBO)
+	for _, attribute := range layout.Attributes {
+		gl.VertexAttribPointer(attribute.Location, attribute.Size, gl.FLOAT, false, stride, gl.PtrOffset(attribute.Offset*4))
+		gl.EnableVertexAttribArray(attribute.Location)
+		gl.VertexAttribDivisor(attribute.Location, 1)
+	}
+	gl.BindVertexArray(0)
+	gl.BindBuffer(gl.ARRAY_BUFFER, 0)
+
+	mesh.instanced = true
+}
+
+// createInstancedPipeline creates the instanced shader and instance buffer
+func (r *Renderer) createInstancedPipeline() error {
+	vertexShaderSource := `
+		#version 410 core
+		layout (location = 0) in vec3 aPos;
+		layout (location = 1) in vec3 aColor;
+		layout (location = 2) in vec3 aNormal;
+		layout (location = 4) in mat4 aModel;
+		
+		out vec3 ourColor;
+		out vec3 normal;
+		
+		uniform mat4 view;
+		uniform mat4 projection;
+		
+		void main()
+		{
+			gl_Position = projection * view * aModel * vec4(aPos, 1.0);
+			ourColor = aColor;
+			normal = mat3(transpose(inverse(aModel))) * aNormal;
+		}
+	` + "\x00"
+
+	shader, err := NewShader(vertexShaderSource, defaultFragmentShaderSource+"\x00")
+	if err != nil {
+		return err
+	}
+	r.registerShader("instanced", shader)
+
+	gl.GenBuffers(1, &r.instanceVBO)
+	return nil
+}
@@ -0,0 +1,52 @@
+//go:build gl
+
+package graphics
+
+import (
+	"testing"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// benchmarkInstances is the number of meshes drawn per benchmark iteration
+const benchmarkInstances = 5000
+
+// instanceGrid returns model matrices for a grid of meshes in front of the camera
+func instanceGrid(count int) []mgl32.Mat4 {
+	matrices := make([]mgl32.Mat4, count)
+	for i := range matrices {
+		matrices[i] = mgl32.Translate3D(float32(i%100)-50, float32(i/100)-25, -60)
+	}
+	return matrices
+}
+
+func BenchmarkRenderInstanced(b *testing.B) {
+	renderer := newGLRenderer(b)
+	matrices := instanceGrid(benchmarkInstances)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := renderer.RenderInstanced("default", matrices); err != nil {
+			b.Fatal(err)
+		}
+		gl.Finish()
+	}
+}
+
+func BenchmarkRenderPerEntity(b *testing.B) {
+	renderer := newGLRenderer(b)
+	world := ecs.NewWorld()
+	for _, matrix := range instanceGrid(benchmarkInstances) {
+		entityID := world.CreateEntity()
+		world.AddComponent(entityID, ecs.NewTransformComponent(matrix.Col(3).Vec3(), mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}))
+		world.AddComponent(entityID, renderer.NewMeshComponent("default"))
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		renderer.Render(world)
+		gl.Finish()
+	}
+}
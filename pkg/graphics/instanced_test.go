@@ -0,0 +1,44 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestInstanceLayout(t *testing.T) {
+	layout := InstanceLayout()
+
+	if layout.Stride != 16 {
+		t.Errorf("Stride = %d floats, want 16 for a mat4", layout.Stride)
+	}
+	if len(layout.Attributes) != 4 {
+		t.Fatalf("%d attributes, want one per matrix column", len(layout.Attributes))
+	}
+	for column, attribute := range layout.Attributes {
+		if attribute.Location != AttribInstanceModel+uint32(column) || attribute.Size != 4 || attribute.Offset != column*4 {
+			t.Errorf("column %d = %+v, want location %d, size 4, offset %d", column, attribute, AttribInstanceModel+uint32(column), column*4)
+		}
+	}
+
+	// The instance attributes must not overlap any vertex attribute
+	for _, vertexLayout := range []VertexLayout{PositionColorLayout(), PositionColorNormalLayout(), PositionNormalTexCoordLayout()} {
+		for _, attribute := range vertexLayout.Attributes {
+			if attribute.Location >= AttribInstanceModel {
+				t.Errorf("vertex attribute %s at location %d overlaps the instance matrix", attribute.Name, attribute.Location)
+			}
+		}
+	}
+}
+
+func TestInstanceLayoutMatchesMat4(t *testing.T) {
+	// Uploaded matrices are read column by column, so the translation lands
+	// in the fourth column's attribute
+	model := mgl32.Translate3D(1, 2, 3)
+	column := InstanceLayout().Attributes[3]
+
+	translation := mgl32.Vec3{model[column.Offset], model[column.Offset+1], model[column.Offset+2]}
+	if translation != (mgl32.Vec3{1, 2, 3}) {
+		t.Errorf("fourth column reads %v, want the translation (1, 2, 3)", translation)
+	}
+}
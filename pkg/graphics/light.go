@@ -0,0 +1,45 @@
+package graphics
+
+import (
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// DirectionalLight represents a light shining uniformly in one direction
+type DirectionalLight struct {
+	Direction mgl32.Vec3 // Direction the light travels in
+	Color     mgl32.Vec3
+	Ambient   float32
+}
+
+// NewDirectionalLight creates a white light shining down and away from the camera
+func NewDirectionalLight() DirectionalLight {
+	return DirectionalLight{
+		Direction: mgl32.Vec3{-0.2, -1.0, -0.3}.Normalize(),
+		Color:     mgl32.Vec3{1, 1, 1},
+		Ambient:   0.2,
+	}
+}
+
+// SetDirectionalLight sets the scene's directional light
+func (r *Renderer) SetDirectionalLight(direction, color mgl32.Vec3, ambient float32) {
+	if direction.Len() > 0 {
+		direction = direction.Normalize()
+	}
+	r.light = DirectionalLight{
+		Direction: direction,
+		Color:     color,
+		Ambient:   ambient,
+	}
+}
+
+// GetDirectionalLight returns the scene's directional light
+func (r *Renderer) GetDirectionalLight() DirectionalLight {
+	return r.light
+}
+
+// applyLight uploads the light uniforms to a shader
+func (r *Renderer) applyLight(shader UniformSetter) {
+	shader.SetVec3("lightDirection", r.light.Direction)
+	shader.SetVec3("lightColor", r.light.Color)
+	shader.SetFloat("ambientStrength", r.light.Ambient)
+}
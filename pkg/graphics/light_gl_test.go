@@ -0,0 +1,21 @@
+//go:build gl
+
+package graphics
+
+import (
+	"testing"
+)
+
+func TestDefaultShaderHasLightUniforms(t *testing.T) {
+	renderer := newGLRenderer(t)
+
+	shader, exists := renderer.lookupShader("default")
+	if !exists {
+		t.Fatal("default shader didn't compile")
+	}
+	for _, name := range []string{"lightDirection", "lightColor", "ambientStrength", "lit"} {
+		if location := shader.GetUniformLocation(name); location < 0 {
+			t.Errorf("default shader has no %s uniform", name)
+		}
+	}
+}
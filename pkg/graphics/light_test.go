@@ -0,0 +1,25 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestLightUniforms(t *testing.T) {
+	renderer := NewRenderer()
+	renderer.SetDirectionalLight(mgl32.Vec3{0, -2, 0}, mgl32.Vec3{1, 0.5, 0.25}, 0.3)
+
+	uniforms := newUniformRecorder()
+	renderer.applyLight(uniforms)
+
+	if got, want := uniforms.vec3s["lightDirection"], (mgl32.Vec3{0, -1, 0}); got != want {
+		t.Errorf("lightDirection = %v, want the normalized %v", got, want)
+	}
+	if got, want := uniforms.vec3s["lightColor"], (mgl32.Vec3{1, 0.5, 0.25}); got != want {
+		t.Errorf("lightColor = %v, want %v", got, want)
+	}
+	if got := uniforms.floats["ambientStrength"]; got != 0.3 {
+		t.Errorf("ambientStrength = %v, want 0.3", got)
+	}
+}
@@ -0,0 +1,129 @@
+package graphics
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// DefaultMaterialID is the material used by entities without a MaterialComponent
+const DefaultMaterialID = "default"
+
+// Material holds a shader and the uniform values shared by everything drawn with it.
+// Materials aren't modified at draw time; per-entity changes go through property blocks.
+type Material struct {
+	ShaderID string
+	Vec3s    map[string]mgl32.Vec3
+	Vec4s    map[string]mgl32.Vec4
+	Floats   map[string]float32
+	// Textures maps sampler uniform names to texture IDs
+	Textures map[string]string
+}
+
+// NewMaterial creates an empty material drawn with the given shader
+func NewMaterial(shaderID string) *Material {
+	return &Material{
+		ShaderID: shaderID,
+		Vec3s:    make(map[string]mgl32.Vec3),
+		Vec4s:    make(map[string]mgl32.Vec4),
+		Floats:   make(map[string]float32),
+		Textures: make(map[string]string),
+	}
+}
+
+// newDefaultMaterial creates the material used when an entity has none
+func newDefaultMaterial() *Material {
+	material := NewMaterial("default")
+	material.Vec4s["tint"] = mgl32.Vec4{1, 1, 1, 1}
+	return material
+}
+
+// RegisterMaterial registers a material under id
+func (r *Renderer) RegisterMaterial(id string, material *Material) error {
+	r.resourceMutex.Lock()
+	defer r.resourceMutex.Unlock()
+
+	if _, exists := r.shaders[material.ShaderID]; !exists {
+		return fmt.Errorf("material %s uses unknown shader %s", id, material.ShaderID)
+	}
+
+	r.materials[id] = material
+	return nil
+}
+
+// GetMaterial returns a registered material
+func (r *Renderer) GetMaterial(id string) (*Material, bool) {
+	r.resourceMutex.RLock()
+	defer r.resourceMutex.RUnlock()
+
+	material, exists := r.materials[id]
+	return material, exists
+}
+
+// ResolveVec4 returns the value of a vec4 uniform with the block override applied
+func (m *Material) ResolveVec4(name string, block *ecs.PropertyBlockComponent) mgl32.Vec4 {
+	if block != nil {
+		if value, exists := block.Vec4s[name]; exists {
+			return value
+		}
+	}
+	return m.Vec4s[name]
+}
+
+// ResolveFloat returns the value of a float uniform with the block override applied
+func (m *Material) ResolveFloat(name string, block *ecs.PropertyBlockComponent) float32 {
+	if block != nil {
+		if value, exists := block.Floats[name]; exists {
+			return value
+		}
+	}
+	return m.Floats[name]
+}
+
+// UniformSetter uploads uniform values to a shader program; *Shader implements it
+type UniformSetter interface {
+	SetVec3(name string, value mgl32.Vec3)
+	SetVec4(name string, value mgl32.Vec4)
+	SetFloat(name string, value float32)
+}
+
+// Apply uploads the material's uniforms, letting block values override them.
+// Only uniforms defined by the material are overridable so that an override
+// can't leak into the next entity drawn with the same shader.
+func (m *Material) Apply(shader UniformSetter, block *ecs.PropertyBlockComponent) {
+	for name, value := range m.Vec3s {
+		shader.SetVec3(name, value)
+	}
+	for name := range m.Vec4s {
+		shader.SetVec4(name, m.ResolveVec4(name, block))
+	}
+	for name := range m.Floats {
+		shader.SetFloat(name, m.ResolveFloat(name, block))
+	}
+}
+
+// TextureSlots returns the sampler names in the order they are bound to texture units
+func (m *Material) TextureSlots() []string {
+	names := make([]string, 0, len(m.Textures))
+	for name := range m.Textures {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// applyMaterial uploads a material's uniforms and binds its textures
+func (r *Renderer) applyMaterial(shader *Shader, material *Material, block *ecs.PropertyBlockComponent) {
+	material.Apply(shader, block)
+
+	for unit, name := range material.TextureSlots() {
+		texture, exists := r.GetTexture(material.Textures[name])
+		if !exists {
+			continue
+		}
+		texture.Bind(uint32(unit))
+		shader.SetInt(name, int32(unit))
+	}
+}
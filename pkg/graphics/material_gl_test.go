@@ -0,0 +1,59 @@
+//go:build gl
+
+package graphics
+
+import (
+	"testing"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestApplyMaterialUniforms(t *testing.T) {
+	renderer := newGLRenderer(t)
+	err := renderer.CreateShader("flat", `
+		#version 410 core
+		layout (location = 0) in vec3 aPos;
+		void main() { gl_Position = vec4(aPos, 1.0); }
+	`, `
+		#version 410 core
+		out vec4 FragColor;
+		uniform vec4 tint;
+		uniform float strength;
+		void main() { FragColor = tint * strength; }
+	`)
+	if err != nil {
+		t.Fatalf("CreateShader: %v", err)
+	}
+
+	material := NewMaterial("flat")
+	material.Vec4s["tint"] = mgl32.Vec4{0.25, 0.5, 0.75, 1}
+	material.Floats["strength"] = 2
+	if err := renderer.RegisterMaterial("flat", material); err != nil {
+		t.Fatalf("RegisterMaterial: %v", err)
+	}
+
+	shader, _ := renderer.lookupShader("flat")
+	shader.Use()
+	block := ecs.NewPropertyBlockComponent()
+	block.SetFloat("strength", 3)
+	renderer.applyMaterial(shader, material, block)
+
+	var tint [4]float32
+	gl.GetUniformfv(shader.ID, shader.GetUniformLocation("tint"), &tint[0])
+	if mgl32.Vec4(tint) != material.Vec4s["tint"] {
+		t.Errorf("tint uniform = %v, want %v", tint, material.Vec4s["tint"])
+	}
+
+	var strength float32
+	gl.GetUniformfv(shader.ID, shader.GetUniformLocation("strength"), &strength)
+	if strength != 3 {
+		t.Errorf("strength uniform = %v, want the overridden 3", strength)
+	}
+
+	// Both locations were cached by the upload
+	if len(shader.locations) != 2 {
+		t.Errorf("%d cached locations, want 2", len(shader.locations))
+	}
+}
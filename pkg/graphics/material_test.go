@@ -0,0 +1,117 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// uniformRecorder is a UniformSetter that keeps the last value of each uniform
+type uniformRecorder struct {
+	vec3s  map[string]mgl32.Vec3
+	vec4s  map[string]mgl32.Vec4
+	floats map[string]float32
+}
+
+func newUniformRecorder() *uniformRecorder {
+	return &uniformRecorder{
+		vec3s:  make(map[string]mgl32.Vec3),
+		vec4s:  make(map[string]mgl32.Vec4),
+		floats: make(map[string]float32),
+	}
+}
+
+func (u *uniformRecorder) SetVec3(name string, value mgl32.Vec3) { u.vec3s[name] = value }
+func (u *uniformRecorder) SetVec4(name string, value mgl32.Vec4) { u.vec4s[name] = value }
+func (u *uniformRecorder) SetFloat(name string, value float32)   { u.floats[name] = value }
+
+func TestPropertyBlockOverridesOneEntity(t *testing.T) {
+	base := mgl32.Vec4{1, 1, 1, 1}
+	red := mgl32.Vec4{1, 0, 0, 1}
+
+	material := NewMaterial("default")
+	material.Vec4s["tint"] = base
+	material.Floats["shininess"] = 8
+
+	overridden := ecs.NewPropertyBlockComponent()
+	overridden.SetVec4("tint", red)
+	// Uniforms the material doesn't define aren't uploaded
+	overridden.SetFloat("unknown", 3)
+
+	// Draw the overridden entity between two entities without a block
+	var uniforms []*uniformRecorder
+	for _, block := range []*ecs.PropertyBlockComponent{nil, overridden, nil} {
+		recorder := newUniformRecorder()
+		material.Apply(recorder, block)
+		uniforms = append(uniforms, recorder)
+	}
+
+	if got := uniforms[1].vec4s["tint"]; got != red {
+		t.Errorf("overridden entity tint = %v, want %v", got, red)
+	}
+	if _, exists := uniforms[1].floats["unknown"]; exists {
+		t.Error("a uniform the material doesn't define was uploaded")
+	}
+	for _, i := range []int{0, 2} {
+		if got := uniforms[i].vec4s["tint"]; got != base {
+			t.Errorf("entity %d tint = %v, want the base %v", i, got, base)
+		}
+		if got := uniforms[i].floats["shininess"]; got != 8 {
+			t.Errorf("entity %d shininess = %v, want 8", i, got)
+		}
+	}
+	if got := material.Vec4s["tint"]; got != base {
+		t.Errorf("the shared material's tint changed to %v", got)
+	}
+
+	overridden.Clear("tint")
+	if got := material.ResolveVec4("tint", overridden); got != base {
+		t.Errorf("tint after Clear = %v, want %v", got, base)
+	}
+}
+
+func TestRegisterMaterial(t *testing.T) {
+	renderer := NewRenderer()
+	renderer.registerShader("toon", &Shader{})
+
+	material := NewMaterial("toon")
+	material.Vec3s["outlineColor"] = mgl32.Vec3{0, 0, 0}
+	if err := renderer.RegisterMaterial("outlined", material); err != nil {
+		t.Fatalf("RegisterMaterial: %v", err)
+	}
+	if got, exists := renderer.GetMaterial("outlined"); !exists || got != material {
+		t.Errorf("GetMaterial(outlined) = %v, %v; want the registered material", got, exists)
+	}
+
+	if err := renderer.RegisterMaterial("broken", NewMaterial("missing")); err == nil {
+		t.Error("registering a material with an unknown shader succeeded")
+	}
+	if _, exists := renderer.GetMaterial("broken"); exists {
+		t.Error("a rejected material was registered")
+	}
+
+	// Every renderer starts with the default material
+	if _, exists := renderer.GetMaterial(DefaultMaterialID); !exists {
+		t.Error("default material missing")
+	}
+}
+
+func TestMaterialTextureSlots(t *testing.T) {
+	material := NewMaterial("default")
+	material.Textures["normalMap"] = "bricks_normal"
+	material.Textures["albedo"] = "bricks"
+	material.Textures["roughness"] = "bricks_rough"
+
+	want := []string{"albedo", "normalMap", "roughness"}
+	got := material.TextureSlots()
+	if len(got) != len(want) {
+		t.Fatalf("TextureSlots() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("TextureSlots() = %v, want %v", got, want)
+			break
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package graphics
+
+import (
+	"fmt"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Mesh represents a 3D mesh
+type Mesh struct {
+	VAO         uint32
+	VBO         uint32
+	EBO         uint32
+	VertexCount int32
+	IndexCount  int32
+
+	// CPU-side copy of the vertex data, kept for debug drawing
+	Vertices     []float32
+	Stride       int // Floats per vertex
+	NormalOffset int // Float offset of the normal attribute, or -1 if absent
+
+	// Radius of the sphere around the mesh origin enclosing all vertices
+	BoundingRadius float32
+	// Local-space box enclosing all vertex positions
+	BoundsMin mgl32.Vec3
+	BoundsMax mgl32.Vec3
+
+	// instanced is set once the VAO reads the instance buffer
+	instanced bool
+}
+
+// Shader attribute locations shared by the built-in shaders
+const (
+	AttribPosition uint32 = 0
+	AttribColor    uint32 = 1
+	AttribNormal   uint32 = 2
+	AttribTexCoord uint32 = 3
+)
+
+// VertexAttribute describes a single float attribute of an interleaved vertex
+type VertexAttribute struct {
+	Name     string // Semantic name such as "position" or "normal"
+	Location uint32 // Shader attribute location
+	Size     int32  // Number of float components
+	Offset   int    // Offset in floats from the start of the vertex
+}
+
+// VertexLayout describes the interleaved float layout of a vertex buffer
+type VertexLayout struct {
+	Stride     int // Floats per vertex
+	Attributes []VertexAttribute
+}
+
+// PositionColorLayout returns the position(3), color(3) layout of the default mesh
+func PositionColorLayout() VertexLayout {
+	return VertexLayout{
+		Stride: 6,
+		Attributes: []VertexAttribute{
+			{Name: "position", Location: AttribPosition, Size: 3, Offset: 0},
+			{Name: "color", Location: AttribColor, Size: 3, Offset: 3},
+		},
+	}
+}
+
+// PositionColorNormalLayout returns the position(3), color(3), normal(3) layout
+func PositionColorNormalLayout() VertexLayout {
+	return VertexLayout{
+		Stride: 9,
+		Attributes: []VertexAttribute{
+			{Name: "position", Location: AttribPosition, Size: 3, Offset: 0},
+			{Name: "color", Location: AttribColor, Size: 3, Offset: 3},
+			{Name: "normal", Location: AttribNormal, Size: 3, Offset: 6},
+		},
+	}
+}
+
+// PositionNormalTexCoordLayout returns the position(3), normal(3), texcoord(2) layout of loaded models
+func PositionNormalTexCoordLayout() VertexLayout {
+	return VertexLayout{
+		Stride: OBJVertexStride,
+		Attributes: []VertexAttribute{
+			{Name: "position", Location: AttribPosition, Size: 3, Offset: 0},
+			{Name: "normal", Location: AttribNormal, Size: 3, Offset: 3},
+			{Name: "texcoord", Location: AttribTexCoord, Size: 2, Offset: 6},
+		},
+	}
+}
+
+// Validate checks that the layout fits within its stride
+func (l VertexLayout) Validate() error {
+	if l.Stride <= 0 {
+		return fmt.Errorf("vertex layout stride must be positive")
+	}
+	for _, attribute := range l.Attributes {
+		if attribute.Size < 1 || attribute.Size > 4 {
+			return fmt.Errorf("vertex attribute %s has invalid size %d", attribute.Name, attribute.Size)
+		}
+		if attribute.Offset < 0 || attribute.Offset+int(attribute.Size) > l.Stride {
+			return fmt.Errorf("vertex attribute %s exceeds the vertex stride", attribute.Name)
+		}
+	}
+	return nil
+}
+
+// offsetOf returns the float offset of the named attribute, or -1 if absent
+func (l VertexLayout) offsetOf(name string) int {
+	for _, attribute := range l.Attributes {
+		if attribute.Name == name {
+			return attribute.Offset
+		}
+	}
+	return -1
+}
+
+// CreateMesh uploads vertex data described by layout and registers it under id.
+// When indices are given an index buffer is created and the mesh is drawn indexed.
+func (r *Renderer) CreateMesh(id string, vertices []float32, indices []uint32, layout VertexLayout) error {
+	if err := layout.Validate(); err != nil {
+		return err
+	}
+	if len(vertices) == 0 || len(vertices)%layout.Stride != 0 {
+		return fmt.Errorf("vertex data length %d is not a multiple of stride %d", len(vertices), layout.Stride)
+	}
+
+	vertexCount := len(vertices) / layout.Stride
+	for _, index := range indices {
+		if int(index) >= vertexCount {
+			return fmt.Errorf("index %d out of range for %d vertices", index, vertexCount)
+		}
+	}
+
+	var VAO, VBO, EBO uint32
+	gl.GenVertexArrays(1, &VAO)
+	gl.GenBuffers(1, &VBO)
+
+	gl.BindVertexArray(VAO)
+
+	gl.BindBuffer(gl.ARRAY_BUFFER, VBO)
+	gl.BufferData(gl.ARRAY_BUFFER, len(vertices)*4, gl.Ptr(vertices), gl.STATIC_DRAW)
+
+	if len(indices) > 0 {
+		gl.GenBuffers(1, &EBO)
+		gl.BindBuffer(gl.ELEMENT_ARRAY_BUFFER, EBO)
+		gl.BufferData(gl.ELEMENT_ARRAY_BUFFER, len(indices)*4, gl.Ptr(indices), gl.STATIC_DRAW)
+	}
+
+	stride := int32(layout.Stride * 4)
+	for _, attribute := range layout.Attributes {
+		gl.VertexAttribPointer(attribute.Location, attribute.Size, gl.FLOAT, false, stride, gl.PtrOffset(attribute.Offset*4))
+		gl.EnableVertexAttribArray(attribute.Location)
+	}
+
+	gl.BindVertexArray(0)
+	r.checkGLError("mesh " + id)
+
+	boundsMin, boundsMax := boundingBox(vertices, layout.Stride)
+
+	r.resourceMutex.Lock()
+	defer r.resourceMutex.Unlock()
+
+	// Replacing a mesh frees the previous buffers
+	if previous, exists := r.meshes[id]; exists {
+		deleteMeshBuffers(previous)
+	}
+
+	r.meshes[id] = &Mesh{
+		VAO:          VAO,
+		VBO:          VBO,
+		EBO:          EBO,
+		VertexCount:  int32(vertexCount),
+		IndexCount:   int32(len(indices)),
+		Vertices:     vertices,
+		Stride:       layout.Stride,
+		NormalOffset: layout.offsetOf("normal"),
+
+		BoundingRadius: boundingRadius(vertices, layout.Stride),
+		BoundsMin:      boundsMin,
+		BoundsMax:      boundsMax,
+	}
+	return nil
+}
+
+// GetMesh returns a registered mesh
+func (r *Renderer) GetMesh(id string) (*Mesh, bool) {
+	r.resourceMutex.RLock()
+	defer r.resourceMutex.RUnlock()
+
+	mesh, exists := r.meshes[id]
+	return mesh, exists
+}
+
+// NewMeshComponent creates a mesh component with its bounds filled in from
+// the registered mesh
+func (r *Renderer) NewMeshComponent(meshID string) *ecs.MeshComponent {
+	component := ecs.NewMeshComponent(meshID)
+	if mesh, exists := r.GetMesh(meshID); exists {
+		component.BoundingRadius = mesh.BoundingRadius
+		component.BoundsMin = mesh.BoundsMin
+		component.BoundsMax = mesh.BoundsMax
+	}
+	return component
+}
+
+// TrackMeshBounds fills in the bounds of mesh components added to a world
+// without them, from the registered mesh, as they are added. Components of
+// meshes registered later keep empty bounds. It returns a function that
+// stops the tracking.
+func (r *Renderer) TrackMeshBounds(world *ecs.World) func() {
+	return world.OnComponentAdded("mesh", func(entityID ecs.EntityID, component ecs.Component) {
+		meshComponent, ok := component.(*ecs.MeshComponent)
+		if !ok || meshComponent.HasBounds() {
+			return
+		}
+		if mesh, exists := r.GetMesh(meshComponent.MeshID); exists {
+			meshComponent.BoundsMin = mesh.BoundsMin
+			meshComponent.BoundsMax = mesh.BoundsMax
+		}
+	})
+}
+
+// UnloadMesh frees a mesh's buffers and unregisters it. Unloading a missing
+// mesh does nothing; the built-in "default" mesh and meshes that are still
+// acquired can't be unloaded.
+func (r *Renderer) UnloadMesh(id string) error {
+	if id == "default" {
+		return fmt.Errorf("the default mesh can't be unloaded")
+	}
+
+	r.resourceMutex.Lock()
+	defer r.resourceMutex.Unlock()
+
+	if refs := r.meshRefs[id]; refs > 0 {
+		return fmt.Errorf("mesh %s is still used by %d references", id, refs)
+	}
+
+	if mesh, exists := r.meshes[id]; exists {
+		deleteMeshBuffers(mesh)
+		delete(r.meshes, id)
+	}
+	return nil
+}
+
+// deleteMeshBuffers frees the GL objects of a mesh. It is a variable so
+// tests can check what is freed without a GL context.
+var deleteMeshBuffers = func(mesh *Mesh) {
+	gl.DeleteVertexArrays(1, &mesh.VAO)
+	gl.DeleteBuffers(1, &mesh.VBO)
+	if mesh.EBO != 0 {
+		gl.DeleteBuffers(1, &mesh.EBO)
+	}
+}
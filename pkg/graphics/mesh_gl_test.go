@@ -0,0 +1,68 @@
+//go:build gl
+
+package graphics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestCreateIndexedMesh(t *testing.T) {
+	renderer := newGLRenderer(t)
+	vertices := []float32{
+		0, 0, 0, 1, 1, 1,
+		1, 0, 0, 1, 1, 1,
+		1, 1, 0, 1, 1, 1,
+		0, 1, 0, 1, 1, 1,
+	}
+
+	if err := renderer.CreateMesh("quad", vertices, []uint32{0, 1, 2, 0, 2, 3}, PositionColorLayout()); err != nil {
+		t.Fatalf("CreateMesh: %v", err)
+	}
+
+	mesh, exists := renderer.GetMesh("quad")
+	if !exists {
+		t.Fatal("quad not registered")
+	}
+	if mesh.VAO == 0 || mesh.VBO == 0 {
+		t.Errorf("VAO = %d, VBO = %d; want non-zero handles", mesh.VAO, mesh.VBO)
+	}
+	if mesh.EBO == 0 {
+		t.Error("indexed mesh has no EBO")
+	}
+	if mesh.VertexCount != 4 || mesh.IndexCount != 6 {
+		t.Errorf("VertexCount = %d, IndexCount = %d; want 4, 6", mesh.VertexCount, mesh.IndexCount)
+	}
+
+	// Without indices no index buffer is made
+	if err := renderer.CreateMesh("triangle", vertices[:18], nil, PositionColorLayout()); err != nil {
+		t.Fatalf("CreateMesh: %v", err)
+	}
+	if triangle, _ := renderer.GetMesh("triangle"); triangle.EBO != 0 {
+		t.Errorf("non-indexed mesh has EBO %d", triangle.EBO)
+	}
+}
+
+func TestCreateCubeBounds(t *testing.T) {
+	renderer := newGLRenderer(t)
+	if err := renderer.CreateCube("cube"); err != nil {
+		t.Fatalf("CreateCube: %v", err)
+	}
+
+	mesh, _ := renderer.GetMesh("cube")
+	want := float32(math.Sqrt(3) / 2)
+	if mgl32.Abs(mesh.BoundingRadius-want) > 1e-6 {
+		t.Errorf("BoundingRadius = %v, want %v", mesh.BoundingRadius, want)
+	}
+
+	// Components made through the renderer carry the mesh's bounds
+	component := renderer.NewMeshComponent("cube")
+	if component.BoundingRadius != mesh.BoundingRadius {
+		t.Errorf("component BoundingRadius = %v, want %v", component.BoundingRadius, mesh.BoundingRadius)
+	}
+	if component.BoundsMin != (mgl32.Vec3{-0.5, -0.5, -0.5}) || component.BoundsMax != (mgl32.Vec3{0.5, 0.5, 0.5}) {
+		t.Errorf("component bounds = %v, %v; want the unit cube", component.BoundsMin, component.BoundsMax)
+	}
+}
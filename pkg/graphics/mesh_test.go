@@ -0,0 +1,53 @@
+package graphics
+
+import (
+	"testing"
+)
+
+func TestVertexLayoutValidate(t *testing.T) {
+	valid := []VertexLayout{PositionColorLayout(), PositionColorNormalLayout(), PositionNormalTexCoordLayout()}
+	for _, layout := range valid {
+		if err := layout.Validate(); err != nil {
+			t.Errorf("built-in layout with stride %d: %v", layout.Stride, err)
+		}
+	}
+
+	invalid := map[string]VertexLayout{
+		"zero stride":        {Stride: 0},
+		"attribute too big":  {Stride: 6, Attributes: []VertexAttribute{{Name: "position", Size: 5}}},
+		"attribute past end": {Stride: 6, Attributes: []VertexAttribute{{Name: "color", Size: 3, Offset: 4}}},
+	}
+	for name, layout := range invalid {
+		if err := layout.Validate(); err == nil {
+			t.Errorf("%s: Validate succeeded, want an error", name)
+		}
+	}
+}
+
+func TestCreateMeshRejectsBadData(t *testing.T) {
+	renderer := NewRenderer()
+	quad := []float32{
+		0, 0, 0, 1, 1, 1,
+		1, 0, 0, 1, 1, 1,
+		1, 1, 0, 1, 1, 1,
+		0, 1, 0, 1, 1, 1,
+	}
+
+	// These fail validation before anything is sent to GL
+	tests := map[string]struct {
+		vertices []float32
+		indices  []uint32
+	}{
+		"no vertices":        {nil, nil},
+		"partial vertex":     {quad[:8], nil},
+		"index out of range": {quad, []uint32{0, 1, 4}},
+	}
+	for name, test := range tests {
+		if err := renderer.CreateMesh("quad", test.vertices, test.indices, PositionColorLayout()); err == nil {
+			t.Errorf("%s: CreateMesh succeeded, want an error", name)
+		}
+	}
+	if _, exists := renderer.GetMesh("quad"); exists {
+		t.Error("a rejected mesh was registered")
+	}
+}
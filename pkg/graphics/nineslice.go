@@ -0,0 +1,103 @@
+package graphics
+
+import (
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// NineSliceQuad is one of the quads of a nine-slice, with corners and
+// texture coordinates in the order used by SpriteQuad
+type NineSliceQuad struct {
+	Corners [4]mgl32.Vec2
+	UVs     [4]mgl32.Vec2
+}
+
+// NineSliceQuads splits a box of size at position into up to nine quads.
+// borders holds the left, right, bottom and top border sizes in world units
+// and uvInsets the same borders as fractions of the texture. When the box is
+// smaller than its borders along an axis, both borders shrink in proportion
+// and the stretched middle disappears. Quads without area are left out.
+func NineSliceQuads(position, size, origin mgl32.Vec2, borders, uvInsets mgl32.Vec4) []NineSliceQuad {
+	left, right := fitBorders(borders[0], borders[1], size.X())
+	bottom, top := fitBorders(borders[2], borders[3], size.Y())
+
+	minX := position.X() - origin.X()*size.X()
+	minY := position.Y() - origin.Y()*size.Y()
+	maxX := minX + size.X()
+	maxY := minY + size.Y()
+
+	xs := [4]float32{minX, minX + left, maxX - right, maxX}
+	ys := [4]float32{minY, minY + bottom, maxY - top, maxY}
+
+	// The first texture row is v = 0, so the bottom of the box maps to v = 1
+	us := [4]float32{0, uvInsets[0], 1 - uvInsets[1], 1}
+	vs := [4]float32{1, 1 - uvInsets[2], uvInsets[3], 0}
+
+	quads := make([]NineSliceQuad, 0, 9)
+	for row := 0; row < 3; row++ {
+		for column := 0; column < 3; column++ {
+			if xs[column+1] <= xs[column] || ys[row+1] <= ys[row] {
+				continue
+			}
+
+			x0, x1 := xs[column], xs[column+1]
+			y0, y1 := ys[row], ys[row+1]
+			u0, u1 := us[column], us[column+1]
+			v0, v1 := vs[row], vs[row+1]
+
+			quads = append(quads, NineSliceQuad{
+				Corners: [4]mgl32.Vec2{{x0, y0}, {x1, y0}, {x1, y1}, {x0, y1}},
+				UVs:     [4]mgl32.Vec2{{u0, v0}, {u1, v0}, {u1, v1}, {u0, v1}},
+			})
+		}
+	}
+	return quads
+}
+
+// fitBorders scales two opposite borders down to fit within length
+func fitBorders(first, second, length float32) (float32, float32) {
+	total := first + second
+	if total <= length || total <= 0 {
+		return first, second
+	}
+	scale := length / total
+	return first * scale, second * scale
+}
+
+// queueNineSlices queues the nine-slices of all entities after the other
+// sprites, so UI panels draw on top of the scene
+func (r *Renderer) queueNineSlices(world *ecs.World) {
+	for _, entityID := range world.GetEntitiesWithComponent("nine_slice") {
+		slice, ok := world.GetComponent(entityID, "nine_slice").(*ecs.NineSliceComponent)
+		if !ok || !slice.Visible {
+			continue
+		}
+		transform, ok := world.GetComponent(entityID, "transform").(*ecs.TransformComponent)
+		if !ok {
+			continue
+		}
+		texture, ok := r.GetTexture(slice.TextureID)
+		if !ok || texture.Width == 0 || texture.Height == 0 {
+			continue
+		}
+
+		borders := slice.Insets.Mul(slice.PixelSize)
+		width := float32(texture.Width)
+		height := float32(texture.Height)
+		uvInsets := mgl32.Vec4{
+			slice.Insets[0] / width,
+			slice.Insets[1] / width,
+			slice.Insets[2] / height,
+			slice.Insets[3] / height,
+		}
+
+		for _, quad := range NineSliceQuads(transform.Position.Vec2(), slice.Size, slice.Origin, borders, uvInsets) {
+			r.spriteQueue = append(r.spriteQueue, queuedQuad{
+				texture: texture,
+				corners: quad.Corners,
+				uvs:     quad.UVs,
+				color:   slice.Color,
+			})
+		}
+	}
+}
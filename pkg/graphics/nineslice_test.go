@@ -0,0 +1,138 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// quadBox returns the bottom-left and top-right corners of a nine-slice quad
+func quadBox(quad NineSliceQuad) (mgl32.Vec2, mgl32.Vec2) {
+	return quad.Corners[0], quad.Corners[2]
+}
+
+func TestNineSliceQuads(t *testing.T) {
+	borders := mgl32.Vec4{1, 2, 1, 1}
+	uvInsets := mgl32.Vec4{0.25, 0.5, 0.25, 0.25}
+
+	tests := []struct {
+		name string
+		size mgl32.Vec2
+	}{
+		{"small", mgl32.Vec2{6, 4}},
+		{"large", mgl32.Vec2{30, 12}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			quads := NineSliceQuads(mgl32.Vec2{}, test.size, mgl32.Vec2{}, borders, uvInsets)
+			if len(quads) != 9 {
+				t.Fatalf("got %d quads, want 9", len(quads))
+			}
+
+			// Corners keep their size whatever the box size
+			if min, max := quadBox(quads[0]); min != (mgl32.Vec2{0, 0}) || max != (mgl32.Vec2{1, 1}) {
+				t.Errorf("bottom-left corner spans %v to %v, want (0, 0) to (1, 1)", min, max)
+			}
+			if min, max := quadBox(quads[8]); min != test.size.Sub(mgl32.Vec2{2, 1}) || max != test.size {
+				t.Errorf("top-right corner spans %v to %v, want %v to %v", min, max, test.size.Sub(mgl32.Vec2{2, 1}), test.size)
+			}
+
+			// The center stretches over the rest
+			if min, max := quadBox(quads[4]); min != (mgl32.Vec2{1, 1}) || max != test.size.Sub(mgl32.Vec2{2, 1}) {
+				t.Errorf("center spans %v to %v, want (1, 1) to %v", min, max, test.size.Sub(mgl32.Vec2{2, 1}))
+			}
+
+			// UVs don't depend on the size; the bottom of the box is v = 1
+			if uvs := quads[0].UVs; uvs[0] != (mgl32.Vec2{0, 1}) || uvs[2] != (mgl32.Vec2{0.25, 0.75}) {
+				t.Errorf("bottom-left corner UVs = %v, want (0, 1) to (0.25, 0.75)", uvs)
+			}
+			if uvs := quads[4].UVs; uvs[0] != (mgl32.Vec2{0.25, 0.75}) || uvs[2] != (mgl32.Vec2{0.5, 0.25}) {
+				t.Errorf("center UVs = %v, want (0.25, 0.75) to (0.5, 0.25)", uvs)
+			}
+			if uvs := quads[8].UVs; uvs[0] != (mgl32.Vec2{0.5, 0.25}) || uvs[2] != (mgl32.Vec2{1, 0}) {
+				t.Errorf("top-right corner UVs = %v, want (0.5, 0.25) to (1, 0)", uvs)
+			}
+		})
+	}
+}
+
+func TestNineSliceQuadsSmallerThanBorders(t *testing.T) {
+	borders := mgl32.Vec4{1, 3, 2, 2}
+	uvInsets := mgl32.Vec4{0.25, 0.25, 0.25, 0.25}
+
+	// Too narrow: the borders shrink in proportion and the middle column goes
+	quads := NineSliceQuads(mgl32.Vec2{}, mgl32.Vec2{2, 10}, mgl32.Vec2{}, borders, uvInsets)
+	if len(quads) != 6 {
+		t.Fatalf("narrow box gave %d quads, want 6", len(quads))
+	}
+	if min, max := quadBox(quads[0]); min != (mgl32.Vec2{0, 0}) || max != (mgl32.Vec2{0.5, 2}) {
+		t.Errorf("bottom-left corner spans %v to %v, want (0, 0) to (0.5, 2)", min, max)
+	}
+	if min, max := quadBox(quads[1]); min != (mgl32.Vec2{0.5, 0}) || max != (mgl32.Vec2{2, 2}) {
+		t.Errorf("bottom-right corner spans %v to %v, want (0.5, 0) to (2, 2)", min, max)
+	}
+	// The corners still show their whole part of the texture
+	if uvs := quads[1].UVs; uvs[0] != (mgl32.Vec2{0.75, 1}) || uvs[2] != (mgl32.Vec2{1, 0.75}) {
+		t.Errorf("bottom-right corner UVs = %v, want (0.75, 1) to (1, 0.75)", uvs)
+	}
+
+	// Too small both ways: only the four corners are left
+	quads = NineSliceQuads(mgl32.Vec2{}, mgl32.Vec2{2, 2}, mgl32.Vec2{}, borders, uvInsets)
+	if len(quads) != 4 {
+		t.Errorf("tiny box gave %d quads, want 4", len(quads))
+	}
+
+	// An empty box has nothing to draw
+	if quads := NineSliceQuads(mgl32.Vec2{}, mgl32.Vec2{}, mgl32.Vec2{}, borders, uvInsets); len(quads) != 0 {
+		t.Errorf("empty box gave %d quads, want 0", len(quads))
+	}
+}
+
+func TestNineSliceQuadsOrigin(t *testing.T) {
+	quads := NineSliceQuads(mgl32.Vec2{10, 10}, mgl32.Vec2{4, 2}, mgl32.Vec2{0.5, 0.5}, mgl32.Vec4{}, mgl32.Vec4{})
+
+	// Without borders only the center is left, pivoted on its middle
+	if len(quads) != 1 {
+		t.Fatalf("got %d quads, want 1", len(quads))
+	}
+	if min, max := quadBox(quads[0]); min != (mgl32.Vec2{8, 9}) || max != (mgl32.Vec2{12, 11}) {
+		t.Errorf("quad spans %v to %v, want (8, 9) to (12, 11)", min, max)
+	}
+}
+
+func TestQueueNineSlices(t *testing.T) {
+	renderer := NewRenderer()
+	renderer.textures["panel"] = &Texture{Width: 16, Height: 16}
+
+	world := ecs.NewWorld()
+	slice := ecs.NewNineSliceComponent("panel", mgl32.Vec4{4, 4, 4, 4}, 0.25, mgl32.Vec2{10, 5})
+	slice.Color = mgl32.Vec4{1, 0, 0, 1}
+	world.NewEntity().
+		WithTransform(mgl32.Vec3{}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}).
+		With(slice).
+		Build()
+	hidden := ecs.NewNineSliceComponent("panel", mgl32.Vec4{4, 4, 4, 4}, 0.25, mgl32.Vec2{10, 5})
+	hidden.Visible = false
+	world.NewEntity().
+		WithTransform(mgl32.Vec3{}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}).
+		With(hidden).
+		Build()
+
+	renderer.queueNineSlices(world)
+
+	if len(renderer.spriteQueue) != 9 {
+		t.Fatalf("queued %d quads, want the 9 of the visible slice", len(renderer.spriteQueue))
+	}
+	// Four texture pixels at a quarter unit each make one unit borders
+	first := renderer.spriteQueue[0]
+	if first.corners[0] != (mgl32.Vec2{-5, -2.5}) || first.corners[2] != (mgl32.Vec2{-4, -1.5}) {
+		t.Errorf("first corner spans %v to %v, want (-5, -2.5) to (-4, -1.5)", first.corners[0], first.corners[2])
+	}
+	if first.uvs[2] != (mgl32.Vec2{0.25, 0.75}) {
+		t.Errorf("first corner UVs end at %v, want (0.25, 0.75)", first.uvs[2])
+	}
+	if first.color != slice.Color {
+		t.Errorf("quad color = %v, want %v", first.color, slice.Color)
+	}
+}
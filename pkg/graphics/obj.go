@@ -0,0 +1,214 @@
+package graphics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// OBJVertexStride is the number of floats per vertex produced by ParseOBJ
+// (position(3), normal(3), texcoord(2))
+const OBJVertexStride = 8
+
+// OBJData holds interleaved vertex data and indices parsed from a Wavefront OBJ file
+type OBJData struct {
+	Vertices []float32
+	Indices  []uint32
+}
+
+// VertexCount returns the number of unique vertices
+func (d *OBJData) VertexCount() int {
+	return len(d.Vertices) / OBJVertexStride
+}
+
+// objIndex references a position, texcoord and normal of a face corner.
+// A value of -1 means the attribute is absent.
+type objIndex struct {
+	v, vt, vn int
+}
+
+// LoadOBJ loads a Wavefront OBJ file and registers it as a mesh under id
+func (r *Renderer) LoadOBJ(id, filepath string) error {
+	data, err := parseOBJFile(filepath)
+	if err != nil {
+		return err
+	}
+
+	return r.CreateMesh(id, data.Vertices, data.Indices, PositionNormalTexCoordLayout())
+}
+
+// ParseOBJ parses vertex positions, normals, texcoords and faces from an OBJ source.
+// Polygons are triangulated as fans and faces without normals get flat normals.
+func ParseOBJ(reader io.Reader) (*OBJData, error) {
+	var positions []mgl32.Vec3
+	var normals []mgl32.Vec3
+	var texcoords []mgl32.Vec2
+
+	data := &OBJData{}
+	cache := make(map[objIndex]uint32)
+
+	scanner := bufio.NewScanner(reader)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		switch fields[0] {
+		case "v":
+			values, err := parseFloats(fields[1:], 3)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			positions = append(positions, mgl32.Vec3{values[0], values[1], values[2]})
+		case "vn":
+			values, err := parseFloats(fields[1:], 3)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			normals = append(normals, mgl32.Vec3{values[0], values[1], values[2]})
+		case "vt":
+			values, err := parseFloats(fields[1:], 2)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			texcoords = append(texcoords, mgl32.Vec2{values[0], values[1]})
+		case "f":
+			if len(fields) < 4 {
+				return nil, fmt.Errorf("line %d: face needs at least 3 vertices", lineNumber)
+			}
+
+			corners := make([]objIndex, 0, len(fields)-1)
+			for _, field := range fields[1:] {
+				corner, err := parseFaceIndex(field, len(positions), len(texcoords), len(normals))
+				if err != nil {
+					return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+				}
+				corners = append(corners, corner)
+			}
+
+			// Triangulate the polygon as a fan around the first corner
+			for i := 1; i+1 < len(corners); i++ {
+				triangle := [3]objIndex{corners[0], corners[i], corners[i+1]}
+				data.addTriangle(triangle, positions, texcoords, normals, cache)
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// addTriangle appends a triangle, reusing vertices that share all attributes
+func (d *OBJData) addTriangle(triangle [3]objIndex, positions []mgl32.Vec3, texcoords []mgl32.Vec2, normals []mgl32.Vec3, cache map[objIndex]uint32) {
+	// Compute a flat normal for corners that don't specify one
+	var flatNormal mgl32.Vec3
+	for _, corner := range triangle {
+		if corner.vn < 0 {
+			edge1 := positions[triangle[1].v].Sub(positions[triangle[0].v])
+			edge2 := positions[triangle[2].v].Sub(positions[triangle[0].v])
+			flatNormal = edge1.Cross(edge2)
+			if flatNormal.Len() > 0 {
+				flatNormal = flatNormal.Normalize()
+			}
+			break
+		}
+	}
+
+	for _, corner := range triangle {
+		// Corners with a flat normal belong to this face only and can't be shared
+		if corner.vn >= 0 {
+			if index, exists := cache[corner]; exists {
+				d.Indices = append(d.Indices, index)
+				continue
+			}
+		}
+
+		normal := flatNormal
+		if corner.vn >= 0 {
+			normal = normals[corner.vn]
+		}
+
+		var texcoord mgl32.Vec2
+		if corner.vt >= 0 {
+			texcoord = texcoords[corner.vt]
+		}
+
+		position := positions[corner.v]
+		index := uint32(d.VertexCount())
+		d.Vertices = append(d.Vertices,
+			position[0], position[1], position[2],
+			normal[0], normal[1], normal[2],
+			texcoord[0], texcoord[1],
+		)
+		d.Indices = append(d.Indices, index)
+
+		if corner.vn >= 0 {
+			cache[corner] = index
+		}
+	}
+}
+
+// parseFloats parses at least count floats from fields
+func parseFloats(fields []string, count int) ([]float32, error) {
+	if len(fields) < count {
+		return nil, fmt.Errorf("expected %d values, got %d", count, len(fields))
+	}
+
+	values := make([]float32, count)
+	for i := 0; i < count; i++ {
+		value, err := strconv.ParseFloat(fields[i], 32)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = float32(value)
+	}
+	return values, nil
+}
+
+// parseFaceIndex parses a face corner in the v, v/vt, v//vn or v/vt/vn form
+func parseFaceIndex(field string, positionCount, texcoordCount, normalCount int) (objIndex, error) {
+	corner := objIndex{v: -1, vt: -1, vn: -1}
+	parts := strings.Split(field, "/")
+
+	counts := []int{positionCount, texcoordCount, normalCount}
+	targets := []*int{&corner.v, &corner.vt, &corner.vn}
+	for i, part := range parts {
+		if i >= len(targets) {
+			return corner, fmt.Errorf("invalid face index %q", field)
+		}
+		if part == "" {
+			continue
+		}
+
+		value, err := strconv.Atoi(part)
+		if err != nil {
+			return corner, fmt.Errorf("invalid face index %q", field)
+		}
+
+		// OBJ indices are 1-based, negative values are relative to the end
+		if value < 0 {
+			value = counts[i] + value
+		} else {
+			value--
+		}
+		if value < 0 || value >= counts[i] {
+			return corner, fmt.Errorf("face index %q out of range", field)
+		}
+		*targets[i] = value
+	}
+
+	if corner.v < 0 {
+		return corner, fmt.Errorf("face index %q has no position", field)
+	}
+	return corner, nil
+}
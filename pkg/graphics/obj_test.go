@@ -0,0 +1,113 @@
+package graphics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestParseOBJCounts(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   string
+		vertices int
+		indices  int
+	}{
+		{
+			name: "quad with shared normals",
+			source: `
+# unit quad facing +z
+v 0 0 0
+v 1 0 0
+v 1 1 0
+v 0 1 0
+vt 0 0
+vt 1 0
+vt 1 1
+vt 0 1
+vn 0 0 1
+f 1/1/1 2/2/1 3/3/1
+f 1/1/1 3/3/1 4/4/1
+`,
+			vertices: 4,
+			indices:  6,
+		},
+		{
+			name: "polygon triangulated as a fan",
+			source: `
+v 0 0 0
+v 1 0 0
+v 1 1 0
+v 0 1 0
+vn 0 0 1
+f 1//1 2//1 3//1 4//1
+`,
+			vertices: 4,
+			indices:  6,
+		},
+		{
+			name: "faces without normals don't share vertices",
+			source: `
+v 0 0 0
+v 1 0 0
+v 1 1 0
+v 0 1 0
+f 1 2 3
+f 1 3 4
+`,
+			vertices: 6,
+			indices:  6,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			data, err := ParseOBJ(strings.NewReader(test.source))
+			if err != nil {
+				t.Fatalf("ParseOBJ: %v", err)
+			}
+			if got := data.VertexCount(); got != test.vertices {
+				t.Errorf("VertexCount() = %d, want %d", got, test.vertices)
+			}
+			if got := len(data.Indices); got != test.indices {
+				t.Errorf("len(Indices) = %d, want %d", got, test.indices)
+			}
+			if got := len(data.Vertices); got != test.vertices*OBJVertexStride {
+				t.Errorf("len(Vertices) = %d, want %d", got, test.vertices*OBJVertexStride)
+			}
+		})
+	}
+}
+
+func TestParseOBJFlatNormals(t *testing.T) {
+	data, err := ParseOBJ(strings.NewReader("v 0 0 0\nv 1 0 0\nv 0 1 0\nf 1 2 3\n"))
+	if err != nil {
+		t.Fatalf("ParseOBJ: %v", err)
+	}
+
+	want := mgl32.Vec3{0, 0, 1}
+	for i := 0; i < data.VertexCount(); i++ {
+		offset := i*OBJVertexStride + 3
+		normal := mgl32.Vec3{data.Vertices[offset], data.Vertices[offset+1], data.Vertices[offset+2]}
+		if !normal.ApproxEqual(want) {
+			t.Errorf("vertex %d normal = %v, want %v", i, normal, want)
+		}
+	}
+}
+
+func TestParseOBJErrors(t *testing.T) {
+	sources := map[string]string{
+		"short face":         "v 0 0 0\nv 1 0 0\nf 1 2\n",
+		"index out of range": "v 0 0 0\nv 1 0 0\nv 0 1 0\nf 1 2 4\n",
+		"bad number":         "v 0 zero 0\n",
+	}
+
+	for name, source := range sources {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParseOBJ(strings.NewReader(source)); err == nil {
+				t.Error("ParseOBJ succeeded, want an error")
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package graphics
+
+import (
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// queueParticles queues the live particles of all emitters as centered
+// quads, after the sprites so effects draw on top of them
+func (r *Renderer) queueParticles(world *ecs.World) {
+	world.ForEachWithComponent("particle_emitter", func(entityID ecs.EntityID, component ecs.Component) {
+		emitter, ok := component.(*ecs.ParticleEmitterComponent)
+		if !ok {
+			return
+		}
+
+		texture := r.whiteTexture
+		if emitter.TextureID != "" {
+			if texture, ok = r.GetTexture(emitter.TextureID); !ok {
+				return
+			}
+		}
+		if texture == nil {
+			return
+		}
+
+		for _, particle := range emitter.Particles() {
+			size := emitter.ParticleSize(particle)
+			r.spriteQueue = append(r.spriteQueue, queuedQuad{
+				texture: texture,
+				corners: SpriteQuad(particle.Position, mgl32.Vec2{size, size}, mgl32.Vec2{0.5, 0.5}, 0),
+				uvs:     FullTextureUVs,
+				color:   emitter.ParticleColor(particle),
+			})
+		}
+	})
+}
@@ -0,0 +1,44 @@
+package graphics
+
+import (
+	"github.com/go-gl/gl/v4.1-core/gl"
+)
+
+// PolygonMode controls how mesh triangles are rasterized
+type PolygonMode int
+
+const (
+	// PolygonFill draws filled triangles
+	PolygonFill PolygonMode = iota
+	// PolygonLine draws triangle edges only
+	PolygonLine
+	// PolygonPoint draws triangle vertices only
+	PolygonPoint
+)
+
+// GLMode returns the OpenGL constant for the polygon mode
+func (m PolygonMode) GLMode() uint32 {
+	switch m {
+	case PolygonLine:
+		return gl.LINE
+	case PolygonPoint:
+		return gl.POINT
+	default:
+		return gl.FILL
+	}
+}
+
+// SetPolygonMode sets how meshes are rasterized during Render
+func (r *Renderer) SetPolygonMode(mode PolygonMode) {
+	r.polygonMode = mode
+}
+
+// GetPolygonMode returns how meshes are rasterized during Render
+func (r *Renderer) GetPolygonMode() PolygonMode {
+	return r.polygonMode
+}
+
+// applyPolygonMode sets the GL polygon mode for both faces
+func applyPolygonMode(mode PolygonMode) {
+	gl.PolygonMode(gl.FRONT_AND_BACK, mode.GLMode())
+}
@@ -0,0 +1,34 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+)
+
+func TestPolygonModeGLMode(t *testing.T) {
+	tests := []struct {
+		mode PolygonMode
+		want uint32
+	}{
+		{PolygonFill, gl.FILL},
+		{PolygonLine, gl.LINE},
+		{PolygonPoint, gl.POINT},
+		{PolygonMode(42), gl.FILL},
+	}
+
+	for _, test := range tests {
+		if got := test.mode.GLMode(); got != test.want {
+			t.Errorf("PolygonMode(%d).GLMode() = %#x, want %#x", test.mode, got, test.want)
+		}
+	}
+
+	renderer := NewRenderer()
+	if renderer.GetPolygonMode() != PolygonFill {
+		t.Errorf("new renderer mode = %d, want PolygonFill", renderer.GetPolygonMode())
+	}
+	renderer.SetPolygonMode(PolygonLine)
+	if renderer.GetPolygonMode() != PolygonLine {
+		t.Errorf("mode after SetPolygonMode = %d, want PolygonLine", renderer.GetPolygonMode())
+	}
+}
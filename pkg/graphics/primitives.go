@@ -0,0 +1,131 @@
+package graphics
+
+import (
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Default tessellation to pass to CreateSphere and SphereData
+const (
+	DefaultSphereRings    = 16
+	DefaultSphereSegments = 32
+)
+
+// CubeData returns a unit cube centered on the origin in the
+// position(3), normal(3), texcoord(2) layout. Each face has its own four
+// vertices so normals stay flat: 24 vertices and 36 indices.
+func CubeData() ([]float32, []uint32) {
+	var vertices []float32
+	var indices []uint32
+
+	faces := [][3]mgl32.Vec3{
+		// normal, u axis, v axis; u x v points along the normal
+		{{1, 0, 0}, {0, 0, -1}, {0, 1, 0}},
+		{{-1, 0, 0}, {0, 0, 1}, {0, 1, 0}},
+		{{0, 1, 0}, {1, 0, 0}, {0, 0, -1}},
+		{{0, -1, 0}, {1, 0, 0}, {0, 0, 1}},
+		{{0, 0, 1}, {1, 0, 0}, {0, 1, 0}},
+		{{0, 0, -1}, {-1, 0, 0}, {0, 1, 0}},
+	}
+	for _, face := range faces {
+		vertices, indices = appendFace(vertices, indices, face[0].Mul(0.5), face[0], face[1], face[2])
+	}
+	return vertices, indices
+}
+
+// QuadData returns a unit quad in the XY plane facing +Z
+func QuadData() ([]float32, []uint32) {
+	return appendFace(nil, nil, mgl32.Vec3{}, mgl32.Vec3{0, 0, 1}, mgl32.Vec3{1, 0, 0}, mgl32.Vec3{0, 1, 0})
+}
+
+// PlaneData returns a unit plane in the XZ plane facing +Y
+func PlaneData() ([]float32, []uint32) {
+	return appendFace(nil, nil, mgl32.Vec3{}, mgl32.Vec3{0, 1, 0}, mgl32.Vec3{1, 0, 0}, mgl32.Vec3{0, 0, -1})
+}
+
+// SphereData returns a UV sphere of diameter 1 centered on the origin.
+// It has (rings+1)*(segments+1) vertices, duplicating the seam and poles
+// for texcoords, and rings*segments*6 indices.
+func SphereData(rings, segments int) ([]float32, []uint32) {
+	if rings < 2 {
+		rings = 2
+	}
+	if segments < 3 {
+		segments = 3
+	}
+
+	vertices := make([]float32, 0, (rings+1)*(segments+1)*OBJVertexStride)
+	for ring := 0; ring <= rings; ring++ {
+		phi := math.Pi * float64(ring) / float64(rings)
+		for segment := 0; segment <= segments; segment++ {
+			theta := 2 * math.Pi * float64(segment) / float64(segments)
+			normal := mgl32.Vec3{
+				float32(math.Sin(phi) * math.Cos(theta)),
+				float32(math.Cos(phi)),
+				float32(-math.Sin(phi) * math.Sin(theta)),
+			}
+			position := normal.Mul(0.5)
+			vertices = append(vertices,
+				position[0], position[1], position[2],
+				normal[0], normal[1], normal[2],
+				float32(segment)/float32(segments), 1-float32(ring)/float32(rings),
+			)
+		}
+	}
+
+	indices := make([]uint32, 0, rings*segments*6)
+	for ring := 0; ring < rings; ring++ {
+		for segment := 0; segment < segments; segment++ {
+			top := uint32(ring*(segments+1) + segment)
+			bottom := top + uint32(segments+1)
+			indices = append(indices,
+				top, bottom, top+1,
+				top+1, bottom, bottom+1,
+			)
+		}
+	}
+	return vertices, indices
+}
+
+// CreateCube registers a unit cube mesh under id
+func (r *Renderer) CreateCube(id string) error {
+	vertices, indices := CubeData()
+	return r.CreateMesh(id, vertices, indices, PositionNormalTexCoordLayout())
+}
+
+// CreateSphere registers a UV sphere mesh of diameter 1 under id
+func (r *Renderer) CreateSphere(id string, rings, segments int) error {
+	vertices, indices := SphereData(rings, segments)
+	return r.CreateMesh(id, vertices, indices, PositionNormalTexCoordLayout())
+}
+
+// CreatePlane registers a unit plane mesh facing +Y under id
+func (r *Renderer) CreatePlane(id string) error {
+	vertices, indices := PlaneData()
+	return r.CreateMesh(id, vertices, indices, PositionNormalTexCoordLayout())
+}
+
+// CreateQuad registers a unit quad mesh facing +Z under id
+func (r *Renderer) CreateQuad(id string) error {
+	vertices, indices := QuadData()
+	return r.CreateMesh(id, vertices, indices, PositionNormalTexCoordLayout())
+}
+
+// appendFace appends a unit square centered on center and spanned by the
+// u and v axes, wound counter-clockwise when seen from the normal side
+func appendFace(vertices []float32, indices []uint32, center, normal, u, v mgl32.Vec3) ([]float32, []uint32) {
+	base := uint32(len(vertices) / OBJVertexStride)
+	corners := [4]mgl32.Vec2{{0, 0}, {1, 0}, {1, 1}, {0, 1}}
+	for _, corner := range corners {
+		position := center.Add(u.Mul(corner[0] - 0.5)).Add(v.Mul(corner[1] - 0.5))
+		vertices = append(vertices,
+			position[0], position[1], position[2],
+			normal[0], normal[1], normal[2],
+			corner[0], corner[1],
+		)
+	}
+
+	indices = append(indices, base, base+1, base+2, base+2, base+3, base)
+	return vertices, indices
+}
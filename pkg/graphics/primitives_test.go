@@ -0,0 +1,121 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// primitiveVertex unpacks vertex i of position(3), normal(3), texcoord(2) data
+func primitiveVertex(vertices []float32, i int) (mgl32.Vec3, mgl32.Vec3, mgl32.Vec2) {
+	v := vertices[i*OBJVertexStride:]
+	return mgl32.Vec3{v[0], v[1], v[2]}, mgl32.Vec3{v[3], v[4], v[5]}, mgl32.Vec2{v[6], v[7]}
+}
+
+// checkWinding fails if a triangle isn't counter-clockwise seen from the
+// side its vertex normals point to
+func checkWinding(t *testing.T, vertices []float32, indices []uint32) {
+	t.Helper()
+	for i := 0; i+2 < len(indices); i += 3 {
+		a, normal, _ := primitiveVertex(vertices, int(indices[i]))
+		b, _, _ := primitiveVertex(vertices, int(indices[i+1]))
+		c, _, _ := primitiveVertex(vertices, int(indices[i+2]))
+
+		face := b.Sub(a).Cross(c.Sub(a))
+		// Triangles at a sphere's poles collapse to a line
+		if face.Len() < 1e-6 {
+			continue
+		}
+		if face.Dot(normal) <= 0 {
+			t.Fatalf("triangle %d is wound clockwise", i/3)
+		}
+	}
+}
+
+func TestCubeData(t *testing.T) {
+	vertices, indices := CubeData()
+
+	if count := len(vertices) / OBJVertexStride; count != 24 {
+		t.Errorf("cube has %d vertices, want 24", count)
+	}
+	if len(indices) != 36 {
+		t.Errorf("cube has %d indices, want 36", len(indices))
+	}
+
+	for i := 0; i < len(vertices)/OBJVertexStride; i++ {
+		position, normal, texCoord := primitiveVertex(vertices, i)
+		// Each vertex sits on the face its normal points out of
+		if d := position.Dot(normal); d < 0.5-1e-6 || d > 0.5+1e-6 {
+			t.Errorf("vertex %d at %v isn't on the face with normal %v", i, position, normal)
+		}
+		for axis := 0; axis < 3; axis++ {
+			if position[axis] != 0.5 && position[axis] != -0.5 {
+				t.Errorf("vertex %d at %v isn't a corner of the unit cube", i, position)
+			}
+		}
+		if texCoord.X() < 0 || texCoord.X() > 1 || texCoord.Y() < 0 || texCoord.Y() > 1 {
+			t.Errorf("vertex %d texcoord %v outside [0, 1]", i, texCoord)
+		}
+	}
+	checkWinding(t, vertices, indices)
+}
+
+func TestSphereData(t *testing.T) {
+	tests := []struct {
+		rings, segments           int
+		wantVertices, wantIndices int
+	}{
+		{DefaultSphereRings, DefaultSphereSegments, 17 * 33, 16 * 32 * 6},
+		{4, 6, 5 * 7, 4 * 6 * 6},
+		// Too few rings and segments are raised to the minimum
+		{1, 1, 3 * 4, 2 * 3 * 6},
+	}
+
+	for _, test := range tests {
+		vertices, indices := SphereData(test.rings, test.segments)
+
+		if count := len(vertices) / OBJVertexStride; count != test.wantVertices {
+			t.Errorf("SphereData(%d, %d) has %d vertices, want %d", test.rings, test.segments, count, test.wantVertices)
+		}
+		if len(indices) != test.wantIndices {
+			t.Errorf("SphereData(%d, %d) has %d indices, want %d", test.rings, test.segments, len(indices), test.wantIndices)
+		}
+
+		for i := 0; i < len(vertices)/OBJVertexStride; i++ {
+			position, normal, _ := primitiveVertex(vertices, i)
+			if l := position.Len(); l < 0.5-1e-5 || l > 0.5+1e-5 {
+				t.Fatalf("vertex %d at distance %v from the center, want 0.5", i, l)
+			}
+			if !vecNear(normal, position.Mul(2)) {
+				t.Fatalf("vertex %d normal %v doesn't point outward", i, normal)
+			}
+		}
+		checkWinding(t, vertices, indices)
+	}
+}
+
+func TestQuadAndPlaneData(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   func() ([]float32, []uint32)
+		normal mgl32.Vec3
+	}{
+		{"quad", QuadData, mgl32.Vec3{0, 0, 1}},
+		{"plane", PlaneData, mgl32.Vec3{0, 1, 0}},
+	}
+
+	for _, test := range tests {
+		vertices, indices := test.data()
+		if count := len(vertices) / OBJVertexStride; count != 4 || len(indices) != 6 {
+			t.Errorf("%s has %d vertices and %d indices, want 4 and 6", test.name, count, len(indices))
+			continue
+		}
+		for i := 0; i < 4; i++ {
+			position, normal, _ := primitiveVertex(vertices, i)
+			if normal != test.normal || position.Dot(test.normal) != 0 {
+				t.Errorf("%s vertex %d at %v with normal %v, want in the plane facing %v", test.name, i, position, normal, test.normal)
+			}
+		}
+		checkWinding(t, vertices, indices)
+	}
+}
@@ -0,0 +1,124 @@
+package graphics
+
+import (
+	"fmt"
+)
+
+// Meshes and textures can be shared by many entities. Users Acquire an
+// asset while they need it and Release it when done; the last Release frees
+// the asset, and Unload refuses to free an asset that is still acquired.
+
+// LoadOBJOrGet acquires the mesh under id, loading it from an OBJ file first
+// if it isn't registered yet
+func (r *Renderer) LoadOBJOrGet(id, filepath string) error {
+	if r.AcquireMesh(id) {
+		return nil
+	}
+	if err := r.LoadOBJ(id, filepath); err != nil {
+		return err
+	}
+	r.AcquireMesh(id)
+	return nil
+}
+
+// LoadTextureOrGet acquires the texture under id, loading it from an image
+// file first if it isn't registered yet
+func (r *Renderer) LoadTextureOrGet(id, filepath string) error {
+	if r.AcquireTexture(id) {
+		return nil
+	}
+	if err := r.LoadTexture(id, filepath); err != nil {
+		return err
+	}
+	r.AcquireTexture(id)
+	return nil
+}
+
+// AcquireMesh adds a reference to a registered mesh and returns false if
+// there is no mesh under id
+func (r *Renderer) AcquireMesh(id string) bool {
+	r.resourceMutex.Lock()
+	defer r.resourceMutex.Unlock()
+
+	if _, exists := r.meshes[id]; !exists {
+		return false
+	}
+	r.meshRefs[id]++
+	return true
+}
+
+// ReleaseMesh drops a reference to a mesh and frees it when none are left.
+// Releasing a mesh that isn't acquired is an error.
+func (r *Renderer) ReleaseMesh(id string) error {
+	r.resourceMutex.Lock()
+	defer r.resourceMutex.Unlock()
+
+	if r.meshRefs[id] == 0 {
+		return fmt.Errorf("mesh %s is not acquired", id)
+	}
+
+	r.meshRefs[id]--
+	if r.meshRefs[id] > 0 {
+		return nil
+	}
+	delete(r.meshRefs, id)
+
+	// The default mesh stays loaded for entities without a mesh of their own
+	if mesh, exists := r.meshes[id]; exists && id != "default" {
+		deleteMeshBuffers(mesh)
+		delete(r.meshes, id)
+	}
+	return nil
+}
+
+// MeshRefCount returns the number of references to a mesh
+func (r *Renderer) MeshRefCount(id string) int {
+	r.resourceMutex.RLock()
+	defer r.resourceMutex.RUnlock()
+
+	return r.meshRefs[id]
+}
+
+// AcquireTexture adds a reference to a registered texture and returns false
+// if there is no texture under id
+func (r *Renderer) AcquireTexture(id string) bool {
+	r.resourceMutex.Lock()
+	defer r.resourceMutex.Unlock()
+
+	if _, exists := r.textures[id]; !exists {
+		return false
+	}
+	r.textureRefs[id]++
+	return true
+}
+
+// ReleaseTexture drops a reference to a texture and frees it when none are
+// left. Releasing a texture that isn't acquired is an error.
+func (r *Renderer) ReleaseTexture(id string) error {
+	r.resourceMutex.Lock()
+	defer r.resourceMutex.Unlock()
+
+	if r.textureRefs[id] == 0 {
+		return fmt.Errorf("texture %s is not acquired", id)
+	}
+
+	r.textureRefs[id]--
+	if r.textureRefs[id] > 0 {
+		return nil
+	}
+	delete(r.textureRefs, id)
+
+	if texture, exists := r.textures[id]; exists {
+		deleteTexture(texture)
+		delete(r.textures, id)
+	}
+	return nil
+}
+
+// TextureRefCount returns the number of references to a texture
+func (r *Renderer) TextureRefCount(id string) int {
+	r.resourceMutex.RLock()
+	defer r.resourceMutex.RUnlock()
+
+	return r.textureRefs[id]
+}
@@ -0,0 +1,160 @@
+package graphics
+
+import (
+	"testing"
+)
+
+// deletedAssets lists the meshes and textures freed through the GL delete hooks
+type deletedAssets struct {
+	meshes   []*Mesh
+	textures []*Texture
+}
+
+// recordDeletes replaces the GL delete hooks for the rest of the test with
+// ones that record what would have been freed
+func recordDeletes(t *testing.T) *deletedAssets {
+	deleted := &deletedAssets{}
+
+	previousMesh, previousTexture := deleteMeshBuffers, deleteTexture
+	deleteMeshBuffers = func(mesh *Mesh) { deleted.meshes = append(deleted.meshes, mesh) }
+	deleteTexture = func(texture *Texture) { deleted.textures = append(deleted.textures, texture) }
+	t.Cleanup(func() {
+		deleteMeshBuffers, deleteTexture = previousMesh, previousTexture
+	})
+	return deleted
+}
+
+func TestMeshRefCount(t *testing.T) {
+	deleted := recordDeletes(t)
+	renderer := NewRenderer()
+	crate := &Mesh{VAO: 3, VBO: 4}
+	renderer.meshes["crate"] = crate
+
+	if renderer.AcquireMesh("missing") {
+		t.Error("AcquireMesh of a missing mesh succeeded")
+	}
+	for i := 1; i <= 3; i++ {
+		if !renderer.AcquireMesh("crate") {
+			t.Fatal("AcquireMesh failed")
+		}
+		if refs := renderer.MeshRefCount("crate"); refs != i {
+			t.Errorf("MeshRefCount() = %d, want %d", refs, i)
+		}
+	}
+
+	// Only the last release frees the buffers
+	for i := 2; i >= 0; i-- {
+		if err := renderer.ReleaseMesh("crate"); err != nil {
+			t.Fatalf("ReleaseMesh: %v", err)
+		}
+		if refs := renderer.MeshRefCount("crate"); refs != i {
+			t.Errorf("MeshRefCount() = %d, want %d", refs, i)
+		}
+		if i > 0 && len(deleted.meshes) != 0 {
+			t.Fatalf("mesh deleted with %d references left", i)
+		}
+	}
+	if len(deleted.meshes) != 1 || deleted.meshes[0] != crate {
+		t.Errorf("deleted meshes %v, want the crate once", deleted.meshes)
+	}
+	if _, exists := renderer.GetMesh("crate"); exists {
+		t.Error("released mesh is still registered")
+	}
+
+	// Another release is an error and frees nothing
+	if err := renderer.ReleaseMesh("crate"); err == nil {
+		t.Error("releasing an unacquired mesh succeeded")
+	}
+	if len(deleted.meshes) != 1 {
+		t.Errorf("extra release deleted %d meshes", len(deleted.meshes)-1)
+	}
+}
+
+func TestDefaultMeshSurvivesRelease(t *testing.T) {
+	deleted := recordDeletes(t)
+	renderer := NewRenderer()
+	renderer.meshes["default"] = &Mesh{VAO: 1}
+
+	renderer.AcquireMesh("default")
+	if err := renderer.ReleaseMesh("default"); err != nil {
+		t.Fatalf("ReleaseMesh: %v", err)
+	}
+	if _, exists := renderer.GetMesh("default"); !exists || len(deleted.meshes) != 0 {
+		t.Error("releasing the default mesh freed it")
+	}
+}
+
+func TestTextureRefCount(t *testing.T) {
+	deleted := recordDeletes(t)
+	renderer := NewRenderer()
+	bricks := &Texture{ID: 7}
+	renderer.textures["bricks"] = bricks
+
+	renderer.AcquireTexture("bricks")
+	renderer.AcquireTexture("bricks")
+	if refs := renderer.TextureRefCount("bricks"); refs != 2 {
+		t.Errorf("TextureRefCount() = %d, want 2", refs)
+	}
+
+	renderer.ReleaseTexture("bricks")
+	if len(deleted.textures) != 0 {
+		t.Fatal("texture deleted with a reference left")
+	}
+	renderer.ReleaseTexture("bricks")
+	if len(deleted.textures) != 1 || deleted.textures[0] != bricks {
+		t.Errorf("deleted textures %v, want the bricks once", deleted.textures)
+	}
+	if _, exists := renderer.GetTexture("bricks"); exists {
+		t.Error("released texture is still registered")
+	}
+	if err := renderer.ReleaseTexture("bricks"); err == nil {
+		t.Error("releasing an unacquired texture succeeded")
+	}
+}
+
+func TestLoadOrGetAcquiresLoadedAssets(t *testing.T) {
+	recordDeletes(t)
+	renderer := NewRenderer()
+	renderer.meshes["crate"] = &Mesh{}
+	renderer.textures["bricks"] = &Texture{}
+
+	// Already loaded assets are only acquired, so the files are never read
+	for i := 0; i < 2; i++ {
+		if err := renderer.LoadOBJOrGet("crate", "missing.obj"); err != nil {
+			t.Fatalf("LoadOBJOrGet: %v", err)
+		}
+		if err := renderer.LoadTextureOrGet("bricks", "missing.png"); err != nil {
+			t.Fatalf("LoadTextureOrGet: %v", err)
+		}
+	}
+
+	if refs := renderer.MeshRefCount("crate"); refs != 2 {
+		t.Errorf("MeshRefCount() = %d, want 2", refs)
+	}
+	if refs := renderer.TextureRefCount("bricks"); refs != 2 {
+		t.Errorf("TextureRefCount() = %d, want 2", refs)
+	}
+}
+
+func TestUnloadDeletesUnusedAssets(t *testing.T) {
+	deleted := recordDeletes(t)
+	renderer := NewRenderer()
+	crate := &Mesh{VAO: 2}
+	bricks := &Texture{ID: 5}
+	renderer.meshes["crate"] = crate
+	renderer.textures["bricks"] = bricks
+
+	if err := renderer.UnloadMesh("crate"); err != nil {
+		t.Fatalf("UnloadMesh: %v", err)
+	}
+	if err := renderer.UnloadTexture("bricks"); err != nil {
+		t.Fatalf("UnloadTexture: %v", err)
+	}
+
+	if len(deleted.meshes) != 1 || deleted.meshes[0] != crate {
+		t.Errorf("deleted meshes %v, want the crate", deleted.meshes)
+	}
+	if len(deleted.textures) != 1 || deleted.textures[0] != bricks {
+		t.Errorf("deleted textures %v, want the bricks", deleted.textures)
+	}
+}
@@ -3,36 +3,95 @@ package graphics
 import (
 	"fmt"
 	"log"
+	"sync"
 
 	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
 	"github.com/go-gl/gl/v4.1-core/gl"
 	"github.com/go-gl/mathgl/mgl32"
 )
 
-// Renderer handles all rendering operations
+// Renderer handles all rendering operations. GL calls must run on the
+// thread owning the GL context; the resource maps are guarded so lookups
+// from other goroutines are safe.
 type Renderer struct {
-	shaders map[string]*Shader
-	meshes  map[string]*Mesh
+	shaders  map[string]*Shader
+	meshes   map[string]*Mesh
+	textures map[string]*Texture
+	camera   *Camera
+
+	// Reference counts of acquired meshes and textures
+	meshRefs    map[string]int
+	textureRefs map[string]int
+
+	// Guards shaders, meshes, textures, their reference counts and materials
+	resourceMutex sync.RWMutex
+
+	// Directional light
+	light DirectionalLight
+
+	// Registered materials
+	materials map[string]*Material
+
+	// Fraction of a physics step elapsed since the last one
+	interpolationAlpha float32
+
+	// Rasterization mode of the mesh pass
+	polygonMode PolygonMode
+
+	// Number of meshes drawn in the last frame after culling
+	lastFrameDrawn int
+
+	// Viewport dimensions in pixels
+	viewportWidth  int
+	viewportHeight int
+
+	// Sprite drawing
+	spriteBatch      *SpriteBatch
+	spriteQueue      []queuedQuad
+	spriteViewCenter mgl32.Vec2
+	spriteViewHeight float32
+	whiteTexture     *Texture
+
+	// Per-instance model matrices of instanced draws
+	instanceVBO uint32
+
+	// Decoded assets waiting for their GL upload on the render thread
+	pendingUploads []func()
+	uploadMutex    sync.Mutex
+
+	// Log GL errors after resource creation and each render pass
+	debug bool
+
+	// Debug drawing
+	debugLines []DebugLine
+	debugVAO   uint32
+	debugVBO   uint32
 }
 
 // Shader represents an OpenGL shader program
 type Shader struct {
-	ID uint32
-}
-
-// Mesh represents a 3D mesh
-type Mesh struct {
-	VAO         uint32
-	VBO         uint32
-	EBO         uint32
-	VertexCount int32
+	ID        uint32
+	locations map[string]int32
 }
 
 // NewRenderer creates a new renderer
 func NewRenderer() *Renderer {
 	return &Renderer{
-		shaders: make(map[string]*Shader),
-		meshes:  make(map[string]*Mesh),
+		shaders:  make(map[string]*Shader),
+		meshes:   make(map[string]*Mesh),
+		textures: make(map[string]*Texture),
+		camera:   NewDefaultCamera(),
+
+		meshRefs:    make(map[string]int),
+		textureRefs: make(map[string]int),
+
+		materials: map[string]*Material{
+			DefaultMaterialID: newDefaultMaterial(),
+		},
+
+		light:            NewDirectionalLight(),
+		spriteBatch:      NewSpriteBatch(DefaultSpriteBatchCapacity),
+		spriteViewHeight: DefaultSpriteViewHeight,
 	}
 }
 
@@ -57,43 +116,173 @@ func (r *Renderer) Init() error {
 
 // Render renders the current scene
 func (r *Renderer) Render(world *ecs.World) {
-	// Get default shader
-	shader, exists := r.shaders["default"]
-	if !exists {
+	if r.camera == nil {
 		return
 	}
 
-	shader.Use()
+	// Rasterize meshes in the configured mode, sprites and debug lines always fill
+	applyPolygonMode(r.polygonMode)
 
-	// Set up projection matrix
-	projection := mgl32.Perspective(mgl32.DegToRad(45.0), 800.0/600.0, 0.1, 100.0)
-	shader.SetMat4("projection", projection)
+	// Skip entities entirely outside the camera's view
+	frustum := r.camera.Frustum()
+	r.lastFrameDrawn = 0
 
-	// Set up view matrix
-	view := mgl32.LookAtV(
-		mgl32.Vec3{0, 0, 3},
-		mgl32.Vec3{0, 0, 0},
-		mgl32.Vec3{0, 1, 0},
-	)
-	shader.SetMat4("view", view)
+	// Shaders are bound lazily as materials switch between them
+	var shader *Shader
 
 	// Render entities with transform and mesh components
-	// This is a simplified version - in a real engine you'd have proper component types
-	entities := world.GetEntitiesWithComponent("transform")
-	for range entities {
+	entities := world.GetEntitiesWithComponent("mesh")
+	for _, entityID := range entities {
+		meshComponent, ok := world.GetComponent(entityID, "mesh").(*ecs.MeshComponent)
+		if !ok || !meshComponent.Visible {
+			continue
+		}
+
+		transform, ok := world.GetComponent(entityID, "transform").(*ecs.TransformComponent)
+		if !ok {
+			continue
+		}
+
+		mesh, exists := r.GetMesh(meshComponent.MeshID)
+		if !exists {
+			continue
+		}
+
+		radius := mesh.BoundingRadius
+		if meshComponent.BoundingRadius > 0 {
+			radius = meshComponent.BoundingRadius
+		}
+		// Cull where the mesh is drawn, between the last two physics steps
+		if !frustum.ContainsSphere(transform.InterpolatedPosition(r.interpolationAlpha), radius*maxScale(transform.Scale)) {
+			continue
+		}
+
+		material, _ := r.GetMaterial(DefaultMaterialID)
+		if materialComponent, ok := world.GetComponent(entityID, "material").(*ecs.MaterialComponent); ok {
+			if custom, exists := r.GetMaterial(materialComponent.MaterialID); exists {
+				material = custom
+			}
+		}
+
+		materialShader, exists := r.lookupShader(material.ShaderID)
+		if !exists {
+			continue
+		}
+		if materialShader != shader {
+			shader = materialShader
+			r.beginShader(shader)
+		}
+
 		// Set model matrix
-		model := mgl32.Ident4()
-		shader.SetMat4("model", model)
+		shader.SetMat4("model", transform.InterpolatedMatrix(r.interpolationAlpha))
 
-		// Render mesh if available
-		if mesh, exists := r.meshes["default"]; exists {
+		// Apply the material with any per-entity overrides
+		block, _ := world.GetComponent(entityID, "property_block").(*ecs.PropertyBlockComponent)
+		r.applyMaterial(shader, material, block)
+
+		// Meshes without normals can't be lit
+		shader.SetBool("lit", mesh.NormalOffset >= 0)
+
+		// Meshes can be wireframed individually
+		if meshComponent.Wireframe && r.polygonMode != PolygonLine {
+			applyPolygonMode(PolygonLine)
+			r.renderMesh(mesh)
+			applyPolygonMode(r.polygonMode)
+		} else {
 			r.renderMesh(mesh)
 		}
+		r.lastFrameDrawn++
 	}
+
+	applyPolygonMode(PolygonFill)
+	r.checkGLError("mesh pass")
+
+	// Render 2D sprites on top of the scene
+	r.queueTilemaps(world)
+	r.queueSpriteEntities(world)
+	r.queueParticles(world)
+	r.queueNineSlices(world)
+	r.renderSprites()
+	r.checkGLError("sprite pass")
+
+	// Render queued debug geometry on top of everything
+	r.renderDebug()
+	r.checkGLError("debug pass")
+}
+
+// beginShader binds a shader and uploads the per-frame camera and light uniforms
+func (r *Renderer) beginShader(shader *Shader) {
+	shader.Use()
+
+	// Set up camera matrices
+	shader.SetMat4("projection", r.camera.ProjectionMatrix())
+	shader.SetMat4("view", r.camera.ViewMatrix())
+
+	// Set up lighting
+	r.applyLight(shader)
+}
+
+// ModelMatrix returns the model matrix of a transform as translate * rotate * scale.
+// The matrix is cached on the transform and only rebuilt when the transform changes.
+func ModelMatrix(transform *ecs.TransformComponent) mgl32.Mat4 {
+	return transform.ModelMatrix()
+}
+
+// SetInterpolationAlpha sets how far the frame is between physics steps.
+// Meshes and sprites are drawn that far from their transform's state saved
+// at the previous step to its current state.
+func (r *Renderer) SetInterpolationAlpha(alpha float32) {
+	r.interpolationAlpha = alpha
+}
+
+// GetInterpolationAlpha returns how far the frame is between physics steps,
+// for blending previous and current physics state when drawing
+func (r *Renderer) GetInterpolationAlpha() float32 {
+	return r.interpolationAlpha
+}
+
+// LastFrameDrawnCount returns the number of meshes drawn in the last frame
+func (r *Renderer) LastFrameDrawnCount() int {
+	return r.lastFrameDrawn
+}
+
+// SetCamera sets the active camera and fits it to the current viewport
+func (r *Renderer) SetCamera(camera *Camera) {
+	r.camera = camera
+	r.updateCameraAspect()
+}
+
+// GetCamera returns the active camera
+func (r *Renderer) GetCamera() *Camera {
+	return r.camera
+}
+
+// SetViewportSize sets the framebuffer dimensions used for the projection aspect
+func (r *Renderer) SetViewportSize(width, height int) {
+	r.viewportWidth = width
+	r.viewportHeight = height
+	r.updateCameraAspect()
+}
+
+// GetViewportSize returns the framebuffer dimensions
+func (r *Renderer) GetViewportSize() (int, int) {
+	return r.viewportWidth, r.viewportHeight
+}
+
+// updateCameraAspect matches the camera aspect ratio to the viewport
+func (r *Renderer) updateCameraAspect() {
+	// A minimized window reports a zero-sized framebuffer
+	if r.camera == nil || r.viewportWidth <= 0 || r.viewportHeight <= 0 {
+		return
+	}
+	r.camera.Aspect = float32(r.viewportWidth) / float32(r.viewportHeight)
 }
 
 // Shutdown cleans up the renderer
 func (r *Renderer) Shutdown() {
+	r.resourceMutex.Lock()
+	defer r.resourceMutex.Unlock()
+
 	// Clean up shaders
 	for _, shader := range r.shaders {
 		gl.DeleteProgram(shader.ID)
@@ -101,20 +290,66 @@ func (r *Renderer) Shutdown() {
 
 	// Clean up meshes
 	for _, mesh := range r.meshes {
-		gl.DeleteVertexArrays(1, &mesh.VAO)
-		gl.DeleteBuffers(1, &mesh.VBO)
-		gl.DeleteBuffers(1, &mesh.EBO)
+		deleteMeshBuffers(mesh)
 	}
+
+	// Clean up textures
+	for _, texture := range r.textures {
+		deleteTexture(texture)
+	}
+
+	// Clean up sprite buffers
+	r.spriteBatch.Delete()
+	if r.whiteTexture != nil {
+		deleteTexture(r.whiteTexture)
+	}
+
+	// Clean up debug buffers
+	gl.DeleteVertexArrays(1, &r.debugVAO)
+	gl.DeleteBuffers(1, &r.debugVBO)
+
+	// Clean up the instance buffer
+	gl.DeleteBuffers(1, &r.instanceVBO)
 }
 
+// defaultFragmentShaderSource lights and tints meshes; it is shared by the
+// default and instanced shaders
+const defaultFragmentShaderSource = `
+	#version 410 core
+	out vec4 FragColor;
+	in vec3 ourColor;
+	in vec3 normal;
+	
+	uniform vec4 tint;
+	uniform bool lit;
+	uniform vec3 lightDirection;
+	uniform vec3 lightColor;
+	uniform float ambientStrength;
+	
+	void main()
+	{
+		vec3 lighting = vec3(1.0);
+		if (lit)
+		{
+			vec3 ambient = ambientStrength * lightColor;
+			float diffuseStrength = max(dot(normalize(normal), -lightDirection), 0.0);
+			vec3 diffuse = diffuseStrength * lightColor;
+			lighting = ambient + diffuse;
+		}
+		FragColor = vec4(lighting * ourColor, 1.0) * tint;
+	}
+`
+
 // createDefaultShaders creates the default shaders
 func (r *Renderer) createDefaultShaders() error {
 	vertexShaderSource := `
 		#version 410 core
 		layout (location = 0) in vec3 aPos;
 		layout (location = 1) in vec3 aColor;
+		layout (location = 2) in vec3 aNormal;
 		
 		out vec3 ourColor;
+		out vec3 normal;
 		
 		uniform mat4 model;
 		uniform mat4 view;
@@ -124,74 +359,97 @@ func (r *Renderer) createDefaultShaders() error {
 		{
 			gl_Position = projection * view * model * vec4(aPos, 1.0);
 			ourColor = aColor;
+			normal = mat3(transpose(inverse(model))) * aNormal;
 		}
 	` + "\x00"
 
-	fragmentShaderSource := `
-		#version 410 core
-		out vec4 FragColor;
-		in vec3 ourColor;
-		
-		void main()
-		{
-			FragColor = vec4(ourColor, 1.0);
-		}
-	` + "\x00"
-
-	shader, err := NewShader(vertexShaderSource, fragmentShaderSource)
+	shader, err := NewShader(vertexShaderSource, defaultFragmentShaderSource+"\x00")
 	if err != nil {
 		return err
 	}
 
-	r.shaders["default"] = shader
+	r.registerShader("default", shader)
+
+	// Meshes without a color attribute fall back to white
+	gl.VertexAttrib3f(AttribColor, 1, 1, 1)
 
 	// Create a simple triangle mesh
-	r.createDefaultMesh()
+	if err := r.createDefaultMesh(); err != nil {
+		return err
+	}
+
+	// Create the sprite pipeline
+	if err := r.createSpritePipeline(); err != nil {
+		return err
+	}
+
+	// Create the debug line pipeline
+	if err := r.createDebugPipeline(); err != nil {
+		return err
+	}
+
+	// Create the instanced mesh pipeline
+	if err := r.createInstancedPipeline(); err != nil {
+		return err
+	}
 
 	return nil
 }
 
 // createDefaultMesh creates a simple triangle mesh
-func (r *Renderer) createDefaultMesh() {
+func (r *Renderer) createDefaultMesh() error {
 	vertices := []float32{
-		// positions        // colors
-		-0.5, -0.5, 0.0, 1.0, 0.0, 0.0,
-		0.5, -0.5, 0.0, 0.0, 1.0, 0.0,
-		0.0, 0.5, 0.0, 0.0, 0.0, 1.0,
+		// positions        // colors        // normals
+		-0.5, -0.5, 0.0, 1.0, 0.0, 0.0, 0.0, 0.0, 1.0,
+		0.5, -0.5, 0.0, 0.0, 1.0, 0.0, 0.0, 0.0, 1.0,
+		0.0, 0.5, 0.0, 0.0, 0.0, 1.0, 0.0, 0.0, 1.0,
 	}
 
-	var VAO, VBO uint32
-	gl.GenVertexArrays(1, &VAO)
-	gl.GenBuffers(1, &VBO)
-
-	gl.BindVertexArray(VAO)
+	return r.CreateMesh("default", vertices, nil, PositionColorNormalLayout())
+}
 
-	gl.BindBuffer(gl.ARRAY_BUFFER, VBO)
-	gl.BufferData(gl.ARRAY_BUFFER, len(vertices)*4, gl.Ptr(vertices), gl.STATIC_DRAW)
+// renderMesh renders a mesh
+func (r *Renderer) renderMesh(mesh *Mesh) {
+	gl.BindVertexArray(mesh.VAO)
+	if mesh.EBO != 0 {
+		gl.DrawElements(gl.TRIANGLES, mesh.IndexCount, gl.UNSIGNED_INT, gl.PtrOffset(0))
+	} else {
+		gl.DrawArrays(gl.TRIANGLES, 0, mesh.VertexCount)
+	}
+	gl.BindVertexArray(0)
+}
 
-	// Position attribute
-	gl.VertexAttribPointer(0, 3, gl.FLOAT, false, 6*4, gl.PtrOffset(0))
-	gl.EnableVertexAttribArray(0)
+// CreateShader compiles a shader program and registers it under id
+func (r *Renderer) CreateShader(id, vertexSource, fragmentSource string) error {
+	shader, err := NewShader(vertexSource+"\x00", fragmentSource+"\x00")
+	if err != nil {
+		return fmt.Errorf("shader %s: %w", id, err)
+	}
 
-	// Color attribute
-	gl.VertexAttribPointer(1, 3, gl.FLOAT, false, 6*4, gl.PtrOffset(3*4))
-	gl.EnableVertexAttribArray(1)
+	r.registerShader(id, shader)
+	r.checkGLError("shader " + id)
+	return nil
+}
 
-	gl.BindVertexArray(0)
+// registerShader registers a shader program under id, freeing any program it replaces
+func (r *Renderer) registerShader(id string, shader *Shader) {
+	r.resourceMutex.Lock()
+	defer r.resourceMutex.Unlock()
 
-	r.meshes["default"] = &Mesh{
-		VAO:         VAO,
-		VBO:         VBO,
-		EBO:         0,
-		VertexCount: 3,
+	// Replacing a shader frees the previous program
+	if previous, exists := r.shaders[id]; exists {
+		gl.DeleteProgram(previous.ID)
 	}
+	r.shaders[id] = shader
 }
 
-// renderMesh renders a mesh
-func (r *Renderer) renderMesh(mesh *Mesh) {
-	gl.BindVertexArray(mesh.VAO)
-	gl.DrawArrays(gl.TRIANGLES, 0, mesh.VertexCount)
-	gl.BindVertexArray(0)
+// lookupShader returns a registered shader program
+func (r *Renderer) lookupShader(id string) (*Shader, bool) {
+	r.resourceMutex.RLock()
+	defer r.resourceMutex.RUnlock()
+
+	shader, exists := r.shaders[id]
+	return shader, exists
 }
 
 // NewShader creates a new shader program
@@ -222,7 +480,7 @@ func NewShader(vertexSource, fragmentSource string) (*Shader, error) {
 	gl.DeleteShader(vertexShader)
 	gl.DeleteShader(fragmentShader)
 
-	return &Shader{ID: program}, nil
+	return &Shader{ID: program, locations: make(map[string]int32)}, nil
 }
 
 // Use activates the shader
@@ -232,7 +490,59 @@ func (s *Shader) Use() {
 
 // SetMat4 sets a mat4 uniform
 func (s *Shader) SetMat4(name string, value mgl32.Mat4) {
-	gl.UniformMatrix4fv(gl.GetUniformLocation(s.ID, gl.Str(name+"\x00")), 1, false, &value[0])
+	gl.UniformMatrix4fv(s.GetUniformLocation(name), 1, false, &value[0])
+}
+
+// SetVec3 sets a vec3 uniform
+func (s *Shader) SetVec3(name string, value mgl32.Vec3) {
+	gl.Uniform3f(s.GetUniformLocation(name), value[0], value[1], value[2])
+}
+
+// SetVec4 sets a vec4 uniform
+func (s *Shader) SetVec4(name string, value mgl32.Vec4) {
+	gl.Uniform4f(s.GetUniformLocation(name), value[0], value[1], value[2], value[3])
+}
+
+// SetFloat sets a float uniform
+func (s *Shader) SetFloat(name string, value float32) {
+	gl.Uniform1f(s.GetUniformLocation(name), value)
+}
+
+// SetInt sets an int uniform
+func (s *Shader) SetInt(name string, value int32) {
+	gl.Uniform1i(s.GetUniformLocation(name), value)
+}
+
+// SetBool sets a bool uniform
+func (s *Shader) SetBool(name string, value bool) {
+	var intValue int32
+	if value {
+		intValue = 1
+	}
+	gl.Uniform1i(s.GetUniformLocation(name), intValue)
+}
+
+// GetUniformLocation returns the location of a uniform, caching it after the first lookup
+func (s *Shader) GetUniformLocation(name string) int32 {
+	return s.cachedLocation(name, func(name string) int32 {
+		return gl.GetUniformLocation(s.ID, gl.Str(name+"\x00"))
+	})
+}
+
+// cachedLocation returns the cached location of a uniform, resolving it with lookup on a miss
+func (s *Shader) cachedLocation(name string, lookup func(name string) int32) int32 {
+	if location, exists := s.locations[name]; exists {
+		return location
+	}
+
+	if s.locations == nil {
+		s.locations = make(map[string]int32)
+	}
+
+	// Missing uniforms (-1) are cached too, GL ignores writes to them
+	location := lookup(name)
+	s.locations[name] = location
+	return location
 }
 
 // compileShader compiles a shader
@@ -0,0 +1,38 @@
+//go:build gl
+
+package graphics
+
+import (
+	"testing"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestRenderCullsInterpolatedPosition(t *testing.T) {
+	renderer := newGLRenderer(t)
+	renderer.SetCamera(newFrustumTestCamera())
+
+	// A physics step moved the mesh from in front of the camera to far off to the side
+	world := ecs.NewWorld()
+	entityID := world.CreateEntity()
+	transform := ecs.NewTransformComponent(mgl32.Vec3{0, 0, -5}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1})
+	transform.StepTo(mgl32.Vec3{100, 0, -5})
+	world.AddComponent(entityID, transform)
+	world.AddComponent(entityID, renderer.NewMeshComponent("default"))
+
+	tests := []struct {
+		alpha float32
+		want  int
+	}{
+		{0, 1},
+		{1, 0},
+	}
+	for _, test := range tests {
+		renderer.SetInterpolationAlpha(test.alpha)
+		renderer.Render(world)
+		if got := renderer.LastFrameDrawnCount(); got != test.want {
+			t.Errorf("LastFrameDrawnCount() at alpha %v = %d, want %d", test.alpha, got, test.want)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package graphics
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestModelMatrix(t *testing.T) {
+	position := mgl32.Vec3{1, 2, 3}
+	rotation := mgl32.Vec3{0.3, 1.2, -0.4}
+	scale := mgl32.Vec3{2, 0.5, 1.5}
+	transform := ecs.NewTransformComponent(position, rotation, scale)
+
+	want := mgl32.Translate3D(1, 2, 3).
+		Mul4(mgl32.HomogRotate3DY(rotation.Y())).
+		Mul4(mgl32.HomogRotate3DX(rotation.X())).
+		Mul4(mgl32.HomogRotate3DZ(rotation.Z())).
+		Mul4(mgl32.Scale3D(2, 0.5, 1.5))
+	if got := ModelMatrix(transform); !got.ApproxEqualThreshold(want, 1e-5) {
+		t.Errorf("ModelMatrix() = %v, want %v", got, want)
+	}
+
+	// Scale is applied before translation, so the origin lands on the position
+	if origin := ModelMatrix(transform).Mul4x1(mgl32.Vec4{0, 0, 0, 1}).Vec3(); !origin.ApproxEqual(position) {
+		t.Errorf("origin maps to %v, want %v", origin, position)
+	}
+}
+
+func TestModelMatrixRebuiltAfterChange(t *testing.T) {
+	transform := ecs.NewTransformComponent(mgl32.Vec3{}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1})
+	if got := ModelMatrix(transform); got != mgl32.Ident4() {
+		t.Fatalf("identity transform gave %v", got)
+	}
+
+	transform.SetPosition(mgl32.Vec3{4, 0, 0})
+	if got, want := ModelMatrix(transform), mgl32.Translate3D(4, 0, 0); got != want {
+		t.Errorf("after SetPosition ModelMatrix() = %v, want %v", got, want)
+	}
+
+	// Writing the field directly is also picked up
+	transform.Position = mgl32.Vec3{0, 5, 0}
+	if got, want := ModelMatrix(transform), mgl32.Translate3D(0, 5, 0); got != want {
+		t.Errorf("after a direct write ModelMatrix() = %v, want %v", got, want)
+	}
+}
+
+func TestViewportSizeUpdatesProjection(t *testing.T) {
+	renderer := NewRenderer()
+	renderer.SetViewportSize(800, 600)
+	before := renderer.GetCamera().ProjectionMatrix()
+
+	renderer.SetViewportSize(1920, 1080)
+	if got := renderer.GetCamera().Aspect; got != 1920.0/1080.0 {
+		t.Errorf("Aspect = %v, want %v", got, 1920.0/1080.0)
+	}
+	if renderer.GetCamera().ProjectionMatrix() == before {
+		t.Error("projection didn't change with the viewport size")
+	}
+
+	// A minimized window keeps the last aspect
+	renderer.SetViewportSize(0, 0)
+	if got := renderer.GetCamera().Aspect; got != 1920.0/1080.0 {
+		t.Errorf("zero-sized viewport changed Aspect to %v", got)
+	}
+
+	// A new camera is fitted to the current viewport
+	renderer.SetViewportSize(400, 400)
+	renderer.SetCamera(NewDefaultCamera())
+	if got := renderer.GetCamera().Aspect; got != 1 {
+		t.Errorf("new camera Aspect = %v, want 1", got)
+	}
+}
+
+// TestConcurrentResourceAccess registers shaders from one goroutine while
+// others look up meshes, shaders and textures. Run it with -race.
+func TestConcurrentResourceAccess(t *testing.T) {
+	renderer := NewRenderer()
+	renderer.meshes["default"] = &Mesh{}
+	renderer.textures["bricks"] = &Texture{}
+	renderer.AcquireTexture("bricks")
+
+	const shaders = 200
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// New IDs only, so no program is deleted and no GL call is made
+		for i := 0; i < shaders; i++ {
+			renderer.registerShader(fmt.Sprintf("shader%d", i), &Shader{ID: uint32(i + 1)})
+		}
+	}()
+
+	for reader := 0; reader < 4; reader++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < shaders; i++ {
+				if _, exists := renderer.GetMesh("default"); !exists {
+					t.Error("default mesh missing during lookups")
+					return
+				}
+				renderer.lookupShader(fmt.Sprintf("shader%d", i))
+				renderer.AcquireMesh("default")
+				renderer.MeshRefCount("default")
+				renderer.AcquireTexture("bricks")
+				renderer.ReleaseTexture("bricks")
+			}
+		}()
+	}
+	wg.Wait()
+
+	for i := 0; i < shaders; i++ {
+		if _, exists := renderer.lookupShader(fmt.Sprintf("shader%d", i)); !exists {
+			t.Fatalf("shader%d not registered", i)
+		}
+	}
+	if refs := renderer.MeshRefCount("default"); refs != 4*shaders {
+		t.Errorf("MeshRefCount() = %d, want %d", refs, 4*shaders)
+	}
+	if refs := renderer.TextureRefCount("bricks"); refs != 1 {
+		t.Errorf("TextureRefCount() = %d, want 1", refs)
+	}
+}
@@ -0,0 +1,36 @@
+package graphics
+
+import (
+	"testing"
+)
+
+func TestShaderCachesUniformLocations(t *testing.T) {
+	shader := &Shader{}
+	locations := map[string]int32{"model": 0, "view": 4}
+	lookups := make(map[string]int)
+	lookup := func(name string) int32 {
+		lookups[name]++
+		if location, exists := locations[name]; exists {
+			return location
+		}
+		return -1
+	}
+
+	for i := 0; i < 3; i++ {
+		for _, name := range []string{"model", "view", "missing"} {
+			want, exists := locations[name]
+			if !exists {
+				want = -1
+			}
+			if got := shader.cachedLocation(name, lookup); got != want {
+				t.Errorf("cachedLocation(%q) = %d, want %d", name, got, want)
+			}
+		}
+	}
+
+	for _, name := range []string{"model", "view", "missing"} {
+		if lookups[name] != 1 {
+			t.Errorf("%q was looked up %d times, want once", name, lookups[name])
+		}
+	}
+}
@@ -0,0 +1,86 @@
+package graphics
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// DefaultCircleSegments is the number of triangles used by FillCircle
+const DefaultCircleSegments = 32
+
+// RectTriangles returns the two triangles covering the rectangle from min to max
+func RectTriangles(min, max mgl32.Vec2) [][3]mgl32.Vec2 {
+	bottomLeft := min
+	bottomRight := mgl32.Vec2{max.X(), min.Y()}
+	topRight := max
+	topLeft := mgl32.Vec2{min.X(), max.Y()}
+
+	return [][3]mgl32.Vec2{
+		{bottomLeft, bottomRight, topRight},
+		{topRight, topLeft, bottomLeft},
+	}
+}
+
+// CircleTriangles returns a triangle fan of segments triangles approximating a circle
+func CircleTriangles(center mgl32.Vec2, radius float32, segments int) [][3]mgl32.Vec2 {
+	if segments < 3 {
+		segments = 3
+	}
+
+	point := func(i int) mgl32.Vec2 {
+		angle := 2 * math.Pi * float64(i) / float64(segments)
+		return mgl32.Vec2{
+			center.X() + radius*float32(math.Cos(angle)),
+			center.Y() + radius*float32(math.Sin(angle)),
+		}
+	}
+
+	triangles := make([][3]mgl32.Vec2, segments)
+	for i := 0; i < segments; i++ {
+		triangles[i] = [3]mgl32.Vec2{center, point(i), point(i + 1)}
+	}
+	return triangles
+}
+
+// FillRect queues a filled rectangle in the sprite pass
+func (r *Renderer) FillRect(min, max mgl32.Vec2, color mgl32.Vec4) {
+	r.fillTriangles(RectTriangles(min, max), color)
+}
+
+// FillCircle queues a filled circle in the sprite pass
+func (r *Renderer) FillCircle(center mgl32.Vec2, radius float32, color mgl32.Vec4) {
+	r.fillTriangles(CircleTriangles(center, radius, DefaultCircleSegments), color)
+}
+
+// fillTriangles queues solid triangles as quads with a repeated last corner,
+// so they share the sprite batch and keep their order relative to sprites
+func (r *Renderer) fillTriangles(triangles [][3]mgl32.Vec2, color mgl32.Vec4) {
+	if r.whiteTexture == nil {
+		return
+	}
+
+	for _, triangle := range triangles {
+		r.spriteQueue = append(r.spriteQueue, queuedQuad{
+			texture: r.whiteTexture,
+			corners: [4]mgl32.Vec2{triangle[0], triangle[1], triangle[2], triangle[2]},
+			uvs:     FullTextureUVs,
+			color:   color,
+		})
+	}
+}
+
+// createWhiteTexture creates the 1x1 white texture solid shapes are drawn with
+func (r *Renderer) createWhiteTexture() error {
+	img := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	img.Set(0, 0, color.White)
+
+	texture, err := NewTexture(img)
+	if err != nil {
+		return err
+	}
+	r.whiteTexture = texture
+	return nil
+}
@@ -0,0 +1,91 @@
+package graphics
+
+import (
+	"math"
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// triangleArea returns the signed area of a triangle, positive when counter-clockwise
+func triangleArea(triangle [3]mgl32.Vec2) float32 {
+	ab := triangle[1].Sub(triangle[0])
+	ac := triangle[2].Sub(triangle[0])
+	return (ab.X()*ac.Y() - ab.Y()*ac.X()) / 2
+}
+
+func TestRectTriangles(t *testing.T) {
+	triangles := RectTriangles(mgl32.Vec2{1, 2}, mgl32.Vec2{4, 6})
+
+	want := [][3]mgl32.Vec2{
+		{{1, 2}, {4, 2}, {4, 6}},
+		{{4, 6}, {1, 6}, {1, 2}},
+	}
+	if len(triangles) != len(want) {
+		t.Fatalf("got %d triangles, want %d", len(triangles), len(want))
+	}
+	for i := range want {
+		if triangles[i] != want[i] {
+			t.Errorf("triangle %d = %v, want %v", i, triangles[i], want[i])
+		}
+		if area := triangleArea(triangles[i]); area != 6 {
+			t.Errorf("triangle %d area = %v, want 6 wound counter-clockwise", i, area)
+		}
+	}
+}
+
+func TestCircleTriangles(t *testing.T) {
+	center := mgl32.Vec2{3, -1}
+	const radius, segments = 2, 12
+
+	triangles := CircleTriangles(center, radius, segments)
+	if len(triangles) != segments {
+		t.Fatalf("got %d triangles, want %d", len(triangles), segments)
+	}
+
+	var area float32
+	for i, triangle := range triangles {
+		if triangle[0] != center {
+			t.Errorf("triangle %d doesn't start at the center", i)
+		}
+		for _, corner := range triangle[1:] {
+			if distance := corner.Sub(center).Len(); mgl32.Abs(distance-radius) > 1e-5 {
+				t.Errorf("triangle %d corner %v is %v from the center, want %v", i, corner, distance, radius)
+			}
+		}
+		// Each triangle starts where the last one ended
+		if next := triangles[(i+1)%segments]; next[1].Sub(triangle[2]).Len() > 1e-5 {
+			t.Errorf("triangle %d ends at %v but the next starts at %v", i, triangle[2], next[1])
+		}
+		area += triangleArea(triangle)
+	}
+
+	// The fan covers the area of a regular polygon with that many sides
+	want := float32(segments) / 2 * radius * radius * float32(math.Sin(2*math.Pi/segments))
+	if mgl32.Abs(area-want) > 1e-4 {
+		t.Errorf("fan area = %v, want %v", area, want)
+	}
+
+	if got := len(CircleTriangles(center, radius, 1)); got != 3 {
+		t.Errorf("1 segment gave %d triangles, want the minimum 3", got)
+	}
+}
+
+func TestFillShapesQueueTriangles(t *testing.T) {
+	renderer := NewRenderer()
+	renderer.whiteTexture = &Texture{ID: 1}
+	red := mgl32.Vec4{1, 0, 0, 1}
+
+	renderer.FillRect(mgl32.Vec2{0, 0}, mgl32.Vec2{1, 1}, red)
+	renderer.FillCircle(mgl32.Vec2{0, 0}, 1, red)
+
+	if got, want := len(renderer.spriteQueue), 2+DefaultCircleSegments; got != want {
+		t.Fatalf("queued %d quads, want %d", got, want)
+	}
+	for i, quad := range renderer.spriteQueue {
+		// Triangles are drawn as quads with the last corner repeated
+		if quad.corners[3] != quad.corners[2] || quad.texture != renderer.whiteTexture || quad.color != red {
+			t.Errorf("quad %d = %+v, want a red triangle on the white texture", i, quad)
+		}
+	}
+}
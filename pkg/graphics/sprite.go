@@ -0,0 +1,240 @@
+package graphics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/go-gl/gl/v4.1-core/gl"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// DefaultSpriteViewHeight is the default number of world units visible vertically in the sprite pass
+const DefaultSpriteViewHeight = 10.0
+
+// queuedQuad is a textured quad waiting for the sprite pass
+type queuedQuad struct {
+	texture *Texture
+	corners [4]mgl32.Vec2
+	uvs     [4]mgl32.Vec2
+	color   mgl32.Vec4
+}
+
+// OrthoMatrix returns an orthographic projection showing height world units
+// vertically around center, with the width derived from aspect
+func OrthoMatrix(center mgl32.Vec2, height, aspect float32) mgl32.Mat4 {
+	halfHeight := height / 2
+	halfWidth := halfHeight * aspect
+	return mgl32.Ortho(
+		center.X()-halfWidth, center.X()+halfWidth,
+		center.Y()-halfHeight, center.Y()+halfHeight,
+		-1, 1,
+	)
+}
+
+// SpriteQuad returns the corners of a sprite in bottom-left, bottom-right,
+// top-right, top-left order, rotated by rotation radians around its origin
+func SpriteQuad(position, size, origin mgl32.Vec2, rotation float32) [4]mgl32.Vec2 {
+	left := -origin.X() * size.X()
+	bottom := -origin.Y() * size.Y()
+	right := left + size.X()
+	top := bottom + size.Y()
+
+	local := [4]mgl32.Vec2{{left, bottom}, {right, bottom}, {right, top}, {left, top}}
+
+	sin := float32(math.Sin(float64(rotation)))
+	cos := float32(math.Cos(float64(rotation)))
+
+	var corners [4]mgl32.Vec2
+	for i, corner := range local {
+		corners[i] = mgl32.Vec2{
+			position.X() + corner.X()*cos - corner.Y()*sin,
+			position.Y() + corner.X()*sin + corner.Y()*cos,
+		}
+	}
+	return corners
+}
+
+// RectUVs returns the texture coordinates of a texture-space rectangle
+// (minU, minV, maxU, maxV), in the corner order used by SpriteQuad
+func RectUVs(rect mgl32.Vec4) [4]mgl32.Vec2 {
+	return [4]mgl32.Vec2{
+		{rect.X(), rect.W()},
+		{rect.Z(), rect.W()},
+		{rect.Z(), rect.Y()},
+		{rect.X(), rect.Y()},
+	}
+}
+
+// SetSpriteView sets the 2D region shown by the sprite pass
+func (r *Renderer) SetSpriteView(center mgl32.Vec2, height float32) {
+	r.spriteViewCenter = center
+	r.spriteViewHeight = height
+}
+
+// SpriteProjection returns the orthographic projection used by the sprite pass
+func (r *Renderer) SpriteProjection() mgl32.Mat4 {
+	aspect := float32(1)
+	if r.viewportWidth > 0 && r.viewportHeight > 0 {
+		aspect = float32(r.viewportWidth) / float32(r.viewportHeight)
+	}
+	return OrthoMatrix(r.spriteViewCenter, r.spriteViewHeight, aspect)
+}
+
+// DrawSprite queues a sprite at position with size, pivot origin and rotation in radians
+func (r *Renderer) DrawSprite(textureID string, position, size, origin mgl32.Vec2, rotation float32, color mgl32.Vec4) error {
+	return r.DrawTexturedQuad(textureID, SpriteQuad(position, size, origin, rotation), FullTextureUVs, color)
+}
+
+// DrawTexturedQuad queues a textured quad to be drawn in the next sprite pass.
+// Corners and texture coordinates are in bottom-left, bottom-right, top-right, top-left order.
+func (r *Renderer) DrawTexturedQuad(textureID string, corners [4]mgl32.Vec2, uvs [4]mgl32.Vec2, color mgl32.Vec4) error {
+	texture, exists := r.GetTexture(textureID)
+	if !exists {
+		return fmt.Errorf("texture %s not found", textureID)
+	}
+
+	r.spriteQueue = append(r.spriteQueue, queuedQuad{
+		texture: texture,
+		corners: corners,
+		uvs:     uvs,
+		color:   color,
+	})
+	return nil
+}
+
+// LastFrameSpriteBatches returns the number of sprite batches drawn last frame
+func (r *Renderer) LastFrameSpriteBatches() int {
+	return r.spriteBatch.FlushCount()
+}
+
+// createSpritePipeline creates the sprite shader and batch
+func (r *Renderer) createSpritePipeline() error {
+	vertexShaderSource := `
+		#version 410 core
+		layout (location = 0) in vec2 aPos;
+		layout (location = 1) in vec2 aTexCoord;
+		layout (location = 2) in vec4 aColor;
+		
+		out vec2 texCoord;
+		out vec4 color;
+		
+		uniform mat4 projection;
+		
+		void main()
+		{
+			gl_Position = projection * vec4(aPos, 0.0, 1.0);
+			texCoord = aTexCoord;
+			color = aColor;
+		}
+	` + "\x00"
+
+	fragmentShaderSource := `
+		#version 410 core
+		out vec4 FragColor;
+		in vec2 texCoord;
+		in vec4 color;
+		
+		uniform sampler2D spriteTexture;
+		
+		void main()
+		{
+			FragColor = texture(spriteTexture, texCoord) * color;
+		}
+	` + "\x00"
+
+	shader, err := NewShader(vertexShaderSource, fragmentShaderSource)
+	if err != nil {
+		return err
+	}
+	r.registerShader("sprite", shader)
+
+	r.spriteBatch.InitGL()
+
+	// Solid shapes are drawn as sprites with a white texture
+	return r.createWhiteTexture()
+}
+
+// queueSpriteEntities queues the sprites of all entities, back to front by Z position
+func (r *Renderer) queueSpriteEntities(world *ecs.World) {
+	type spriteEntity struct {
+		id        ecs.EntityID
+		sprite    *ecs.SpriteComponent
+		transform *ecs.TransformComponent
+	}
+
+	var sprites []spriteEntity
+	for _, entityID := range world.GetEntitiesWithComponent("sprite") {
+		sprite, ok := world.GetComponent(entityID, "sprite").(*ecs.SpriteComponent)
+		if !ok || !sprite.Visible {
+			continue
+		}
+
+		transform, ok := world.GetComponent(entityID, "transform").(*ecs.TransformComponent)
+		if !ok {
+			continue
+		}
+
+		sprites = append(sprites, spriteEntity{id: entityID, sprite: sprite, transform: transform})
+	}
+
+	// Sort by depth, then by ID so equal depths draw in a stable order
+	sort.Slice(sprites, func(i, j int) bool {
+		if sprites[i].transform.Position.Z() != sprites[j].transform.Position.Z() {
+			return sprites[i].transform.Position.Z() < sprites[j].transform.Position.Z()
+		}
+		return sprites[i].id < sprites[j].id
+	})
+
+	for _, entity := range sprites {
+		position := entity.transform.InterpolatedPosition(r.interpolationAlpha).Vec2()
+		size := mgl32.Vec2{
+			entity.sprite.Size.X() * entity.transform.Scale.X(),
+			entity.sprite.Size.Y() * entity.transform.Scale.Y(),
+		}
+
+		corners := SpriteQuad(position, size, entity.sprite.Origin, entity.transform.EulerAngles().Z())
+
+		// Animated sprites draw the current frame of their atlas
+		textureID := entity.sprite.TextureID
+		uvs := FullTextureUVs
+		if animation, ok := world.GetComponent(entity.id, "animation").(*ecs.AnimationComponent); ok && len(animation.Frames) > 0 {
+			if animation.AtlasID != "" {
+				textureID = animation.AtlasID
+			}
+			uvs = RectUVs(animation.CurrentFrame())
+		}
+
+		// Missing textures are skipped rather than failing the frame
+		_ = r.DrawTexturedQuad(textureID, corners, uvs, entity.sprite.Color)
+	}
+}
+
+// renderSprites draws and clears the queued quads in submission order
+func (r *Renderer) renderSprites() {
+	r.spriteBatch.Begin()
+	if len(r.spriteQueue) == 0 {
+		return
+	}
+
+	shader, exists := r.lookupShader("sprite")
+	if !exists {
+		r.spriteQueue = r.spriteQueue[:0]
+		return
+	}
+
+	shader.Use()
+	shader.SetMat4("projection", r.SpriteProjection())
+	shader.SetInt("spriteTexture", 0)
+
+	// Sprites are drawn in submission order on top of the scene
+	gl.Disable(gl.DEPTH_TEST)
+	for _, quad := range r.spriteQueue {
+		r.spriteBatch.Draw(quad.texture, quad.corners, quad.uvs, quad.color)
+	}
+	r.spriteBatch.End()
+	gl.Enable(gl.DEPTH_TEST)
+
+	r.spriteQueue = r.spriteQueue[:0]
+}
@@ -0,0 +1,81 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestOrthoMatrix(t *testing.T) {
+	projection := OrthoMatrix(mgl32.Vec2{4, 2}, 10, 2)
+
+	if want := mgl32.Ortho(-6, 14, -3, 7, -1, 1); projection != want {
+		t.Errorf("OrthoMatrix() = %v, want %v", projection, want)
+	}
+
+	// The view center and edges map to the center and edges of clip space
+	points := []struct{ world, clip mgl32.Vec2 }{
+		{mgl32.Vec2{4, 2}, mgl32.Vec2{0, 0}},
+		{mgl32.Vec2{14, 7}, mgl32.Vec2{1, 1}},
+		{mgl32.Vec2{-6, -3}, mgl32.Vec2{-1, -1}},
+	}
+	for _, point := range points {
+		clip := projection.Mul4x1(mgl32.Vec4{point.world.X(), point.world.Y(), 0, 1}).Vec2()
+		if clip.Sub(point.clip).Len() > 1e-5 {
+			t.Errorf("%v maps to %v, want %v", point.world, clip, point.clip)
+		}
+	}
+}
+
+func TestSpriteQuad(t *testing.T) {
+	tests := []struct {
+		name                   string
+		position, size, origin mgl32.Vec2
+		rotation               float32
+		want                   [4]mgl32.Vec2
+	}{
+		{
+			name:     "centered",
+			position: mgl32.Vec2{10, 5},
+			size:     mgl32.Vec2{4, 2},
+			origin:   mgl32.Vec2{0.5, 0.5},
+			want:     [4]mgl32.Vec2{{8, 4}, {12, 4}, {12, 6}, {8, 6}},
+		},
+		{
+			name:     "bottom-left origin",
+			position: mgl32.Vec2{1, 1},
+			size:     mgl32.Vec2{2, 3},
+			want:     [4]mgl32.Vec2{{1, 1}, {3, 1}, {3, 4}, {1, 4}},
+		},
+		{
+			name:     "quarter turn about the center",
+			position: mgl32.Vec2{0, 0},
+			size:     mgl32.Vec2{4, 2},
+			origin:   mgl32.Vec2{0.5, 0.5},
+			rotation: mgl32.DegToRad(90),
+			want:     [4]mgl32.Vec2{{1, -2}, {1, 2}, {-1, 2}, {-1, -2}},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			corners := SpriteQuad(test.position, test.size, test.origin, test.rotation)
+			for i := range corners {
+				if corners[i].Sub(test.want[i]).Len() > 1e-5 {
+					t.Errorf("corner %d = %v, want %v", i, corners[i], test.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestRectUVs(t *testing.T) {
+	uvs := RectUVs(mgl32.Vec4{0.25, 0.5, 0.75, 1})
+	want := [4]mgl32.Vec2{{0.25, 1}, {0.75, 1}, {0.75, 0.5}, {0.25, 0.5}}
+	if uvs != want {
+		t.Errorf("RectUVs() = %v, want %v", uvs, want)
+	}
+	if RectUVs(mgl32.Vec4{0, 0, 1, 1}) != FullTextureUVs {
+		t.Error("the whole texture doesn't give FullTextureUVs")
+	}
+}
@@ -0,0 +1,122 @@
+package graphics
+
+import (
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/jpeg" // Register JPEG decoding
+	_ "image/png"  // Register PNG decoding
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+)
+
+// Texture represents an OpenGL 2D texture
+type Texture struct {
+	ID     uint32
+	Width  int32
+	Height int32
+}
+
+// LoadTexture loads a PNG or JPEG image and registers it as a texture under id
+func (r *Renderer) LoadTexture(id, filepath string) error {
+	rgba, err := decodeImageFile(filepath)
+	if err != nil {
+		return err
+	}
+
+	return r.CreateTexture(id, rgba)
+}
+
+// CreateTexture uploads an image and registers it as a texture under id
+func (r *Renderer) CreateTexture(id string, img image.Image) error {
+	texture, err := NewTexture(img)
+	if err != nil {
+		return err
+	}
+
+	r.resourceMutex.Lock()
+	defer r.resourceMutex.Unlock()
+
+	// Replacing a texture frees the previous one
+	if previous, exists := r.textures[id]; exists {
+		deleteTexture(previous)
+	}
+
+	r.textures[id] = texture
+	r.checkGLError("texture " + id)
+	return nil
+}
+
+// GetTexture returns a registered texture
+func (r *Renderer) GetTexture(id string) (*Texture, bool) {
+	r.resourceMutex.RLock()
+	defer r.resourceMutex.RUnlock()
+
+	texture, exists := r.textures[id]
+	return texture, exists
+}
+
+// UnloadTexture frees a texture and unregisters it. Unloading a missing
+// texture does nothing; a texture that is still acquired can't be unloaded.
+func (r *Renderer) UnloadTexture(id string) error {
+	r.resourceMutex.Lock()
+	defer r.resourceMutex.Unlock()
+
+	if refs := r.textureRefs[id]; refs > 0 {
+		return fmt.Errorf("texture %s is still used by %d references", id, refs)
+	}
+
+	if texture, exists := r.textures[id]; exists {
+		deleteTexture(texture)
+		delete(r.textures, id)
+	}
+	return nil
+}
+
+// NewTexture uploads an image to a new texture. The first image row maps to v = 0.
+func NewTexture(img image.Image) (*Texture, error) {
+	rgba := toRGBA(img)
+	width := int32(rgba.Rect.Size().X)
+	height := int32(rgba.Rect.Size().Y)
+	if width == 0 || height == 0 {
+		return nil, fmt.Errorf("texture image is empty")
+	}
+
+	var id uint32
+	gl.GenTextures(1, &id)
+	gl.BindTexture(gl.TEXTURE_2D, id)
+
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_S, gl.CLAMP_TO_EDGE)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_WRAP_T, gl.CLAMP_TO_EDGE)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MIN_FILTER, gl.LINEAR)
+	gl.TexParameteri(gl.TEXTURE_2D, gl.TEXTURE_MAG_FILTER, gl.LINEAR)
+
+	gl.TexImage2D(gl.TEXTURE_2D, 0, gl.RGBA, width, height, 0, gl.RGBA, gl.UNSIGNED_BYTE, gl.Ptr(rgba.Pix))
+	gl.BindTexture(gl.TEXTURE_2D, 0)
+
+	return &Texture{ID: id, Width: width, Height: height}, nil
+}
+
+// deleteTexture frees the GL texture object. Like deleteMeshBuffers it is
+// a variable for tests.
+var deleteTexture = func(texture *Texture) {
+	gl.DeleteTextures(1, &texture.ID)
+}
+
+// Bind binds the texture to a texture unit
+func (t *Texture) Bind(unit uint32) {
+	gl.ActiveTexture(gl.TEXTURE0 + unit)
+	gl.BindTexture(gl.TEXTURE_2D, t.ID)
+}
+
+// toRGBA converts an image to tightly packed RGBA
+func toRGBA(img image.Image) *image.RGBA {
+	if rgba, ok := img.(*image.RGBA); ok && rgba.Stride == rgba.Rect.Size().X*4 && rgba.Rect.Min == (image.Point{}) {
+		return rgba
+	}
+
+	bounds := img.Bounds()
+	rgba := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(rgba, rgba.Bounds(), img, bounds.Min, draw.Src)
+	return rgba
+}
@@ -0,0 +1,54 @@
+package graphics
+
+import (
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// queueTilemaps queues the tiles of all tilemaps that fall inside the sprite
+// view. Tiles of one map share its atlas, so they batch into a single draw.
+func (r *Renderer) queueTilemaps(world *ecs.World) {
+	aspect := float32(1)
+	if r.viewportWidth > 0 && r.viewportHeight > 0 {
+		aspect = float32(r.viewportWidth) / float32(r.viewportHeight)
+	}
+	halfView := mgl32.Vec2{r.spriteViewHeight * aspect / 2, r.spriteViewHeight / 2}
+	viewMin := r.spriteViewCenter.Sub(halfView)
+	viewMax := r.spriteViewCenter.Add(halfView)
+
+	for _, entityID := range world.GetEntitiesWithComponent("tilemap") {
+		tilemap, ok := world.GetComponent(entityID, "tilemap").(*ecs.TilemapComponent)
+		if !ok {
+			continue
+		}
+		transform, ok := world.GetComponent(entityID, "transform").(*ecs.TransformComponent)
+		if !ok {
+			continue
+		}
+		texture, ok := r.GetTexture(tilemap.AtlasID)
+		if !ok {
+			continue
+		}
+
+		origin := transform.Position.Vec2()
+		minX, minY := tilemap.WorldToTile(origin, viewMin)
+		maxX, maxY := tilemap.WorldToTile(origin, viewMax)
+
+		for y := max(minY, 0); y <= min(maxY, tilemap.Height-1); y++ {
+			for x := max(minX, 0); x <= min(maxX, tilemap.Width-1); x++ {
+				tile := tilemap.GetTile(x, y)
+				if tile == ecs.EmptyTile {
+					continue
+				}
+
+				corner := tilemap.TileToWorld(origin, x, y)
+				r.spriteQueue = append(r.spriteQueue, queuedQuad{
+					texture: texture,
+					corners: SpriteQuad(corner, tilemap.TileSize, mgl32.Vec2{0, 0}, 0),
+					uvs:     RectUVs(tilemap.TileRect(tile)),
+					color:   mgl32.Vec4{1, 1, 1, 1},
+				})
+			}
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package graphics
+
+import (
+	"testing"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestQueueTilemaps(t *testing.T) {
+	renderer := NewRenderer()
+	renderer.textures["tiles"] = &Texture{}
+	renderer.SetSpriteView(mgl32.Vec2{0, 0}, 100)
+
+	world := ecs.NewWorld()
+	tilemap := ecs.NewTilemapComponent("tiles", 2, 2, 4, 4, mgl32.Vec2{1, 1})
+	tilemap.SetTile(0, 0, 0)
+	tilemap.SetTile(3, 2, 1)
+	world.NewEntity().
+		WithTransform(mgl32.Vec3{}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}).
+		With(tilemap).
+		Build()
+
+	// Only set cells are queued, all with the map's atlas
+	renderer.queueTilemaps(world)
+	if len(renderer.spriteQueue) != 2 {
+		t.Fatalf("queued %d tiles, want 2", len(renderer.spriteQueue))
+	}
+	for _, quad := range renderer.spriteQueue {
+		if quad.texture != renderer.textures["tiles"] {
+			t.Error("tile queued with another texture than the atlas")
+		}
+	}
+	if corner := renderer.spriteQueue[1].corners[0]; corner != (mgl32.Vec2{3, 2}) {
+		t.Errorf("tile (3, 2) queued at %v, want (3, 2)", corner)
+	}
+
+	// SetTile changes what is queued next frame
+	tilemap.SetTile(3, 2, 3)
+	renderer.spriteQueue = renderer.spriteQueue[:0]
+	renderer.queueTilemaps(world)
+	if got, want := renderer.spriteQueue[1].uvs, RectUVs(mgl32.Vec4{0.5, 0.5, 1, 1}); got != want {
+		t.Errorf("updated tile UVs = %v, want %v", got, want)
+	}
+
+	tilemap.SetTile(0, 0, ecs.EmptyTile)
+	renderer.spriteQueue = renderer.spriteQueue[:0]
+	renderer.queueTilemaps(world)
+	if len(renderer.spriteQueue) != 1 {
+		t.Errorf("queued %d tiles after clearing one, want 1", len(renderer.spriteQueue))
+	}
+}
+
+func TestQueueTilemapsCullsOutsideView(t *testing.T) {
+	renderer := NewRenderer()
+	renderer.textures["tiles"] = &Texture{}
+	renderer.SetViewportSize(100, 100)
+	renderer.SetSpriteView(mgl32.Vec2{0, 0}, 4)
+
+	world := ecs.NewWorld()
+	tilemap := ecs.NewTilemapComponent("tiles", 1, 1, 20, 1, mgl32.Vec2{1, 1})
+	for x := 0; x < 20; x++ {
+		tilemap.SetTile(x, 0, 0)
+	}
+	world.NewEntity().
+		WithTransform(mgl32.Vec3{-10, 0, 0}, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}).
+		With(tilemap).
+		Build()
+
+	// The view spans x = -2 to 2, touching cells 8 to 12
+	renderer.queueTilemaps(world)
+	if len(renderer.spriteQueue) != 5 {
+		t.Errorf("queued %d tiles, want the 5 in view", len(renderer.spriteQueue))
+	}
+}
@@ -0,0 +1,63 @@
+//go:build gl
+
+package graphics
+
+import (
+	"image"
+	"testing"
+
+	"github.com/go-gl/gl/v4.1-core/gl"
+)
+
+func TestUnloadMesh(t *testing.T) {
+	renderer := newGLRenderer(t)
+	if err := renderer.CreateCube("cube"); err != nil {
+		t.Fatalf("CreateCube: %v", err)
+	}
+	mesh, _ := renderer.GetMesh("cube")
+	before := len(renderer.meshes)
+
+	if err := renderer.UnloadMesh("cube"); err != nil {
+		t.Fatalf("UnloadMesh: %v", err)
+	}
+	if len(renderer.meshes) != before-1 {
+		t.Errorf("%d meshes after unload, want %d", len(renderer.meshes), before-1)
+	}
+	if _, exists := renderer.GetMesh("cube"); exists {
+		t.Error("unloaded mesh still registered")
+	}
+	if gl.IsVertexArray(mesh.VAO) || gl.IsBuffer(mesh.VBO) || gl.IsBuffer(mesh.EBO) {
+		t.Error("unloaded mesh's GL objects still exist")
+	}
+
+	// A second unload is a no-op
+	if err := renderer.UnloadMesh("cube"); err != nil {
+		t.Errorf("second UnloadMesh: %v", err)
+	}
+	if len(renderer.meshes) != before-1 {
+		t.Errorf("%d meshes after double unload, want %d", len(renderer.meshes), before-1)
+	}
+}
+
+func TestUnloadTexture(t *testing.T) {
+	renderer := newGLRenderer(t)
+	if err := renderer.CreateTexture("bricks", image.NewRGBA(image.Rect(0, 0, 4, 4))); err != nil {
+		t.Fatalf("CreateTexture: %v", err)
+	}
+	texture, _ := renderer.GetTexture("bricks")
+	before := len(renderer.textures)
+
+	if err := renderer.UnloadTexture("bricks"); err != nil {
+		t.Fatalf("UnloadTexture: %v", err)
+	}
+	if len(renderer.textures) != before-1 {
+		t.Errorf("%d textures after unload, want %d", len(renderer.textures), before-1)
+	}
+	if gl.IsTexture(texture.ID) {
+		t.Error("unloaded texture still exists")
+	}
+
+	if err := renderer.UnloadTexture("bricks"); err != nil {
+		t.Errorf("second UnloadTexture: %v", err)
+	}
+}
@@ -0,0 +1,35 @@
+package graphics
+
+import (
+	"testing"
+)
+
+func TestUnloadGuards(t *testing.T) {
+	renderer := NewRenderer()
+	renderer.meshes["default"] = &Mesh{}
+	renderer.meshes["crate"] = &Mesh{}
+	renderer.textures["bricks"] = &Texture{}
+	renderer.AcquireMesh("crate")
+	renderer.AcquireTexture("bricks")
+
+	if err := renderer.UnloadMesh("default"); err == nil {
+		t.Error("unloading the default mesh succeeded")
+	}
+	if err := renderer.UnloadMesh("crate"); err == nil {
+		t.Error("unloading an acquired mesh succeeded")
+	}
+	if err := renderer.UnloadTexture("bricks"); err == nil {
+		t.Error("unloading an acquired texture succeeded")
+	}
+	if len(renderer.meshes) != 2 || len(renderer.textures) != 1 {
+		t.Errorf("refused unloads changed the maps: %d meshes, %d textures", len(renderer.meshes), len(renderer.textures))
+	}
+
+	// Unloading something that was never loaded does nothing
+	if err := renderer.UnloadMesh("missing"); err != nil {
+		t.Errorf("UnloadMesh of a missing mesh: %v", err)
+	}
+	if err := renderer.UnloadTexture("missing"); err != nil {
+		t.Errorf("UnloadTexture of a missing texture: %v", err)
+	}
+}
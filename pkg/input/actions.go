@@ -0,0 +1,151 @@
+package input
+
+import (
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// Actions belong to a player, so local players can bind the same action
+// name to different keys or gamepads. The calls without a player act on
+// player 0.
+
+// actionKey names an action of one player
+type actionKey struct {
+	player int
+	name   string
+}
+
+// actionBinding holds the inputs bound to an action
+type actionBinding struct {
+	keys    []glfw.Key
+	buttons []glfw.MouseButton
+	// gamepadButtons are read from the gamepad assigned to the action's player
+	gamepadButtons []glfw.GamepadButton
+}
+
+// BindAction binds keys to an action of player 0, in addition to any inputs already bound
+func (m *Manager) BindAction(name string, keys ...glfw.Key) {
+	m.BindPlayerAction(0, name, keys...)
+}
+
+// BindMouseAction binds a mouse button to an action of player 0, in addition to any inputs already bound
+func (m *Manager) BindMouseAction(name string, button glfw.MouseButton) {
+	binding := m.actionBinding(0, name)
+	binding.buttons = append(binding.buttons, button)
+}
+
+// BindPlayerAction binds keys to an action of a player, in addition to any inputs already bound
+func (m *Manager) BindPlayerAction(player int, name string, keys ...glfw.Key) {
+	binding := m.actionBinding(player, name)
+	binding.keys = append(binding.keys, keys...)
+}
+
+// BindGamepadAction binds buttons of the gamepad assigned to a player to one
+// of their actions, in addition to any inputs already bound. The buttons
+// follow the player when AssignGamepad gives them another gamepad.
+func (m *Manager) BindGamepadAction(player int, name string, buttons ...glfw.GamepadButton) {
+	binding := m.actionBinding(player, name)
+	binding.gamepadButtons = append(binding.gamepadButtons, buttons...)
+}
+
+// UnbindAction removes an action of player 0 and all its bindings
+func (m *Manager) UnbindAction(name string) {
+	m.UnbindPlayerAction(0, name)
+}
+
+// UnbindPlayerAction removes an action of a player and all its bindings
+func (m *Manager) UnbindPlayerAction(player int, name string) {
+	delete(m.actions, actionKey{player, name})
+}
+
+// IsActionPressed returns true if any input bound to the action of player 0 is pressed
+func (m *Manager) IsActionPressed(name string) bool {
+	return m.IsPlayerActionPressed(0, name)
+}
+
+// IsActionJustPressed returns true if the action of player 0 became pressed this frame.
+// Pressing a second bound input while the first is held doesn't count.
+func (m *Manager) IsActionJustPressed(name string) bool {
+	return m.IsPlayerActionJustPressed(0, name)
+}
+
+// IsActionJustReleased returns true if the last held input of the action of
+// player 0 was released this frame
+func (m *Manager) IsActionJustReleased(name string) bool {
+	return m.IsPlayerActionJustReleased(0, name)
+}
+
+// IsPlayerActionPressed returns true if any input bound to a player's action is pressed
+func (m *Manager) IsPlayerActionPressed(player int, name string) bool {
+	return m.actionState(player, name, m.currentInput())
+}
+
+// IsPlayerActionJustPressed returns true if a player's action became pressed this frame
+func (m *Manager) IsPlayerActionJustPressed(player int, name string) bool {
+	return m.actionState(player, name, m.currentInput()) && !m.actionState(player, name, m.previousInput())
+}
+
+// IsPlayerActionJustReleased returns true if the last held input of a
+// player's action was released this frame
+func (m *Manager) IsPlayerActionJustReleased(player int, name string) bool {
+	return !m.actionState(player, name, m.currentInput()) && m.actionState(player, name, m.previousInput())
+}
+
+// actionBinding returns the binding of a player's action, creating it if needed
+func (m *Manager) actionBinding(player int, name string) *actionBinding {
+	key := actionKey{player, name}
+	binding, exists := m.actions[key]
+	if !exists {
+		binding = &actionBinding{}
+		m.actions[key] = binding
+	}
+	return binding
+}
+
+// inputState is the state of the inputs actions can be bound to in one frame
+type inputState struct {
+	keys    map[glfw.Key]bool
+	buttons map[glfw.MouseButton]bool
+	// gamepads holds the pressed buttons of each polled gamepad
+	gamepads map[int][]bool
+}
+
+// currentInput returns this frame's input state
+func (m *Manager) currentInput() inputState {
+	return inputState{m.keys, m.mouseButtons, m.gamepadButtons}
+}
+
+// previousInput returns the last frame's input state
+func (m *Manager) previousInput() inputState {
+	return inputState{m.prevKeys, m.prevMouseButtons, m.prevGamepadButtons}
+}
+
+// actionState ORs together the bound inputs of a player's action in the given state
+func (m *Manager) actionState(player int, name string, state inputState) bool {
+	binding, exists := m.actions[actionKey{player, name}]
+	if !exists {
+		return false
+	}
+
+	for _, key := range binding.keys {
+		if state.keys[key] {
+			return true
+		}
+	}
+	for _, button := range binding.buttons {
+		if state.buttons[button] {
+			return true
+		}
+	}
+
+	jid, assigned := m.playerGamepads[player]
+	if !assigned {
+		return false
+	}
+	pressed := state.gamepads[jid]
+	for _, button := range binding.gamepadButtons {
+		if int(button) < len(pressed) && pressed[button] {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// pressKey delivers a key event as GLFW would during PollEvents
+func pressKey(m *Manager, key glfw.Key, action glfw.Action) {
+	m.keyCallback(nil, key, 0, action, 0)
+}
+
+func TestActionBoundToTwoKeys(t *testing.T) {
+	for _, key := range []glfw.Key{glfw.KeySpace, glfw.KeyW} {
+		manager := NewManager(nil)
+		manager.BindAction("jump", glfw.KeySpace, glfw.KeyW)
+
+		manager.Update()
+		pressKey(manager, key, glfw.Press)
+		if !manager.IsActionPressed("jump") || !manager.IsActionJustPressed("jump") {
+			t.Errorf("key %d didn't trigger jump", key)
+		}
+
+		// Held on the next frame: pressed but not just pressed
+		manager.Update()
+		if !manager.IsActionPressed("jump") || manager.IsActionJustPressed("jump") {
+			t.Errorf("key %d held: pressed = %v, just pressed = %v; want true, false",
+				key, manager.IsActionPressed("jump"), manager.IsActionJustPressed("jump"))
+		}
+
+		manager.Update()
+		pressKey(manager, key, glfw.Release)
+		if manager.IsActionPressed("jump") || !manager.IsActionJustReleased("jump") {
+			t.Errorf("key %d released: jump still pressed or not just released", key)
+		}
+	}
+}
+
+func TestActionJustPressedOnlyOnTransition(t *testing.T) {
+	manager := NewManager(nil)
+	manager.BindAction("jump", glfw.KeySpace, glfw.KeyW)
+
+	manager.Update()
+	pressKey(manager, glfw.KeySpace, glfw.Press)
+	if !manager.IsActionJustPressed("jump") {
+		t.Fatal("first key didn't just press jump")
+	}
+
+	// The second key joins while the first is held: no new press
+	manager.Update()
+	pressKey(manager, glfw.KeyW, glfw.Press)
+	if manager.IsActionJustPressed("jump") {
+		t.Error("pressing a second bound key counted as a new press")
+	}
+
+	// Releasing one of two held keys doesn't release the action
+	manager.Update()
+	pressKey(manager, glfw.KeySpace, glfw.Release)
+	if !manager.IsActionPressed("jump") || manager.IsActionJustReleased("jump") {
+		t.Error("releasing one of two held keys released jump")
+	}
+}
+
+func TestMouseActionAndUnbind(t *testing.T) {
+	manager := NewManager(nil)
+	manager.BindAction("fire", glfw.KeySpace)
+	manager.BindMouseAction("fire", glfw.MouseButtonLeft)
+
+	manager.Update()
+	manager.mouseButtonCallback(nil, glfw.MouseButtonLeft, glfw.Press, 0)
+	if !manager.IsActionJustPressed("fire") {
+		t.Error("mouse button didn't trigger fire")
+	}
+
+	manager.UnbindAction("fire")
+	if manager.IsActionPressed("fire") {
+		t.Error("unbound action is still pressed")
+	}
+	if manager.IsActionPressed("unknown") {
+		t.Error("an action that was never bound is pressed")
+	}
+}
@@ -0,0 +1,115 @@
+package input
+
+import (
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// virtualCursor is a cursor position that follows mouse movement but stays
+// within the window, even while the real cursor is disabled
+type virtualCursor struct {
+	x, y          float64
+	width, height float64
+	// Last raw position, used to turn callbacks into deltas
+	lastX, lastY float64
+	hasLast      bool
+}
+
+// move offsets the virtual cursor and clamps it to the window bounds
+func (c *virtualCursor) move(dx, dy float64) {
+	c.x = clamp(c.x+dx, 0, c.width)
+	c.y = clamp(c.y+dy, 0, c.height)
+}
+
+// track feeds a raw cursor position, moves the virtual cursor by its delta
+// and returns the delta. The first position after a reset has no delta.
+func (c *virtualCursor) track(xpos, ypos float64) (float64, float64) {
+	var dx, dy float64
+	if c.hasLast {
+		dx = xpos - c.lastX
+		dy = ypos - c.lastY
+		c.move(dx, dy)
+	}
+	c.lastX = xpos
+	c.lastY = ypos
+	c.hasLast = true
+	return dx, dy
+}
+
+// clamp limits value to [min, max]
+func clamp(value, min, max float64) float64 {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
+
+// initCursor sizes the virtual cursor to the window and follows resizes
+func (m *Manager) initCursor() {
+	width, height := m.window.GetSize()
+	m.SetCursorBounds(float64(width), float64(height))
+
+	x, y := m.window.GetCursorPos()
+	m.cursor.x = clamp(x, 0, m.cursor.width)
+	m.cursor.y = clamp(y, 0, m.cursor.height)
+
+	m.window.SetSizeCallback(func(window *glfw.Window, width, height int) {
+		m.SetCursorBounds(float64(width), float64(height))
+	})
+}
+
+// SetCursorBounds sets the area the virtual cursor is clamped to
+func (m *Manager) SetCursorBounds(width, height float64) {
+	m.cursor.width = width
+	m.cursor.height = height
+	m.cursor.move(0, 0)
+}
+
+// GetVirtualCursor returns the virtual cursor position. Unlike GetMousePosition
+// it stays within the window when the cursor is disabled for mouse-look.
+func (m *Manager) GetVirtualCursor() (float64, float64) {
+	return m.cursor.x, m.cursor.y
+}
+
+// SetVirtualCursor moves the virtual cursor, clamped to the window bounds
+func (m *Manager) SetVirtualCursor(x, y float64) {
+	m.cursor.x = clamp(x, 0, m.cursor.width)
+	m.cursor.y = clamp(y, 0, m.cursor.height)
+}
+
+// SetRelativeMouseMode captures the cursor for mouse-look, hiding it and
+// reporting unaccelerated motion where the platform supports it, or releases it
+func (m *Manager) SetRelativeMouseMode(enabled bool) {
+	mode := glfw.CursorNormal
+	if enabled {
+		mode = glfw.CursorDisabled
+	}
+	m.SetCursorMode(mode)
+
+	if glfw.RawMouseMotionSupported() {
+		raw := glfw.False
+		if enabled {
+			raw = glfw.True
+		}
+		m.window.SetInputMode(glfw.RawMouseMotion, raw)
+	}
+}
+
+// IsRelativeMouseMode returns true if the cursor is captured for mouse-look
+func (m *Manager) IsRelativeMouseMode() bool {
+	return m.IsCursorDisabled()
+}
+
+// resetMouseBaseline drops the last raw cursor position and the pending
+// mouse delta, so the first motion after a mode change doesn't jump
+func (m *Manager) resetMouseBaseline() {
+	m.cursor.hasLast = false
+	m.mouseDelta.reset()
+}
+
+// IsCursorDisabled returns true if the cursor is hidden and locked for mouse-look
+func (m *Manager) IsCursorDisabled() bool {
+	return m.cursorMode == glfw.CursorDisabled
+}
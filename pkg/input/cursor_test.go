@@ -0,0 +1,119 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+func TestVirtualCursorInDisabledMode(t *testing.T) {
+	manager := NewManager(nil)
+	manager.cursorMode = glfw.CursorDisabled
+	manager.SetCursorBounds(800, 600)
+	manager.SetVirtualCursor(400, 300)
+
+	// With the cursor disabled the raw position is unbounded; only its
+	// movement matters, and the first position is just the baseline
+	moves := []struct {
+		rawX, rawY float64
+		x, y       float64
+	}{
+		{5000, 5000, 400, 300},
+		{5100, 4950, 500, 250},
+		{5050, 4950, 450, 250},
+		{6000, 4000, 800, 0},
+		{5900, 4100, 700, 100},
+		{-2000, 9000, 0, 600},
+		{-1990, 8990, 10, 590},
+	}
+	for i, move := range moves {
+		manager.cursorPosCallback(nil, move.rawX, move.rawY)
+		x, y := manager.GetVirtualCursor()
+		if x != move.x || y != move.y {
+			t.Errorf("move %d to raw (%v, %v): virtual cursor = (%v, %v), want (%v, %v)", i, move.rawX, move.rawY, x, y, move.x, move.y)
+		}
+	}
+
+	if !manager.IsRelativeMouseMode() {
+		t.Error("IsRelativeMouseMode() = false with the cursor disabled")
+	}
+}
+
+func TestVirtualCursorBounds(t *testing.T) {
+	manager := NewManager(nil)
+	manager.SetCursorBounds(800, 600)
+
+	manager.SetVirtualCursor(-50, 900)
+	if x, y := manager.GetVirtualCursor(); x != 0 || y != 600 {
+		t.Errorf("virtual cursor = (%v, %v), want it clamped to (0, 600)", x, y)
+	}
+
+	// Shrinking the window pulls the cursor inside
+	manager.SetVirtualCursor(700, 500)
+	manager.SetCursorBounds(640, 480)
+	if x, y := manager.GetVirtualCursor(); x != 640 || y != 480 {
+		t.Errorf("virtual cursor after resize = (%v, %v), want (640, 480)", x, y)
+	}
+}
+
+func TestVirtualCursorIgnoresJumpAfterReset(t *testing.T) {
+	manager := NewManager(nil)
+	manager.SetCursorBounds(800, 600)
+	manager.SetVirtualCursor(100, 100)
+
+	manager.cursorPosCallback(nil, 10, 10)
+	manager.cursorPosCallback(nil, 20, 20)
+
+	// A mode change may warp the raw cursor; that isn't movement
+	manager.resetMouseBaseline()
+	manager.cursorPosCallback(nil, 400, 300)
+	manager.cursorPosCallback(nil, 405, 300)
+
+	if x, y := manager.GetVirtualCursor(); x != 115 || y != 110 {
+		t.Errorf("virtual cursor = (%v, %v), want (115, 110)", x, y)
+	}
+}
+
+func TestBaselineResetDropsPendingDelta(t *testing.T) {
+	manager := NewManager(nil)
+	manager.SetMouseSmoothing(0.25)
+	moveMouse(manager, 0, 0)
+	moveMouse(manager, 40, 40)
+
+	// Capturing the cursor mid-frame discards the motion so far
+	manager.resetMouseBaseline()
+	if dx, dy := manager.GetMouseDelta(); dx != 0 || dy != 0 {
+		t.Errorf("delta right after the reset = (%v, %v), want zero", dx, dy)
+	}
+	if manager.cursor.hasLast {
+		t.Error("raw baseline kept after the reset")
+	}
+	if got := manager.GetMouseSmoothing(); got != 0.25 {
+		t.Errorf("GetMouseSmoothing() = %v after the reset, want 0.25", got)
+	}
+
+	// The next frame after capture starts from zero
+	moveMouse(manager, -700, 900)
+	manager.Update()
+	if dx, dy := manager.GetMouseDelta(); dx != 0 || dy != 0 {
+		t.Errorf("delta the frame after capture = (%v, %v), want zero", dx, dy)
+	}
+}
+
+func TestIsRelativeMouseMode(t *testing.T) {
+	manager := NewManager(nil)
+	if manager.IsRelativeMouseMode() {
+		t.Error("new manager starts in relative mouse mode")
+	}
+
+	manager.cursorMode = glfw.CursorDisabled
+	if !manager.IsRelativeMouseMode() {
+		t.Error("IsRelativeMouseMode() = false with the cursor disabled")
+	}
+
+	// A hidden cursor still moves freely, so it isn't relative mode
+	manager.cursorMode = glfw.CursorHidden
+	if manager.IsRelativeMouseMode() {
+		t.Error("IsRelativeMouseMode() = true with the cursor only hidden")
+	}
+}
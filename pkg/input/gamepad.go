@@ -0,0 +1,182 @@
+package input
+
+import (
+	"fmt"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// GamepadEventType represents the kind of gamepad event
+type GamepadEventType int
+
+const (
+	// GamepadConnected is fired when a gamepad is plugged in
+	GamepadConnected GamepadEventType = iota
+	// GamepadDisconnected is fired when a gamepad is unplugged
+	GamepadDisconnected
+)
+
+// GamepadEvent represents a gamepad connection change
+type GamepadEvent struct {
+	Type       GamepadEventType
+	JoystickID int
+	// Player is the player the gamepad was assigned to, or -1 if unassigned
+	Player int
+}
+
+// JoystickSource abstracts joystick discovery so it can be replaced in tests
+type JoystickSource interface {
+	// Present returns true if the joystick is connected
+	Present(jid int) bool
+	// SetCallback registers the connect/disconnect callback
+	SetCallback(callback func(jid int, connected bool))
+}
+
+// GamepadButtonSource is implemented by joystick sources that can report
+// gamepad buttons. Without it, gamepad buttons bound to actions never press.
+type GamepadButtonSource interface {
+	// GamepadButtons returns which buttons of a gamepad are pressed, indexed
+	// by glfw.GamepadButton, or nil if it isn't a connected gamepad
+	GamepadButtons(jid int) []bool
+}
+
+// glfwJoystickSource is the JoystickSource backed by GLFW
+type glfwJoystickSource struct{}
+
+func (glfwJoystickSource) Present(jid int) bool {
+	return glfw.Joystick(jid).Present()
+}
+
+func (glfwJoystickSource) SetCallback(callback func(jid int, connected bool)) {
+	glfw.SetJoystickCallback(func(joy glfw.Joystick, event glfw.PeripheralEvent) {
+		callback(int(joy), event == glfw.Connected)
+	})
+}
+
+func (glfwJoystickSource) GamepadButtons(jid int) []bool {
+	state := glfw.Joystick(jid).GetGamepadState()
+	if state == nil {
+		return nil
+	}
+
+	pressed := make([]bool, len(state.Buttons))
+	for i, action := range state.Buttons {
+		pressed[i] = action == glfw.Press
+	}
+	return pressed
+}
+
+// MaxJoysticks is the number of joystick slots scanned on init
+const MaxJoysticks = int(glfw.JoystickLast) + 1
+
+// SetJoystickSource replaces the joystick source; call before Init
+func (m *Manager) SetJoystickSource(source JoystickSource) {
+	m.joysticks = source
+}
+
+// initGamepads scans connected joysticks and registers the hot-plug callback
+func (m *Manager) initGamepads() {
+	if m.joysticks == nil {
+		m.joysticks = glfwJoystickSource{}
+	}
+
+	for jid := 0; jid < MaxJoysticks; jid++ {
+		if m.joysticks.Present(jid) {
+			m.gamepads[jid] = true
+		}
+	}
+
+	m.joysticks.SetCallback(m.joystickCallback)
+}
+
+// OnGamepadEvent registers a listener for gamepad connect/disconnect events
+func (m *Manager) OnGamepadEvent(listener func(event GamepadEvent)) {
+	m.gamepadListeners = append(m.gamepadListeners, listener)
+}
+
+// IsGamepadConnected returns true if the joystick is connected
+func (m *Manager) IsGamepadConnected(jid int) bool {
+	return m.gamepads[jid]
+}
+
+// GetConnectedGamepads returns the IDs of all connected joysticks
+func (m *Manager) GetConnectedGamepads() []int {
+	var gamepads []int
+	for jid := 0; jid < MaxJoysticks; jid++ {
+		if m.gamepads[jid] {
+			gamepads = append(gamepads, jid)
+		}
+	}
+	return gamepads
+}
+
+// AssignGamepad assigns a connected joystick to a player, replacing any previous assignment
+func (m *Manager) AssignGamepad(player int, jid int) error {
+	if !m.gamepads[jid] {
+		return fmt.Errorf("gamepad %d is not connected", jid)
+	}
+
+	// A gamepad can only belong to one player
+	for otherPlayer, otherJID := range m.playerGamepads {
+		if otherJID == jid {
+			delete(m.playerGamepads, otherPlayer)
+		}
+	}
+
+	m.playerGamepads[player] = jid
+	return nil
+}
+
+// UnassignGamepad removes the gamepad assignment of a player
+func (m *Manager) UnassignGamepad(player int) {
+	delete(m.playerGamepads, player)
+}
+
+// GetPlayerGamepad returns the joystick assigned to a player
+func (m *Manager) GetPlayerGamepad(player int) (int, bool) {
+	jid, exists := m.playerGamepads[player]
+	return jid, exists
+}
+
+// pollGamepads keeps the last frame's gamepad buttons and reads the buttons
+// of every gamepad assigned to a player
+func (m *Manager) pollGamepads() {
+	m.prevGamepadButtons = m.gamepadButtons
+	m.gamepadButtons = nil
+
+	source, ok := m.joysticks.(GamepadButtonSource)
+	if !ok || len(m.playerGamepads) == 0 {
+		return
+	}
+	m.gamepadButtons = make(map[int][]bool, len(m.playerGamepads))
+	for _, jid := range m.playerGamepads {
+		m.gamepadButtons[jid] = source.GamepadButtons(jid)
+	}
+}
+
+// joystickCallback tracks connection state and clears assignments on disconnect
+func (m *Manager) joystickCallback(jid int, connected bool) {
+	event := GamepadEvent{
+		JoystickID: jid,
+		Player:     -1,
+	}
+
+	if connected {
+		m.gamepads[jid] = true
+		event.Type = GamepadConnected
+	} else {
+		delete(m.gamepads, jid)
+		event.Type = GamepadDisconnected
+
+		for player, assigned := range m.playerGamepads {
+			if assigned == jid {
+				delete(m.playerGamepads, player)
+				event.Player = player
+			}
+		}
+	}
+
+	for _, listener := range m.gamepadListeners {
+		listener(event)
+	}
+}
@@ -0,0 +1,198 @@
+package input
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// mockJoysticks is a JoystickSource whose connections are driven by the test
+type mockJoysticks struct {
+	present  map[int]bool
+	buttons  map[int][]bool
+	callback func(jid int, connected bool)
+}
+
+func (m *mockJoysticks) Present(jid int) bool {
+	return m.present[jid]
+}
+
+func (m *mockJoysticks) SetCallback(callback func(jid int, connected bool)) {
+	m.callback = callback
+}
+
+func (m *mockJoysticks) GamepadButtons(jid int) []bool {
+	// Like GLFW, hand out a fresh state on every poll
+	return append([]bool(nil), m.buttons[jid]...)
+}
+
+// press sets whether a button of a gamepad is held
+func (m *mockJoysticks) press(jid int, button glfw.GamepadButton, held bool) {
+	if m.buttons[jid] == nil {
+		m.buttons[jid] = make([]bool, glfw.ButtonLast+1)
+	}
+	m.buttons[jid][button] = held
+}
+
+// newGamepadManager creates a manager using a mock joystick source with the
+// given joysticks connected at init
+func newGamepadManager(t *testing.T, connected ...int) (*Manager, *mockJoysticks) {
+	t.Helper()
+
+	joysticks := &mockJoysticks{present: make(map[int]bool), buttons: make(map[int][]bool)}
+	for _, jid := range connected {
+		joysticks.present[jid] = true
+	}
+
+	manager := NewManager(nil)
+	manager.SetJoystickSource(joysticks)
+	manager.initGamepads()
+	if joysticks.callback == nil {
+		t.Fatal("initGamepads didn't register a callback")
+	}
+	return manager, joysticks
+}
+
+func TestGamepadsConnectedAtInit(t *testing.T) {
+	manager, _ := newGamepadManager(t, 0, 2)
+
+	if got, want := manager.GetConnectedGamepads(), []int{0, 2}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetConnectedGamepads() = %v, want %v", got, want)
+	}
+	if manager.IsGamepadConnected(1) {
+		t.Error("IsGamepadConnected(1) = true for an absent joystick")
+	}
+}
+
+func TestGamepadHotPlugEvents(t *testing.T) {
+	manager, joysticks := newGamepadManager(t)
+
+	var events []GamepadEvent
+	manager.OnGamepadEvent(func(event GamepadEvent) {
+		events = append(events, event)
+	})
+
+	joysticks.callback(3, true)
+	if !manager.IsGamepadConnected(3) {
+		t.Error("gamepad 3 not connected after its connect event")
+	}
+
+	joysticks.callback(3, false)
+	if manager.IsGamepadConnected(3) {
+		t.Error("gamepad 3 still connected after its disconnect event")
+	}
+
+	want := []GamepadEvent{
+		{Type: GamepadConnected, JoystickID: 3, Player: -1},
+		{Type: GamepadDisconnected, JoystickID: 3, Player: -1},
+	}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %+v, want %+v", events, want)
+	}
+}
+
+func TestGamepadPlayerAssignment(t *testing.T) {
+	manager, joysticks := newGamepadManager(t, 0, 1)
+
+	if err := manager.AssignGamepad(5, 7); err == nil {
+		t.Error("assigning a disconnected gamepad succeeded")
+	}
+
+	if err := manager.AssignGamepad(0, 0); err != nil {
+		t.Fatalf("AssignGamepad(0, 0): %v", err)
+	}
+	if err := manager.AssignGamepad(1, 1); err != nil {
+		t.Fatalf("AssignGamepad(1, 1): %v", err)
+	}
+	if jid, ok := manager.GetPlayerGamepad(1); !ok || jid != 1 {
+		t.Errorf("GetPlayerGamepad(1) = %d, %v; want 1, true", jid, ok)
+	}
+
+	// Moving a gamepad to another player takes it from the first
+	if err := manager.AssignGamepad(1, 0); err != nil {
+		t.Fatalf("AssignGamepad(1, 0): %v", err)
+	}
+	if _, ok := manager.GetPlayerGamepad(0); ok {
+		t.Error("player 0 kept gamepad 0 after it moved to player 1")
+	}
+	if jid, _ := manager.GetPlayerGamepad(1); jid != 0 {
+		t.Errorf("player 1 has gamepad %d, want 0", jid)
+	}
+
+	// Unplugging clears the assignment and reports the player
+	var events []GamepadEvent
+	manager.OnGamepadEvent(func(event GamepadEvent) {
+		events = append(events, event)
+	})
+	joysticks.callback(0, false)
+
+	if _, ok := manager.GetPlayerGamepad(1); ok {
+		t.Error("player 1 kept gamepad 0 after it was unplugged")
+	}
+	want := []GamepadEvent{{Type: GamepadDisconnected, JoystickID: 0, Player: 1}}
+	if !reflect.DeepEqual(events, want) {
+		t.Errorf("events = %+v, want %+v", events, want)
+	}
+
+	manager.UnassignGamepad(1)
+	if _, ok := manager.GetPlayerGamepad(1); ok {
+		t.Error("UnassignGamepad didn't clear the assignment")
+	}
+}
+
+func TestPlayerActionsOnSeparateGamepads(t *testing.T) {
+	manager, joysticks := newGamepadManager(t, 0, 1)
+	for player, jid := range []int{0, 1} {
+		if err := manager.AssignGamepad(player, jid); err != nil {
+			t.Fatalf("AssignGamepad(%d, %d): %v", player, jid, err)
+		}
+		manager.BindGamepadAction(player, "jump", glfw.ButtonA)
+	}
+
+	// Player 1 presses jump on their gamepad
+	joysticks.press(1, glfw.ButtonA, true)
+	manager.Update()
+
+	if manager.IsPlayerActionPressed(0, "jump") {
+		t.Error("player 0 jumped from player 1's gamepad")
+	}
+	if !manager.IsPlayerActionPressed(1, "jump") {
+		t.Error("IsPlayerActionPressed(1, jump) = false, want true")
+	}
+	if !manager.IsPlayerActionJustPressed(1, "jump") {
+		t.Error("IsPlayerActionJustPressed(1, jump) = false on the first frame")
+	}
+
+	// Holding it is no longer a fresh press
+	manager.Update()
+	if manager.IsPlayerActionJustPressed(1, "jump") {
+		t.Error("IsPlayerActionJustPressed(1, jump) = true while held")
+	}
+
+	// Releasing on player 1 and pressing on player 0 swaps who's jumping
+	joysticks.press(1, glfw.ButtonA, false)
+	joysticks.press(0, glfw.ButtonA, true)
+	manager.Update()
+
+	if !manager.IsPlayerActionJustReleased(1, "jump") {
+		t.Error("IsPlayerActionJustReleased(1, jump) = false after release")
+	}
+	if !manager.IsPlayerActionJustPressed(0, "jump") {
+		t.Error("IsPlayerActionJustPressed(0, jump) = false after press")
+	}
+	// The calls without a player act on player 0
+	if !manager.IsActionPressed("jump") {
+		t.Error("IsActionPressed(jump) = false, want player 0's state")
+	}
+
+	// Keys are scoped too
+	manager.BindPlayerAction(1, "fire", glfw.KeyEnter)
+	manager.keys[glfw.KeyEnter] = true
+	if manager.IsActionPressed("fire") {
+		t.Error("player 0 fired from a key bound for player 1")
+	}
+	if !manager.IsPlayerActionPressed(1, "fire") {
+		t.Error("IsPlayerActionPressed(1, fire) = false, want true")
+	}
+}
@@ -1,6 +1,9 @@
 package input
 
 import (
+	"encoding/json"
+	"log"
+
 	"github.com/go-gl/glfw/v3.3/glfw"
 )
 
@@ -11,19 +14,42 @@ type Manager struct {
 	// Keyboard state
 	keys     map[glfw.Key]bool
 	prevKeys map[glfw.Key]bool
+	// Keys the OS auto-repeated this frame
+	repeats map[glfw.Key]bool
 
 	// Mouse state
 	mousePos struct {
 		x, y float64
 	}
-	prevMousePos struct {
-		x, y float64
-	}
+	mouseDelta       mouseDelta
 	mouseButtons     map[glfw.MouseButton]bool
 	prevMouseButtons map[glfw.MouseButton]bool
 
+	// Virtual cursor, clamped to the window
+	cursor     virtualCursor
+	cursorMode int
+
+	// Named actions of each player bound to keys, mouse and gamepad buttons
+	actions map[actionKey]*actionBinding
+
+	// Text typed since the last frame
+	typedRunes []rune
+
 	// Mouse scroll
 	scrollX, scrollY float64
+
+	// Input recording and playback
+	recorder *inputRecorder
+	playback *json.Decoder
+
+	// Gamepads
+	joysticks        JoystickSource
+	gamepads         map[int]bool
+	playerGamepads   map[int]int
+	gamepadListeners []func(event GamepadEvent)
+	// Pressed buttons of the assigned gamepads, by joystick
+	gamepadButtons     map[int][]bool
+	prevGamepadButtons map[int][]bool
 }
 
 // NewManager creates a new input manager
@@ -32,8 +58,13 @@ func NewManager(window *glfw.Window) *Manager {
 		window:           window,
 		keys:             make(map[glfw.Key]bool),
 		prevKeys:         make(map[glfw.Key]bool),
+		repeats:          make(map[glfw.Key]bool),
 		mouseButtons:     make(map[glfw.MouseButton]bool),
 		prevMouseButtons: make(map[glfw.MouseButton]bool),
+		gamepads:         make(map[int]bool),
+		playerGamepads:   make(map[int]int),
+		cursorMode:       glfw.CursorNormal,
+		actions:          make(map[actionKey]*actionBinding),
 	}
 }
 
@@ -44,12 +75,30 @@ func (m *Manager) Init() error {
 	m.window.SetMouseButtonCallback(m.mouseButtonCallback)
 	m.window.SetCursorPosCallback(m.cursorPosCallback)
 	m.window.SetScrollCallback(m.scrollCallback)
+	m.window.SetCharCallback(m.charCallback)
+
+	// Keep the virtual cursor within the window
+	m.initCursor()
+
+	// Track gamepad hot-plugging
+	m.initGamepads()
 
 	return nil
 }
 
-// Update updates the input state
+// Update starts a new input frame. It must be called once per frame
+// immediately before glfw.PollEvents: it saves the current state as the
+// previous frame's and clears per-frame accumulators, so the events delivered
+// by PollEvents make up exactly one frame of input.
 func (m *Manager) Update() {
+	// Record the frame that was just polled
+	if m.recorder != nil {
+		if err := m.recordFrame(); err != nil {
+			log.Printf("Input recording failed: %v", err)
+			m.recorder = nil
+		}
+	}
+
 	// Update previous states
 	for key := range m.keys {
 		m.prevKeys[key] = m.keys[key]
@@ -59,13 +108,28 @@ func (m *Manager) Update() {
 		m.prevMouseButtons[button] = m.mouseButtons[button]
 	}
 
-	// Update mouse position
-	m.prevMousePos.x = m.mousePos.x
-	m.prevMousePos.y = m.mousePos.y
+	// Gamepads aren't event driven, so read this frame's buttons now
+	m.pollGamepads()
+
+	// Start a new mouse delta
+	m.mouseDelta.advance()
+
+	// Repeats only last for the frame they fired in
+	for key := range m.repeats {
+		delete(m.repeats, key)
+	}
 
 	// Reset scroll
 	m.scrollX = 0
 	m.scrollY = 0
+
+	// Drop text nobody consumed last frame
+	m.typedRunes = m.typedRunes[:0]
+
+	// Recorded input replaces this frame's events
+	if m.playback != nil {
+		m.playFrame()
+	}
 }
 
 // IsKeyPressed returns true if a key is currently pressed
@@ -83,6 +147,12 @@ func (m *Manager) IsKeyJustReleased(key glfw.Key) bool {
 	return !m.keys[key] && m.prevKeys[key]
 }
 
+// IsKeyRepeating returns true if the key auto-repeated this frame while held.
+// The first press isn't a repeat; use IsKeyJustPressed for it.
+func (m *Manager) IsKeyRepeating(key glfw.Key) bool {
+	return m.repeats[key]
+}
+
 // IsMouseButtonPressed returns true if a mouse button is currently pressed
 func (m *Manager) IsMouseButtonPressed(button glfw.MouseButton) bool {
 	return m.mouseButtons[button]
@@ -103,12 +173,13 @@ func (m *Manager) GetMousePosition() (float64, float64) {
 	return m.mousePos.x, m.mousePos.y
 }
 
-// GetMouseDelta returns the mouse movement delta since last frame
+// GetMouseDelta returns the mouse movement since last frame in pixels,
+// smoothed if SetMouseSmoothing is set. Cursor warps don't count as movement.
 func (m *Manager) GetMouseDelta() (float64, float64) {
-	return m.mousePos.x - m.prevMousePos.x, m.mousePos.y - m.prevMousePos.y
+	return m.mouseDelta.smoothed()
 }
 
-// GetScroll returns the scroll delta since last frame
+// GetScroll returns the scroll accumulated by the last PollEvents
 func (m *Manager) GetScroll() (float64, float64) {
 	return m.scrollX, m.scrollY
 }
@@ -116,18 +187,37 @@ func (m *Manager) GetScroll() (float64, float64) {
 // SetCursorMode sets the cursor mode
 func (m *Manager) SetCursorMode(mode int) {
 	m.window.SetInputMode(glfw.CursorMode, mode)
+	m.cursorMode = mode
+
+	// GLFW may warp the raw cursor on mode changes; don't count that as movement
+	m.resetMouseBaseline()
+}
+
+// HandleKey feeds a key event to the manager. Init installs the manager as
+// the window's key callback; code that installs its own must forward key
+// events here.
+func (m *Manager) HandleKey(key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
+	m.keyCallback(m.window, key, scancode, action, mods)
 }
 
 // Callbacks
 func (m *Manager) keyCallback(window *glfw.Window, key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
+	if m.playback != nil {
+		return
+	}
 	if action == glfw.Press {
 		m.keys[key] = true
 	} else if action == glfw.Release {
 		m.keys[key] = false
+	} else if action == glfw.Repeat {
+		m.repeats[key] = true
 	}
 }
 
 func (m *Manager) mouseButtonCallback(window *glfw.Window, button glfw.MouseButton, action glfw.Action, mods glfw.ModifierKey) {
+	if m.playback != nil {
+		return
+	}
 	if action == glfw.Press {
 		m.mouseButtons[button] = true
 	} else if action == glfw.Release {
@@ -136,11 +226,21 @@ func (m *Manager) mouseButtonCallback(window *glfw.Window, button glfw.MouseButt
 }
 
 func (m *Manager) cursorPosCallback(window *glfw.Window, xpos, ypos float64) {
+	if m.playback != nil {
+		// Keep the baseline current so live input resumes without a jump
+		m.cursor.lastX, m.cursor.lastY, m.cursor.hasLast = xpos, ypos, true
+		return
+	}
 	m.mousePos.x = xpos
 	m.mousePos.y = ypos
+	m.mouseDelta.add(m.cursor.track(xpos, ypos))
 }
 
 func (m *Manager) scrollCallback(window *glfw.Window, xoffset, yoffset float64) {
-	m.scrollX = xoffset
-	m.scrollY = yoffset
+	if m.playback != nil {
+		return
+	}
+	// Several scroll events can arrive in one poll
+	m.scrollX += xoffset
+	m.scrollY += yoffset
 }
@@ -0,0 +1,75 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+func TestScrollReportedForOneFrame(t *testing.T) {
+	manager := NewManager(nil)
+
+	// Frame 1: Update, then PollEvents delivers two scroll events
+	manager.Update()
+	manager.scrollCallback(nil, 0, 1)
+	manager.scrollCallback(nil, 0.5, 2)
+	if x, y := manager.GetScroll(); x != 0.5 || y != 3 {
+		t.Errorf("frame 1 scroll = (%v, %v), want (0.5, 3)", x, y)
+	}
+
+	// Frame 2: no events, so the scroll is gone
+	manager.Update()
+	if x, y := manager.GetScroll(); x != 0 || y != 0 {
+		t.Errorf("frame 2 scroll = (%v, %v), want it reported only once", x, y)
+	}
+
+	// Frame 3: new events are seen
+	manager.Update()
+	manager.scrollCallback(nil, 0, -1)
+	if x, y := manager.GetScroll(); x != 0 || y != -1 {
+		t.Errorf("frame 3 scroll = (%v, %v), want (0, -1)", x, y)
+	}
+}
+
+func TestKeyRepeat(t *testing.T) {
+	manager := NewManager(nil)
+
+	// Each frame's key actions and the key state the game should see
+	frames := []struct {
+		actions      []glfw.Action
+		justPressed  bool
+		repeating    bool
+		justReleased bool
+		pressed      bool
+	}{
+		{[]glfw.Action{glfw.Press}, true, false, false, true},
+		// Held before the repeat delay
+		{nil, false, false, false, true},
+		{[]glfw.Action{glfw.Repeat}, false, true, false, true},
+		// A slow frame can collect several repeats
+		{[]glfw.Action{glfw.Repeat, glfw.Repeat}, false, true, false, true},
+		{nil, false, false, false, true},
+		{[]glfw.Action{glfw.Repeat}, false, true, false, true},
+		{[]glfw.Action{glfw.Release}, false, false, true, false},
+	}
+
+	for i, frame := range frames {
+		manager.Update()
+		for _, action := range frame.actions {
+			pressKey(manager, glfw.KeyBackspace, action)
+		}
+
+		if got := manager.IsKeyJustPressed(glfw.KeyBackspace); got != frame.justPressed {
+			t.Errorf("frame %d: IsKeyJustPressed() = %v, want %v", i, got, frame.justPressed)
+		}
+		if got := manager.IsKeyRepeating(glfw.KeyBackspace); got != frame.repeating {
+			t.Errorf("frame %d: IsKeyRepeating() = %v, want %v", i, got, frame.repeating)
+		}
+		if got := manager.IsKeyJustReleased(glfw.KeyBackspace); got != frame.justReleased {
+			t.Errorf("frame %d: IsKeyJustReleased() = %v, want %v", i, got, frame.justReleased)
+		}
+		if got := manager.IsKeyPressed(glfw.KeyBackspace); got != frame.pressed {
+			t.Errorf("frame %d: IsKeyPressed() = %v, want %v", i, got, frame.pressed)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package input
+
+// mouseDelta accumulates the mouse movement of a frame and smooths it over
+// frames with an exponential filter
+type mouseDelta struct {
+	// Movement reported by callbacks this frame
+	x, y float64
+	// Smoothed movement of the previous frame
+	lastX, lastY float64
+	// smoothing is the weight of the previous frame, in [0, 1)
+	smoothing float64
+}
+
+// add accumulates movement reported by a cursor callback
+func (d *mouseDelta) add(dx, dy float64) {
+	d.x += dx
+	d.y += dy
+}
+
+// smoothed returns this frame's movement blended with the previous frame's
+func (d *mouseDelta) smoothed() (float64, float64) {
+	keep := d.smoothing
+	return keep*d.lastX + (1-keep)*d.x, keep*d.lastY + (1-keep)*d.y
+}
+
+// advance ends the frame, feeding its smoothed movement into the filter
+func (d *mouseDelta) advance() {
+	d.lastX, d.lastY = d.smoothed()
+	d.x = 0
+	d.y = 0
+}
+
+// reset forgets this frame's movement and the smoothing history
+func (d *mouseDelta) reset() {
+	*d = mouseDelta{smoothing: d.smoothing}
+}
+
+// SetMouseSmoothing sets how much of the previous frame's mouse delta is kept
+// in GetMouseDelta, from 0 (no smoothing) towards 1 (heavy smoothing)
+func (m *Manager) SetMouseSmoothing(factor float64) {
+	m.mouseDelta.smoothing = clamp(factor, 0, 0.99)
+}
+
+// GetMouseSmoothing returns the mouse smoothing factor
+func (m *Manager) GetMouseSmoothing() float64 {
+	return m.mouseDelta.smoothing
+}
+
+// GetMouseDeltaNormalized returns GetMouseDelta as a fraction of the viewport
+// size, so mouse-look speed doesn't depend on the resolution
+func (m *Manager) GetMouseDeltaNormalized(viewportW, viewportH int) (float64, float64) {
+	if viewportW <= 0 || viewportH <= 0 {
+		return 0, 0
+	}
+
+	dx, dy := m.GetMouseDelta()
+	return dx / float64(viewportW), dy / float64(viewportH)
+}
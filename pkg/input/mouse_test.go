@@ -0,0 +1,85 @@
+package input
+
+import (
+	"math"
+	"testing"
+)
+
+// moveMouse runs one input frame in which the cursor moves to x, y
+func moveMouse(manager *Manager, x, y float64) {
+	manager.Update()
+	manager.cursorPosCallback(nil, x, y)
+}
+
+func TestMouseDeltaNormalized(t *testing.T) {
+	manager := NewManager(nil)
+	moveMouse(manager, 100, 100)
+	moveMouse(manager, 180, 40)
+
+	if dx, dy := manager.GetMouseDeltaNormalized(800, 600); math.Abs(dx-0.1) > 1e-12 || math.Abs(dy+0.1) > 1e-12 {
+		t.Errorf("GetMouseDeltaNormalized(800, 600) = (%v, %v), want (0.1, -0.1)", dx, dy)
+	}
+	// Half the resolution, twice the fraction
+	if dx, dy := manager.GetMouseDeltaNormalized(400, 300); math.Abs(dx-0.2) > 1e-12 || math.Abs(dy+0.2) > 1e-12 {
+		t.Errorf("GetMouseDeltaNormalized(400, 300) = (%v, %v), want (0.2, -0.2)", dx, dy)
+	}
+	if dx, dy := manager.GetMouseDeltaNormalized(0, 600); dx != 0 || dy != 0 {
+		t.Errorf("GetMouseDeltaNormalized with a zero width = (%v, %v), want zero", dx, dy)
+	}
+}
+
+func TestMouseSmoothingStepResponse(t *testing.T) {
+	manager := NewManager(nil)
+	manager.SetMouseSmoothing(0.5)
+	moveMouse(manager, 0, 0)
+
+	// A constant 8 pixels per frame approaches 8, halving the gap each frame,
+	// then decays the same way once the mouse stops
+	want := []float64{4, 6, 7, 7.5, 3.75, 1.875}
+	x := 0.0
+	for frame, expected := range want {
+		if frame < 4 {
+			x += 8
+		}
+		moveMouse(manager, x, 0)
+		if dx, _ := manager.GetMouseDelta(); math.Abs(dx-expected) > 1e-12 {
+			t.Errorf("frame %d delta = %v, want %v", frame, dx, expected)
+		}
+	}
+}
+
+func TestMouseSmoothingDisabled(t *testing.T) {
+	manager := NewManager(nil)
+	moveMouse(manager, 0, 0)
+	moveMouse(manager, 8, 0)
+
+	if dx, _ := manager.GetMouseDelta(); dx != 8 {
+		t.Errorf("unsmoothed delta = %v, want 8", dx)
+	}
+
+	manager.SetMouseSmoothing(5)
+	if got := manager.GetMouseSmoothing(); got >= 1 {
+		t.Errorf("GetMouseSmoothing() = %v, want it clamped below 1", got)
+	}
+}
+
+func TestMouseDeltaAfterBaselineReset(t *testing.T) {
+	manager := NewManager(nil)
+	manager.SetMouseSmoothing(0.5)
+	moveMouse(manager, 0, 0)
+	moveMouse(manager, 10, 10)
+
+	// A warp, e.g. from capturing the cursor, must not read as movement
+	manager.resetMouseBaseline()
+	moveMouse(manager, 5000, -3000)
+	if dx, dy := manager.GetMouseDelta(); dx != 0 || dy != 0 {
+		t.Errorf("delta after the warp = (%v, %v), want zero", dx, dy)
+	}
+
+	// Movement after the warp is measured from the new position, without
+	// the smoothing history from before it
+	moveMouse(manager, 5004, -3000)
+	if dx, _ := manager.GetMouseDelta(); dx != 2 {
+		t.Errorf("delta after moving on = %v, want 2", dx)
+	}
+}
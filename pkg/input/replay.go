@@ -0,0 +1,157 @@
+package input
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"sort"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// inputFrame is the recorded input state of one frame
+type inputFrame struct {
+	Keys    []glfw.Key         `json:"keys,omitempty"`
+	Repeats []glfw.Key         `json:"repeats,omitempty"`
+	Buttons []glfw.MouseButton `json:"buttons,omitempty"`
+	MouseX  float64            `json:"mouseX"`
+	MouseY  float64            `json:"mouseY"`
+	DeltaX  float64            `json:"deltaX,omitempty"`
+	DeltaY  float64            `json:"deltaY,omitempty"`
+	ScrollX float64            `json:"scrollX,omitempty"`
+	ScrollY float64            `json:"scrollY,omitempty"`
+	Text    string             `json:"text,omitempty"`
+}
+
+// inputRecorder writes one frame per Update
+type inputRecorder struct {
+	encoder *json.Encoder
+	// started is set once a whole frame has been polled since recording began
+	started bool
+	// runes typed this frame, kept even if the game consumes them
+	runes []rune
+}
+
+// StartRecording records the input of every following frame to writer, one
+// JSON object per frame, until StopRecording. Gamepads aren't recorded.
+func (m *Manager) StartRecording(writer io.Writer) {
+	m.recorder = &inputRecorder{encoder: json.NewEncoder(writer)}
+}
+
+// StopRecording records the current frame and stops recording
+func (m *Manager) StopRecording() error {
+	if m.recorder == nil {
+		return nil
+	}
+
+	err := m.recordFrame()
+	m.recorder = nil
+	return err
+}
+
+// IsRecording returns true while input is being recorded
+func (m *Manager) IsRecording() bool {
+	return m.recorder != nil
+}
+
+// StartPlayback replays input recorded by StartRecording, one frame per
+// Update, in place of live input. Playback stops at the end of the recording.
+func (m *Manager) StartPlayback(reader io.Reader) {
+	m.playback = json.NewDecoder(reader)
+}
+
+// StopPlayback returns control to live input
+func (m *Manager) StopPlayback() {
+	m.playback = nil
+}
+
+// IsPlayingBack returns true while recorded input replaces live input
+func (m *Manager) IsPlayingBack() bool {
+	return m.playback != nil
+}
+
+// recordFrame writes the input state of the frame that was just polled
+func (m *Manager) recordFrame() error {
+	recorder := m.recorder
+	if !recorder.started {
+		recorder.started = true
+		recorder.runes = recorder.runes[:0]
+		return nil
+	}
+
+	frame := inputFrame{
+		MouseX:  m.mousePos.x,
+		MouseY:  m.mousePos.y,
+		DeltaX:  m.mouseDelta.x,
+		DeltaY:  m.mouseDelta.y,
+		ScrollX: m.scrollX,
+		ScrollY: m.scrollY,
+		Text:    string(recorder.runes),
+	}
+	for key, down := range m.keys {
+		if down {
+			frame.Keys = append(frame.Keys, key)
+		}
+	}
+	for key := range m.repeats {
+		frame.Repeats = append(frame.Repeats, key)
+	}
+	for button, down := range m.mouseButtons {
+		if down {
+			frame.Buttons = append(frame.Buttons, button)
+		}
+	}
+
+	// Sort so identical input produces identical recordings
+	sort.Slice(frame.Keys, func(i, j int) bool { return frame.Keys[i] < frame.Keys[j] })
+	sort.Slice(frame.Repeats, func(i, j int) bool { return frame.Repeats[i] < frame.Repeats[j] })
+	sort.Slice(frame.Buttons, func(i, j int) bool { return frame.Buttons[i] < frame.Buttons[j] })
+
+	recorder.runes = recorder.runes[:0]
+	return recorder.encoder.Encode(frame)
+}
+
+// playFrame loads the next recorded frame as the current input state
+func (m *Manager) playFrame() {
+	var frame inputFrame
+	if err := m.playback.Decode(&frame); err != nil {
+		if !errors.Is(err, io.EOF) {
+			log.Printf("Input playback failed: %v", err)
+		}
+		m.playback = nil
+
+		// Recorded keys shouldn't stay held once live input takes over
+		m.releaseAll()
+		return
+	}
+
+	m.releaseAll()
+	for _, key := range frame.Keys {
+		m.keys[key] = true
+	}
+	for _, key := range frame.Repeats {
+		m.repeats[key] = true
+	}
+	for _, button := range frame.Buttons {
+		m.mouseButtons[button] = true
+	}
+
+	m.mousePos.x = frame.MouseX
+	m.mousePos.y = frame.MouseY
+	m.mouseDelta.add(frame.DeltaX, frame.DeltaY)
+	m.cursor.move(frame.DeltaX, frame.DeltaY)
+	m.scrollX = frame.ScrollX
+	m.scrollY = frame.ScrollY
+	m.typedRunes = append(m.typedRunes, []rune(frame.Text)...)
+}
+
+// releaseAll marks every key and mouse button as released
+func (m *Manager) releaseAll() {
+	for key := range m.keys {
+		m.keys[key] = false
+	}
+	for button := range m.mouseButtons {
+		m.mouseButtons[button] = false
+	}
+}
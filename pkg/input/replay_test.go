@@ -0,0 +1,122 @@
+package input
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// frameObservation is what a game reads from the manager in one frame
+type frameObservation struct {
+	JumpJustPressed  bool
+	Forward          bool
+	ForwardRepeating bool
+	ClickJustPressed bool
+	DeltaX, DeltaY   float64
+	ScrollY          float64
+	Text             string
+}
+
+// observe reads one frame's input the way a game would
+func observe(manager *Manager) frameObservation {
+	dx, dy := manager.GetMouseDelta()
+	_, scrollY := manager.GetScroll()
+	return frameObservation{
+		JumpJustPressed:  manager.IsKeyJustPressed(glfw.KeySpace),
+		Forward:          manager.IsKeyPressed(glfw.KeyW),
+		ForwardRepeating: manager.IsKeyRepeating(glfw.KeyW),
+		ClickJustPressed: manager.IsMouseButtonJustPressed(glfw.MouseButtonLeft),
+		DeltaX:           dx,
+		DeltaY:           dy,
+		ScrollY:          scrollY,
+		Text:             string(manager.ConsumeTypedRunes()),
+	}
+}
+
+// liveFrames is a scripted input sequence, one function of events per frame
+var liveFrames = []func(m *Manager){
+	func(m *Manager) { m.cursorPosCallback(nil, 100, 100) },
+	func(m *Manager) { m.keyCallback(nil, glfw.KeySpace, 0, glfw.Press, 0) },
+	func(m *Manager) {
+		m.keyCallback(nil, glfw.KeyW, 0, glfw.Press, 0)
+		m.cursorPosCallback(nil, 110, 95)
+	},
+	func(m *Manager) { m.keyCallback(nil, glfw.KeyW, 0, glfw.Repeat, 0) },
+	func(m *Manager) {
+		m.keyCallback(nil, glfw.KeySpace, 0, glfw.Release, 0)
+		m.mouseButtonCallback(nil, glfw.MouseButtonLeft, glfw.Press, 0)
+		m.scrollCallback(nil, 0, 2)
+	},
+	func(m *Manager) {
+		m.charCallback(nil, 'h')
+		m.charCallback(nil, 'i')
+	},
+	func(m *Manager) { m.keyCallback(nil, glfw.KeySpace, 0, glfw.Press, 0) },
+	func(m *Manager) {},
+}
+
+func TestRecordAndPlayBack(t *testing.T) {
+	var recording bytes.Buffer
+	live := NewManager(nil)
+	live.StartRecording(&recording)
+
+	var want []frameObservation
+	for _, events := range liveFrames {
+		live.Update()
+		events(live)
+		want = append(want, observe(live))
+	}
+	if err := live.StopRecording(); err != nil {
+		t.Fatalf("StopRecording: %v", err)
+	}
+
+	if frames := strings.Count(recording.String(), "\n"); frames != len(liveFrames) {
+		t.Fatalf("recorded %d frames, want %d", frames, len(liveFrames))
+	}
+
+	replay := NewManager(nil)
+	replay.StartPlayback(&recording)
+	for i := range liveFrames {
+		replay.Update()
+		// Live events are ignored during playback
+		replay.keyCallback(nil, glfw.KeyEscape, 0, glfw.Press, 0)
+		replay.cursorPosCallback(nil, 9999, 9999)
+
+		if got := observe(replay); !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("frame %d replayed as %+v, want %+v", i, got, want[i])
+		}
+	}
+	if replay.IsKeyPressed(glfw.KeyEscape) {
+		t.Error("live key press leaked into playback")
+	}
+
+	// The recording is exhausted on the next frame, releasing held keys
+	replay.Update()
+	if replay.IsPlayingBack() {
+		t.Error("still playing back after the last frame")
+	}
+	if replay.IsKeyPressed(glfw.KeySpace) || replay.IsKeyPressed(glfw.KeyW) {
+		t.Error("recorded keys still held after playback ended")
+	}
+}
+
+func TestRecordingIsDeterministic(t *testing.T) {
+	record := func() string {
+		var recording bytes.Buffer
+		manager := NewManager(nil)
+		manager.StartRecording(&recording)
+		for _, events := range liveFrames {
+			manager.Update()
+			events(manager)
+		}
+		manager.StopRecording()
+		return recording.String()
+	}
+
+	if first, second := record(), record(); first != second {
+		t.Errorf("identical input recorded differently:\n%s\n%s", first, second)
+	}
+}
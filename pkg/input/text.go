@@ -0,0 +1,30 @@
+package input
+
+import (
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// ConsumeTypedRunes returns the characters typed this frame, honoring the
+// keyboard layout, and clears the buffer so they are only returned once.
+// Editing keys such as backspace and enter are read through the key API.
+func (m *Manager) ConsumeTypedRunes() []rune {
+	if len(m.typedRunes) == 0 {
+		return nil
+	}
+
+	runes := make([]rune, len(m.typedRunes))
+	copy(runes, m.typedRunes)
+	m.typedRunes = m.typedRunes[:0]
+	return runes
+}
+
+func (m *Manager) charCallback(window *glfw.Window, char rune) {
+	if m.playback != nil {
+		return
+	}
+
+	m.typedRunes = append(m.typedRunes, char)
+	if m.recorder != nil {
+		m.recorder.runes = append(m.recorder.runes, char)
+	}
+}
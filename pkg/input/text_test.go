@@ -0,0 +1,48 @@
+package input
+
+import (
+	"testing"
+)
+
+func TestTypedRunesDrainOnce(t *testing.T) {
+	manager := NewManager(nil)
+
+	manager.Update()
+	for _, char := range "héllo" {
+		manager.charCallback(nil, char)
+	}
+
+	if got := string(manager.ConsumeTypedRunes()); got != "héllo" {
+		t.Errorf("ConsumeTypedRunes() = %q, want %q", got, "héllo")
+	}
+	if got := manager.ConsumeTypedRunes(); got != nil {
+		t.Errorf("second ConsumeTypedRunes() = %q, want nil", string(got))
+	}
+
+	// More typing in the same frame is returned by the next consume
+	manager.charCallback(nil, '!')
+	if got := string(manager.ConsumeTypedRunes()); got != "!" {
+		t.Errorf("ConsumeTypedRunes() = %q, want %q", got, "!")
+	}
+}
+
+func TestUnconsumedRunesDroppedNextFrame(t *testing.T) {
+	manager := NewManager(nil)
+
+	manager.Update()
+	manager.charCallback(nil, 'a')
+	returned := manager.ConsumeTypedRunes()
+	manager.charCallback(nil, 'b')
+
+	// Text nobody read last frame doesn't leak into this one
+	manager.Update()
+	manager.charCallback(nil, 'c')
+	if got := string(manager.ConsumeTypedRunes()); got != "c" {
+		t.Errorf("ConsumeTypedRunes() = %q, want %q", got, "c")
+	}
+
+	// A returned slice isn't overwritten by later typing
+	if string(returned) != "a" {
+		t.Errorf("earlier result changed to %q", string(returned))
+	}
+}
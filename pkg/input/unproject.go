@@ -0,0 +1,67 @@
+package input
+
+import (
+	"github.com/aminasadiam/jigxel-engine/pkg/graphics"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// ScreenToRay returns the world-space ray through a screen position.
+// Screen coordinates have their origin at the top-left and must be in the
+// same units as the viewport size.
+func (m *Manager) ScreenToRay(x, y float64, camera *graphics.Camera, viewportW, viewportH int) (mgl32.Vec3, mgl32.Vec3) {
+	return screenToRay(x, y, camera, viewportW, viewportH)
+}
+
+// ScreenToWorld returns the world position under a screen position, on the
+// plane through the camera target facing the camera. The screen center maps
+// to the camera target.
+func (m *Manager) ScreenToWorld(x, y float64, camera *graphics.Camera, viewportW, viewportH int) mgl32.Vec3 {
+	origin, direction := screenToRay(x, y, camera, viewportW, viewportH)
+
+	// Intersect with the target plane
+	forward := camera.Forward()
+	denominator := direction.Dot(forward)
+	if denominator == 0 {
+		return origin
+	}
+	distance := camera.Target.Sub(origin).Dot(forward) / denominator
+	return origin.Add(direction.Mul(distance))
+}
+
+// ScreenToSpriteWorld returns the 2D world position under a screen position
+// for the orthographic sprite view centered at center showing height units
+func (m *Manager) ScreenToSpriteWorld(x, y float64, center mgl32.Vec2, height float32, viewportW, viewportH int) mgl32.Vec2 {
+	if viewportW <= 0 || viewportH <= 0 {
+		return center
+	}
+
+	aspect := float32(viewportW) / float32(viewportH)
+	width := height * aspect
+
+	// Map to [-0.5, 0.5] with Y pointing up
+	nx := float32(x)/float32(viewportW) - 0.5
+	ny := 0.5 - float32(y)/float32(viewportH)
+
+	return mgl32.Vec2{center.X() + nx*width, center.Y() + ny*height}
+}
+
+// screenToRay unprojects a screen position onto the near and far planes
+func screenToRay(x, y float64, camera *graphics.Camera, viewportW, viewportH int) (mgl32.Vec3, mgl32.Vec3) {
+	if viewportW <= 0 || viewportH <= 0 {
+		return camera.Position, camera.Forward()
+	}
+
+	// Normalized device coordinates, with Y flipped to point up
+	ndcX := float32(2*x/float64(viewportW) - 1)
+	ndcY := float32(1 - 2*y/float64(viewportH))
+
+	inverse := camera.ProjectionMatrix().Mul4(camera.ViewMatrix()).Inv()
+	near := mgl32.TransformCoordinate(mgl32.Vec3{ndcX, ndcY, -1}, inverse)
+	far := mgl32.TransformCoordinate(mgl32.Vec3{ndcX, ndcY, 1}, inverse)
+
+	direction := far.Sub(near)
+	if direction.Len() == 0 {
+		return near, camera.Forward()
+	}
+	return near, direction.Normalize()
+}
@@ -0,0 +1,66 @@
+package input
+
+import (
+	"testing"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/graphics"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+func TestScreenCenterMapsToTarget(t *testing.T) {
+	manager := NewManager(nil)
+	cameras := []*graphics.Camera{
+		graphics.NewDefaultCamera(),
+		graphics.NewCamera(mgl32.Vec3{4, 5, 6}, mgl32.Vec3{1, 0, -2}, 60, 16.0/9.0),
+		graphics.NewOrthographicCamera(mgl32.Vec3{0, 10, 0.01}, mgl32.Vec3{2, 0, 0}, -5, 5, -5, 5, 0.1, 50),
+	}
+
+	for i, camera := range cameras {
+		got := manager.ScreenToWorld(640, 360, camera, 1280, 720)
+		if got.Sub(camera.Target).Len() > 1e-3 {
+			t.Errorf("camera %d: screen center maps to %v, want the target %v", i, got, camera.Target)
+		}
+
+		// The center ray runs along the camera's view direction
+		_, direction := manager.ScreenToRay(640, 360, camera, 1280, 720)
+		if direction.Sub(camera.Forward()).Len() > 1e-3 {
+			t.Errorf("camera %d: center ray direction = %v, want %v", i, direction, camera.Forward())
+		}
+	}
+}
+
+func TestScreenToWorldCorners(t *testing.T) {
+	manager := NewManager(nil)
+
+	// Looking down -Z from 10 units away with a 90 degree square view, the
+	// target plane spans 20 units each way
+	camera := graphics.NewCamera(mgl32.Vec3{0, 0, 10}, mgl32.Vec3{}, 90, 1)
+
+	corners := []struct {
+		x, y float64
+		want mgl32.Vec3
+	}{
+		{0, 0, mgl32.Vec3{-10, 10, 0}},
+		{400, 400, mgl32.Vec3{10, -10, 0}},
+		{400, 0, mgl32.Vec3{10, 10, 0}},
+	}
+	for _, corner := range corners {
+		got := manager.ScreenToWorld(corner.x, corner.y, camera, 400, 400)
+		if got.Sub(corner.want).Len() > 1e-3 {
+			t.Errorf("ScreenToWorld(%v, %v) = %v, want %v", corner.x, corner.y, got, corner.want)
+		}
+	}
+}
+
+func TestScreenToSpriteWorld(t *testing.T) {
+	manager := NewManager(nil)
+	center := mgl32.Vec2{5, 3}
+
+	if got := manager.ScreenToSpriteWorld(400, 300, center, 10, 800, 600); got != center {
+		t.Errorf("screen center = %v, want the view center %v", got, center)
+	}
+	// The top-left corner is half the view up and to the left; the width follows the aspect
+	if got, want := manager.ScreenToSpriteWorld(0, 0, center, 10, 800, 600), (mgl32.Vec2{5 - 20.0/3, 8}); got.Sub(want).Len() > 1e-4 {
+		t.Errorf("top-left = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,170 @@
+package netsync
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+)
+
+// Client applies packets from a server to a local world. Server entities
+// are created locally on their first update, with their own local IDs.
+type Client struct {
+	// entities maps server entity IDs to local ones
+	entities map[ecs.EntityID]ecs.EntityID
+	// received holds the component types applied to each server entity
+	received map[ecs.EntityID]map[string]bool
+	decoder  *json.Decoder
+	// lastTick is the tick of the last packet applied, once applied is set
+	lastTick uint64
+	applied  bool
+}
+
+// NewClient creates a client reading packets from reader. Pass nil to only
+// apply packets with Apply.
+func NewClient(reader io.Reader) *Client {
+	client := &Client{
+		entities: make(map[ecs.EntityID]ecs.EntityID),
+		received: make(map[ecs.EntityID]map[string]bool),
+	}
+	if reader != nil {
+		client.decoder = json.NewDecoder(reader)
+	}
+	return client
+}
+
+// Receive reads the next packet and applies it to world
+func (c *Client) Receive(world *ecs.World) error {
+	if c.decoder == nil {
+		return fmt.Errorf("client has no reader")
+	}
+
+	var packet Packet
+	if err := c.decoder.Decode(&packet); err != nil {
+		return err
+	}
+	return c.Apply(world, &packet)
+}
+
+// Apply creates, updates and destroys local entities to match a packet.
+// Components are decoded with the component registry, so every replicated
+// type must be registered. A delta packet must be the one after the last
+// applied, and a full packet must be newer than it; otherwise the world is
+// left unchanged and the error wraps ErrPacketGap. A full packet drops the
+// entities and components it doesn't hold.
+//
+// If a component fails to decode, the updates before it stay applied but
+// the packet doesn't count as applied, so the next delta is rejected and a
+// full packet is needed to resync.
+func (c *Client) Apply(world *ecs.World, packet *Packet) error {
+	if packet.Full {
+		if c.applied && packet.Tick <= c.lastTick {
+			return fmt.Errorf("%w: got full tick %d after %d", ErrPacketGap, packet.Tick, c.lastTick)
+		}
+	} else if packet.Tick != c.lastTick+1 {
+		return fmt.Errorf("%w: got tick %d after %d", ErrPacketGap, packet.Tick, c.lastTick)
+	}
+
+	if packet.Full {
+		c.dropMissing(world, packet)
+	}
+
+	for _, update := range packet.Updates {
+		if err := c.applyUpdate(world, update); err != nil {
+			return err
+		}
+	}
+
+	for _, serverID := range packet.Destroyed {
+		if localID, exists := c.entities[serverID]; exists {
+			world.DestroyEntity(localID)
+			delete(c.entities, serverID)
+			delete(c.received, serverID)
+		}
+	}
+
+	c.lastTick = packet.Tick
+	c.applied = true
+	return nil
+}
+
+// dropMissing destroys the local entities and removes the components that a
+// full packet doesn't hold, since their removal may have been in a lost packet
+func (c *Client) dropMissing(world *ecs.World, packet *Packet) {
+	updates := make(map[ecs.EntityID]EntityUpdate, len(packet.Updates))
+	for _, update := range packet.Updates {
+		updates[update.ID] = update
+	}
+
+	for _, serverID := range sortedIDs(c.entities) {
+		localID := c.entities[serverID]
+		update, kept := updates[serverID]
+		if !kept {
+			world.DestroyEntity(localID)
+			delete(c.entities, serverID)
+			delete(c.received, serverID)
+			continue
+		}
+
+		received := c.received[serverID]
+		componentTypes := make([]string, 0, len(received))
+		for componentType := range received {
+			componentTypes = append(componentTypes, componentType)
+		}
+		sort.Strings(componentTypes)
+		for _, componentType := range componentTypes {
+			if _, sent := update.Components[componentType]; !sent {
+				world.RemoveComponent(localID, componentType)
+				delete(received, componentType)
+			}
+		}
+	}
+}
+
+// LocalEntity returns the local entity replicating a server entity
+func (c *Client) LocalEntity(serverID ecs.EntityID) (ecs.EntityID, bool) {
+	localID, exists := c.entities[serverID]
+	return localID, exists
+}
+
+// applyUpdate applies the changes of one entity
+func (c *Client) applyUpdate(world *ecs.World, update EntityUpdate) error {
+	localID, exists := c.entities[update.ID]
+	if !exists {
+		localID = world.CreateEntity()
+		world.AddComponent(localID, NewReplicatedComponent())
+		c.entities[update.ID] = localID
+		c.received[update.ID] = make(map[string]bool)
+	}
+	received := c.received[update.ID]
+
+	// Decode in a fixed order so observers see the same sequence every time
+	componentTypes := make([]string, 0, len(update.Components))
+	for componentType := range update.Components {
+		componentTypes = append(componentTypes, componentType)
+	}
+	sort.Strings(componentTypes)
+
+	for _, componentType := range componentTypes {
+		// Decode into a fresh component and replace the old one, so fields
+		// the server cleared don't linger and the world reindexes tags and
+		// notifies observers
+		component, err := ecs.NewComponent(componentType)
+		if err != nil {
+			return fmt.Errorf("entity %d: %w", update.ID, err)
+		}
+		if err := json.Unmarshal(update.Components[componentType], component); err != nil {
+			return fmt.Errorf("entity %d component %s: %w", update.ID, componentType, err)
+		}
+		world.AddComponent(localID, component)
+		received[componentType] = true
+	}
+
+	for _, componentType := range update.Removed {
+		world.RemoveComponent(localID, componentType)
+		delete(received, componentType)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+// Package netsync replicates component state of flagged entities from a
+// server world to client worlds. The server encodes a delta packet each
+// tick holding only the components that changed since the last packet; the
+// client applies it to its own world. Transport is left to the caller:
+// packets are written to an io.Writer and read from an io.Reader as JSON.
+//
+// Each delta builds on the one before it, so the transport must be reliable
+// and ordered, such as TCP. A client rejects a packet that doesn't follow
+// the last one it applied; the server then sends a full packet with Reset
+// to bring it back in sync.
+package netsync
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"sort"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+)
+
+// ReplicatedComponent flags an entity for replication
+type ReplicatedComponent struct{}
+
+func (r *ReplicatedComponent) GetType() string {
+	return "replicated"
+}
+
+// NewReplicatedComponent creates a replication flag
+func NewReplicatedComponent() *ReplicatedComponent {
+	return &ReplicatedComponent{}
+}
+
+func init() {
+	ecs.RegisterComponentType("replicated", func() ecs.Component { return &ReplicatedComponent{} })
+}
+
+// ErrPacketGap is returned by Client.Apply for a delta packet that doesn't
+// follow the last one applied, because one was lost or reordered
+var ErrPacketGap = errors.New("packet doesn't follow the last one applied")
+
+// Packet is the state change of the replicated entities over one tick.
+// Entity IDs are those of the server world.
+type Packet struct {
+	Tick uint64 `json:"tick"`
+	// Full packets hold the whole replicated state instead of changes, and
+	// are applied whatever the client missed, as long as they are newer than
+	// the last packet it applied
+	Full    bool           `json:"full,omitempty"`
+	Updates []EntityUpdate `json:"updates,omitempty"`
+	// Destroyed lists entities that were destroyed or are no longer replicated
+	Destroyed []ecs.EntityID `json:"destroyed,omitempty"`
+}
+
+// EntityUpdate holds the changed components of one entity
+type EntityUpdate struct {
+	ID         ecs.EntityID               `json:"id"`
+	Components map[string]json.RawMessage `json:"components,omitempty"`
+	// Removed lists replicated component types the entity no longer has
+	Removed []string `json:"removed,omitempty"`
+}
+
+// IsEmpty reports whether the packet carries no changes
+func (p *Packet) IsEmpty() bool {
+	return len(p.Updates) == 0 && len(p.Destroyed) == 0
+}
+
+// WritePacket writes a packet as one line of JSON
+func WritePacket(writer io.Writer, packet *Packet) error {
+	return json.NewEncoder(writer).Encode(packet)
+}
+
+// sortedIDs returns the keys of a map in ID order
+func sortedIDs[V any](entities map[ecs.EntityID]V) []ecs.EntityID {
+	ids := make([]ecs.EntityID, 0, len(entities))
+	for entityID := range entities {
+		ids = append(ids, entityID)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i] < ids[j]
+	})
+	return ids
+}
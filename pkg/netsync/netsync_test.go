@@ -0,0 +1,394 @@
+package netsync
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// newReplicatedEntity adds an entity with a transform and, when flagged, the
+// replication flag
+func newReplicatedEntity(world *ecs.World, position mgl32.Vec3, replicated bool) ecs.EntityID {
+	builder := world.NewEntity().
+		WithTransform(position, mgl32.Vec3{}, mgl32.Vec3{1, 1, 1}).
+		WithTag("player")
+	if replicated {
+		builder.With(NewReplicatedComponent())
+	}
+	return builder.Build()
+}
+
+// updatedIDs returns the server IDs of a packet's updates
+func updatedIDs(packet *Packet) []ecs.EntityID {
+	ids := make([]ecs.EntityID, len(packet.Updates))
+	for i, update := range packet.Updates {
+		ids[i] = update.ID
+	}
+	return ids
+}
+
+func TestEncodeSendsOnlyChanges(t *testing.T) {
+	world := ecs.NewWorld()
+	first := newReplicatedEntity(world, mgl32.Vec3{1, 2, 3}, true)
+	second := newReplicatedEntity(world, mgl32.Vec3{4, 5, 6}, true)
+	newReplicatedEntity(world, mgl32.Vec3{}, false)
+
+	server := NewServer("transform")
+
+	// The first packet holds every replicated transform and nothing else
+	packet, err := server.Encode(world)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if ids := updatedIDs(packet); len(ids) != 2 || ids[0] != first || ids[1] != second {
+		t.Fatalf("first packet updates %v, want %d and %d", ids, first, second)
+	}
+	for _, update := range packet.Updates {
+		if len(update.Components) != 1 || update.Components["transform"] == nil {
+			t.Errorf("entity %d sent components %v, want only the transform", update.ID, update.Components)
+		}
+	}
+
+	// Nothing changed, so nothing is sent
+	packet, err = server.Encode(world)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if !packet.IsEmpty() {
+		t.Errorf("unchanged world sent %+v", packet)
+	}
+
+	// Only the moved entity is sent again
+	world.GetComponent(second, "transform").(*ecs.TransformComponent).Position = mgl32.Vec3{7, 8, 9}
+	packet, err = server.Encode(world)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if ids := updatedIDs(packet); len(ids) != 1 || ids[0] != second {
+		t.Errorf("packet after a move updates %v, want only %d", ids, second)
+	}
+
+	// Reset sends the full state again
+	server.Reset()
+	packet, err = server.Encode(world)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	if ids := updatedIDs(packet); len(ids) != 2 {
+		t.Errorf("packet after Reset updates %v, want both entities", ids)
+	}
+}
+
+func TestClientAppliesTransforms(t *testing.T) {
+	serverWorld, clientWorld := ecs.NewWorld(), ecs.NewWorld()
+	entityID := newReplicatedEntity(serverWorld, mgl32.Vec3{1, 2, 3}, true)
+
+	var wire bytes.Buffer
+	server := NewServer("transform", "physics")
+	client := NewClient(&wire)
+
+	// send encodes a packet through the wire and applies it on the client
+	send := func() {
+		t.Helper()
+		if err := server.Send(serverWorld, &wire); err != nil {
+			t.Fatalf("Send: %v", err)
+		}
+		if err := client.Receive(clientWorld); err != nil {
+			t.Fatalf("Receive: %v", err)
+		}
+	}
+
+	send()
+	localID, exists := client.LocalEntity(entityID)
+	if !exists {
+		t.Fatal("client created no entity")
+	}
+	transform, ok := clientWorld.GetComponent(localID, "transform").(*ecs.TransformComponent)
+	if !ok {
+		t.Fatal("client entity has no transform")
+	}
+	if transform.Position != (mgl32.Vec3{1, 2, 3}) || transform.Scale != (mgl32.Vec3{1, 1, 1}) {
+		t.Errorf("client transform = %v, %v; want (1, 2, 3), (1, 1, 1)", transform.Position, transform.Scale)
+	}
+	if clientWorld.GetComponent(localID, "tag") != nil {
+		t.Error("client received a component that isn't replicated")
+	}
+
+	// Updates replace the client's component with the decoded one
+	serverTransform := serverWorld.GetComponent(entityID, "transform").(*ecs.TransformComponent)
+	serverTransform.Position = mgl32.Vec3{-1, 0, 5}
+	serverTransform.SetRotation(mgl32.Vec3{0, 1.5, 0})
+	send()
+	transform = clientWorld.GetComponent(localID, "transform").(*ecs.TransformComponent)
+	if transform.Position != serverTransform.Position || transform.Quaternion != serverTransform.Quaternion {
+		t.Errorf("client transform = %v, %v; want %v, %v", transform.Position, transform.Quaternion, serverTransform.Position, serverTransform.Quaternion)
+	}
+
+	// Removed components and destroyed entities follow
+	serverWorld.RemoveComponent(entityID, "transform")
+	send()
+	if clientWorld.GetComponent(localID, "transform") != nil {
+		t.Error("client kept a removed transform")
+	}
+	serverWorld.DestroyEntity(entityID)
+	send()
+	if _, exists := client.LocalEntity(entityID); exists {
+		t.Error("client still maps the destroyed entity")
+	}
+	if len(clientWorld.GetEntities()) != 0 {
+		t.Errorf("client world has entities %v, want none", clientWorld.GetEntities())
+	}
+}
+
+// encode encodes the next packet or fails the test
+func encode(t *testing.T, server *Server, world *ecs.World) *Packet {
+	t.Helper()
+	packet, err := server.Encode(world)
+	if err != nil {
+		t.Fatalf("Encode: %v", err)
+	}
+	return packet
+}
+
+func TestClientRejectsPacketGaps(t *testing.T) {
+	serverWorld, clientWorld := ecs.NewWorld(), ecs.NewWorld()
+	entityID := newReplicatedEntity(serverWorld, mgl32.Vec3{1, 0, 0}, true)
+
+	server := NewServer("transform", "tag")
+	client := NewClient(nil)
+
+	if err := client.Apply(clientWorld, encode(t, server, serverWorld)); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	localID, _ := client.LocalEntity(entityID)
+	transform := clientWorld.GetComponent(localID, "transform").(*ecs.TransformComponent)
+	tag := clientWorld.GetComponent(localID, "tag").(*ecs.TagComponent)
+
+	// Each delta holds a different component, so losing one loses its change
+	serverWorld.GetComponent(entityID, "transform").(*ecs.TransformComponent).Position = mgl32.Vec3{2, 0, 0}
+	moved := encode(t, server, serverWorld)
+	serverWorld.GetComponent(entityID, "tag").(*ecs.TagComponent).AddTag("hurt")
+	tagged := encode(t, server, serverWorld)
+
+	// The tag delta arrives before the move; it is rejected untouched
+	err := client.Apply(clientWorld, tagged)
+	if !errors.Is(err, ErrPacketGap) {
+		t.Fatalf("Apply out of order = %v, want ErrPacketGap", err)
+	}
+	if tag.HasTag("hurt") {
+		t.Error("rejected packet changed the client world")
+	}
+
+	// Going back to the skipped packet is rejected too
+	if err := client.Apply(clientWorld, moved); err != nil {
+		t.Fatalf("Apply of the next packet: %v", err)
+	}
+	if err := client.Apply(clientWorld, moved); !errors.Is(err, ErrPacketGap) {
+		t.Errorf("Apply of a repeated packet = %v, want ErrPacketGap", err)
+	}
+
+	// A full packet after Reset brings the client back in sync
+	server.Reset()
+	if err := client.Apply(clientWorld, encode(t, server, serverWorld)); err != nil {
+		t.Fatalf("Apply of a full packet: %v", err)
+	}
+	transform = clientWorld.GetComponent(localID, "transform").(*ecs.TransformComponent)
+	tag = clientWorld.GetComponent(localID, "tag").(*ecs.TagComponent)
+	if transform.Position != (mgl32.Vec3{2, 0, 0}) || !tag.HasTag("hurt") {
+		t.Errorf("client has position %v and tags %v, want (2, 0, 0) and hurt", transform.Position, tag.Tags)
+	}
+
+	if err := client.Receive(clientWorld); err == nil {
+		t.Error("Receive without a reader succeeded")
+	}
+}
+
+func TestFullPacketDropsMissedRemovals(t *testing.T) {
+	serverWorld, clientWorld := ecs.NewWorld(), ecs.NewWorld()
+	kept := newReplicatedEntity(serverWorld, mgl32.Vec3{1, 0, 0}, true)
+	destroyed := newReplicatedEntity(serverWorld, mgl32.Vec3{2, 0, 0}, true)
+
+	server := NewServer("transform", "tag")
+	client := NewClient(nil)
+	if err := client.Apply(clientWorld, encode(t, server, serverWorld)); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	// The packet holding these removals is lost
+	serverWorld.RemoveComponent(kept, "tag")
+	serverWorld.DestroyEntity(destroyed)
+	encode(t, server, serverWorld)
+
+	server.Reset()
+	if err := client.Apply(clientWorld, encode(t, server, serverWorld)); err != nil {
+		t.Fatalf("Apply of a full packet: %v", err)
+	}
+	if _, exists := client.LocalEntity(destroyed); exists {
+		t.Error("client kept an entity destroyed while it was out of sync")
+	}
+	localID, _ := client.LocalEntity(kept)
+	if clientWorld.GetComponent(localID, "tag") != nil {
+		t.Error("client kept a component removed while it was out of sync")
+	}
+	if clientWorld.GetComponent(localID, "transform") == nil {
+		t.Error("full packet removed a component it holds")
+	}
+	if got := len(clientWorld.GetEntities()); got != 1 {
+		t.Errorf("client world has %d entities, want 1", got)
+	}
+}
+
+func TestClientRejectsStaleFullPackets(t *testing.T) {
+	serverWorld, clientWorld := ecs.NewWorld(), ecs.NewWorld()
+	entityID := newReplicatedEntity(serverWorld, mgl32.Vec3{1, 0, 0}, true)
+
+	server := NewServer("transform", "tag")
+	client := NewClient(nil)
+
+	// The first full packet is delayed behind a newer one
+	stale := encode(t, server, serverWorld)
+	serverWorld.GetComponent(entityID, "transform").(*ecs.TransformComponent).Position = mgl32.Vec3{2, 0, 0}
+	server.Reset()
+	if err := client.Apply(clientWorld, encode(t, server, serverWorld)); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	if err := client.Apply(clientWorld, stale); !errors.Is(err, ErrPacketGap) {
+		t.Fatalf("Apply of a stale full packet = %v, want ErrPacketGap", err)
+	}
+	localID, _ := client.LocalEntity(entityID)
+	if got := clientWorld.GetComponent(localID, "transform").(*ecs.TransformComponent).Position; got != (mgl32.Vec3{2, 0, 0}) {
+		t.Errorf("stale full packet rolled the client back to %v", got)
+	}
+
+	// The tick didn't move back, so the next delta still applies
+	serverWorld.GetComponent(entityID, "transform").(*ecs.TransformComponent).Position = mgl32.Vec3{3, 0, 0}
+	if err := client.Apply(clientWorld, encode(t, server, serverWorld)); err != nil {
+		t.Fatalf("Apply of the next delta: %v", err)
+	}
+}
+
+func TestClientDecodeFailureDoesNotAdvanceTick(t *testing.T) {
+	serverWorld, clientWorld := ecs.NewWorld(), ecs.NewWorld()
+	entityID := newReplicatedEntity(serverWorld, mgl32.Vec3{1, 0, 0}, true)
+
+	server := NewServer("transform", "tag")
+	client := NewClient(nil)
+	if err := client.Apply(clientWorld, encode(t, server, serverWorld)); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	// A delta whose component can't be decoded
+	serverWorld.GetComponent(entityID, "transform").(*ecs.TransformComponent).Position = mgl32.Vec3{2, 0, 0}
+	broken := encode(t, server, serverWorld)
+	broken.Updates[0].Components["transform"] = []byte(`{"Position":"not a vector"}`)
+	if err := client.Apply(clientWorld, broken); err == nil {
+		t.Fatal("Apply of an undecodable packet succeeded")
+	}
+
+	// The broken packet didn't count, so the client needs a full packet
+	if err := client.Apply(clientWorld, encode(t, server, serverWorld)); !errors.Is(err, ErrPacketGap) {
+		t.Fatalf("Apply of the delta after a failed one = %v, want ErrPacketGap", err)
+	}
+	server.Reset()
+	if err := client.Apply(clientWorld, encode(t, server, serverWorld)); err != nil {
+		t.Fatalf("Apply of a full packet: %v", err)
+	}
+	localID, _ := client.LocalEntity(entityID)
+	if got := clientWorld.GetComponent(localID, "transform").(*ecs.TransformComponent).Position; got != (mgl32.Vec3{2, 0, 0}) {
+		t.Errorf("client position = %v after resyncing, want (2, 0, 0)", got)
+	}
+}
+
+func TestClientReplacesUpdatedComponents(t *testing.T) {
+	serverWorld, clientWorld := ecs.NewWorld(), ecs.NewWorld()
+	entityID := serverWorld.NewEntity().
+		With(NewReplicatedComponent()).
+		With(ecs.NewTagComponent("enemy")).
+		Build()
+	block := ecs.NewPropertyBlockComponent()
+	block.SetVec4("tint", mgl32.Vec4{1, 0, 0, 1})
+	block.SetVec4("glow", mgl32.Vec4{0, 1, 0, 1})
+	serverWorld.AddComponent(entityID, block)
+
+	server := NewServer("tag", "property_block")
+	client := NewClient(nil)
+	if err := client.Apply(clientWorld, encode(t, server, serverWorld)); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+	localID, _ := client.LocalEntity(entityID)
+
+	// A deleted map key and a changed tag
+	delete(block.Vec4s, "tint")
+	tag := serverWorld.GetComponent(entityID, "tag").(*ecs.TagComponent)
+	tag.RemoveTag("enemy")
+	tag.AddTag("ally")
+	if err := client.Apply(clientWorld, encode(t, server, serverWorld)); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	vec4s := clientWorld.GetComponent(localID, "property_block").(*ecs.PropertyBlockComponent).Vec4s
+	if _, kept := vec4s["tint"]; kept || len(vec4s) != 1 {
+		t.Errorf("client property block = %v, want only glow", vec4s)
+	}
+	if got := clientWorld.GetEntitiesByTag("enemy"); len(got) != 0 {
+		t.Errorf("GetEntitiesByTag(enemy) = %v after the tag changed, want none", got)
+	}
+	if got := clientWorld.GetEntitiesByTag("ally"); len(got) != 1 || got[0] != localID {
+		t.Errorf("GetEntitiesByTag(ally) = %v, want [%d]", got, localID)
+	}
+}
+
+// brokenComponent fails to encode while fail is set
+type brokenComponent struct {
+	fail bool
+}
+
+func (b *brokenComponent) GetType() string {
+	return "broken"
+}
+
+func (b *brokenComponent) MarshalJSON() ([]byte, error) {
+	if b.fail {
+		return nil, errors.New("can't encode")
+	}
+	return []byte("{}"), nil
+}
+
+func TestServerEncodeFailureKeepsState(t *testing.T) {
+	serverWorld, clientWorld := ecs.NewWorld(), ecs.NewWorld()
+	moved := newReplicatedEntity(serverWorld, mgl32.Vec3{1, 0, 0}, true)
+	failing := newReplicatedEntity(serverWorld, mgl32.Vec3{}, true)
+	broken := &brokenComponent{}
+	serverWorld.AddComponent(failing, broken)
+	ecs.RegisterComponentType("broken", func() ecs.Component { return &brokenComponent{} })
+
+	server := NewServer("transform", "broken")
+	client := NewClient(nil)
+	if err := client.Apply(clientWorld, encode(t, server, serverWorld)); err != nil {
+		t.Fatalf("Apply: %v", err)
+	}
+
+	// The move is encoded before the failing entity, but nothing counts as sent
+	serverWorld.GetComponent(moved, "transform").(*ecs.TransformComponent).Position = mgl32.Vec3{2, 0, 0}
+	broken.fail = true
+	if _, err := server.Encode(serverWorld); err == nil {
+		t.Fatal("Encode of a broken component succeeded")
+	}
+
+	// The next packet follows the last good one and still holds the move
+	broken.fail = false
+	packet := encode(t, server, serverWorld)
+	if ids := updatedIDs(packet); len(ids) != 1 || ids[0] != moved {
+		t.Errorf("packet after a failed Encode updates %v, want only %d", ids, moved)
+	}
+	if err := client.Apply(clientWorld, packet); err != nil {
+		t.Fatalf("Apply of the packet after a failed Encode: %v", err)
+	}
+	localID, _ := client.LocalEntity(moved)
+	if got := clientWorld.GetComponent(localID, "transform").(*ecs.TransformComponent).Position; got != (mgl32.Vec3{2, 0, 0}) {
+		t.Errorf("client position = %v, want (2, 0, 0)", got)
+	}
+}
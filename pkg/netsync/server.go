@@ -0,0 +1,115 @@
+package netsync
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+)
+
+// Server encodes delta packets of a world's replicated entities. It
+// remembers the last state it encoded for each component, so a component is
+// only sent again when its encoded form changes. The first packet, and the
+// first after Reset, is full.
+type Server struct {
+	componentTypes []string
+	tick           uint64
+	// sent holds the last encoded form of each component, by entity and type
+	sent map[ecs.EntityID]map[string]string
+	// full is set until the next packet, which then holds the whole state
+	full bool
+}
+
+// NewServer creates a server replicating the given component types
+func NewServer(componentTypes ...string) *Server {
+	return &Server{
+		componentTypes: componentTypes,
+		sent:           make(map[ecs.EntityID]map[string]string),
+		full:           true,
+	}
+}
+
+// Reset forgets what was sent, so the next packet is full, for example when
+// a client joins or reports ErrPacketGap
+func (s *Server) Reset() {
+	s.sent = make(map[ecs.EntityID]map[string]string)
+	s.full = true
+}
+
+// Encode returns the changes of the world's replicated entities since the
+// last call, in entity creation order. If a component fails to encode, the
+// server is left as it was, so the next packet covers the same changes.
+func (s *Server) Encode(world *ecs.World) (*Packet, error) {
+	packet := &Packet{Tick: s.tick + 1, Full: s.full}
+
+	replicated := world.GetEntitiesWithComponent("replicated")
+	sent := make(map[ecs.EntityID]map[string]string, len(replicated))
+	for _, entityID := range replicated {
+		update, entitySent, err := s.encodeEntity(world, entityID)
+		if err != nil {
+			return nil, err
+		}
+		sent[entityID] = entitySent
+
+		// A full packet lists every entity, so the client can drop the rest
+		if packet.Full || len(update.Components) > 0 || len(update.Removed) > 0 {
+			packet.Updates = append(packet.Updates, update)
+		}
+	}
+
+	for _, entityID := range sortedIDs(s.sent) {
+		if _, present := sent[entityID]; !present {
+			packet.Destroyed = append(packet.Destroyed, entityID)
+		}
+	}
+
+	// Everything encoded, so the packet can count as sent
+	s.tick = packet.Tick
+	s.full = false
+	s.sent = sent
+	return packet, nil
+}
+
+// Send encodes the next packet and writes it to writer
+func (s *Server) Send(world *ecs.World, writer io.Writer) error {
+	packet, err := s.Encode(world)
+	if err != nil {
+		return err
+	}
+	return WritePacket(writer, packet)
+}
+
+// encodeEntity returns the replicated components of an entity that changed
+// and the encoded form of all of them, without changing what was sent
+func (s *Server) encodeEntity(world *ecs.World, entityID ecs.EntityID) (EntityUpdate, map[string]string, error) {
+	update := EntityUpdate{ID: entityID}
+	previous := s.sent[entityID]
+	sent := make(map[string]string, len(s.componentTypes))
+
+	for _, componentType := range s.componentTypes {
+		component := world.GetComponent(entityID, componentType)
+		if component == nil {
+			if _, wasSent := previous[componentType]; wasSent {
+				update.Removed = append(update.Removed, componentType)
+			}
+			continue
+		}
+
+		data, err := json.Marshal(component)
+		if err != nil {
+			return update, nil, fmt.Errorf("entity %d component %s: %w", entityID, componentType, err)
+		}
+		sent[componentType] = string(data)
+		if last, wasSent := previous[componentType]; wasSent && last == string(data) {
+			continue
+		}
+
+		if update.Components == nil {
+			update.Components = make(map[string]json.RawMessage)
+		}
+		update.Components[componentType] = data
+	}
+
+	return update, sent, nil
+}
@@ -0,0 +1,117 @@
+package noise
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Generator produces deterministic, seeded 2D Perlin noise
+type Generator struct {
+	seed int64
+	perm [512]int
+}
+
+// gradients2D are the unit gradient directions used by Noise2D
+var gradients2D = [8][2]float64{
+	{1, 0}, {-1, 0}, {0, 1}, {0, -1},
+	{math.Sqrt2 / 2, math.Sqrt2 / 2}, {-math.Sqrt2 / 2, math.Sqrt2 / 2},
+	{math.Sqrt2 / 2, -math.Sqrt2 / 2}, {-math.Sqrt2 / 2, -math.Sqrt2 / 2},
+}
+
+// NewGenerator creates a noise generator from a seed
+func NewGenerator(seed int64) *Generator {
+	generator := NewGeneratorFromRand(rand.New(rand.NewSource(seed)))
+	generator.seed = seed
+	return generator
+}
+
+// NewGeneratorFromRand creates a noise generator whose permutation is drawn from rng,
+// so noise stays reproducible when rng comes from a seeded source
+func NewGeneratorFromRand(rng *rand.Rand) *Generator {
+	generator := &Generator{}
+
+	permutation := rng.Perm(256)
+	for i := 0; i < 256; i++ {
+		generator.perm[i] = permutation[i]
+		generator.perm[i+256] = permutation[i]
+	}
+
+	return generator
+}
+
+// Seed returns the seed the generator was created with, or 0 if created from a rand source
+func (g *Generator) Seed() int64 {
+	return g.seed
+}
+
+// Noise2D returns coherent noise in [-1, 1] at the given coordinates
+func (g *Generator) Noise2D(x, y float64) float64 {
+	// Cell coordinates and position within the cell
+	x0 := math.Floor(x)
+	y0 := math.Floor(y)
+	fx := x - x0
+	fy := y - y0
+
+	xi := int(x0) & 255
+	yi := int(y0) & 255
+
+	// Dot products with the gradients at the four cell corners
+	n00 := g.gradient(xi, yi, fx, fy)
+	n10 := g.gradient(xi+1, yi, fx-1, fy)
+	n01 := g.gradient(xi, yi+1, fx, fy-1)
+	n11 := g.gradient(xi+1, yi+1, fx-1, fy-1)
+
+	u := fade(fx)
+	v := fade(fy)
+
+	value := lerp(lerp(n00, n10, u), lerp(n01, n11, u), v)
+
+	// Unit gradients bound 2D Perlin noise to ±sqrt(2)/2
+	return clamp(value*math.Sqrt2, -1, 1)
+}
+
+// Fractal2D sums octaves of noise for a more detailed result in [-1, 1]
+func (g *Generator) Fractal2D(x, y float64, octaves int, lacunarity, persistence float64) float64 {
+	total := 0.0
+	amplitude := 1.0
+	frequency := 1.0
+	maxAmplitude := 0.0
+
+	for i := 0; i < octaves; i++ {
+		total += g.Noise2D(x*frequency, y*frequency) * amplitude
+		maxAmplitude += amplitude
+		amplitude *= persistence
+		frequency *= lacunarity
+	}
+
+	if maxAmplitude == 0 {
+		return 0
+	}
+	return total / maxAmplitude
+}
+
+// gradient returns the dot product of the corner gradient with the offset
+func (g *Generator) gradient(xi, yi int, dx, dy float64) float64 {
+	hash := g.perm[g.perm[xi&255]+(yi&255)] & 7
+	grad := gradients2D[hash]
+	return grad[0]*dx + grad[1]*dy
+}
+
+// fade is the quintic smoothstep used to interpolate between corners
+func fade(t float64) float64 {
+	return t * t * t * (t*(t*6-15) + 10)
+}
+
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
+
+func clamp(value, min, max float64) float64 {
+	if value < min {
+		return min
+	}
+	if value > max {
+		return max
+	}
+	return value
+}
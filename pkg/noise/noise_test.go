@@ -0,0 +1,69 @@
+package noise
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSameSeedSameNoise(t *testing.T) {
+	a := NewGenerator(42)
+	b := NewGenerator(42)
+	other := NewGenerator(7)
+
+	differs := false
+	for y := 0.0; y < 8; y += 0.37 {
+		for x := 0.0; x < 8; x += 0.37 {
+			if a.Noise2D(x, y) != b.Noise2D(x, y) {
+				t.Fatalf("seed 42 gave two values at (%v, %v)", x, y)
+			}
+			if a.Fractal2D(x, y, 4, 2, 0.5) != b.Fractal2D(x, y, 4, 2, 0.5) {
+				t.Fatalf("seed 42 gave two fractal values at (%v, %v)", x, y)
+			}
+			if a.Noise2D(x, y) != other.Noise2D(x, y) {
+				differs = true
+			}
+		}
+	}
+	if !differs {
+		t.Error("seeds 42 and 7 gave the same field")
+	}
+	if a.Seed() != 42 {
+		t.Errorf("Seed() = %d, want 42", a.Seed())
+	}
+}
+
+func TestNoiseRange(t *testing.T) {
+	generator := NewGenerator(1)
+	for y := -20.0; y < 20; y += 0.13 {
+		for x := -20.0; x < 20; x += 0.13 {
+			if value := generator.Noise2D(x, y); value < -1 || value > 1 {
+				t.Fatalf("Noise2D(%v, %v) = %v, outside [-1, 1]", x, y, value)
+			}
+			if value := generator.Fractal2D(x, y, 5, 2, 0.5); value < -1 || value > 1 {
+				t.Fatalf("Fractal2D(%v, %v) = %v, outside [-1, 1]", x, y, value)
+			}
+		}
+	}
+
+	// Perlin noise is zero on the lattice
+	if value := generator.Noise2D(3, -4); value != 0 {
+		t.Errorf("Noise2D(3, -4) = %v, want 0", value)
+	}
+}
+
+func TestNoiseSmooth(t *testing.T) {
+	generator := NewGenerator(99)
+	const step = 0.01
+
+	// The noise gradient is bounded, so nearby samples stay close
+	maxDiff := 0.0
+	for y := 0.0; y < 5; y += 0.1 {
+		for x := 0.0; x < 5; x += step {
+			diff := math.Abs(generator.Noise2D(x+step, y) - generator.Noise2D(x, y))
+			maxDiff = math.Max(maxDiff, diff)
+		}
+	}
+	if maxDiff > 0.05 {
+		t.Errorf("adjacent samples %v apart differ by up to %v", step, maxDiff)
+	}
+}
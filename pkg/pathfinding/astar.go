@@ -0,0 +1,151 @@
+package pathfinding
+
+import (
+	"container/heap"
+	"math"
+)
+
+// Heuristic selects how paths move between cells and how the remaining
+// distance to the goal is estimated
+type Heuristic int
+
+const (
+	// Manhattan moves between edge neighbors only
+	Manhattan Heuristic = iota
+	// Diagonal also moves between corner neighbors, without cutting past
+	// blocked cells
+	Diagonal
+)
+
+// Neighbor offsets; the first four are edge neighbors
+var neighborOffsets = [8]GridCoord{
+	{1, 0}, {-1, 0}, {0, 1}, {0, -1},
+	{1, 1}, {-1, 1}, {1, -1}, {-1, -1},
+}
+
+// FindPath finds the cheapest path from start to goal moving between edge
+// neighbors. See FindPathWith.
+func FindPath(grid *Grid, start, goal GridCoord) ([]GridCoord, bool) {
+	return FindPathWith(grid, start, goal, Manhattan)
+}
+
+// FindPathWith finds the cheapest path from start to goal with A*. The path
+// includes both ends. It returns false if either end is blocked or outside
+// the grid, or if no path exists.
+func FindPathWith(grid *Grid, start, goal GridCoord, heuristic Heuristic) ([]GridCoord, bool) {
+	if !grid.IsWalkable(start) || !grid.IsWalkable(goal) {
+		return nil, false
+	}
+
+	neighbors := neighborOffsets[:4]
+	if heuristic == Diagonal {
+		neighbors = neighborOffsets[:]
+	}
+
+	costs := map[GridCoord]float64{start: 0}
+	cameFrom := make(map[GridCoord]GridCoord)
+	closed := make(map[GridCoord]bool)
+
+	open := &openSet{}
+	heap.Push(open, &openNode{cell: start, estimate: estimate(start, goal, heuristic)})
+
+	for open.Len() > 0 {
+		current := heap.Pop(open).(*openNode).cell
+		if current == goal {
+			return buildPath(cameFrom, start, goal), true
+		}
+		if closed[current] {
+			continue
+		}
+		closed[current] = true
+
+		for _, offset := range neighbors {
+			next := GridCoord{current.X + offset.X, current.Y + offset.Y}
+			if !grid.IsWalkable(next) || closed[next] {
+				continue
+			}
+
+			step := 1.0
+			if offset.X != 0 && offset.Y != 0 {
+				// Don't squeeze diagonally between two blocked cells or past a corner
+				if !grid.IsWalkable(GridCoord{current.X + offset.X, current.Y}) ||
+					!grid.IsWalkable(GridCoord{current.X, current.Y + offset.Y}) {
+					continue
+				}
+				step = math.Sqrt2
+			}
+
+			cost := costs[current] + step*grid.Cost(next)
+			if known, seen := costs[next]; seen && cost >= known {
+				continue
+			}
+
+			costs[next] = cost
+			cameFrom[next] = current
+			heap.Push(open, &openNode{
+				cell:     next,
+				cost:     cost,
+				estimate: cost + estimate(next, goal, heuristic),
+			})
+		}
+	}
+
+	return nil, false
+}
+
+// estimate returns a lower bound of the cost from a cell to the goal
+func estimate(from, to GridCoord, heuristic Heuristic) float64 {
+	dx := math.Abs(float64(to.X - from.X))
+	dy := math.Abs(float64(to.Y - from.Y))
+
+	if heuristic == Diagonal {
+		// Octile distance: diagonal steps for the shorter axis, straight for the rest
+		return math.Max(dx, dy) + (math.Sqrt2-1)*math.Min(dx, dy)
+	}
+	return dx + dy
+}
+
+// buildPath walks back from goal to start
+func buildPath(cameFrom map[GridCoord]GridCoord, start, goal GridCoord) []GridCoord {
+	path := []GridCoord{goal}
+	for cell := goal; cell != start; {
+		cell = cameFrom[cell]
+		path = append(path, cell)
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// openNode is a cell waiting to be expanded
+type openNode struct {
+	cell     GridCoord
+	cost     float64
+	estimate float64
+}
+
+// openSet is a min-heap of nodes by estimated total cost. Ties prefer the
+// node with the higher cost so far, which is closer to the goal.
+type openSet []*openNode
+
+func (s openSet) Len() int { return len(s) }
+
+func (s openSet) Less(i, j int) bool {
+	if s[i].estimate != s[j].estimate {
+		return s[i].estimate < s[j].estimate
+	}
+	return s[i].cost > s[j].cost
+}
+
+func (s openSet) Swap(i, j int) { s[i], s[j] = s[j], s[i] }
+
+func (s *openSet) Push(x interface{}) { *s = append(*s, x.(*openNode)) }
+
+func (s *openSet) Pop() interface{} {
+	old := *s
+	node := old[len(old)-1]
+	*s = old[:len(old)-1]
+	return node
+}
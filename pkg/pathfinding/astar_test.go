@@ -0,0 +1,163 @@
+package pathfinding
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// checkPath fails the test unless path runs from start to goal over
+// walkable cells, each a single move from the last
+func checkPath(t *testing.T, grid *Grid, path []GridCoord, start, goal GridCoord, diagonal bool) {
+	t.Helper()
+
+	if len(path) == 0 || path[0] != start || path[len(path)-1] != goal {
+		t.Fatalf("path %v doesn't run from %v to %v", path, start, goal)
+	}
+	for i, cell := range path {
+		if !grid.IsWalkable(cell) {
+			t.Fatalf("path %v crosses blocked cell %v", path, cell)
+		}
+		if i == 0 {
+			continue
+		}
+		dx, dy := abs(cell.X-path[i-1].X), abs(cell.Y-path[i-1].Y)
+		if dx > 1 || dy > 1 || dx+dy == 0 || (!diagonal && dx+dy != 1) {
+			t.Fatalf("path %v jumps from %v to %v", path, path[i-1], cell)
+		}
+	}
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
+
+func TestFindPathStraight(t *testing.T) {
+	grid := NewGrid(5, 3)
+
+	path, found := FindPath(grid, GridCoord{0, 1}, GridCoord{4, 1})
+	want := []GridCoord{{0, 1}, {1, 1}, {2, 1}, {3, 1}, {4, 1}}
+	if !found || !reflect.DeepEqual(path, want) {
+		t.Errorf("FindPath() = %v, %v; want %v, true", path, found, want)
+	}
+
+	// Start and goal can be the same cell
+	if path, found := FindPath(grid, GridCoord{2, 2}, GridCoord{2, 2}); !found || !reflect.DeepEqual(path, []GridCoord{{2, 2}}) {
+		t.Errorf("FindPath() to itself = %v, %v; want the single cell", path, found)
+	}
+}
+
+func TestFindPathAroundObstacle(t *testing.T) {
+	// A wall at x = 2 with a gap at the top
+	grid := NewGrid(5, 5)
+	for y := 0; y < 4; y++ {
+		grid.SetBlocked(GridCoord{2, y}, true)
+	}
+
+	start, goal := GridCoord{0, 0}, GridCoord{4, 0}
+	path, found := FindPath(grid, start, goal)
+	if !found {
+		t.Fatal("FindPath() found no way round the wall")
+	}
+	checkPath(t, grid, path, start, goal, false)
+
+	// Up four, across four, down four
+	if len(path) != 13 {
+		t.Errorf("path has %d cells, want the shortest of 13: %v", len(path), path)
+	}
+}
+
+func TestFindPathUnreachable(t *testing.T) {
+	grid := NewGrid(5, 5)
+	for y := 0; y < 5; y++ {
+		grid.SetBlocked(GridCoord{2, y}, true)
+	}
+
+	tests := []struct {
+		name        string
+		start, goal GridCoord
+	}{
+		{"walled off", GridCoord{0, 0}, GridCoord{4, 4}},
+		{"blocked goal", GridCoord{0, 0}, GridCoord{2, 2}},
+		{"blocked start", GridCoord{2, 0}, GridCoord{0, 0}},
+		{"goal outside", GridCoord{0, 0}, GridCoord{-1, 0}},
+	}
+	for _, test := range tests {
+		for _, heuristic := range []Heuristic{Manhattan, Diagonal} {
+			if path, found := FindPathWith(grid, test.start, test.goal, heuristic); found || path != nil {
+				t.Errorf("%s: FindPathWith(%v) = %v, %v; want nil, false", test.name, heuristic, path, found)
+			}
+		}
+	}
+}
+
+func TestFindPathDiagonal(t *testing.T) {
+	grid := NewGrid(4, 4)
+
+	path, found := FindPathWith(grid, GridCoord{0, 0}, GridCoord{3, 3}, Diagonal)
+	want := []GridCoord{{0, 0}, {1, 1}, {2, 2}, {3, 3}}
+	if !found || !reflect.DeepEqual(path, want) {
+		t.Errorf("FindPathWith(Diagonal) = %v, %v; want %v", path, found, want)
+	}
+
+	// Corners can't be cut past a blocked cell
+	grid.SetBlocked(GridCoord{1, 0}, true)
+	start, goal := GridCoord{0, 0}, GridCoord{2, 1}
+	path, found = FindPathWith(grid, start, goal, Diagonal)
+	if !found {
+		t.Fatal("FindPathWith(Diagonal) found no path")
+	}
+	checkPath(t, grid, path, start, goal, true)
+	if path[1] == (GridCoord{1, 1}) {
+		t.Errorf("path %v cuts the corner of the blocked cell", path)
+	}
+}
+
+func TestFindPathAvoidsCostlyCells(t *testing.T) {
+	// A swamp in the middle row costs more than the detour round it
+	grid := NewGrid(3, 3)
+	grid.SetCost(GridCoord{1, 1}, 10)
+
+	start, goal := GridCoord{1, 0}, GridCoord{1, 2}
+	path, found := FindPath(grid, start, goal)
+	if !found {
+		t.Fatal("FindPath() found no path")
+	}
+	checkPath(t, grid, path, start, goal, false)
+	for _, cell := range path {
+		if cell == (GridCoord{1, 1}) {
+			t.Errorf("path %v goes through the costly cell", path)
+		}
+	}
+
+	// Costs below 1 are raised to 1
+	grid.SetCost(GridCoord{0, 0}, 0.1)
+	if got := grid.Cost(GridCoord{0, 0}); got != 1 {
+		t.Errorf("Cost() = %v, want 1", got)
+	}
+}
+
+func TestGridFromTilemap(t *testing.T) {
+	tilemap := ecs.NewTilemapComponent("tiles", 2, 2, 3, 2, mgl32.Vec2{1, 1})
+	tilemap.SolidTiles = []int{1}
+	tilemap.SetTile(1, 0, 1)
+	tilemap.SetTile(2, 1, 0)
+
+	grid := GridFromTilemap(tilemap)
+	if grid.Width != 3 || grid.Height != 2 {
+		t.Fatalf("grid is %dx%d, want 3x2", grid.Width, grid.Height)
+	}
+	for y := 0; y < 2; y++ {
+		for x := 0; x < 3; x++ {
+			cell := GridCoord{x, y}
+			if want := !tilemap.IsSolid(x, y); grid.IsWalkable(cell) != want {
+				t.Errorf("IsWalkable(%v) = %v, want %v", cell, grid.IsWalkable(cell), want)
+			}
+		}
+	}
+}
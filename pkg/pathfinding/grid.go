@@ -0,0 +1,90 @@
+package pathfinding
+
+import (
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+)
+
+// GridCoord is the position of a cell in a grid
+type GridCoord struct {
+	X, Y int
+}
+
+// Grid is a navigation grid of walkable and blocked cells. Every walkable
+// cell has a cost of entering it, 1 by default.
+type Grid struct {
+	Width  int
+	Height int
+
+	blocked []bool
+	costs   []float64
+}
+
+// NewGrid creates a grid of width by height walkable cells
+func NewGrid(width, height int) *Grid {
+	costs := make([]float64, width*height)
+	for i := range costs {
+		costs[i] = 1
+	}
+
+	return &Grid{
+		Width:   width,
+		Height:  height,
+		blocked: make([]bool, width*height),
+		costs:   costs,
+	}
+}
+
+// GridFromTilemap creates a grid with the tilemap's solid cells blocked
+func GridFromTilemap(tilemap *ecs.TilemapComponent) *Grid {
+	grid := NewGrid(tilemap.Width, tilemap.Height)
+	for y := 0; y < tilemap.Height; y++ {
+		for x := 0; x < tilemap.Width; x++ {
+			if tilemap.IsSolid(x, y) {
+				grid.SetBlocked(GridCoord{x, y}, true)
+			}
+		}
+	}
+	return grid
+}
+
+// InBounds reports whether a cell lies inside the grid
+func (g *Grid) InBounds(cell GridCoord) bool {
+	return cell.X >= 0 && cell.X < g.Width && cell.Y >= 0 && cell.Y < g.Height
+}
+
+// SetBlocked blocks or unblocks a cell. Cells outside the grid are ignored.
+func (g *Grid) SetBlocked(cell GridCoord, blocked bool) {
+	if g.InBounds(cell) {
+		g.blocked[g.index(cell)] = blocked
+	}
+}
+
+// IsWalkable reports whether a cell is inside the grid and not blocked
+func (g *Grid) IsWalkable(cell GridCoord) bool {
+	return g.InBounds(cell) && !g.blocked[g.index(cell)]
+}
+
+// SetCost sets the cost of entering a cell; costs below 1 are raised to 1
+// so the heuristics never overestimate
+func (g *Grid) SetCost(cell GridCoord, cost float64) {
+	if !g.InBounds(cell) {
+		return
+	}
+	if cost < 1 {
+		cost = 1
+	}
+	g.costs[g.index(cell)] = cost
+}
+
+// Cost returns the cost of entering a cell
+func (g *Grid) Cost(cell GridCoord) float64 {
+	if !g.InBounds(cell) {
+		return 0
+	}
+	return g.costs[g.index(cell)]
+}
+
+// index returns the position of a cell in the grid's slices
+func (g *Grid) index(cell GridCoord) int {
+	return cell.Y*g.Width + cell.X
+}
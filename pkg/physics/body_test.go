@@ -0,0 +1,103 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGravityScale(t *testing.T) {
+	world := NewWorld()
+	normal := NewRigidBody(1, Vector2{0, 100}, 1, 1, 1)
+	light := NewRigidBody(2, Vector2{10, 100}, 1, 1, 1)
+	light.GravityScale = 0.5
+	floating := NewRigidBody(3, Vector2{20, 100}, 1, 1, 1)
+	floating.GravityScale = 0
+	rising := NewRigidBody(4, Vector2{30, 100}, 1, 1, 2)
+	rising.GravityScale = -1
+	for _, body := range []*RigidBody{normal, light, floating, rising} {
+		world.AddBody(body)
+	}
+
+	const step = 1.0 / 60.0
+	world.Update(step)
+
+	tests := []struct {
+		body *RigidBody
+		want float64
+	}{
+		{normal, -9.81},
+		{light, -4.905},
+		{floating, 0},
+		// Acceleration doesn't depend on mass
+		{rising, 9.81},
+	}
+	for _, test := range tests {
+		if acceleration := test.body.Velocity.Y / step; math.Abs(acceleration-test.want) > 1e-9 {
+			t.Errorf("body %d accelerated at %v, want %v", test.body.ID, acceleration, test.want)
+		}
+	}
+}
+
+func TestApplyImpulse(t *testing.T) {
+	light := NewRigidBody(1, Vector2{}, 1, 1, 1)
+	heavy := NewRigidBody(2, Vector2{}, 1, 1, 4)
+	static := NewRigidBody(3, Vector2{}, 1, 1, 0)
+
+	for _, body := range []*RigidBody{light, heavy, static} {
+		body.ApplyImpulse(Vector2{8, 0})
+	}
+
+	if light.Velocity != (Vector2{8, 0}) {
+		t.Errorf("light body velocity = %v, want (8, 0)", light.Velocity)
+	}
+	if heavy.Velocity != (Vector2{2, 0}) {
+		t.Errorf("heavy body velocity = %v, want (2, 0)", heavy.Velocity)
+	}
+	if static.Velocity != (Vector2{}) {
+		t.Errorf("static body velocity = %v, want zero", static.Velocity)
+	}
+}
+
+func TestApplyForceLastsOneStep(t *testing.T) {
+	world := NewWorld()
+	world.SetGravity(Vector2{})
+	body := NewRigidBody(1, Vector2{}, 1, 1, 2)
+	static := NewRigidBody(2, Vector2{10, 0}, 1, 1, 0)
+	world.AddBody(body)
+	world.AddBody(static)
+
+	body.ApplyForce(Vector2{120, 0})
+	static.ApplyForce(Vector2{120, 0})
+	world.Update(1.0 / 60.0)
+
+	// 120 / 2 units per second squared for 1/60 of a second
+	if math.Abs(body.Velocity.X-1) > 1e-9 {
+		t.Errorf("velocity after force = %v, want 1", body.Velocity.X)
+	}
+	if body.Force != (Vector2{}) {
+		t.Errorf("force not cleared after the step: %v", body.Force)
+	}
+
+	world.Update(1.0 / 60.0)
+	if math.Abs(body.Velocity.X-1) > 1e-9 {
+		t.Errorf("velocity kept changing after the force was cleared: %v", body.Velocity.X)
+	}
+	if static.Velocity != (Vector2{}) || static.Position != (Vector2{10, 0}) {
+		t.Error("force moved a static body")
+	}
+}
+
+func TestSetVelocity(t *testing.T) {
+	body := NewRigidBody(1, Vector2{}, 1, 1, 1)
+	static := NewRigidBody(2, Vector2{}, 1, 1, 0)
+
+	body.SetVelocity(Vector2{3, 4})
+	static.SetVelocity(Vector2{3, 4})
+
+	if body.Velocity != (Vector2{3, 4}) {
+		t.Errorf("velocity = %v, want (3, 4)", body.Velocity)
+	}
+	if static.Velocity != (Vector2{}) {
+		t.Errorf("static body velocity = %v, want zero", static.Velocity)
+	}
+}
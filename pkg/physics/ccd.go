@@ -0,0 +1,95 @@
+package physics
+
+import (
+	"math"
+)
+
+// sweepHit is the first contact found along a body's swept motion
+type sweepHit struct {
+	// time is the fraction of the motion travelled before touching, in [0, 1]
+	time float64
+	// normal is the contact normal, pointing against the motion
+	normal Vector2
+}
+
+// sweepBody moves a CCD body from start by delta, stopping along the contact
+// normal at the first body in its path. Motion along the contact surface is
+// kept so fast bodies still slide; the caller must hold the write lock.
+func (w *World) sweepBody(body *RigidBody, start, delta Vector2, bodies []*RigidBody) {
+	var first *sweepHit
+	for _, other := range bodies {
+		if other == body || other.Sensor {
+			continue
+		}
+		if hit := sweepAABB(body, start, delta, other); hit != nil && (first == nil || hit.time < first.time) {
+			first = hit
+		}
+	}
+
+	body.Position = start.Add(delta)
+	if first == nil {
+		return
+	}
+
+	// Clamp the motion along the normal and stop moving into the contact
+	if first.normal.X != 0 {
+		body.Position.X = start.X + delta.X*first.time
+		if body.Velocity.X*first.normal.X < 0 {
+			body.Velocity.X = 0
+		}
+	} else {
+		body.Position.Y = start.Y + delta.Y*first.time
+		if body.Velocity.Y*first.normal.Y < 0 {
+			body.Velocity.Y = 0
+		}
+	}
+}
+
+// sweepAABB raycasts a box moving from start by delta against another box,
+// using the other box grown by the moving box's half extents. Boxes that
+// already overlap at the start are left to the contact solver.
+func sweepAABB(body *RigidBody, start, delta Vector2, other *RigidBody) *sweepHit {
+	halfWidth := (body.Width + other.Width) / 2
+	halfHeight := (body.Height + other.Height) / 2
+
+	entryX, exitX, ok := sweepAxis(start.X, delta.X, other.Position.X, halfWidth)
+	if !ok {
+		return nil
+	}
+	entryY, exitY, ok := sweepAxis(start.Y, delta.Y, other.Position.Y, halfHeight)
+	if !ok {
+		return nil
+	}
+
+	entry := math.Max(entryX, entryY)
+	exit := math.Min(exitX, exitY)
+	if entry >= exit || entry < 0 || entry > 1 {
+		return nil
+	}
+
+	if entryX > entryY {
+		return &sweepHit{time: entry, normal: Vector2{-math.Copysign(1, delta.X), 0}}
+	}
+	return &sweepHit{time: entry, normal: Vector2{0, -math.Copysign(1, delta.Y)}}
+}
+
+// sweepAxis returns the times a point moving from start by delta enters and
+// leaves the slab [center-half, center+half], or false if it never does
+func sweepAxis(start, delta, center, half float64) (float64, float64, bool) {
+	low := center - half
+	high := center + half
+
+	if delta == 0 {
+		if start <= low || start >= high {
+			return 0, 0, false
+		}
+		return math.Inf(-1), math.Inf(1), true
+	}
+
+	entry := (low - start) / delta
+	exit := (high - start) / delta
+	if entry > exit {
+		entry, exit = exit, entry
+	}
+	return entry, exit, true
+}
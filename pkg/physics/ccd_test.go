@@ -0,0 +1,75 @@
+package physics
+
+import (
+	"testing"
+)
+
+// fireAtWall shoots a small body at 1000 units per second toward a static
+// wall 0.1 units thick and returns the body after one step
+func fireAtWall(ccd bool) *RigidBody {
+	world := NewWorld()
+	world.SetGravity(Vector2{})
+	world.AddBody(NewRigidBody(1, Vector2{10, 0}, 0.1, 10, 0))
+
+	bullet := NewRigidBody(2, Vector2{0, 0}, 0.1, 0.1, 0.01)
+	bullet.Velocity = Vector2{1000, 0}
+	bullet.CCD = ccd
+	world.AddBody(bullet)
+
+	world.Update(1.0 / 60.0)
+	return bullet
+}
+
+func TestCCDStopsAtThinWall(t *testing.T) {
+	// Without CCD one step carries the body clean through the wall
+	if tunnelled := fireAtWall(false); tunnelled.Position.X <= 10 {
+		t.Fatalf("body without CCD stopped at x = %v; the test no longer tunnels", tunnelled.Position.X)
+	}
+
+	bullet := fireAtWall(true)
+
+	// Touching the wall, the two half widths apart
+	if x := bullet.Position.X; x > 9.9+1e-9 || x < 9.8 {
+		t.Errorf("CCD body stopped at x = %v, want against the wall at 9.9", x)
+	}
+	if bullet.Velocity.X > 0 {
+		t.Errorf("CCD body still moving into the wall at %v", bullet.Velocity.X)
+	}
+}
+
+func TestCCDKeepsSlidingMotion(t *testing.T) {
+	world := NewWorld()
+	world.SetGravity(Vector2{})
+	world.AddBody(NewRigidBody(1, Vector2{10, 0}, 0.1, 100, 0))
+
+	bullet := NewRigidBody(2, Vector2{0, 0}, 0.1, 0.1, 0.01)
+	bullet.Velocity = Vector2{1000, 60}
+	bullet.CCD = true
+	world.AddBody(bullet)
+
+	world.Update(1.0 / 60.0)
+
+	// Motion along the wall is kept while motion into it stops
+	if bullet.Position.Y != 1 {
+		t.Errorf("y = %v, want 1 after sliding along the wall", bullet.Position.Y)
+	}
+	if bullet.Position.X > 9.9+1e-9 {
+		t.Errorf("x = %v, past the wall", bullet.Position.X)
+	}
+}
+
+func TestSweepAABBMisses(t *testing.T) {
+	body := NewRigidBody(1, Vector2{}, 1, 1, 1)
+	wall := NewRigidBody(2, Vector2{10, 10}, 1, 1, 0)
+
+	// Passing above the wall, and stopping short of it
+	if hit := sweepAABB(body, Vector2{0, 0}, Vector2{20, 0}, wall); hit != nil {
+		t.Errorf("sweep passing the wall hit it at %v", hit.time)
+	}
+	if hit := sweepAABB(body, Vector2{0, 10}, Vector2{5, 0}, wall); hit != nil {
+		t.Errorf("sweep stopping short hit the wall at %v", hit.time)
+	}
+	if hit := sweepAABB(body, Vector2{0, 10}, Vector2{20, 0}, wall); hit == nil || hit.normal != (Vector2{-1, 0}) {
+		t.Errorf("sweep into the wall = %+v, want a hit with normal (-1, 0)", hit)
+	}
+}
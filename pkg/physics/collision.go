@@ -0,0 +1,125 @@
+package physics
+
+import (
+	"math"
+	"sort"
+)
+
+// Collision describes a contact between two bodies found during a step
+type Collision struct {
+	BodyA uint64
+	BodyB uint64
+	// Normal points from BodyA towards BodyB
+	Normal Vector2
+	// ImpactVelocity is the closing speed along Normal before the collision is resolved
+	ImpactVelocity float64
+}
+
+// StepResult holds the contacts produced by a single physics step
+type StepResult struct {
+	// Contacts holds every pair touching after the step
+	Contacts []Collision
+	// Entered holds the pairs that started touching during the step
+	Entered []Collision
+}
+
+// pairKey identifies a pair of bodies independently of their order
+type pairKey struct {
+	low, high uint64
+}
+
+// makePairKey returns the canonical key of a body pair
+func makePairKey(a, b uint64) pairKey {
+	if a > b {
+		a, b = b, a
+	}
+	return pairKey{low: a, high: b}
+}
+
+// OnCollisionEnter registers a listener called for each pair that starts touching.
+// Listeners run after the step completes, outside the world lock.
+func (w *World) OnCollisionEnter(listener func(collision Collision)) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.collisionListeners = append(w.collisionListeners, listener)
+}
+
+// GetLastStepResult returns the contacts produced by the most recent step
+func (w *World) GetLastStepResult() StepResult {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	return w.lastResult
+}
+
+// Step advances the simulation and returns the contacts it produced
+func (w *World) Step(deltaTime float64) StepResult {
+	w.mutex.Lock()
+	w.integrateVelocities(deltaTime)
+	result, contacts := w.checkCollisions()
+	joints := w.prepareJoints(deltaTime)
+	w.solveContacts(contacts, joints, deltaTime)
+	w.integratePositions(deltaTime)
+	w.lastResult = result
+	listeners := w.collisionListeners
+	w.mutex.Unlock()
+
+	for _, collision := range result.Entered {
+		for _, listener := range listeners {
+			listener(collision)
+		}
+	}
+
+	return result
+}
+
+// sortedActiveBodies returns the active bodies ordered by ID so collision
+// processing is deterministic
+func (w *World) sortedActiveBodies() []*RigidBody {
+	bodies := make([]*RigidBody, 0, len(w.bodies))
+	for _, body := range w.bodies {
+		if body.Active {
+			bodies = append(bodies, body)
+		}
+	}
+
+	sort.Slice(bodies, func(i, j int) bool {
+		return bodies[i].ID < bodies[j].ID
+	})
+	return bodies
+}
+
+// newCollision builds the contact data for two overlapping bodies
+func newCollision(body1, body2 *RigidBody) Collision {
+	normal, _ := contactAxis(body1, body2)
+
+	// Positive when the bodies move towards each other along the normal
+	relativeVelocity := body1.Velocity.Sub(body2.Velocity)
+
+	return Collision{
+		BodyA:          body1.ID,
+		BodyB:          body2.ID,
+		Normal:         normal,
+		ImpactVelocity: relativeVelocity.Dot(normal),
+	}
+}
+
+// contactAxis returns the axis of least penetration between two overlapping
+// boxes, pointing from body1 to body2, and the penetration depth along it
+func contactAxis(body1, body2 *RigidBody) (Vector2, float64) {
+	separation := body2.Position.Sub(body1.Position)
+	overlapX := (body1.Width+body2.Width)/2 - math.Abs(separation.X)
+	overlapY := (body1.Height+body2.Height)/2 - math.Abs(separation.Y)
+
+	if overlapX < overlapY {
+		if separation.X < 0 {
+			return Vector2{-1, 0}, overlapX
+		}
+		return Vector2{1, 0}, overlapX
+	}
+	if separation.Y < 0 {
+		return Vector2{0, -1}, overlapY
+	}
+	return Vector2{0, 1}, overlapY
+}
@@ -0,0 +1,76 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCollisionEnterImpactVelocity(t *testing.T) {
+	tests := []struct {
+		name                 string
+		velocityA, velocityB Vector2
+		want                 float64
+	}{
+		{"head on", Vector2{3, 0}, Vector2{-2, 0}, 5},
+		{"catching up", Vector2{4, 0}, Vector2{1, 0}, 3},
+		{"into a resting body", Vector2{6, 1}, Vector2{0, 0}, 6},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			world := NewWorld()
+			world.SetGravity(Vector2{})
+
+			a := NewRigidBody(1, Vector2{0, 0}, 1, 1, 1)
+			a.Velocity = test.velocityA
+			b := NewRigidBody(2, Vector2{2, 0}, 1, 1, 1)
+			b.Velocity = test.velocityB
+			world.AddBody(a)
+			world.AddBody(b)
+
+			var entered []Collision
+			world.OnCollisionEnter(func(collision Collision) {
+				entered = append(entered, collision)
+			})
+
+			for i := 0; i < 120 && len(entered) == 0; i++ {
+				world.Step(1.0 / 60.0)
+			}
+			if len(entered) != 1 {
+				t.Fatalf("got %d enter events, want 1", len(entered))
+			}
+
+			collision := entered[0]
+			if collision.BodyA != 1 || collision.BodyB != 2 {
+				t.Errorf("pair = %d, %d; want 1, 2", collision.BodyA, collision.BodyB)
+			}
+			if collision.Normal != (Vector2{1, 0}) {
+				t.Errorf("Normal = %v, want (1, 0)", collision.Normal)
+			}
+			if math.Abs(collision.ImpactVelocity-test.want) > 1e-9 {
+				t.Errorf("ImpactVelocity = %v, want %v", collision.ImpactVelocity, test.want)
+			}
+		})
+	}
+}
+
+func TestCollisionEnterReportedOnce(t *testing.T) {
+	world := NewWorld()
+	world.AddBody(NewRigidBody(1, Vector2{0, 0}, 10, 1, 0))
+	world.AddBody(NewRigidBody(2, Vector2{0, 0.9}, 1, 1, 1))
+
+	entered := 0
+	world.OnCollisionEnter(func(collision Collision) {
+		entered++
+	})
+
+	for i := 0; i < 30; i++ {
+		world.Step(1.0 / 60.0)
+	}
+	if entered != 1 {
+		t.Errorf("resting contact entered %d times, want once", entered)
+	}
+	if contacts := world.GetLastStepResult().Contacts; len(contacts) != 1 {
+		t.Errorf("last step has %d contacts, want 1", len(contacts))
+	}
+}
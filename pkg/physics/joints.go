@@ -0,0 +1,179 @@
+package physics
+
+// Joint constrains the motion of two bodies. Joints are solved with the
+// contacts, after velocities are integrated and before positions are.
+type Joint interface {
+	// GetBodies returns the IDs of the joined bodies
+	GetBodies() (uint64, uint64)
+	// Prepare is called once per step before the solver's velocity passes
+	Prepare(bodyA, bodyB *RigidBody, deltaTime float64)
+	// Solve is called on each of the solver's velocity passes
+	Solve(bodyA, bodyB *RigidBody, deltaTime float64)
+	// Clone returns a copy of the joint sharing no state with it, for World.Clone
+	Clone() Joint
+}
+
+// DistanceJoint is a rigid link holding two bodies' centers at a fixed distance
+type DistanceJoint struct {
+	BodyA, BodyB uint64
+	Length       float64
+}
+
+// NewDistanceJoint creates a rigid link of the given length
+func NewDistanceJoint(bodyA, bodyB uint64, length float64) *DistanceJoint {
+	return &DistanceJoint{
+		BodyA:  bodyA,
+		BodyB:  bodyB,
+		Length: length,
+	}
+}
+
+// GetBodies returns the IDs of the joined bodies
+func (j *DistanceJoint) GetBodies() (uint64, uint64) {
+	return j.BodyA, j.BodyB
+}
+
+// Clone returns a copy of the joint
+func (j *DistanceJoint) Clone() Joint {
+	clone := *j
+	return &clone
+}
+
+// Prepare does nothing; the link is solved entirely in the velocity passes
+func (j *DistanceJoint) Prepare(bodyA, bodyB *RigidBody, deltaTime float64) {}
+
+// Solve removes the relative velocity along the link, plus a bias that
+// corrects part of the length error
+func (j *DistanceJoint) Solve(bodyA, bodyB *RigidBody, deltaTime float64) {
+	inverseMass := bodyA.InverseMass + bodyB.InverseMass
+	axis, distance := jointAxis(bodyA, bodyB)
+	if inverseMass == 0 || distance == 0 {
+		return
+	}
+
+	bias := 0.0
+	if deltaTime > 0 {
+		bias = baumgarte / deltaTime * (distance - j.Length)
+	}
+
+	relativeVelocity := bodyB.Velocity.Sub(bodyA.Velocity).Dot(axis)
+	impulse := -(relativeVelocity + bias) / inverseMass
+	applyJointImpulse(bodyA, bodyB, axis.Mul(impulse))
+}
+
+// SpringJoint pulls two bodies towards a rest distance following Hooke's law
+type SpringJoint struct {
+	BodyA, BodyB uint64
+	RestLength   float64
+	// Stiffness is the force per unit of stretch
+	Stiffness float64
+	// Damping is the force per unit of relative speed along the spring
+	Damping float64
+}
+
+// NewSpringJoint creates a spring with the given rest length, stiffness and damping
+func NewSpringJoint(bodyA, bodyB uint64, restLength, stiffness, damping float64) *SpringJoint {
+	return &SpringJoint{
+		BodyA:      bodyA,
+		BodyB:      bodyB,
+		RestLength: restLength,
+		Stiffness:  stiffness,
+		Damping:    damping,
+	}
+}
+
+// GetBodies returns the IDs of the joined bodies
+func (j *SpringJoint) GetBodies() (uint64, uint64) {
+	return j.BodyA, j.BodyB
+}
+
+// Clone returns a copy of the joint
+func (j *SpringJoint) Clone() Joint {
+	clone := *j
+	return &clone
+}
+
+// Prepare applies the spring and damping forces for the step
+func (j *SpringJoint) Prepare(bodyA, bodyB *RigidBody, deltaTime float64) {
+	axis, distance := jointAxis(bodyA, bodyB)
+	if distance == 0 {
+		return
+	}
+
+	relativeVelocity := bodyB.Velocity.Sub(bodyA.Velocity).Dot(axis)
+	force := -j.Stiffness*(distance-j.RestLength) - j.Damping*relativeVelocity
+	applyJointImpulse(bodyA, bodyB, axis.Mul(force*deltaTime))
+}
+
+// Solve does nothing; a spring is a force, not a constraint
+func (j *SpringJoint) Solve(bodyA, bodyB *RigidBody, deltaTime float64) {}
+
+// AddJoint adds a joint to the world
+func (w *World) AddJoint(joint Joint) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.joints = append(w.joints, joint)
+}
+
+// RemoveJoint removes a joint from the world
+func (w *World) RemoveJoint(joint Joint) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	for i, existing := range w.joints {
+		if existing == joint {
+			w.joints = append(w.joints[:i], w.joints[i+1:]...)
+			return
+		}
+	}
+}
+
+// GetJoints returns the world's joints
+func (w *World) GetJoints() []Joint {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	joints := make([]Joint, len(w.joints))
+	copy(joints, w.joints)
+	return joints
+}
+
+// boundJoint is a joint with its bodies looked up for a step
+type boundJoint struct {
+	joint        Joint
+	bodyA, bodyB *RigidBody
+}
+
+// prepareJoints binds joints to their bodies and runs their per-step work.
+// Joints with a missing or inactive body are skipped; the caller must hold the write lock.
+func (w *World) prepareJoints(deltaTime float64) []boundJoint {
+	joints := make([]boundJoint, 0, len(w.joints))
+	for _, joint := range w.joints {
+		idA, idB := joint.GetBodies()
+		bodyA, bodyB := w.bodies[idA], w.bodies[idB]
+		if bodyA == nil || bodyB == nil || !bodyA.Active || !bodyB.Active {
+			continue
+		}
+
+		joint.Prepare(bodyA, bodyB, deltaTime)
+		joints = append(joints, boundJoint{joint: joint, bodyA: bodyA, bodyB: bodyB})
+	}
+	return joints
+}
+
+// jointAxis returns the direction from bodyA to bodyB and their distance
+func jointAxis(bodyA, bodyB *RigidBody) (Vector2, float64) {
+	separation := bodyB.Position.Sub(bodyA.Position)
+	distance := separation.Length()
+	if distance == 0 {
+		return Vector2{}, 0
+	}
+	return separation.Div(distance), distance
+}
+
+// applyJointImpulse applies an impulse pushing bodyB along it and bodyA against it
+func applyJointImpulse(bodyA, bodyB *RigidBody, impulse Vector2) {
+	bodyA.Velocity = bodyA.Velocity.Sub(impulse.Mul(bodyA.InverseMass))
+	bodyB.Velocity = bodyB.Velocity.Add(impulse.Mul(bodyB.InverseMass))
+}
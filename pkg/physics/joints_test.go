@@ -0,0 +1,86 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+// separation returns the distance between two bodies' centers
+func separation(world *World, idA, idB uint64) float64 {
+	return world.GetBody(idB).Position.Sub(world.GetBody(idA).Position).Length()
+}
+
+func TestDistanceJointHoldsSwingingBody(t *testing.T) {
+	world := NewWorld()
+	world.AddBody(NewRigidBody(1, Vector2{0, 10}, 0.1, 0.1, 0))
+	world.AddBody(NewRigidBody(2, Vector2{3, 10}, 0.1, 0.1, 1))
+	world.AddJoint(NewDistanceJoint(1, 2, 3))
+
+	for i := 0; i < 120; i++ {
+		world.Update(1.0 / 60.0)
+		if distance := separation(world, 1, 2); math.Abs(distance-3) > 0.05 {
+			t.Fatalf("step %d: separation = %v, want 3", i, distance)
+		}
+	}
+
+	// The body swung down rather than staying put or falling freely
+	if y := world.GetBody(2).Position.Y; y >= 10 || y < 7-0.05 {
+		t.Errorf("swinging body at y = %v, want between 7 and 10", y)
+	}
+}
+
+func TestDistanceJointPullsToLength(t *testing.T) {
+	world := NewWorld()
+	world.SetGravity(Vector2{})
+	world.AddBody(NewRigidBody(1, Vector2{0, 0}, 0.1, 0.1, 1))
+	world.AddBody(NewRigidBody(2, Vector2{5, 0}, 0.1, 0.1, 1))
+	world.AddJoint(NewDistanceJoint(1, 2, 3))
+
+	for i := 0; i < 60; i++ {
+		world.Update(1.0 / 60.0)
+	}
+
+	if distance := separation(world, 1, 2); math.Abs(distance-3) > 0.01 {
+		t.Errorf("separation after a second = %v, want 3", distance)
+	}
+	// Equal masses meet in the middle
+	if a, b := world.GetBody(1).Position.X, world.GetBody(2).Position.X; math.Abs(a+b-5) > 1e-6 {
+		t.Errorf("bodies at %v and %v, want centered on 2.5", a, b)
+	}
+}
+
+func TestSpringJointSettlesAtRestLength(t *testing.T) {
+	world := NewWorld()
+	world.SetGravity(Vector2{})
+	world.AddBody(NewRigidBody(1, Vector2{0, 0}, 0.1, 0.1, 0))
+	world.AddBody(NewRigidBody(2, Vector2{4, 0}, 0.1, 0.1, 1))
+	world.AddJoint(NewSpringJoint(1, 2, 2, 50, 5))
+
+	// Stretched, the spring first pulls the body in
+	world.Update(1.0 / 60.0)
+	if velocity := world.GetBody(2).Velocity.X; velocity >= 0 {
+		t.Errorf("stretched spring gave velocity %v, want negative", velocity)
+	}
+
+	for i := 0; i < 600; i++ {
+		world.Update(1.0 / 60.0)
+	}
+	if distance := separation(world, 1, 2); math.Abs(distance-2) > 0.01 {
+		t.Errorf("separation after damping = %v, want the rest length 2", distance)
+	}
+}
+
+func TestRemoveJoint(t *testing.T) {
+	world := NewWorld()
+	world.SetGravity(Vector2{})
+	world.AddBody(NewRigidBody(1, Vector2{0, 0}, 0.1, 0.1, 1))
+	world.AddBody(NewRigidBody(2, Vector2{5, 0}, 0.1, 0.1, 1))
+	joint := NewDistanceJoint(1, 2, 3)
+	world.AddJoint(joint)
+	world.RemoveJoint(joint)
+
+	world.Update(1.0 / 60.0)
+	if len(world.GetJoints()) != 0 || separation(world, 1, 2) != 5 {
+		t.Error("removed joint still acts on its bodies")
+	}
+}
@@ -0,0 +1,113 @@
+package physics
+
+import (
+	"encoding/json"
+	"io"
+	"math"
+	"sort"
+)
+
+// worldState is the serialized form of a physics world
+type worldState struct {
+	Gravity  Vector2      `json:"gravity"`
+	TimeStep float64      `json:"timeStep"`
+	Bodies   []*RigidBody `json:"bodies"`
+
+	// Solver settings
+	SolverIterations int     `json:"solverIterations"`
+	Friction         float64 `json:"friction"`
+	WarmStarting     bool    `json:"warmStarting"`
+}
+
+// UnmarshalJSON decodes a body, defaulting GravityScale to 1 for saves made
+// before the field existed
+func (b *RigidBody) UnmarshalJSON(data []byte) error {
+	type plainBody RigidBody
+	body := plainBody{GravityScale: 1}
+	if err := json.Unmarshal(data, &body); err != nil {
+		return err
+	}
+
+	*b = RigidBody(body)
+	return nil
+}
+
+// Save writes the world's settings, including the solver's, and bodies as JSON
+func (w *World) Save(writer io.Writer) error {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	state := worldState{
+		Gravity:  w.gravity,
+		TimeStep: w.timeStep,
+		Bodies:   make([]*RigidBody, 0, len(w.bodies)),
+
+		SolverIterations: w.solverIterations,
+		Friction:         w.friction,
+		WarmStarting:     w.warmStarting,
+	}
+	for _, body := range w.bodies {
+		state.Bodies = append(state.Bodies, body)
+	}
+
+	// Save in ID order so identical worlds produce identical output
+	sort.Slice(state.Bodies, func(i, j int) bool {
+		return state.Bodies[i].ID < state.Bodies[j].ID
+	})
+
+	return json.NewEncoder(writer).Encode(state)
+}
+
+// Load replaces the world's settings and bodies with ones read by Save.
+// Collision listeners and joints are kept and contact tracking starts fresh.
+// Saves made before the solver settings were saved get the default ones.
+func (w *World) Load(reader io.Reader) error {
+	state, err := DecodeWorldState(reader)
+	if err != nil {
+		return err
+	}
+
+	w.ApplyState(state)
+	return nil
+}
+
+// WorldState is a stream written by Save, decoded and ready to apply. Its
+// bodies are used as they are, so apply it to one world only once.
+type WorldState struct {
+	state worldState
+}
+
+// DecodeWorldState reads a stream written by Save without touching any world
+func DecodeWorldState(reader io.Reader) (*WorldState, error) {
+	state := worldState{
+		SolverIterations: DefaultSolverIterations,
+		Friction:         DefaultFriction,
+		WarmStarting:     true,
+	}
+	if err := json.NewDecoder(reader).Decode(&state); err != nil {
+		return nil, err
+	}
+	return &WorldState{state: state}, nil
+}
+
+// ApplyState replaces the world's settings and bodies with a decoded state,
+// keeping listeners and joints like Load
+func (w *World) ApplyState(decoded *WorldState) {
+	state := decoded.state
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.gravity = state.Gravity
+	w.timeStep = state.TimeStep
+	w.solverIterations = max(state.SolverIterations, 1)
+	w.friction = math.Max(state.Friction, 0)
+	w.warmStarting = state.WarmStarting
+	w.bodies = make(map[uint64]*RigidBody, len(state.Bodies))
+	for _, body := range state.Bodies {
+		w.bodies[body.ID] = body
+	}
+	w.contacts = make(map[pairKey]bool)
+	w.impulses = make(map[pairKey]contactImpulse)
+	w.lastResult = StepResult{}
+}
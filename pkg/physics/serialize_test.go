@@ -0,0 +1,45 @@
+package physics
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSaveKeepsSolverSettings(t *testing.T) {
+	world := NewWorld()
+	world.SetSolverIterations(3)
+	world.SetFriction(0.9)
+	world.SetWarmStarting(false)
+	world.AddBody(NewRigidBody(1, Vector2{0, 10}, 1, 1, 1))
+
+	var saved bytes.Buffer
+	if err := world.Save(&saved); err != nil {
+		t.Fatalf("Save: %v", err)
+	}
+	loaded := NewWorld()
+	if err := loaded.Load(&saved); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if loaded.solverIterations != 3 || loaded.friction != 0.9 || loaded.IsWarmStarting() {
+		t.Errorf("loaded solver settings = %d iterations, friction %v, warm-starting %v; want 3, 0.9, false",
+			loaded.solverIterations, loaded.friction, loaded.IsWarmStarting())
+	}
+}
+
+func TestLoadOldSaveUsesDefaultSolverSettings(t *testing.T) {
+	world := NewWorld()
+	world.SetSolverIterations(3)
+	world.SetWarmStarting(false)
+
+	old := `{"gravity":{"X":0,"Y":-9.81},"timeStep":0.016666666666666666,"bodies":[]}`
+	if err := world.Load(strings.NewReader(old)); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if world.solverIterations != DefaultSolverIterations || world.friction != DefaultFriction || !world.IsWarmStarting() {
+		t.Errorf("solver settings = %d iterations, friction %v, warm-starting %v; want the defaults",
+			world.solverIterations, world.friction, world.IsWarmStarting())
+	}
+}
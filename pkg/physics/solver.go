@@ -0,0 +1,155 @@
+package physics
+
+import (
+	"math"
+)
+
+const (
+	// DefaultSolverIterations is the number of velocity passes over all contacts per step
+	DefaultSolverIterations = 8
+	// DefaultFriction is the Coulomb friction coefficient between touching bodies
+	DefaultFriction = 0.4
+
+	// baumgarte is the fraction of penetration corrected per step
+	baumgarte = 0.2
+	// penetrationSlop is the overlap allowed before correction kicks in, to keep resting contacts stable
+	penetrationSlop = 0.01
+)
+
+// contactImpulse holds the impulses accumulated on a contact during a step
+type contactImpulse struct {
+	Normal  float64
+	Tangent float64
+}
+
+// contact is a touching pair prepared for the solver
+type contact struct {
+	key          pairKey
+	bodyA, bodyB *RigidBody
+	normal       Vector2
+	depth        float64
+	mass         float64
+	impulse      contactImpulse
+}
+
+// SetWarmStarting enables seeding each step's solver with the impulses of
+// the previous step, which lets stacks settle in far fewer iterations
+func (w *World) SetWarmStarting(enabled bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.warmStarting = enabled
+	if !enabled {
+		w.impulses = make(map[pairKey]contactImpulse)
+	}
+}
+
+// IsWarmStarting returns true if warm-starting is enabled
+func (w *World) IsWarmStarting() bool {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+	return w.warmStarting
+}
+
+// SetSolverIterations sets the number of velocity passes per step
+func (w *World) SetSolverIterations(iterations int) {
+	if iterations < 1 {
+		iterations = 1
+	}
+
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.solverIterations = iterations
+}
+
+// SetFriction sets the friction coefficient used for all contacts
+func (w *World) SetFriction(friction float64) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+	w.friction = math.Max(friction, 0)
+}
+
+// newContact prepares an overlapping pair for the solver, or returns nil if
+// neither body can move
+func newContact(body1, body2 *RigidBody) *contact {
+	inverseMass := body1.InverseMass + body2.InverseMass
+	if inverseMass == 0 {
+		return nil
+	}
+
+	normal, depth := contactAxis(body1, body2)
+	return &contact{
+		key:    makePairKey(body1.ID, body2.ID),
+		bodyA:  body1,
+		bodyB:  body2,
+		normal: normal,
+		depth:  depth,
+		mass:   1 / inverseMass,
+	}
+}
+
+// solveContacts runs the sequential impulse solver over the step's contacts and
+// joints, and caches the accumulated contact impulses for the next step; the
+// caller must hold the write lock
+func (w *World) solveContacts(contacts []*contact, joints []boundJoint, deltaTime float64) {
+	if w.warmStarting {
+		for _, c := range contacts {
+			if cached, exists := w.impulses[c.key]; exists {
+				c.impulse = cached
+				c.apply(c.normal.Mul(cached.Normal).Add(c.tangent().Mul(cached.Tangent)))
+			}
+		}
+	}
+
+	for i := 0; i < w.solverIterations; i++ {
+		for _, c := range contacts {
+			c.solve(deltaTime, w.friction)
+		}
+		for _, j := range joints {
+			j.joint.Solve(j.bodyA, j.bodyB, deltaTime)
+		}
+	}
+
+	impulses := make(map[pairKey]contactImpulse, len(contacts))
+	if w.warmStarting {
+		for _, c := range contacts {
+			impulses[c.key] = c.impulse
+		}
+	}
+	w.impulses = impulses
+}
+
+// tangent returns the friction direction of the contact
+func (c *contact) tangent() Vector2 {
+	return Vector2{-c.normal.Y, c.normal.X}
+}
+
+// apply applies an impulse pushing bodyB along it and bodyA against it
+func (c *contact) apply(impulse Vector2) {
+	c.bodyA.Velocity = c.bodyA.Velocity.Sub(impulse.Mul(c.bodyA.InverseMass))
+	c.bodyB.Velocity = c.bodyB.Velocity.Add(impulse.Mul(c.bodyB.InverseMass))
+}
+
+// solve runs one velocity pass on the contact, clamping the accumulated
+// impulses rather than the per-pass ones so warm-started values can be undone
+func (c *contact) solve(deltaTime, friction float64) {
+	// Friction, bounded by the current normal impulse
+	tangent := c.tangent()
+	relativeVelocity := c.bodyB.Velocity.Sub(c.bodyA.Velocity)
+	maxFriction := friction * c.impulse.Normal
+
+	previous := c.impulse.Tangent
+	c.impulse.Tangent = math.Max(-maxFriction, math.Min(maxFriction, previous-relativeVelocity.Dot(tangent)*c.mass))
+	c.apply(tangent.Mul(c.impulse.Tangent - previous))
+
+	// Normal, pushing apart and correcting some of the penetration
+	relativeVelocity = c.bodyB.Velocity.Sub(c.bodyA.Velocity)
+	bias := 0.0
+	if deltaTime > 0 {
+		bias = baumgarte / deltaTime * math.Max(c.depth-penetrationSlop, 0)
+	}
+
+	previous = c.impulse.Normal
+	c.impulse.Normal = math.Max(previous+(bias-relativeVelocity.Dot(c.normal))*c.mass, 0)
+	c.apply(c.normal.Mul(c.impulse.Normal - previous))
+}
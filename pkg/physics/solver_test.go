@@ -0,0 +1,57 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+// stackMotion drops a stack of boxes onto the ground and returns the frame
+// after which every box stays below a small speed, and the average speed of
+// the boxes over the run
+func stackMotion(warmStarting bool, boxes, frames int) (int, float64) {
+	world := NewWorld()
+	world.SetWarmStarting(warmStarting)
+	world.SetSolverIterations(4)
+	world.AddBody(NewRigidBody(1, Vector2{0, -0.5}, 20, 1, 0))
+	for i := 0; i < boxes; i++ {
+		world.AddBody(NewRigidBody(uint64(i+2), Vector2{0, 0.5 + float64(i)}, 1, 1, 1))
+	}
+
+	settled := 0
+	totalSpeed := 0.0
+	for frame := 0; frame < frames; frame++ {
+		world.Step(1.0 / 60.0)
+
+		fastest := 0.0
+		for _, body := range world.GetBodies() {
+			speed := body.Velocity.Length()
+			fastest = math.Max(fastest, speed)
+			totalSpeed += speed
+		}
+		if fastest > 0.05 {
+			settled = frame + 1
+		}
+	}
+	return settled, totalSpeed / float64(boxes*frames)
+}
+
+func TestWarmStartingSettlesStack(t *testing.T) {
+	warmSettled, warmJitter := stackMotion(true, 6, 300)
+	coldSettled, coldJitter := stackMotion(false, 6, 300)
+	t.Logf("warm: settled after %d frames, average speed %.3g; cold: settled after %d frames, average speed %.3g",
+		warmSettled, warmJitter, coldSettled, coldJitter)
+
+	if warmSettled >= coldSettled {
+		t.Errorf("warm-started stack settled after %d frames, cold after %d; want warm faster", warmSettled, coldSettled)
+	}
+	if warmJitter >= coldJitter {
+		t.Errorf("warm-started average speed %v, cold %v; want warm lower", warmJitter, coldJitter)
+	}
+
+	// Both stacks come to rest
+	for _, warmStarting := range []bool{true, false} {
+		if settled, _ := stackMotion(warmStarting, 6, 300); settled >= 300 {
+			t.Errorf("stack with warm-starting %v never settled", warmStarting)
+		}
+	}
+}
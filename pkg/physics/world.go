@@ -2,6 +2,7 @@ package physics
 
 import (
 	"math"
+	"sort"
 	"sync"
 )
 
@@ -11,6 +12,18 @@ type World struct {
 	gravity   Vector2
 	timeStep  float64
 	mutex     sync.RWMutex
+
+	// Collision tracking
+	contacts           map[pairKey]bool
+	lastResult         StepResult
+	collisionListeners []func(collision Collision)
+
+	// Contact solver
+	solverIterations int
+	friction         float64
+	warmStarting     bool
+	impulses         map[pairKey]contactImpulse
+	joints           []Joint
 }
 
 // Vector2 represents a 2D vector
@@ -29,6 +42,14 @@ type RigidBody struct {
 	Width    float64
 	Height   float64
 	Active   bool
+	// CCD sweeps the body's motion each step so it can't tunnel through thin bodies
+	CCD bool
+	// GravityScale multiplies the world gravity for this body; 0 ignores
+	// gravity and negative values float the body upward
+	GravityScale float64
+	// Sensor bodies report contacts like any other body but are never
+	// pushed apart from, or block the sweep of, the bodies they touch
+	Sensor bool
 }
 
 // NewWorld creates a new physics world
@@ -37,6 +58,12 @@ func NewWorld() *World {
 		bodies:   make(map[uint64]*RigidBody),
 		gravity:  Vector2{0, -9.81},
 		timeStep: 1.0 / 60.0,
+		contacts: make(map[pairKey]bool),
+
+		solverIterations: DefaultSolverIterations,
+		friction:         DefaultFriction,
+		warmStarting:     true,
+		impulses:         make(map[pairKey]contactImpulse),
 	}
 }
 
@@ -64,6 +91,61 @@ func (w *World) GetBody(id uint64) *RigidBody {
 	return w.bodies[id]
 }
 
+// GetBodies returns all bodies ordered by ID
+func (w *World) GetBodies() []*RigidBody {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	bodies := make([]*RigidBody, 0, len(w.bodies))
+	for _, body := range w.bodies {
+		bodies = append(bodies, body)
+	}
+
+	sort.Slice(bodies, func(i, j int) bool {
+		return bodies[i].ID < bodies[j].ID
+	})
+	return bodies
+}
+
+// Clone returns a deep copy of the world that can be stepped independently
+func (w *World) Clone() *World {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	clone := &World{
+		bodies:   make(map[uint64]*RigidBody, len(w.bodies)),
+		gravity:  w.gravity,
+		timeStep: w.timeStep,
+		contacts: make(map[pairKey]bool, len(w.contacts)),
+
+		solverIterations: w.solverIterations,
+		friction:         w.friction,
+		warmStarting:     w.warmStarting,
+		impulses:         make(map[pairKey]contactImpulse, len(w.impulses)),
+		joints:           make([]Joint, 0, len(w.joints)),
+	}
+
+	for id, body := range w.bodies {
+		bodyCopy := *body
+		clone.bodies[id] = &bodyCopy
+	}
+
+	// Joints refer to bodies by ID, so the copies bind to the cloned bodies
+	for _, joint := range w.joints {
+		clone.joints = append(clone.joints, joint.Clone())
+	}
+
+	// Keep contact state so the clone doesn't report existing contacts as new
+	for key := range w.contacts {
+		clone.contacts[key] = true
+	}
+	for key, impulse := range w.impulses {
+		clone.impulses[key] = impulse
+	}
+
+	return clone
+}
+
 // SetGravity sets the gravity vector
 func (w *World) SetGravity(gravity Vector2) {
 	w.gravity = gravity
@@ -71,9 +153,11 @@ func (w *World) SetGravity(gravity Vector2) {
 
 // Update updates the physics simulation
 func (w *World) Update(deltaTime float64) {
-	w.mutex.Lock()
-	defer w.mutex.Unlock()
-	
+	w.Step(deltaTime)
+}
+
+// integrateVelocities applies forces to all bodies; the caller must hold the write lock
+func (w *World) integrateVelocities(deltaTime float64) {
 	// Update all bodies
 	for _, body := range w.bodies {
 		if !body.Active {
@@ -81,38 +165,69 @@ func (w *World) Update(deltaTime float64) {
 		}
 		
 		// Apply gravity
-		body.Force = body.Force.Add(w.gravity.Mul(body.Mass))
+		body.Force = body.Force.Add(w.gravity.Mul(body.Mass * body.GravityScale))
 		
 		// Update velocity
 		body.Velocity = body.Velocity.Add(body.Force.Mul(deltaTime).Mul(body.InverseMass))
 		
-		// Update position
-		body.Position = body.Position.Add(body.Velocity.Mul(deltaTime))
-		
 		// Reset force
 		body.Force = Vector2{0, 0}
 	}
-	
-	// Check collisions
-	w.checkCollisions()
 }
 
-// checkCollisions checks for collisions between all bodies
-func (w *World) checkCollisions() {
-	bodies := make([]*RigidBody, 0, len(w.bodies))
+// integratePositions moves all bodies by their solved velocities; the caller must hold the write lock
+func (w *World) integratePositions(deltaTime float64) {
 	for _, body := range w.bodies {
-		if body.Active {
-			bodies = append(bodies, body)
+		if !body.Active || body.CCD {
+			continue
 		}
+		
+		body.Position = body.Position.Add(body.Velocity.Mul(deltaTime))
 	}
-	
+
+	// Sweep CCD bodies against the others' new positions, in ID order
+	bodies := w.sortedActiveBodies()
+	for _, body := range bodies {
+		if body.CCD {
+			w.sweepBody(body, body.Position, body.Velocity.Mul(deltaTime), bodies)
+		}
+	}
+}
+
+// checkCollisions checks for collisions between all bodies and returns the
+// contacts the solver needs to resolve
+func (w *World) checkCollisions() (StepResult, []*contact) {
+	bodies := w.sortedActiveBodies()
+
+	var result StepResult
+	var solverContacts []*contact
+	contacts := make(map[pairKey]bool, len(w.contacts))
+
 	for i := 0; i < len(bodies); i++ {
 		for j := i + 1; j < len(bodies); j++ {
 			if w.checkCollision(bodies[i], bodies[j]) {
-				w.resolveCollision(bodies[i], bodies[j])
+				// Record contact data before the collision is resolved
+				collision := newCollision(bodies[i], bodies[j])
+				key := makePairKey(bodies[i].ID, bodies[j].ID)
+
+				result.Contacts = append(result.Contacts, collision)
+				if !w.contacts[key] {
+					result.Entered = append(result.Entered, collision)
+				}
+				contacts[key] = true
+
+				if bodies[i].Sensor || bodies[j].Sensor {
+					continue
+				}
+				if c := newContact(bodies[i], bodies[j]); c != nil {
+					solverContacts = append(solverContacts, c)
+				}
 			}
 		}
 	}
+
+	w.contacts = contacts
+	return result, solverContacts
 }
 
 // checkCollision checks if two bodies are colliding
@@ -131,39 +246,6 @@ func (w *World) checkCollision(body1, body2 *RigidBody) bool {
 	return !(right1 < left2 || left1 > right2 || bottom1 > top2 || top1 < bottom2)
 }
 
-// resolveCollision resolves a collision between two bodies
-func (w *World) resolveCollision(body1, body2 *RigidBody) {
-	// Simple collision resolution - just separate the bodies
-	// In a real implementation, you'd use proper impulse-based collision response
-	
-	// Calculate separation vector
-	separation := body2.Position.Sub(body1.Position)
-	distance := separation.Length()
-	
-	if distance == 0 {
-		separation = Vector2{1, 0}
-		distance = 1
-	}
-	
-	// Normalize separation vector
-	normal := separation.Div(distance)
-	
-	// Calculate overlap
-	overlap := (body1.Width + body2.Width) / 2 - distance
-	
-	if overlap > 0 {
-		// Move bodies apart
-		separationVector := normal.Mul(overlap / 2)
-		
-		if body1.InverseMass > 0 {
-			body1.Position = body1.Position.Sub(separationVector)
-		}
-		if body2.InverseMass > 0 {
-			body2.Position = body2.Position.Add(separationVector)
-		}
-	}
-}
-
 // Vector2 methods
 func (v Vector2) Add(other Vector2) Vector2 {
 	return Vector2{v.X + other.X, v.Y + other.Y}
@@ -181,6 +263,10 @@ func (v Vector2) Div(scalar float64) Vector2 {
 	return Vector2{v.X / scalar, v.Y / scalar}
 }
 
+func (v Vector2) Dot(other Vector2) float64 {
+	return v.X*other.X + v.Y*other.Y
+}
+
 func (v Vector2) Length() float64 {
 	return math.Sqrt(v.X*v.X + v.Y*v.Y)
 }
@@ -202,5 +288,35 @@ func NewRigidBody(id uint64, position Vector2, width, height, mass float64) *Rig
 		Width:       width,
 		Height:      height,
 		Active:      true,
+		GravityScale: 1,
+	}
+}
+
+// IsStatic returns true if the body has no inverse mass and can't be moved by forces
+func (b *RigidBody) IsStatic() bool {
+	return b.InverseMass == 0
+}
+
+// ApplyForce adds a force applied over the next step; forces are cleared after each step
+func (b *RigidBody) ApplyForce(force Vector2) {
+	if b.IsStatic() {
+		return
+	}
+	b.Force = b.Force.Add(force)
+}
+
+// ApplyImpulse changes the velocity immediately by impulse * InverseMass
+func (b *RigidBody) ApplyImpulse(impulse Vector2) {
+	if b.IsStatic() {
+		return
+	}
+	b.Velocity = b.Velocity.Add(impulse.Mul(b.InverseMass))
+}
+
+// SetVelocity sets the velocity of a dynamic body
+func (b *RigidBody) SetVelocity(velocity Vector2) {
+	if b.IsStatic() {
+		return
 	}
+	b.Velocity = velocity
 }
@@ -0,0 +1,253 @@
+package physics
+
+import (
+	"math"
+	"sort"
+	"sync"
+)
+
+// Vector3 represents a 3D vector
+type Vector3 struct {
+	X, Y, Z float64
+}
+
+// Vector3 methods
+func (v Vector3) Add(other Vector3) Vector3 {
+	return Vector3{v.X + other.X, v.Y + other.Y, v.Z + other.Z}
+}
+
+func (v Vector3) Sub(other Vector3) Vector3 {
+	return Vector3{v.X - other.X, v.Y - other.Y, v.Z - other.Z}
+}
+
+func (v Vector3) Mul(scalar float64) Vector3 {
+	return Vector3{v.X * scalar, v.Y * scalar, v.Z * scalar}
+}
+
+func (v Vector3) Dot(other Vector3) float64 {
+	return v.X*other.X + v.Y*other.Y + v.Z*other.Z
+}
+
+func (v Vector3) Length() float64 {
+	return math.Sqrt(v.Dot(v))
+}
+
+// RigidBody3D represents a 3D physics body with a box collider
+type RigidBody3D struct {
+	ID          uint64
+	Position    Vector3
+	Velocity    Vector3
+	Force       Vector3
+	Mass        float64
+	InverseMass float64
+	// HalfExtents is half the size of the box along each axis
+	HalfExtents  Vector3
+	Active       bool
+	GravityScale float64
+}
+
+// NewRigidBody3D creates a new 3D rigid body; a mass of 0 makes it static
+func NewRigidBody3D(id uint64, position, halfExtents Vector3, mass float64) *RigidBody3D {
+	inverseMass := 0.0
+	if mass > 0 {
+		inverseMass = 1.0 / mass
+	}
+
+	return &RigidBody3D{
+		ID:           id,
+		Position:     position,
+		Mass:         mass,
+		InverseMass:  inverseMass,
+		HalfExtents:  halfExtents,
+		Active:       true,
+		GravityScale: 1,
+	}
+}
+
+// Min returns the lowest corner of the body's box
+func (b *RigidBody3D) Min() Vector3 {
+	return b.Position.Sub(b.HalfExtents)
+}
+
+// Max returns the highest corner of the body's box
+func (b *RigidBody3D) Max() Vector3 {
+	return b.Position.Add(b.HalfExtents)
+}
+
+// Overlaps returns true if the boxes of two bodies intersect
+func (b *RigidBody3D) Overlaps(other *RigidBody3D) bool {
+	min1, max1 := b.Min(), b.Max()
+	min2, max2 := other.Min(), other.Max()
+
+	return !(max1.X < min2.X || min1.X > max2.X ||
+		max1.Y < min2.Y || min1.Y > max2.Y ||
+		max1.Z < min2.Z || min1.Z > max2.Z)
+}
+
+// ApplyForce adds a force applied over the next step; forces are cleared after each step
+func (b *RigidBody3D) ApplyForce(force Vector3) {
+	if b.InverseMass == 0 {
+		return
+	}
+	b.Force = b.Force.Add(force)
+}
+
+// ApplyImpulse changes the velocity immediately by impulse * InverseMass
+func (b *RigidBody3D) ApplyImpulse(impulse Vector3) {
+	if b.InverseMass == 0 {
+		return
+	}
+	b.Velocity = b.Velocity.Add(impulse.Mul(b.InverseMass))
+}
+
+// Collision3D describes two 3D bodies touching after a step
+type Collision3D struct {
+	BodyA uint64
+	BodyB uint64
+	// Normal points from BodyA towards BodyB along the axis of least penetration
+	Normal Vector3
+	Depth  float64
+}
+
+// World3D is a 3D physics world of axis-aligned boxes. It is separate from
+// the 2D World; both can run side by side.
+type World3D struct {
+	bodies  map[uint64]*RigidBody3D
+	gravity Vector3
+	mutex   sync.RWMutex
+}
+
+// NewWorld3D creates a new 3D physics world with gravity along -Y
+func NewWorld3D() *World3D {
+	return &World3D{
+		bodies:  make(map[uint64]*RigidBody3D),
+		gravity: Vector3{0, -9.81, 0},
+	}
+}
+
+// AddBody adds a rigid body to the world
+func (w *World3D) AddBody(body *RigidBody3D) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.bodies[body.ID] = body
+}
+
+// RemoveBody removes a rigid body from the world
+func (w *World3D) RemoveBody(id uint64) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	delete(w.bodies, id)
+}
+
+// GetBody returns a rigid body by ID
+func (w *World3D) GetBody(id uint64) *RigidBody3D {
+	w.mutex.RLock()
+	defer w.mutex.RUnlock()
+
+	return w.bodies[id]
+}
+
+// SetGravity sets the gravity vector
+func (w *World3D) SetGravity(gravity Vector3) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.gravity = gravity
+}
+
+// Update updates the physics simulation
+func (w *World3D) Update(deltaTime float64) {
+	w.Step(deltaTime)
+}
+
+// Step integrates all bodies, then separates overlapping pairs and returns them
+func (w *World3D) Step(deltaTime float64) []Collision3D {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	bodies := make([]*RigidBody3D, 0, len(w.bodies))
+	for _, body := range w.bodies {
+		if body.Active {
+			bodies = append(bodies, body)
+		}
+	}
+	sort.Slice(bodies, func(i, j int) bool {
+		return bodies[i].ID < bodies[j].ID
+	})
+
+	for _, body := range bodies {
+		if body.InverseMass == 0 {
+			continue
+		}
+
+		force := body.Force.Add(w.gravity.Mul(body.Mass * body.GravityScale))
+		body.Velocity = body.Velocity.Add(force.Mul(deltaTime * body.InverseMass))
+		body.Position = body.Position.Add(body.Velocity.Mul(deltaTime))
+		body.Force = Vector3{}
+	}
+
+	var collisions []Collision3D
+	for i := 0; i < len(bodies); i++ {
+		for j := i + 1; j < len(bodies); j++ {
+			if !bodies[i].Overlaps(bodies[j]) {
+				continue
+			}
+
+			collision := newCollision3D(bodies[i], bodies[j])
+			resolveCollision3D(bodies[i], bodies[j], collision)
+			collisions = append(collisions, collision)
+		}
+	}
+	return collisions
+}
+
+// newCollision3D finds the axis of least penetration between two overlapping boxes
+func newCollision3D(body1, body2 *RigidBody3D) Collision3D {
+	separation := body2.Position.Sub(body1.Position)
+	extents := body1.HalfExtents.Add(body2.HalfExtents)
+	overlaps := [3]float64{
+		extents.X - math.Abs(separation.X),
+		extents.Y - math.Abs(separation.Y),
+		extents.Z - math.Abs(separation.Z),
+	}
+	components := [3]float64{separation.X, separation.Y, separation.Z}
+
+	axis := 0
+	for i := 1; i < 3; i++ {
+		if overlaps[i] < overlaps[axis] {
+			axis = i
+		}
+	}
+
+	var normal [3]float64
+	normal[axis] = math.Copysign(1, components[axis])
+	return Collision3D{
+		BodyA:  body1.ID,
+		BodyB:  body2.ID,
+		Normal: Vector3{normal[0], normal[1], normal[2]},
+		Depth:  overlaps[axis],
+	}
+}
+
+// resolveCollision3D pushes two bodies apart in proportion to their inverse
+// masses and removes their closing velocity along the normal
+func resolveCollision3D(body1, body2 *RigidBody3D, collision Collision3D) {
+	inverseMass := body1.InverseMass + body2.InverseMass
+	if inverseMass == 0 {
+		return
+	}
+
+	correction := collision.Normal.Mul(collision.Depth / inverseMass)
+	body1.Position = body1.Position.Sub(correction.Mul(body1.InverseMass))
+	body2.Position = body2.Position.Add(correction.Mul(body2.InverseMass))
+
+	closing := body1.Velocity.Sub(body2.Velocity).Dot(collision.Normal)
+	if closing <= 0 {
+		return
+	}
+	impulse := collision.Normal.Mul(closing / inverseMass)
+	body1.Velocity = body1.Velocity.Sub(impulse.Mul(body1.InverseMass))
+	body2.Velocity = body2.Velocity.Add(impulse.Mul(body2.InverseMass))
+}
@@ -0,0 +1,78 @@
+package physics
+
+import (
+	"math"
+	"testing"
+)
+
+func TestOverlaps3D(t *testing.T) {
+	unit := Vector3{0.5, 0.5, 0.5}
+	box := NewRigidBody3D(1, Vector3{}, unit, 1)
+
+	tests := []struct {
+		name     string
+		position Vector3
+		want     bool
+	}{
+		{"overlapping", Vector3{0.5, 0.5, 0.5}, true},
+		{"touching faces", Vector3{1, 0, 0}, true},
+		{"apart along X", Vector3{1.5, 0, 0}, false},
+		{"apart along Y", Vector3{0, -2, 0}, false},
+		// Overlapping in X and Y but not Z, which a 2D test would miss
+		{"apart along Z only", Vector3{0.2, 0.2, 3}, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			other := NewRigidBody3D(2, test.position, unit, 1)
+			if got := box.Overlaps(other); got != test.want {
+				t.Errorf("Overlaps() = %v, want %v", got, test.want)
+			}
+			if got := other.Overlaps(box); got != test.want {
+				t.Errorf("reversed Overlaps() = %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGravityIntegration3D(t *testing.T) {
+	world := NewWorld3D()
+	body := NewRigidBody3D(1, Vector3{1, 10, 2}, Vector3{0.5, 0.5, 0.5}, 2)
+	world.AddBody(body)
+
+	const step = 1.0 / 60.0
+	for i := 0; i < 60; i++ {
+		world.Update(step)
+	}
+
+	// Semi-implicit Euler: after n steps the body fell g*dt^2*n(n+1)/2
+	wantY := 10 - 9.81*step*step*60*61/2
+	if math.Abs(body.Position.Y-wantY) > 1e-9 {
+		t.Errorf("y after a second = %v, want %v", body.Position.Y, wantY)
+	}
+	if math.Abs(body.Velocity.Y+9.81) > 1e-9 {
+		t.Errorf("vertical velocity = %v, want -9.81", body.Velocity.Y)
+	}
+	if body.Position.X != 1 || body.Position.Z != 2 {
+		t.Errorf("gravity moved the body sideways to %v", body.Position)
+	}
+}
+
+func TestBoxRestsOnGround3D(t *testing.T) {
+	world := NewWorld3D()
+	world.AddBody(NewRigidBody3D(1, Vector3{0, -0.5, 0}, Vector3{10, 0.5, 10}, 0))
+	box := NewRigidBody3D(2, Vector3{0, 3, 0}, Vector3{0.5, 0.5, 0.5}, 1)
+	world.AddBody(box)
+
+	var collisions []Collision3D
+	for i := 0; i < 120; i++ {
+		collisions = world.Step(1.0 / 60.0)
+	}
+
+	if math.Abs(box.Position.Y-0.5) > 0.01 {
+		t.Errorf("box resting at y = %v, want 0.5 on the ground", box.Position.Y)
+	}
+	if len(collisions) != 1 || collisions[0].Normal != (Vector3{0, 1, 0}) {
+		t.Errorf("collisions = %+v, want one with normal (0, 1, 0)", collisions)
+	}
+}
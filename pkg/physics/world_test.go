@@ -0,0 +1,41 @@
+package physics
+
+import (
+	"testing"
+)
+
+func TestCloneStepsIndependently(t *testing.T) {
+	world := NewWorld()
+	world.AddBody(NewRigidBody(1, Vector2{0, 10}, 1, 1, 1))
+	falling := NewRigidBody(2, Vector2{5, 10}, 1, 1, 1)
+	falling.Velocity = Vector2{1, 0}
+	world.AddBody(falling)
+	world.AddJoint(NewDistanceJoint(1, 2, 5))
+
+	clone := world.Clone()
+	for i := 0; i < 30; i++ {
+		clone.Update(1.0 / 60.0)
+	}
+
+	for _, body := range world.GetBodies() {
+		cloned := clone.GetBody(body.ID)
+		if cloned == body {
+			t.Fatalf("body %d is shared with the clone", body.ID)
+		}
+		if cloned.Position.Y >= body.Position.Y {
+			t.Errorf("cloned body %d didn't fall: y = %v, original y = %v", body.ID, cloned.Position.Y, body.Position.Y)
+		}
+	}
+	if got := world.GetBody(1).Position; got != (Vector2{0, 10}) {
+		t.Errorf("original body 1 moved to %v", got)
+	}
+	if got := world.GetBody(2); got.Position != (Vector2{5, 10}) || got.Velocity != (Vector2{1, 0}) {
+		t.Errorf("original body 2 changed to position %v, velocity %v", got.Position, got.Velocity)
+	}
+
+	// Changing the clone's joint leaves the original's alone
+	clone.GetJoints()[0].(*DistanceJoint).Length = 1
+	if got := world.GetJoints()[0].(*DistanceJoint).Length; got != 5 {
+		t.Errorf("original joint length = %v after changing the clone's, want 5", got)
+	}
+}
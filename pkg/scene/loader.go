@@ -0,0 +1,132 @@
+package scene
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+	"github.com/go-gl/mathgl/mgl32"
+)
+
+// Scene represents a scene description loaded from JSON
+type Scene struct {
+	Entities []EntityData `json:"entities"`
+}
+
+// EntityData describes a single entity and its components
+type EntityData struct {
+	Tags      []string       `json:"tags,omitempty"`
+	Transform *TransformData `json:"transform,omitempty"`
+	Mesh      *MeshData      `json:"mesh,omitempty"`
+	Physics   *PhysicsData   `json:"physics,omitempty"`
+	Audio     *AudioData     `json:"audio,omitempty"`
+}
+
+// TransformData describes a transform component
+type TransformData struct {
+	Position [3]float32 `json:"position"`
+	Rotation [3]float32 `json:"rotation"`
+	Scale    [3]float32 `json:"scale"`
+}
+
+// MeshData describes a mesh component
+type MeshData struct {
+	MeshID  string `json:"id"`
+	Visible *bool  `json:"visible,omitempty"`
+}
+
+// PhysicsData describes a physics component
+type PhysicsData struct {
+	BodyID uint64  `json:"body"`
+	Mass   float64 `json:"mass"`
+}
+
+// AudioData describes an audio component
+type AudioData struct {
+	SoundID string  `json:"sound"`
+	Volume  float64 `json:"volume"`
+	Loop    bool    `json:"loop"`
+}
+
+// Parse parses a scene from a JSON source
+func Parse(reader io.Reader) (*Scene, error) {
+	var scene Scene
+	decoder := json.NewDecoder(reader)
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&scene); err != nil {
+		return nil, err
+	}
+	return &scene, nil
+}
+
+// LoadFile loads a scene from a JSON file
+func LoadFile(filepath string) (*Scene, error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return Parse(file)
+}
+
+// Instantiate creates the scene's entities in the world and returns their IDs
+func (s *Scene) Instantiate(world *ecs.World) []ecs.EntityID {
+	entities := make([]ecs.EntityID, 0, len(s.Entities))
+	for _, data := range s.Entities {
+		entities = append(entities, data.Instantiate(world))
+	}
+	return entities
+}
+
+// Instantiate creates a single entity in the world
+func (d *EntityData) Instantiate(world *ecs.World) ecs.EntityID {
+	entityID := world.CreateEntity()
+
+	if len(d.Tags) > 0 {
+		world.AddComponent(entityID, ecs.NewTagComponent(d.Tags...))
+	}
+
+	if d.Transform != nil {
+		// An omitted scale means unit scale rather than a collapsed entity
+		scale := mgl32.Vec3(d.Transform.Scale)
+		if scale == (mgl32.Vec3{}) {
+			scale = mgl32.Vec3{1, 1, 1}
+		}
+
+		world.AddComponent(entityID, ecs.NewTransformComponent(
+			mgl32.Vec3(d.Transform.Position),
+			mgl32.Vec3(d.Transform.Rotation),
+			scale,
+		))
+	}
+
+	if d.Mesh != nil {
+		mesh := ecs.NewMeshComponent(d.Mesh.MeshID)
+		if d.Mesh.Visible != nil {
+			mesh.Visible = *d.Mesh.Visible
+		}
+		world.AddComponent(entityID, mesh)
+	}
+
+	if d.Physics != nil {
+		world.AddComponent(entityID, ecs.NewPhysicsComponent(d.Physics.BodyID, d.Physics.Mass))
+	}
+
+	if d.Audio != nil {
+		world.AddComponent(entityID, ecs.NewAudioComponent(d.Audio.SoundID, d.Audio.Volume, d.Audio.Loop))
+	}
+
+	return entityID
+}
+
+// hasAnyTag returns true if the entity data has one of the given tags
+func (d *EntityData) hasAnyTag(tags map[string]bool) bool {
+	for _, tag := range d.Tags {
+		if tags[tag] {
+			return true
+		}
+	}
+	return false
+}
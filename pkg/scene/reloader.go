@@ -0,0 +1,165 @@
+package scene
+
+import (
+	"log"
+	"os"
+	"time"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+)
+
+// DefaultPollInterval is how often the reloader checks the scene file for changes
+const DefaultPollInterval = 0.5
+
+// Reloader watches a scene file and reloads its entities when the file changes.
+// It implements ecs.System so it can be added to the world it manages.
+type Reloader struct {
+	path         string
+	pollInterval float64
+	elapsed      float64
+	modTime      time.Time
+	size         int64
+	preserved    map[string]bool
+	entities     []ecs.EntityID
+	onReload     func(entities []ecs.EntityID)
+}
+
+// NewReloader creates a reloader for the scene file at path
+func NewReloader(path string) *Reloader {
+	return &Reloader{
+		path:         path,
+		pollInterval: DefaultPollInterval,
+		preserved:    make(map[string]bool),
+	}
+}
+
+// Load loads the scene file into the world for the first time
+func (r *Reloader) Load(world *ecs.World) error {
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return err
+	}
+
+	scene, err := LoadFile(r.path)
+	if err != nil {
+		return err
+	}
+
+	r.modTime = info.ModTime()
+	r.size = info.Size()
+	r.entities = scene.Instantiate(world)
+	return nil
+}
+
+// Preserve marks entities with any of the given tags to survive reloads
+func (r *Reloader) Preserve(tags ...string) {
+	for _, tag := range tags {
+		r.preserved[tag] = true
+	}
+}
+
+// SetPollInterval sets how often, in seconds, the file is checked for changes
+func (r *Reloader) SetPollInterval(interval float64) {
+	r.pollInterval = interval
+}
+
+// SetOnReload sets a callback invoked with the entities created by each reload
+func (r *Reloader) SetOnReload(callback func(entities []ecs.EntityID)) {
+	r.onReload = callback
+}
+
+// GetEntities returns the entities currently owned by the reloader
+func (r *Reloader) GetEntities() []ecs.EntityID {
+	entities := make([]ecs.EntityID, len(r.entities))
+	copy(entities, r.entities)
+	return entities
+}
+
+// Update polls the scene file and reloads it when it has changed
+func (r *Reloader) Update(deltaTime float64, world *ecs.World) {
+	r.elapsed += deltaTime
+	if r.elapsed < r.pollInterval {
+		return
+	}
+	r.elapsed = 0
+
+	if _, err := r.CheckReload(world); err != nil {
+		log.Printf("Scene reload of %s failed: %v", r.path, err)
+	}
+}
+
+// GetName returns the system name
+func (r *Reloader) GetName() string {
+	return "SceneReloader"
+}
+
+// CheckReload reloads the scene if the file changed since the last load.
+// On a parse error the current world is kept untouched.
+func (r *Reloader) CheckReload(world *ecs.World) (bool, error) {
+	info, err := os.Stat(r.path)
+	if err != nil {
+		return false, err
+	}
+
+	if info.ModTime().Equal(r.modTime) && info.Size() == r.size {
+		return false, nil
+	}
+
+	// Remember the file state even on failure so a broken file isn't reparsed every poll
+	r.modTime = info.ModTime()
+	r.size = info.Size()
+
+	scene, err := LoadFile(r.path)
+	if err != nil {
+		return false, err
+	}
+
+	r.reload(scene, world)
+	return true, nil
+}
+
+// reload replaces the owned entities with the scene's, keeping preserved ones
+func (r *Reloader) reload(scene *Scene, world *ecs.World) {
+	kept := make([]ecs.EntityID, 0)
+	for _, entityID := range r.entities {
+		if r.isPreserved(entityID, world) {
+			kept = append(kept, entityID)
+			continue
+		}
+		world.DestroyEntity(entityID)
+	}
+
+	created := make([]ecs.EntityID, 0, len(scene.Entities))
+	for i := range scene.Entities {
+		data := &scene.Entities[i]
+
+		// Preserved entities already exist in the world
+		if data.hasAnyTag(r.preserved) {
+			continue
+		}
+		created = append(created, data.Instantiate(world))
+	}
+
+	r.entities = append(kept, created...)
+
+	log.Printf("Reloaded scene %s (%d entities)", r.path, len(created))
+	if r.onReload != nil {
+		r.onReload(created)
+	}
+}
+
+// isPreserved returns true if the entity has a preserved tag
+func (r *Reloader) isPreserved(entityID ecs.EntityID, world *ecs.World) bool {
+	component := world.GetComponent(entityID, "tag")
+	if component == nil {
+		return false
+	}
+
+	tag := component.(*ecs.TagComponent)
+	for _, t := range tag.Tags {
+		if r.preserved[t] {
+			return true
+		}
+	}
+	return false
+}
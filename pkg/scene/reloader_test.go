@@ -0,0 +1,136 @@
+package scene
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+)
+
+// writeScene writes a scene file and moves its modification time forward so
+// every write is seen as a change
+func writeScene(t *testing.T, path, source string, modTime time.Time) {
+	t.Helper()
+
+	if err := os.WriteFile(path, []byte(source), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+	if err := os.Chtimes(path, modTime, modTime); err != nil {
+		t.Fatalf("Chtimes: %v", err)
+	}
+}
+
+// entitiesWithMesh returns the IDs of the mesh components in the world, by mesh ID
+func entitiesWithMesh(world *ecs.World) map[string]ecs.EntityID {
+	meshes := make(map[string]ecs.EntityID)
+	for _, entityID := range world.GetEntitiesWithComponent("mesh") {
+		meshes[world.GetComponent(entityID, "mesh").(*ecs.MeshComponent).MeshID] = entityID
+	}
+	return meshes
+}
+
+func TestReloaderReloadsChangedFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scene.json")
+	start := time.Now().Add(-time.Hour)
+	writeScene(t, path, `{"entities": [
+		{"tags": ["player"], "mesh": {"id": "player"}},
+		{"mesh": {"id": "rock"}}
+	]}`, start)
+
+	world := ecs.NewWorld()
+	reloader := NewReloader(path)
+	reloader.Preserve("player")
+	if err := reloader.Load(world); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	player := entitiesWithMesh(world)["player"]
+
+	reloaded, err := reloader.CheckReload(world)
+	if err != nil || reloaded {
+		t.Fatalf("CheckReload on an unchanged file = %v, %v; want false, nil", reloaded, err)
+	}
+
+	var created []ecs.EntityID
+	reloader.SetOnReload(func(entities []ecs.EntityID) {
+		created = entities
+	})
+	writeScene(t, path, `{"entities": [
+		{"tags": ["player"], "mesh": {"id": "player"}},
+		{"mesh": {"id": "tree"}},
+		{"mesh": {"id": "bush"}}
+	]}`, start.Add(time.Minute))
+
+	reloaded, err = reloader.CheckReload(world)
+	if err != nil || !reloaded {
+		t.Fatalf("CheckReload after a change = %v, %v; want true, nil", reloaded, err)
+	}
+
+	meshes := entitiesWithMesh(world)
+	if meshes["player"] != player {
+		t.Errorf("preserved player is entity %d, want the original %d", meshes["player"], player)
+	}
+	if _, exists := meshes["rock"]; exists {
+		t.Error("entity removed from the scene is still in the world")
+	}
+	for _, id := range []string{"tree", "bush"} {
+		if _, exists := meshes[id]; !exists {
+			t.Errorf("new entity %q is missing", id)
+		}
+	}
+	if len(meshes) != 3 || world.GetEntityCount() != 3 {
+		t.Errorf("world has %d entities, want 3", world.GetEntityCount())
+	}
+	if len(created) != 2 {
+		t.Errorf("OnReload got %d entities, want 2", len(created))
+	}
+	if got := len(reloader.GetEntities()); got != 3 {
+		t.Errorf("GetEntities() has %d entities, want 3", got)
+	}
+}
+
+func TestReloaderKeepsWorldOnParseError(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scene.json")
+	start := time.Now().Add(-time.Hour)
+	writeScene(t, path, `{"entities": [{"mesh": {"id": "rock"}}]}`, start)
+
+	world := ecs.NewWorld()
+	reloader := NewReloader(path)
+	if err := reloader.Load(world); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	writeScene(t, path, `{"entities": [`, start.Add(time.Minute))
+	if reloaded, err := reloader.CheckReload(world); err == nil || reloaded {
+		t.Fatalf("CheckReload of a broken file = %v, %v; want false and an error", reloaded, err)
+	}
+	if _, exists := entitiesWithMesh(world)["rock"]; !exists || world.GetEntityCount() != 1 {
+		t.Error("a failed reload changed the world")
+	}
+}
+
+func TestReloaderPollsOnInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "scene.json")
+	start := time.Now().Add(-time.Hour)
+	writeScene(t, path, `{"entities": [{"mesh": {"id": "rock"}}]}`, start)
+
+	world := ecs.NewWorld()
+	reloader := NewReloader(path)
+	reloader.SetPollInterval(1)
+	if err := reloader.Load(world); err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	writeScene(t, path, `{"entities": [{"mesh": {"id": "tree"}}]}`, start.Add(time.Minute))
+
+	reloader.Update(0.5, world)
+	if _, exists := entitiesWithMesh(world)["rock"]; !exists {
+		t.Fatal("reloaded before the poll interval elapsed")
+	}
+
+	reloader.Update(0.5, world)
+	if _, exists := entitiesWithMesh(world)["tree"]; !exists {
+		t.Fatal("didn't reload once the poll interval elapsed")
+	}
+}
@@ -0,0 +1,100 @@
+package tween
+
+import (
+	"math"
+)
+
+// EaseFunc maps linear progress t in [0, 1] to eased progress, with
+// f(0) = 0 and f(1) = 1
+type EaseFunc func(t float64) float64
+
+// Linear moves at a constant rate
+func Linear(t float64) float64 {
+	return t
+}
+
+// QuadIn starts slow and accelerates
+func QuadIn(t float64) float64 {
+	return t * t
+}
+
+// QuadOut starts fast and decelerates
+func QuadOut(t float64) float64 {
+	return t * (2 - t)
+}
+
+// QuadInOut accelerates to the midpoint, then decelerates
+func QuadInOut(t float64) float64 {
+	if t < 0.5 {
+		return 2 * t * t
+	}
+	return -1 + (4-2*t)*t
+}
+
+// CubicIn starts slower than QuadIn and accelerates
+func CubicIn(t float64) float64 {
+	return t * t * t
+}
+
+// CubicOut starts faster than QuadOut and decelerates
+func CubicOut(t float64) float64 {
+	t--
+	return t*t*t + 1
+}
+
+// CubicInOut is the cubic version of QuadInOut
+func CubicInOut(t float64) float64 {
+	if t < 0.5 {
+		return 4 * t * t * t
+	}
+	t = 2*t - 2
+	return t*t*t/2 + 1
+}
+
+// SineIn eases in along a quarter sine wave
+func SineIn(t float64) float64 {
+	return 1 - math.Cos(t*math.Pi/2)
+}
+
+// SineOut eases out along a quarter sine wave
+func SineOut(t float64) float64 {
+	return math.Sin(t * math.Pi / 2)
+}
+
+// SineInOut eases in and out along half a sine wave
+func SineInOut(t float64) float64 {
+	return (1 - math.Cos(t*math.Pi)) / 2
+}
+
+// BounceOut hits the end and bounces off it with decreasing height
+func BounceOut(t float64) float64 {
+	const n = 7.5625
+	const d = 2.75
+
+	switch {
+	case t < 1/d:
+		return n * t * t
+	case t < 2/d:
+		t -= 1.5 / d
+		return n*t*t + 0.75
+	case t < 2.5/d:
+		t -= 2.25 / d
+		return n*t*t + 0.9375
+	default:
+		t -= 2.625 / d
+		return n*t*t + 0.984375
+	}
+}
+
+// BounceIn bounces off the start before moving to the end
+func BounceIn(t float64) float64 {
+	return 1 - BounceOut(1-t)
+}
+
+// BounceInOut bounces in for the first half and out for the second
+func BounceInOut(t float64) float64 {
+	if t < 0.5 {
+		return BounceIn(2*t) / 2
+	}
+	return (1 + BounceOut(2*t-1)) / 2
+}
@@ -0,0 +1,48 @@
+package tween
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEaseFuncs(t *testing.T) {
+	tests := []struct {
+		name string
+		ease EaseFunc
+		half float64
+	}{
+		{"Linear", Linear, 0.5},
+		{"QuadIn", QuadIn, 0.25},
+		{"QuadOut", QuadOut, 0.75},
+		{"QuadInOut", QuadInOut, 0.5},
+		{"CubicIn", CubicIn, 0.125},
+		{"CubicOut", CubicOut, 0.875},
+		{"CubicInOut", CubicInOut, 0.5},
+		{"SineIn", SineIn, 1 - math.Sqrt2/2},
+		{"SineOut", SineOut, math.Sqrt2 / 2},
+		{"SineInOut", SineInOut, 0.5},
+		{"BounceIn", BounceIn, 0.234375},
+		{"BounceOut", BounceOut, 0.765625},
+		{"BounceInOut", BounceInOut, 0.5},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			for _, sample := range []struct{ t, want float64 }{{0, 0}, {0.5, test.half}, {1, 1}} {
+				if got := test.ease(sample.t); math.Abs(got-sample.want) > 1e-9 {
+					t.Errorf("%s(%v) = %v, want %v", test.name, sample.t, got, sample.want)
+				}
+			}
+		})
+	}
+}
+
+func TestInOutEasesAreSymmetric(t *testing.T) {
+	for name, ease := range map[string]EaseFunc{"QuadInOut": QuadInOut, "CubicInOut": CubicInOut, "SineInOut": SineInOut, "BounceInOut": BounceInOut} {
+		for _, x := range []float64{0.1, 0.25, 0.4} {
+			if got, want := ease(1-x), 1-ease(x); math.Abs(got-want) > 1e-9 {
+				t.Errorf("%s(%v) = %v, want %v", name, 1-x, got, want)
+			}
+		}
+	}
+}
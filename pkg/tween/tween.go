@@ -0,0 +1,131 @@
+package tween
+
+import (
+	"time"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+)
+
+// Animation interpolates a value from From to To over Duration, shaped by Ease
+type Animation struct {
+	From     float64
+	To       float64
+	Duration time.Duration
+	// Ease shapes the progress; nil is linear. It is not saved, so a loaded
+	// animation plays linearly until Ease is set again.
+	Ease EaseFunc `json:"-"`
+
+	// Elapsed is the time played so far
+	Elapsed time.Duration
+}
+
+// Tween creates an animation from one value to another
+func Tween(from, to float64, duration time.Duration, ease EaseFunc) *Animation {
+	return &Animation{
+		From:     from,
+		To:       to,
+		Duration: duration,
+		Ease:     ease,
+	}
+}
+
+// Update advances the animation by deltaTime seconds and returns the new value
+func (a *Animation) Update(deltaTime float64) float64 {
+	a.Elapsed += time.Duration(deltaTime * float64(time.Second))
+	if a.Elapsed > a.Duration {
+		a.Elapsed = a.Duration
+	}
+	return a.Value()
+}
+
+// Progress returns the linear progress from 0 to 1
+func (a *Animation) Progress() float64 {
+	if a.Duration <= 0 {
+		return 1
+	}
+	return float64(a.Elapsed) / float64(a.Duration)
+}
+
+// Value returns the eased value at the current time
+func (a *Animation) Value() float64 {
+	t := a.Progress()
+	if a.Ease != nil {
+		t = a.Ease(t)
+	}
+	return a.From + (a.To-a.From)*t
+}
+
+// Done reports whether the animation has reached its end
+func (a *Animation) Done() bool {
+	return a.Elapsed >= a.Duration
+}
+
+// Reset rewinds the animation to its start
+func (a *Animation) Reset() {
+	a.Elapsed = 0
+}
+
+// Component plays an animation on an entity, passing the value to Apply
+// every frame. The animation is saved with the world but the callbacks are
+// not, so they have to be set again after loading.
+type Component struct {
+	Animation *Animation
+	// Apply receives the value each frame, for example to move a transform
+	Apply func(value float64) `json:"-"`
+	// OnComplete is called once after the last value has been applied
+	OnComplete func() `json:"-"`
+}
+
+func (c *Component) GetType() string {
+	return "tween"
+}
+
+func init() {
+	ecs.RegisterComponentType("tween", func() ecs.Component { return &Component{} })
+}
+
+// NewComponent creates a tween component applying animation's value
+func NewComponent(animation *Animation, apply func(value float64), onComplete func()) *Component {
+	return &Component{
+		Animation:  animation,
+		Apply:      apply,
+		OnComplete: onComplete,
+	}
+}
+
+// System advances tween components each frame and removes them once their
+// animation completes
+type System struct{}
+
+// NewSystem creates a tween system
+func NewSystem() *System {
+	return &System{}
+}
+
+// Update advances every tween by deltaTime. Callbacks run outside the world
+// lock, so they may read and change the world.
+func (s *System) Update(deltaTime float64, world *ecs.World) {
+	for _, entityID := range world.GetEntitiesWithComponent("tween") {
+		component, ok := world.GetComponent(entityID, "tween").(*Component)
+		if !ok || component.Animation == nil {
+			continue
+		}
+
+		value := component.Animation.Update(deltaTime)
+		if component.Apply != nil {
+			component.Apply(value)
+		}
+
+		if component.Animation.Done() {
+			if component.OnComplete != nil {
+				component.OnComplete()
+			}
+			world.DeferRemoveComponent(entityID, "tween")
+		}
+	}
+}
+
+// GetName returns the system name
+func (s *System) GetName() string {
+	return "TweenSystem"
+}
@@ -0,0 +1,68 @@
+package tween
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aminasadiam/jigxel-engine/pkg/ecs"
+)
+
+func TestAnimationUpdate(t *testing.T) {
+	animation := Tween(10, 20, time.Second, QuadIn)
+
+	if got := animation.Update(0.5); got != 12.5 {
+		t.Errorf("value at half time = %v, want 12.5", got)
+	}
+	if animation.Done() {
+		t.Error("Done() = true half way through")
+	}
+
+	// Overshooting stops on the last value
+	if got := animation.Update(2); got != 20 || !animation.Done() {
+		t.Errorf("value past the end = %v, Done() = %v; want 20, true", got, animation.Done())
+	}
+
+	animation.Reset()
+	if got := animation.Value(); got != 10 || animation.Done() {
+		t.Errorf("after Reset value = %v, Done() = %v; want 10, false", got, animation.Done())
+	}
+}
+
+func TestAnimationDefaults(t *testing.T) {
+	// A nil ease is linear
+	if got := Tween(0, 4, time.Second, nil).Update(0.25); got != 1 {
+		t.Errorf("linear value = %v, want 1", got)
+	}
+
+	// A zero duration finishes at once
+	if animation := Tween(0, 4, 0, Linear); animation.Value() != 4 || !animation.Done() {
+		t.Errorf("zero duration value = %v, Done() = %v; want 4, true", animation.Value(), animation.Done())
+	}
+}
+
+func TestSystemAppliesAndCompletes(t *testing.T) {
+	world := ecs.NewWorld()
+	world.AddSystem(NewSystem())
+
+	var applied []float64
+	completed := 0
+	entityID := world.CreateEntity()
+	world.AddComponent(entityID, NewComponent(Tween(0, 1, time.Second, Linear),
+		func(value float64) { applied = append(applied, value) },
+		func() { completed++ }))
+
+	for i := 0; i < 6; i++ {
+		world.Update(0.25)
+	}
+
+	if want := []float64{0.25, 0.5, 0.75, 1}; !reflect.DeepEqual(applied, want) {
+		t.Errorf("applied %v, want %v", applied, want)
+	}
+	if completed != 1 {
+		t.Errorf("OnComplete called %d times, want 1", completed)
+	}
+	if world.HasComponent(entityID, "tween") {
+		t.Error("finished tween is still on the entity")
+	}
+}